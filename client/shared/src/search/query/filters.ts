@@ -22,6 +22,7 @@ export enum FilterType {
     lang = 'lang',
     message = 'message',
     patterntype = 'patterntype',
+    rank = 'rank',
     repo = 'repo',
     repogroup = 'repogroup',
     repohascommitafter = 'repohascommitafter',
@@ -248,6 +249,12 @@ export const FILTERS: Record<NegatableFilter, NegatableFilterDefinition> &
         description: 'The pattern type (regexp, literal, structural) in use',
         singular: true,
     },
+    [FilterType.rank]: {
+        description: 'Rank results by the importance of their files and the quality of their matches.',
+        discreteValues: () => ['yes', 'no'].map(value => ({ label: value })),
+        default: 'no',
+        singular: true,
+    },
     [FilterType.repo]: {
         alias: 'r',
         negatable: true,
@@ -47,6 +47,12 @@ describe('scanPredicate', () => {
             '{"path":["contains"],"parameters":"(stuff)"}'
         )
     })
+
+    test('scan recognized file.has.owner syntax', () => {
+        expect(scanPredicate('file', 'has.owner(@org/team)')).toMatchInlineSnapshot(
+            '{"path":["has","owner"],"parameters":"(@org/team)"}'
+        )
+    })
 })
 
 describe('resolveAccess', () => {
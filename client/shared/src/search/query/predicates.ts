@@ -35,6 +35,10 @@ export const PREDICATES: Access[] = [
                 name: 'contains',
                 fields: [{ name: 'content' }],
             },
+            {
+                name: 'has',
+                fields: [{ name: 'owner' }],
+            },
         ],
     },
 ]
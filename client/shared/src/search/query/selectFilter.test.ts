@@ -51,6 +51,13 @@ describe('selectorCompletion', () => {
         `)
     })
 
+    test('suggest depth 1 content completions', () => {
+        expect(selectorCompletion(create('content.'))).toMatchInlineSnapshot(`
+            content,
+            content.group(1)
+        `)
+    })
+
     test('suggest depth 2 commit.diff completions', () => {
         expect(selectorCompletion(create('commit.diff.'))).toMatchInlineSnapshot(`
             commit,
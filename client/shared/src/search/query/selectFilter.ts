@@ -11,10 +11,11 @@ export const SELECTORS: Access[] = [
     },
     {
         name: 'file',
-        fields: [{ name: 'directory' }, { name: 'path' }],
+        fields: [{ name: 'directory' }, { name: 'path' }, { name: 'owners' }],
     },
     {
         name: 'content',
+        fields: [{ name: 'group(1)' }],
     },
     {
         name: 'symbol',
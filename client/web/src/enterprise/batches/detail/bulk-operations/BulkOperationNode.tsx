@@ -40,6 +40,11 @@ const OPERATION_TITLES: Record<BulkOperationType, JSX.Element> = {
             <SourceBranchIcon className="icon-inline text-muted" /> Merge changesets
         </>
     ),
+    AUTO_MERGE: (
+        <>
+            <SourceBranchIcon className="icon-inline text-muted" /> Auto-merge changesets
+        </>
+    ),
     CLOSE: (
         <>
             <SourceBranchIcon className="icon-inline text-danger" /> Close changesets
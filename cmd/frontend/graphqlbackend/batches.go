@@ -290,6 +290,11 @@ type MergeChangesetsArgs struct {
 	Squash bool
 }
 
+type AutoMergeChangesetsArgs struct {
+	BulkOperationBaseArgs
+	Squash bool
+}
+
 type CloseChangesetsArgs struct {
 	BulkOperationBaseArgs
 }
@@ -352,6 +357,7 @@ type BatchChangesResolver interface {
 	CreateChangesetComments(ctx context.Context, args *CreateChangesetCommentsArgs) (BulkOperationResolver, error)
 	ReenqueueChangesets(ctx context.Context, args *ReenqueueChangesetsArgs) (BulkOperationResolver, error)
 	MergeChangesets(ctx context.Context, args *MergeChangesetsArgs) (BulkOperationResolver, error)
+	AutoMergeChangesets(ctx context.Context, args *AutoMergeChangesetsArgs) (BulkOperationResolver, error)
 	CloseChangesets(ctx context.Context, args *CloseChangesetsArgs) (BulkOperationResolver, error)
 	PublishChangesets(ctx context.Context, args *PublishChangesetsArgs) (BulkOperationResolver, error)
 
@@ -924,6 +930,17 @@ type BatchSpecWorkspaceStepResolver interface {
 
 	DiffStat(ctx context.Context) (*DiffStat, error)
 	Diff(ctx context.Context) (PreviewRepositoryComparisonResolver, error)
+
+	Artifacts(ctx context.Context) ([]BatchSpecWorkspaceStepArtifactResolver, error)
+}
+
+type BatchSpecWorkspaceStepArtifactResolver interface {
+	ID() graphql.ID
+	Name() string
+	Path() string
+	ByteSize() int32
+	URL() string
+	CreatedAt() DateTime
 }
 
 type BatchSpecWorkspaceEnvironmentVariableResolver interface {
@@ -2,13 +2,11 @@ package graphqlbackend
 
 import (
 	"context"
-	"regexp"
 
 	"github.com/cockroachdb/errors"
 	"github.com/sourcegraph/go-langserver/pkg/lsp"
 	"github.com/sourcegraph/sourcegraph/internal/compute"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
-	"github.com/sourcegraph/sourcegraph/internal/search/query"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 )
@@ -19,12 +17,23 @@ type ComputeArgs struct {
 
 type ComputeResolver interface {
 	Compute(ctx context.Context, args *ComputeArgs) ([]*computeResultResolver, error)
+	ComputeResults(ctx context.Context, args *ComputeArgs) (*computeResultsResolver, error)
 }
 
+// ComputeResults GQL result resolver definitions.
+
+type computeResultsResolver struct {
+	results  []*computeResultResolver
+	limitHit bool
+}
+
+func (r *computeResultsResolver) Results() []*computeResultResolver { return r.results }
+func (r *computeResultsResolver) LimitHit() bool                    { return r.limitHit }
+
 // A dummy type to express the union of compute results. This how its done by the GQL library we use.
 // https://github.com/graph-gophers/graphql-go/blob/af5bb93e114f0cd4cc095dd8eae0b67070ae8f20/example/starwars/starwars.go#L485-L487
 //
-// union ComputeResult = ComputeMatchContext | ComputeText
+// union ComputeResult = ComputeMatchContext | ComputeText | ComputeGroup
 type computeResultResolver struct {
 	result interface{}
 }
@@ -109,18 +118,32 @@ func toLspRange(r compute.Range) lsp.Range {
 // ComputeText GQL result resolver definitions.
 
 type computeTextResolver struct {
-	repository *RepositoryResolver //nolint
-	commit     string              //nolint
-	path       string              //nolint
+	repository *RepositoryResolver
 	t          *compute.Text
 }
 
-func (c *computeTextResolver) Repository() *RepositoryResolver { return nil }
-func (r *computeTextResolver) Commit() *string                 { return nil }
-func (r *computeTextResolver) Path() *string                   { return nil }
-func (r *computeTextResolver) Kind() *string                   { return nil }
+func (c *computeTextResolver) Repository() *RepositoryResolver { return c.repository }
+func (r *computeTextResolver) Commit() *string                 { return optionalString(r.t.Commit) }
+func (r *computeTextResolver) Path() *string                   { return optionalString(r.t.Path) }
+func (r *computeTextResolver) Kind() *string                   { return optionalString(r.t.Kind) }
 func (r *computeTextResolver) Value() string                   { return r.t.Value }
 
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// ComputeGroup GQL result resolver definitions.
+
+type computeGroupResolver struct {
+	g *compute.Group
+}
+
+func (r *computeGroupResolver) Value() string { return r.g.Value }
+func (r *computeGroupResolver) Count() int32  { return int32(r.g.Count) }
+
 // Definitions required by https://github.com/graph-gophers/graphql-go to resolve
 // a union type in GraphQL.
 
@@ -134,6 +157,11 @@ func (r *computeResultResolver) ToComputeText() (*computeTextResolver, bool) {
 	return res, ok
 }
 
+func (r *computeResultResolver) ToComputeGroup() (*computeGroupResolver, bool) {
+	res, ok := r.result.(*computeGroupResolver)
+	return res, ok
+}
+
 func toComputeMatchContextResolver(fm *result.FileMatch, mc *compute.MatchContext, db dbutil.DB) *computeMatchContextResolver {
 	type repoKey struct {
 		Name types.RepoName
@@ -163,59 +191,76 @@ func toComputeMatchContextResolver(fm *result.FileMatch, mc *compute.MatchContex
 	}
 }
 
-func toComputeResultResolver(r *computeMatchContextResolver) *computeResultResolver {
+func toComputeTextResolver(fm *result.FileMatch, t *compute.Text, db dbutil.DB) *computeTextResolver {
+	return &computeTextResolver{
+		repository: NewRepositoryResolver(db, fm.Repo.ToRepo()),
+		t:          t,
+	}
+}
+
+func toComputeResultResolver(r interface{}) *computeResultResolver {
 	return &computeResultResolver{result: r}
 }
 
-func regexpFromQuery(q string) (*regexp.Regexp, error) {
-	plan, err := query.Pipeline(query.Init(q, query.SearchTypeRegex))
-	if err != nil {
-		return nil, err
-	}
-	if len(plan) != 1 {
-		return nil, errors.New("compute endpoint only supports one search pattern currently ('and' or 'or' operators are not supported yet)")
-	}
-	switch node := plan[0].Pattern.(type) {
-	case query.Operator:
-		if len(node.Operands) == 1 {
-			if pattern, ok := node.Operands[0].(query.Pattern); ok && !pattern.Negated {
-				rp, err := regexp.Compile(pattern.Value)
+func toResultResolverList(ctx context.Context, cmd compute.Command, matches []result.Match, db dbutil.DB) ([]*computeResultResolver, error) {
+	var computeResult []*computeResultResolver
+	switch c := cmd.(type) {
+	case *compute.MatchOnly:
+		for _, m := range matches {
+			if fm, ok := m.(*result.FileMatch); ok {
+				matchContext := compute.FromFileMatch(fm, c.MatchPattern)
+				computeResult = append(computeResult, toComputeResultResolver(toComputeMatchContextResolver(fm, matchContext, db)))
+			}
+		}
+
+	case *compute.Replace:
+		for _, m := range matches {
+			if fm, ok := m.(*result.FileMatch); ok {
+				text, err := c.Run(ctx, fm)
 				if err != nil {
-					return nil, errors.Wrap(err, "regular expression is not valid for compute endpoint")
+					return nil, err
 				}
-				return rp, nil
+				computeResult = append(computeResult, toComputeResultResolver(toComputeTextResolver(fm, text, db)))
 			}
 		}
-		return nil, errors.New("compute endpoint only supports one search pattern currently ('and' or 'or' operators are not supported yet)")
-	case query.Pattern:
-		if !node.Negated {
-			return regexp.Compile(node.Value)
+
+	case *compute.Output:
+		for _, m := range matches {
+			if fm, ok := m.(*result.FileMatch); ok {
+				if text := c.Run(fm); text != nil {
+					computeResult = append(computeResult, toComputeResultResolver(toComputeTextResolver(fm, text, db)))
+				}
+			}
 		}
-	}
-	// unreachable
-	return nil, nil
-}
 
-func toResultResolverList(pattern *regexp.Regexp, matches []result.Match, db dbutil.DB) []*computeResultResolver {
-	var computeResult []*computeResultResolver
-	for _, m := range matches {
-		if fm, ok := m.(*result.FileMatch); ok {
-			matchContext := compute.FromFileMatch(fm, pattern)
-			computeResult = append(computeResult, toComputeResultResolver(toComputeMatchContextResolver(fm, matchContext, db)))
+	case *compute.Count:
+		for _, group := range c.Run(matches) {
+			computeResult = append(computeResult, toComputeResultResolver(&computeGroupResolver{g: group}))
 		}
+
+	default:
+		return nil, errors.Errorf("unsupported compute command %s", cmd)
 	}
-	return computeResult
+	return computeResult, nil
 }
 
 // NewComputeImplementer is a function that abstracts away the need to have a
 // handle on (*schemaResolver) Compute.
 func NewComputeImplementer(ctx context.Context, db dbutil.DB, args *ComputeArgs) ([]*computeResultResolver, error) {
-	pattern, err := regexpFromQuery(args.Query)
+	computeResults, err := newComputeResultsResolver(ctx, db, args)
+	if err != nil {
+		return nil, err
+	}
+	return computeResults.results, nil
+}
+
+func newComputeResultsResolver(ctx context.Context, db dbutil.DB, args *ComputeArgs) (*computeResultsResolver, error) {
+	computeQuery, err := compute.Parse(args.Query)
 	if err != nil {
 		return nil, err
 	}
 	patternType := "regexp"
-	job, err := NewSearchImplementer(ctx, db, &SearchArgs{Query: args.Query, PatternType: &patternType})
+	job, err := NewSearchImplementer(ctx, db, &SearchArgs{Query: computeQuery.SearchQuery, PatternType: &patternType})
 	if err != nil {
 		return nil, err
 	}
@@ -224,9 +269,17 @@ func NewComputeImplementer(ctx context.Context, db dbutil.DB, args *ComputeArgs)
 	if err != nil {
 		return nil, err
 	}
-	return toResultResolverList(pattern, results.Matches, db), nil
+	computeResults, err := toResultResolverList(ctx, computeQuery.Command, results.Matches, db)
+	if err != nil {
+		return nil, err
+	}
+	return &computeResultsResolver{results: computeResults, limitHit: results.LimitHit()}, nil
 }
 
 func (r *schemaResolver) Compute(ctx context.Context, args *ComputeArgs) ([]*computeResultResolver, error) {
 	return NewComputeImplementer(ctx, r.db, args)
 }
+
+func (r *schemaResolver) ComputeResults(ctx context.Context, args *ComputeArgs) (*computeResultsResolver, error) {
+	return newComputeResultsResolver(ctx, r.db, args)
+}
@@ -16,6 +16,14 @@ import (
 type InsightsResolver interface {
 	Insights(ctx context.Context, args *InsightsArgs) (InsightConnectionResolver, error)
 	InsightDashboards(ctx context.Context, args *InsightDashboardsArgs) (InsightsDashboardConnectionResolver, error)
+
+	// Mutations
+	ImportInsightsData(ctx context.Context, args *ImportInsightsDataArgs) (*EmptyResponse, error)
+}
+
+type ImportInsightsDataArgs struct {
+	Format string
+	Data   string
 }
 
 type InsightsArgs struct {
@@ -52,10 +60,20 @@ type InsightSeriesResolver interface {
 type InsightResolver interface {
 	Title() string
 	Description() string
-	Series() []InsightSeriesResolver
+	Series(ctx context.Context, args *InsightSeriesArgs) ([]InsightSeriesResolver, error)
+	Export(ctx context.Context, args *InsightExportArgs) (string, error)
 	ID() string
 }
 
+type InsightSeriesArgs struct {
+	From *DateTime
+	To   *DateTime
+}
+
+type InsightExportArgs struct {
+	Format string
+}
+
 type InsightConnectionResolver interface {
 	Nodes(ctx context.Context) ([]InsightResolver, error)
 	TotalCount(ctx context.Context) (int32, error)
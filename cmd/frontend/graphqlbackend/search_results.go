@@ -33,6 +33,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/honey"
 	"github.com/sourcegraph/sourcegraph/internal/rcache"
 	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/codeowners"
 	"github.com/sourcegraph/sourcegraph/internal/search/filter"
 	"github.com/sourcegraph/sourcegraph/internal/search/query"
 	searchrepos "github.com/sourcegraph/sourcegraph/internal/search/repos"
@@ -996,7 +997,21 @@ func (r *searchResolver) resultsStreaming(ctx context.Context) (*SearchResultsRe
 	if sp, _ := r.Plan.ToParseTree().StringValue(query.FieldSelect); sp != "" {
 		// Ensure downstream events sent on the stream are processed by `select:`.
 		selectPath, _ := filter.SelectPathFromString(sp) // Invariant: error already checked
+		if selectPath.String() == "file.owners" {
+			// Replace selected files by their owners.
+			r.stream = codeowners.WithSelectOwners(ctx, r.stream, codeowners.NewOwnerSelector(codeowners.DefaultResolver))
+		}
 		r.stream = streaming.WithSelect(r.stream, selectPath)
+		if group, ok := selectPath.Group(); ok {
+			// Narrow file matches to the values of the selected capture group.
+			r.stream = streaming.WithSelectGroup(r.stream, result.NewGroupSelector(r.Plan.ToParseTree(), group))
+		}
+	}
+	if r.Query.BoolValue(query.FieldRank) {
+		// Rank results within a bounded window before they are sent.
+		var flushRanking func()
+		r.stream, flushRanking = run.WithRanking(ctx, r.db, r.stream)
+		defer flushRanking()
 	}
 	sr, err := r.resultsRecursive(ctx, r.Plan)
 	srr := r.resultsToResolver(sr)
@@ -1070,7 +1085,16 @@ func (r *searchResolver) resultsRecursive(ctx context.Context, plan query.Plan)
 			if err != nil {
 				return nil, err
 			}
-			return r.resultsRecursive(ctx, plan)
+			predicateResults, err := r.resultsRecursive(ctx, plan)
+			if err != nil || predicateResults == nil {
+				return predicateResults, err
+			}
+			if owner, ok := pred.(*query.FileHasOwnerPredicate); ok {
+				// Ownership is not known to the search backends, so the
+				// files found by the plan are filtered by their owners.
+				predicateResults.Matches, err = codeowners.FilterByOwner(ctx, codeowners.DefaultResolver, predicateResults.Matches, owner.Owner)
+			}
+			return predicateResults, err
 		})
 		if errors.Is(err, ErrPredicateNoResults) {
 			continue
@@ -1092,6 +1116,12 @@ func (r *searchResolver) resultsRecursive(ctx context.Context, plan query.Plan)
 
 		if newResult != nil {
 			newResult.Matches = result.Select(newResult.Matches, q)
+			if selectsOwners(q) {
+				newResult.Matches, err = codeowners.NewOwnerSelector(codeowners.DefaultResolver).Select(ctx, newResult.Matches)
+				if err != nil {
+					return nil, err
+				}
+			}
 			sr = union(sr, newResult)
 			if len(sr.Matches) > wantCount {
 				sr.Matches = sr.Matches[:wantCount]
@@ -1101,11 +1131,18 @@ func (r *searchResolver) resultsRecursive(ctx context.Context, plan query.Plan)
 	}
 
 	if sr != nil {
-		r.sortResults(sr.Matches)
+		r.sortResults(ctx, sr.Matches)
 	}
 	return sr, err
 }
 
+// selectsOwners returns whether q selects the owners of files with
+// select:file.owners.
+func selectsOwners(q query.Basic) bool {
+	v, _ := q.ToParseTree().StringValue(query.FieldSelect)
+	return v == "file.owners"
+}
+
 // searchResultsToRepoNodes converts a set of search results into repository nodes
 // such that they can be used to replace a repository predicate
 func searchResultsToRepoNodes(matches []result.Match) ([]query.Node, error) {
@@ -1670,7 +1707,7 @@ func (r *searchResolver) toSearchResults(ctx context.Context, agg *run.Aggregato
 	}
 	alert, err := ao.Done(&common)
 
-	r.sortResults(matches)
+	r.sortResults(ctx, matches)
 
 	return &SearchResults{
 		Matches: matches,
@@ -1767,12 +1804,16 @@ func compareSearchResults(left, right result.Match, exactFilePatterns map[string
 	return arepo < brepo
 }
 
-func (r *searchResolver) sortResults(results []result.Match) {
+func (r *searchResolver) sortResults(ctx context.Context, results []result.Match) {
 	var exactPatterns map[string]struct{}
 	if getBoolPtr(r.UserSettings.SearchGlobbing, false) {
 		exactPatterns = r.getExactFilePatterns()
 	}
 	sort.Slice(results, func(i, j int) bool { return compareSearchResults(results[i], results[j], exactPatterns) })
+
+	if r.Query.BoolValue(query.FieldRank) {
+		run.RankMatches(ctx, r.db, results)
+	}
 }
 
 // getExactFilePatterns returns the set of file patterns without glob syntax.
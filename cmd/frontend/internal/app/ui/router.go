@@ -80,6 +80,7 @@ const (
 
 	routeSearchQueryBuilder = "search.query-builder"
 	routeSearchStream       = "search.stream"
+	routeComputeStream      = "compute.stream"
 	routeSearchConsole      = "search.console"
 	routeSearchNotebook     = "search.notebook"
 
@@ -138,6 +139,7 @@ func newRouter() *mux.Router {
 	r.Path("/search/badge").Methods("GET").Name(routeSearchBadge)
 	r.Path("/search/query-builder").Methods("GET").Name(routeSearchQueryBuilder)
 	r.Path("/search/stream").Methods("GET").Name(routeSearchStream)
+	r.Path("/search/compute/stream").Methods("GET").Name(routeComputeStream)
 	r.Path("/search/console").Methods("GET").Name(routeSearchConsole)
 	r.Path("/search/notebook").Methods("GET").Name(routeSearchNotebook)
 	r.Path("/sign-in").Methods("GET").Name(uirouter.RouteSignIn)
@@ -298,6 +300,9 @@ func initRouter(db dbutil.DB, router *mux.Router, codeIntelResolver graphqlbacke
 	// streaming search
 	router.Get(routeSearchStream).Handler(search.StreamHandler(db))
 
+	// streaming compute
+	router.Get(routeComputeStream).Handler(search.ComputeStreamHandler(db))
+
 	// search badge
 	router.Get(routeSearchBadge).Handler(searchBadgeHandler())
 
@@ -28,7 +28,12 @@ func newTest() *httptestutil.Client {
 		enterpriseServices.GitHubWebhook,
 		enterpriseServices.GitLabWebhook,
 		enterpriseServices.BitbucketServerWebhook,
+		enterpriseServices.BitbucketCloudWebhook,
 		enterpriseServices.NewCodeIntelUploadHandler,
+		enterpriseServices.InsightsDataHandler,
+		enterpriseServices.BatchesStepArtifactsHandler,
+		enterpriseServices.BatchesExecutionLogsHandler,
+		enterpriseServices.CodeIntelIndexLogsHandler,
 		rateLimiter,
 	))
 }
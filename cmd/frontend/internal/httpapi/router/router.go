@@ -8,10 +8,16 @@ import (
 )
 
 const (
-	LSIFUpload = "lsif.upload"
-	GraphQL    = "graphql"
+	LSIFUpload    = "lsif.upload"
+	LSIFIndexLogs = "lsif.index-logs"
+	GraphQL       = "graphql"
+	InsightsData  = "insights.data"
 
-	SearchStream = "search.stream"
+	BatchesStepArtifacts = "batches.step-artifacts"
+	BatchesExecutionLogs = "batches.execution-logs"
+
+	SearchStream  = "search.stream"
+	ComputeStream = "compute.stream"
 
 	SrcCliVersion  = "src-cli.version"
 	SrcCliDownload = "src-cli.download"
@@ -25,6 +31,7 @@ const (
 	GitHubWebhooks          = "github.webhooks"
 	GitLabWebhooks          = "gitlab.webhooks"
 	BitbucketServerWebhooks = "bitbucketServer.webhooks"
+	BitbucketCloudWebhooks  = "bitbucketCloud.webhooks"
 
 	SavedQueriesListAll    = "internal.saved-queries.list-all"
 	SavedQueriesGetInfo    = "internal.saved-queries.get-info"
@@ -72,8 +79,14 @@ func New(base *mux.Router) *mux.Router {
 	base.Path("/github-webhooks").Methods("POST").Name(GitHubWebhooks)
 	base.Path("/gitlab-webhooks").Methods("POST").Name(GitLabWebhooks)
 	base.Path("/bitbucket-server-webhooks").Methods("POST").Name(BitbucketServerWebhooks)
+	base.Path("/bitbucket-cloud-webhooks").Methods("POST").Name(BitbucketCloudWebhooks)
 	base.Path("/lsif/upload").Methods("POST").Name(LSIFUpload)
+	base.Path("/lsif/indexes/logs/stream").Methods("GET").Name(LSIFIndexLogs)
+	base.Path("/insights/data").Methods("GET", "POST").Name(InsightsData)
+	base.Path("/batches/artifacts").Methods("GET", "POST").Name(BatchesStepArtifacts)
+	base.Path("/batches/logs/stream").Methods("GET").Name(BatchesExecutionLogs)
 	base.Path("/search/stream").Methods("GET").Name(SearchStream)
+	base.Path("/search/compute/stream").Methods("GET").Name(ComputeStream)
 	base.Path("/src-cli/version").Methods("GET").Name(SrcCliVersion)
 	base.Path("/src-cli/{rest:.*}").Methods("GET").Name(SrcCliDownload)
 
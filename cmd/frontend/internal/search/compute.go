@@ -0,0 +1,271 @@
+package search
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/compute"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+)
+
+// ComputeStreamHandler is an http handler which streams back the results of
+// the compute query in the "q" parameter as they are computed from search
+// results. See compute.Parse for the syntax of compute queries.
+//
+// Results are sent in "results" events holding a JSON array of compute
+// results, each with a "type" of "matchContext", "text", or "group". Like
+// for streaming search, "progress" events report the progress of the search,
+// and a final "done" event is always sent. The groups of count commands are
+// only sent once the search has finished.
+func ComputeStreamHandler(db dbutil.DB) http.Handler {
+	return &computeStreamHandler{
+		streamHandler: streamHandler{
+			db:                  db,
+			newSearchResolver:   defaultNewSearchResolver,
+			flushTickerInternal: 100 * time.Millisecond,
+			pingTickerInterval:  5 * time.Second,
+		},
+	}
+}
+
+type computeStreamHandler struct {
+	streamHandler
+}
+
+type computeMatchContextEvent struct {
+	Type string `json:"type"`
+	*compute.MatchContext
+}
+
+type computeTextEvent struct {
+	Type string `json:"type"`
+	*compute.Text
+}
+
+type computeGroupEvent struct {
+	Type string `json:"type"`
+	*compute.Group
+}
+
+// computeConcurrency is the number of file matches whose results are computed
+// concurrently.
+const computeConcurrency = 8
+
+func (h *computeStreamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	q := r.URL.Query().Get("q")
+	if q == "" {
+		http.Error(w, "no query found", http.StatusBadRequest)
+		return
+	}
+	computeQuery, err := compute.Parse(q)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tr, ctx := trace.New(ctx, "compute.ServeStream", q)
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	eventWriter, err := streamhttp.NewWriter(w)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Always send a final done event so clients know the stream is shutting
+	// down.
+	defer eventWriter.Event("done", map[string]interface{}{})
+
+	// Log events to trace
+	eventWriter.StatHook = eventStreamOTHook(tr.LogFields)
+
+	events, inputs, results := h.startSearch(ctx, &args{
+		Query:       computeQuery.SearchQuery,
+		Version:     "V2",
+		PatternType: "regexp",
+	})
+	events = batchEvents(events, 50*time.Millisecond)
+
+	progress := progressAggregator{
+		Start:        time.Now(),
+		Limit:        inputs.MaxResults(),
+		Trace:        trace.URL(trace.ID(ctx)),
+		DisplayLimit: math.MaxInt32,
+	}
+
+	sendProgress := func() {
+		_ = eventWriter.Event("progress", progress.Current())
+	}
+
+	// Store marshalled results and flush periodically or when we go over
+	// 32kb, like matches of streaming search.
+	resultsBuf := streamhttp.NewJSONArrayBuf(32*1024, func(data []byte) error {
+		return eventWriter.EventBytes("results", data)
+	})
+	resultsFlush := func() {
+		if err := resultsBuf.Flush(); err != nil {
+			// EOF
+			return
+		}
+
+		if progress.Dirty {
+			sendProgress()
+		}
+	}
+	flushTicker := time.NewTicker(h.flushTickerInternal)
+	defer flushTicker.Stop()
+
+	pingTicker := time.NewTicker(h.pingTickerInterval)
+	defer pingTicker.Stop()
+
+	var counter *compute.Counter
+	if command, ok := computeQuery.Command.(*compute.Count); ok {
+		counter = compute.NewCounter(command)
+	}
+
+	// Computing the result of a file match may be slow, e.g. replace reads
+	// the file from gitserver, so file matches are computed by a bounded
+	// number of goroutines and only their results are sent from this loop.
+	work := make(chan *result.FileMatch)
+	computed := make(chan interface{})
+	go func(work <-chan *result.FileMatch, computed chan<- interface{}) {
+		defer close(computed)
+
+		bounded := goroutine.NewBounded(computeConcurrency)
+		for fm := range work {
+			fm := fm
+			bounded.Go(func() error {
+				if v := computeFileMatch(ctx, computeQuery.Command, fm); v != nil {
+					computed <- v
+				}
+				return nil
+			})
+		}
+		_ = bounded.Wait()
+	}(work, computed)
+
+	// handleEvent returns the file matches of the event that are left to
+	// compute.
+	handleEvent := func(event streaming.SearchEvent) []*result.FileMatch {
+		progress.Update(event)
+
+		repoMetadata, err := getEventRepoMetadata(ctx, h.db, event)
+		if err != nil {
+			log15.Error("failed to get repo metadata", "error", err)
+			return nil
+		}
+
+		matches := make([]result.Match, 0, len(event.Results))
+		for _, match := range event.Results {
+			// Don't compute results from matches which we cannot map to a repo the
+			// actor has access to, like streaming search.
+			if md, ok := repoMetadata[match.RepoName().ID]; !ok || md.Name != match.RepoName().Name {
+				continue
+			}
+			matches = append(matches, match)
+		}
+
+		if counter != nil {
+			counter.Add(matches)
+			return nil
+		}
+		fileMatches := make([]*result.FileMatch, 0, len(matches))
+		for _, match := range matches {
+			if fm, ok := match.(*result.FileMatch); ok {
+				fileMatches = append(fileMatches, fm)
+			}
+		}
+		return fileMatches
+	}
+
+	var pending []*result.FileMatch
+	for events != nil || computed != nil {
+		// Hand the pending file matches to the goroutines computing them
+		// without blocking this loop, and stop them once the search is done.
+		var next chan<- *result.FileMatch
+		var nextMatch *result.FileMatch
+		if len(pending) > 0 {
+			next, nextMatch = work, pending[0]
+		} else if events == nil && work != nil {
+			close(work)
+			work = nil
+		}
+
+		select {
+		case event, ok := <-events:
+			if !ok {
+				events = nil
+				continue
+			}
+			pending = append(pending, handleEvent(event)...)
+		case next <- nextMatch:
+			pending = pending[1:]
+		case v, ok := <-computed:
+			if !ok {
+				computed = nil
+				continue
+			}
+			_ = resultsBuf.Append(v)
+		case <-flushTicker.C:
+			resultsFlush()
+		case <-pingTicker.C:
+			sendProgress()
+		}
+	}
+
+	if counter != nil {
+		for _, group := range counter.Groups() {
+			_ = resultsBuf.Append(computeGroupEvent{Type: "group", Group: group})
+		}
+	}
+	resultsFlush()
+
+	if _, err = results(); err != nil {
+		_ = eventWriter.Event("error", streamhttp.EventError{Message: err.Error()})
+		return
+	}
+
+	_ = eventWriter.Event("progress", progress.Final())
+}
+
+// computeFileMatch returns the event for the result of the given command on
+// the given file match, or nil if there is none.
+func computeFileMatch(ctx context.Context, command compute.Command, fm *result.FileMatch) interface{} {
+	switch c := command.(type) {
+	case *compute.MatchOnly:
+		matchContext := compute.FromFileMatch(fm, c.MatchPattern)
+		if len(matchContext.Matches) == 0 {
+			return nil
+		}
+		return computeMatchContextEvent{Type: "matchContext", MatchContext: matchContext}
+
+	case *compute.Replace:
+		text, err := c.Run(ctx, fm)
+		if err != nil {
+			log15.Warn("compute: failed to replace in file", "repo", fm.Repo.Name, "path", fm.Path, "error", err)
+			return nil
+		}
+		return computeTextEvent{Type: "text", Text: text}
+
+	case *compute.Output:
+		if text := c.Run(fm); text != nil {
+			return computeTextEvent{Type: "text", Text: text}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	api2 "github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+func TestServeComputeStream(t *testing.T) {
+	database.Mocks.Repos.Metadata = func(ctx context.Context, ids ...api2.RepoID) (_ []*types.SearchedRepo, err error) {
+		res := make([]*types.SearchedRepo, 0, len(ids))
+		for _, id := range ids {
+			if id == 3 {
+				// The actor cannot access repo3.
+				continue
+			}
+			res = append(res, &types.SearchedRepo{ID: id, Name: mkRepoMatch(int(id)).Name})
+		}
+		return res, nil
+	}
+	t.Cleanup(func() { database.Mocks.Repos.Metadata = nil })
+
+	git.Mocks.ReadFile = func(commit api2.CommitID, name string) ([]byte, error) {
+		return []byte("require a v1\n"), nil
+	}
+	t.Cleanup(func() { git.Mocks.ReadFile = nil })
+
+	mkFileMatch := func(id int, lines ...string) *result.FileMatch {
+		fm := &result.FileMatch{
+			File: result.File{
+				Repo:     types.RepoName{ID: api2.RepoID(id), Name: mkRepoMatch(id).Name},
+				CommitID: "deadbeef",
+				Path:     "go.mod",
+			},
+		}
+		for _, line := range lines {
+			fm.LineMatches = append(fm.LineMatches, &result.LineMatch{Preview: line})
+		}
+		return fm
+	}
+
+	for _, tc := range []struct {
+		query           string
+		wantSearchQuery string
+		want            []map[string]interface{}
+	}{
+		{
+			query:           `content:output((\w+) v(\d+) -> $1@$2 in $repo)`,
+			wantSearchQuery: `count:10000 content:"(\\w+) v(\\d+)"`,
+			want: []map[string]interface{}{
+				{"type": "text", "kind": "output", "repository": "repo1", "commit": "deadbeef", "path": "go.mod", "value": "a@1 in repo1\nb@2 in repo1"},
+				{"type": "text", "kind": "output", "repository": "repo2", "commit": "deadbeef", "path": "go.mod", "value": "a@1 in repo2"},
+			},
+		},
+		{
+			query:           `content:replace((\w+) v(\d+) -> $1@$2)`,
+			wantSearchQuery: `count:10000 content:"(\\w+) v(\\d+)"`,
+			want: []map[string]interface{}{
+				{"type": "text", "kind": "replace-in-place", "repository": "repo1", "commit": "deadbeef", "path": "go.mod", "value": "require a@1\n"},
+				{"type": "text", "kind": "replace-in-place", "repository": "repo2", "commit": "deadbeef", "path": "go.mod", "value": "require a@1\n"},
+			},
+		},
+		{
+			query:           `content:count((\w+) v(\d+) -> $1)`,
+			wantSearchQuery: `count:10000 content:"(\\w+) v(\\d+)"`,
+			want: []map[string]interface{}{
+				{"type": "group", "value": "a", "count": float64(2)},
+				{"type": "group", "value": "b", "count": float64(1)},
+			},
+		},
+	} {
+		t.Run(tc.query, func(t *testing.T) {
+			mock := &mockSearchResolver{
+				done: make(chan struct{}),
+			}
+
+			var searchQuery string
+			ts := httptest.NewServer(&computeStreamHandler{
+				streamHandler: streamHandler{
+					flushTickerInternal: 1 * time.Millisecond,
+					pingTickerInterval:  1 * time.Millisecond,
+					newSearchResolver: func(_ context.Context, _ dbutil.DB, args *graphqlbackend.SearchArgs) (searchResolver, error) {
+						mock.c = args.Stream
+						searchQuery = args.Query
+						return mock, nil
+					},
+				},
+			})
+			defer ts.Close()
+
+			resp, err := http.Get(ts.URL + "?q=" + url.QueryEscape(tc.query))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			var have []map[string]interface{}
+			var done bool
+			g := errgroup.Group{}
+			g.Go(func() error {
+				dec := streamhttp.NewDecoder(resp.Body)
+				for dec.Scan() {
+					switch string(dec.Event()) {
+					case "results":
+						var results []map[string]interface{}
+						if err := json.Unmarshal(dec.Data(), &results); err != nil {
+							return err
+						}
+						have = append(have, results...)
+					case "done":
+						done = true
+					}
+				}
+				return dec.Err()
+			})
+
+			mock.c.Send(streaming.SearchEvent{
+				Results: []result.Match{
+					mkFileMatch(1, "a v1", "b v2"),
+					mkFileMatch(2, "a v1"),
+					mkFileMatch(3, "filtered v3"),
+				},
+			})
+			mock.Close()
+			if err := g.Wait(); err != nil {
+				t.Fatal(err)
+			}
+
+			if searchQuery != tc.wantSearchQuery {
+				t.Errorf("unexpected search query. want=%q have=%q", tc.wantSearchQuery, searchQuery)
+			}
+			// File matches are computed concurrently, so the order of their
+			// results is undefined.
+			sort.SliceStable(have, func(i, j int) bool {
+				return fmt.Sprint(have[i]["repository"]) < fmt.Sprint(have[j]["repository"])
+			})
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Errorf("unexpected results (-want +got):\n%s", diff)
+			}
+			if !done {
+				t.Error("expected done event")
+			}
+		})
+	}
+}
@@ -25,6 +25,8 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/honey"
 	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/search/run"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
@@ -112,8 +114,20 @@ func (h *streamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		_ = eventWriter.Event("progress", progress.Current())
 	}
 
+	sp, _ := inputs.Query.StringValue(query.FieldSelect)
+	selectPath, _ := filter.SelectPathFromString(sp) // Invariant: select already validated
+	_, selectGroup := selectPath.Group()
+
 	filters := &streaming.SearchFilters{
-		Globbing: false, // TODO
+		Globbing:    false, // TODO
+		SelectGroup: selectGroup,
+	}
+
+	// Values of a selected capture group are counted by filters, but only
+	// sent once.
+	var groupDedup *result.GroupDeduper
+	if selectGroup {
+		groupDedup = result.NewGroupDeduper()
 	}
 
 	// Store marshalled matches and flush periodically or when we go over
@@ -143,6 +157,10 @@ func (h *streamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		progress.Update(event)
 		filters.Update(event)
 
+		if groupDedup != nil {
+			event.Results = dedupGroups(groupDedup, event.Results)
+		}
+
 		// Truncate the event to the match limit before fetching repo metadata
 		for i, match := range event.Results {
 			if display <= 0 {
@@ -415,6 +433,20 @@ func withDecoration(ctx context.Context, eventMatch streamhttp.EventMatch, inter
 	return eventMatch
 }
 
+// dedupGroups returns the file matches which contain values of the selected
+// capture group that have not been sent yet, narrowed to those values.
+func dedupGroups(d *result.GroupDeduper, matches []result.Match) []result.Match {
+	deduped := make([]result.Match, 0, len(matches))
+	for _, match := range matches {
+		if fm, ok := match.(*result.FileMatch); ok {
+			if fm = d.Dedup(fm); fm != nil {
+				deduped = append(deduped, fm)
+			}
+		}
+	}
+	return deduped
+}
+
 func fromMatch(match result.Match, repoCache map[api.RepoID]*types.SearchedRepo) streamhttp.EventMatch {
 	switch v := match.(type) {
 	case *result.FileMatch:
@@ -2,6 +2,9 @@ package main
 
 import (
 	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -9,6 +12,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/apiclient"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/command"
 	apiworker "github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/worker"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/executor"
 	"github.com/sourcegraph/sourcegraph/internal/env"
 	"github.com/sourcegraph/sourcegraph/internal/hostname"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
@@ -21,12 +25,19 @@ type Config struct {
 	FrontendUsername     string
 	FrontendPassword     string
 	QueueName            string
+	QueueNames           string
+	Queues               []executor.WeightedQueue
 	QueuePollInterval    time.Duration
 	MaximumNumJobs       int
 	FirecrackerImage     string
 	VMStartupScriptPath  string
 	VMPrefix             string
 	UseFirecracker       bool
+	UseProcessRunner     bool
+	ProcessEnvAllowlist  string
+	ProcessUseCgroups    bool
+	ProcessMaxOpenFiles  int
+	ProcessMaxProcesses  int
 	FirecrackerNumCPUs   int
 	FirecrackerMemory    string
 	FirecrackerDiskSpace string
@@ -34,16 +45,24 @@ type Config struct {
 	CleanupTaskInterval  time.Duration
 	NumTotalJobs         int
 	MaxActiveTime        time.Duration
+	CacheVolumesDir      string
+	CacheVolumesMaxSize  int
 }
 
 func (c *Config) Load() {
 	c.FrontendURL = c.Get("EXECUTOR_FRONTEND_URL", "", "The external URL of the sourcegraph instance.")
 	c.FrontendUsername = c.Get("EXECUTOR_FRONTEND_USERNAME", "", "The username supplied to the frontend.")
 	c.FrontendPassword = c.Get("EXECUTOR_FRONTEND_PASSWORD", "", "The password supplied to the frontend.")
-	c.QueueName = c.Get("EXECUTOR_QUEUE_NAME", "", "The name of the queue to listen to.")
+	c.QueueName = c.GetOptional("EXECUTOR_QUEUE_NAME", "The name of the queue to listen to.")
+	c.QueueNames = c.GetOptional("EXECUTOR_QUEUE_NAMES", "A comma-separated list of queues to listen to, each optionally followed by a colon and a positive weight (e.g. codeintel:3,batches:1). Mutually exclusive with EXECUTOR_QUEUE_NAME.")
 	c.QueuePollInterval = c.GetInterval("EXECUTOR_QUEUE_POLL_INTERVAL", "1s", "Interval between dequeue requests.")
 	c.MaximumNumJobs = c.GetInt("EXECUTOR_MAXIMUM_NUM_JOBS", "1", "Number of virtual machines or containers that can be running at once.")
 	c.UseFirecracker = c.GetBool("EXECUTOR_USE_FIRECRACKER", "true", "Whether to isolate commands in virtual machines.")
+	c.UseProcessRunner = c.GetBool("EXECUTOR_USE_PROCESS_RUNNER", "false", "Whether to run commands directly as child processes without docker. Only use this in trusted environments. Requires EXECUTOR_USE_FIRECRACKER=false.")
+	c.ProcessEnvAllowlist = c.GetOptional("EXECUTOR_PROCESS_ENV_ALLOWLIST", "A comma-separated list of host environment variables that are forwarded to commands run by the process runner.")
+	c.ProcessUseCgroups = c.GetBool("EXECUTOR_PROCESS_USE_CGROUPS", "false", "Whether to limit the CPU and memory of commands run by the process runner via systemd-run.")
+	c.ProcessMaxOpenFiles = c.GetInt("EXECUTOR_PROCESS_MAX_OPEN_FILES", "65536", "The maximum number of open files of commands run by the process runner. Set to 0 to inherit the limit of the executor.")
+	c.ProcessMaxProcesses = c.GetInt("EXECUTOR_PROCESS_MAX_PROCESSES", "4096", "The maximum number of processes of the user running commands with the process runner. Set to 0 to inherit the limit of the executor.")
 	c.FirecrackerImage = c.Get("EXECUTOR_FIRECRACKER_IMAGE", "sourcegraph/ignite-ubuntu:insiders", "The base image to use for virtual machines.")
 	c.VMStartupScriptPath = c.GetOptional("EXECUTOR_VM_STARTUP_SCRIPT_PATH", "A path to a file on the host that is loaded into a fresh virtual machine and executed on startup.")
 	c.VMPrefix = c.Get("EXECUTOR_VM_PREFIX", "executor", "A name prefix for virtual machines controlled by this instance.")
@@ -54,6 +73,8 @@ func (c *Config) Load() {
 	c.CleanupTaskInterval = c.GetInterval("EXECUTOR_CLEANUP_TASK_INTERVAL", "1m", "The frequency with which to run periodic cleanup tasks.")
 	c.NumTotalJobs = c.GetInt("EXECUTOR_NUM_TOTAL_JOBS", "0", "The maximum number of jobs that will be dequeued by the worker.")
 	c.MaxActiveTime = c.GetInterval("EXECUTOR_MAX_ACTIVE_TIME", "0", "The maximum time that can be spent by the worker dequeueing records to be handled.")
+	c.CacheVolumesDir = c.GetOptional("EXECUTOR_CACHE_VOLUMES_DIR", "A directory on the host that holds the cache volumes shared by jobs. Cache volumes are disabled if not set.")
+	c.CacheVolumesMaxSize = c.GetInt("EXECUTOR_CACHE_VOLUMES_MAX_SIZE_MB", "10240", "The maximum total size in megabytes of the cache volumes before the least recently used ones are removed.")
 }
 
 func (c *Config) Validate() error {
@@ -62,6 +83,24 @@ func (c *Config) Validate() error {
 		c.AddError(fmt.Errorf("EXECUTOR_FIRECRACKER_NUM_CPUS must be 1 or an even number"))
 	}
 
+	if (c.QueueName == "") == (c.QueueNames == "") {
+		c.AddError(fmt.Errorf("exactly one of EXECUTOR_QUEUE_NAME and EXECUTOR_QUEUE_NAMES must be set"))
+	} else if c.QueueNames != "" {
+		queues, err := parseQueueNames(c.QueueNames)
+		if err != nil {
+			c.AddError(fmt.Errorf("invalid EXECUTOR_QUEUE_NAMES: %w", err))
+		}
+		c.Queues = queues
+	}
+
+	if c.UseProcessRunner && c.UseFirecracker {
+		c.AddError(fmt.Errorf("EXECUTOR_USE_PROCESS_RUNNER requires EXECUTOR_USE_FIRECRACKER to be false"))
+	}
+
+	if c.CacheVolumesDir != "" && !filepath.IsAbs(c.CacheVolumesDir) {
+		c.AddError(fmt.Errorf("EXECUTOR_CACHE_VOLUMES_DIR must be an absolute path"))
+	}
+
 	return c.BaseConfig.Validate()
 }
 
@@ -69,10 +108,13 @@ func (c *Config) APIWorkerOptions() apiworker.Options {
 	return apiworker.Options{
 		VMPrefix:             c.VMPrefix,
 		QueueName:            c.QueueName,
+		Queues:               c.Queues,
 		WorkerOptions:        c.WorkerOptions(),
 		FirecrackerOptions:   c.FirecrackerOptions(),
+		ProcessOptions:       c.ProcessOptions(),
 		ResourceOptions:      c.ResourceOptions(),
 		MaximumRuntimePerJob: c.MaximumRuntimePerJob,
+		CacheVolumesDir:      c.CacheVolumesDir,
 		GitServicePath:       "/.executors/git",
 		ClientOptions:        c.ClientOptions(),
 		RedactedValues: map[string]string{
@@ -85,16 +127,31 @@ func (c *Config) APIWorkerOptions() apiworker.Options {
 
 func (c *Config) WorkerOptions() workerutil.WorkerOptions {
 	return workerutil.WorkerOptions{
-		Name:              fmt.Sprintf("executor_%s_worker", c.QueueName),
+		Name:              fmt.Sprintf("executor_%s_worker", c.queueLabel()),
 		NumHandlers:       c.MaximumNumJobs,
 		Interval:          c.QueuePollInterval,
 		HeartbeatInterval: 5 * time.Second,
-		Metrics:           makeWorkerMetrics(c.QueueName),
+		Metrics:           makeWorkerMetrics(c.queueLabel()),
 		NumTotalJobs:      c.NumTotalJobs,
 		MaxActiveTime:     c.MaxActiveTime,
 	}
 }
 
+// queueLabel returns the name of the queue the executor listens to, or the names of
+// all queues joined by underscores if it listens to multiple queues.
+func (c *Config) queueLabel() string {
+	if len(c.Queues) == 0 {
+		return c.QueueName
+	}
+
+	names := make([]string, 0, len(c.Queues))
+	for _, queue := range c.Queues {
+		names = append(names, queue.Name)
+	}
+
+	return strings.Join(names, "_")
+}
+
 func (c *Config) FirecrackerOptions() command.FirecrackerOptions {
 	return command.FirecrackerOptions{
 		Enabled:             c.UseFirecracker,
@@ -103,6 +160,23 @@ func (c *Config) FirecrackerOptions() command.FirecrackerOptions {
 	}
 }
 
+func (c *Config) ProcessOptions() command.ProcessOptions {
+	var allowedEnvVars []string
+	for _, name := range strings.Split(c.ProcessEnvAllowlist, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			allowedEnvVars = append(allowedEnvVars, name)
+		}
+	}
+
+	return command.ProcessOptions{
+		Enabled:        c.UseProcessRunner,
+		AllowedEnvVars: allowedEnvVars,
+		UseCgroups:     c.ProcessUseCgroups,
+		MaxOpenFiles:   c.ProcessMaxOpenFiles,
+		MaxProcesses:   c.ProcessMaxProcesses,
+	}
+}
+
 func (c *Config) ResourceOptions() command.ResourceOptions {
 	return command.ResourceOptions{
 		NumCPUs:   c.FirecrackerNumCPUs,
@@ -135,3 +209,33 @@ func (c *Config) EndpointOptions() apiclient.EndpointOptions {
 		Password: c.FrontendPassword,
 	}
 }
+
+// parseQueueNames parses a comma-separated list of queue names, each optionally followed
+// by a colon and a positive weight. Queues without an explicit weight have a weight of 1.
+func parseQueueNames(value string) ([]executor.WeightedQueue, error) {
+	var queues []executor.WeightedQueue
+	seen := map[string]struct{}{}
+
+	for _, part := range strings.Split(value, ",") {
+		name, weight := strings.TrimSpace(part), 1
+		if i := strings.LastIndex(name, ":"); i >= 0 {
+			w, err := strconv.Atoi(strings.TrimSpace(name[i+1:]))
+			if err != nil || w <= 0 {
+				return nil, fmt.Errorf("weight of queue %q must be a positive integer", name[:i])
+			}
+			name, weight = strings.TrimSpace(name[:i]), w
+		}
+
+		if name == "" {
+			return nil, fmt.Errorf("queue names must not be empty")
+		}
+		if _, ok := seen[name]; ok {
+			return nil, fmt.Errorf("queue %q is listed more than once", name)
+		}
+		seen[name] = struct{}{}
+
+		queues = append(queues, executor.WeightedQueue{Name: name, Weight: weight})
+	}
+
+	return queues, nil
+}
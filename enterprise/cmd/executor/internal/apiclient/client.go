@@ -76,6 +76,30 @@ func (c *Client) Dequeue(ctx context.Context, queueName string, job *executor.Jo
 	return c.client.DoAndDecode(ctx, req, &job)
 }
 
+// DequeueFromQueues dequeues a job from one of the given queues. The frontend picks the
+// queue, and the name of the queue is set on the returned job.
+func (c *Client) DequeueFromQueues(ctx context.Context, queues []executor.WeightedQueue, job *executor.Job) (_ bool, err error) {
+	queueNames := make([]string, 0, len(queues))
+	for _, queue := range queues {
+		queueNames = append(queueNames, queue.Name)
+	}
+	ctx, endObservation := c.operations.dequeue.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("queueNames", strings.Join(queueNames, ", ")),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	req, err := c.makeRequest("POST", "dequeue", executor.DequeueRequest{
+		ExecutorName:     c.options.ExecutorName,
+		ExecutorHostname: c.options.ExecutorHostname,
+		Queues:           queues,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return c.client.DoAndDecode(ctx, req, &job)
+}
+
 func (c *Client) AddExecutionLogEntry(ctx context.Context, queueName string, jobID int, entry workerutil.ExecutionLogEntry) (entryID int, err error) {
 	ctx, endObservation := c.operations.addExecutionLogEntry.With(ctx, &err, observation.Args{LogFields: []log.Field{
 		log.String("queueName", queueName),
@@ -117,6 +141,28 @@ func (c *Client) UpdateExecutionLogEntry(ctx context.Context, queueName string,
 	return c.client.DoAndDrop(ctx, req)
 }
 
+func (c *Client) AppendExecutionLogEntryOutput(ctx context.Context, queueName string, jobID, entryID int, out string) (err error) {
+	ctx, endObservation := c.operations.appendExecutionLogEntryOutput.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("queueName", queueName),
+		log.Int("jobID", jobID),
+		log.Int("entryID", entryID),
+		log.Int("outLen", len(out)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	req, err := c.makeRequest("POST", fmt.Sprintf("%s/appendExecutionLogEntryOutput", queueName), executor.AppendExecutionLogEntryOutputRequest{
+		ExecutorName: c.options.ExecutorName,
+		JobID:        jobID,
+		EntryID:      entryID,
+		Out:          out,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.client.DoAndDrop(ctx, req)
+}
+
 func (c *Client) MarkComplete(ctx context.Context, queueName string, jobID int) (err error) {
 	ctx, endObservation := c.operations.markComplete.With(ctx, &err, observation.Args{LogFields: []log.Field{
 		log.String("queueName", queueName),
@@ -82,6 +82,35 @@ func TestDequeueBadResponse(t *testing.T) {
 	})
 }
 
+func TestDequeueFromQueues(t *testing.T) {
+	spec := routeSpec{
+		expectedMethod:   "POST",
+		expectedPath:     "/.executors/queue/dequeue",
+		expectedUsername: "test",
+		expectedPassword: "hunter2",
+		expectedPayload:  `{"executorHostname": "", "executorName": "deadbeef", "queues": [{"name": "codeintel", "weight": 3}, {"name": "batches", "weight": 1}]}`,
+		responseStatus:   http.StatusOK,
+		responsePayload:  `{"id": 42, "queue": "batches"}`,
+	}
+
+	testRoute(t, spec, func(client *Client) {
+		var job executor.Job
+		dequeued, err := client.DequeueFromQueues(context.Background(), []executor.WeightedQueue{
+			{Name: "codeintel", Weight: 3},
+			{Name: "batches", Weight: 1},
+		}, &job)
+		if err != nil {
+			t.Fatalf("unexpected error dequeueing record: %s", err)
+		}
+		if !dequeued {
+			t.Fatalf("expected record to be dequeued")
+		}
+		if job.ID != 42 || job.Queue != "batches" {
+			t.Errorf("unexpected job. want=%d/%q have=%d/%q", 42, "batches", job.ID, job.Queue)
+		}
+	})
+}
+
 func TestAddExecutionLogEntry(t *testing.T) {
 	entry := workerutil.ExecutionLogEntry{
 		Key:        "foo",
@@ -232,6 +261,24 @@ func TestUpdateExecutionLogEntryBadResponse(t *testing.T) {
 	})
 }
 
+func TestAppendExecutionLogEntryOutput(t *testing.T) {
+	spec := routeSpec{
+		expectedMethod:   "POST",
+		expectedPath:     "/.executors/queue/test_queue/appendExecutionLogEntryOutput",
+		expectedUsername: "test",
+		expectedPassword: "hunter2",
+		expectedPayload:  `{"executorName": "deadbeef", "jobId": 42, "entryId": 99, "out": "stdout: hello\n"}`,
+		responseStatus:   http.StatusNoContent,
+		responsePayload:  ``,
+	}
+
+	testRoute(t, spec, func(client *Client) {
+		if err := client.AppendExecutionLogEntryOutput(context.Background(), "test_queue", 42, 99, "stdout: hello\n"); err != nil {
+			t.Fatalf("unexpected error appending log output: %s", err)
+		}
+	})
+}
+
 func TestMarkComplete(t *testing.T) {
 	spec := routeSpec{
 		expectedMethod:   "POST",
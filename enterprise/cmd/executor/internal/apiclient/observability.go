@@ -8,13 +8,14 @@ import (
 )
 
 type operations struct {
-	dequeue                 *observation.Operation
-	addExecutionLogEntry    *observation.Operation
-	updateExecutionLogEntry *observation.Operation
-	markComplete            *observation.Operation
-	markErrored             *observation.Operation
-	markFailed              *observation.Operation
-	heartbeat               *observation.Operation
+	dequeue                       *observation.Operation
+	addExecutionLogEntry          *observation.Operation
+	updateExecutionLogEntry       *observation.Operation
+	appendExecutionLogEntryOutput *observation.Operation
+	markComplete                  *observation.Operation
+	markErrored                   *observation.Operation
+	markFailed                    *observation.Operation
+	heartbeat                     *observation.Operation
 }
 
 func newOperations(observationContext *observation.Context) *operations {
@@ -34,12 +35,13 @@ func newOperations(observationContext *observation.Context) *operations {
 	}
 
 	return &operations{
-		dequeue:                 op("Dequeue"),
-		addExecutionLogEntry:    op("AddExecutionLogEntry"),
-		updateExecutionLogEntry: op("UpdateExecutionLogEntry"),
-		markComplete:            op("MarkComplete"),
-		markErrored:             op("MarkErrored"),
-		markFailed:              op("MarkFailed"),
-		heartbeat:               op("Heartbeat"),
+		dequeue:                       op("Dequeue"),
+		addExecutionLogEntry:          op("AddExecutionLogEntry"),
+		updateExecutionLogEntry:       op("UpdateExecutionLogEntry"),
+		appendExecutionLogEntryOutput: op("AppendExecutionLogEntryOutput"),
+		markComplete:                  op("MarkComplete"),
+		markErrored:                   op("MarkErrored"),
+		markFailed:                    op("MarkFailed"),
+		heartbeat:                     op("Heartbeat"),
 	}
 }
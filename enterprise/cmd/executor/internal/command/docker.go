@@ -31,7 +31,7 @@ func formatRawOrDockerCommand(spec CommandSpec, dir string, options Options) com
 		Command: flatten(
 			"docker", "run", "--rm",
 			dockerResourceFlags(options.ResourceOptions),
-			dockerVolumeFlags(dir, spec.ScriptPath),
+			dockerVolumeFlags(dir, spec.ScriptPath, options.CacheVolumes),
 			dockerWorkingdirectoryFlags(spec.Dir),
 			dockerEnvFlags(spec.Env),
 			dockerEntrypointFlags(),
@@ -49,8 +49,13 @@ func dockerResourceFlags(options ResourceOptions) []string {
 	}
 }
 
-func dockerVolumeFlags(wd, scriptPath string) []string {
-	return []string{"-v", wd + ":/data"}
+func dockerVolumeFlags(wd, scriptPath string, cacheVolumes []CacheVolume) []string {
+	flags := []string{"-v", wd + ":/data"}
+	for _, volume := range cacheVolumes {
+		flags = append(flags, "-v", volume.HostPath+":"+volume.MountPath)
+	}
+
+	return flags
 }
 
 func dockerWorkingdirectoryFlags(dir string) []string {
@@ -87,3 +87,41 @@ func TestFormatRawOrDockerCommandDockerCommand(t *testing.T) {
 		t.Errorf("unexpected command (-want +got):\n%s", diff)
 	}
 }
+
+func TestFormatRawOrDockerCommandDockerScriptCacheVolumes(t *testing.T) {
+	actual := formatRawOrDockerCommand(
+		CommandSpec{
+			Image:      "golang:latest",
+			ScriptPath: "myscript.sh",
+			Operation:  makeTestOperation(),
+		},
+		"/proj/src",
+		Options{
+			ResourceOptions: ResourceOptions{
+				NumCPUs: 4,
+				Memory:  "20G",
+			},
+			CacheVolumes: []CacheVolume{
+				{Name: "go-modules", HostPath: "/cache/go-modules", MountPath: "/go/pkg/mod"},
+			},
+		},
+	)
+
+	expected := command{
+		Command: []string{
+			"docker", "run", "--rm",
+			"--cpus", "4",
+			"--memory", "20G",
+			"-v", "/proj/src:/data",
+			"-v", "/cache/go-modules:/go/pkg/mod",
+			"-w", "/data",
+			"--entrypoint",
+			"/bin/sh",
+			"golang:latest",
+			"/data/.sourcegraph-executor/myscript.sh",
+		},
+	}
+	if diff := cmp.Diff(expected, actual, commandComparer); diff != "" {
+		t.Errorf("unexpected command (-want +got):\n%s", diff)
+	}
+}
@@ -3,6 +3,8 @@ package command
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
@@ -20,6 +22,10 @@ type commandRunner interface {
 
 const firecrackerContainerDir = "/work"
 
+// firecrackerCacheDir is the directory inside of the virtual machine to which cache
+// volumes are copied.
+const firecrackerCacheDir = "/cache"
+
 // formatFirecrackerCommand constructs the command to run on the host via a Firecracker
 // virtual machine in order to invoke the given spec. If the spec specifies an image, then
 // the command will be run inside of a container inside of the VM. Otherwise, the command
@@ -30,6 +36,7 @@ const firecrackerContainerDir = "/work"
 // also been the name supplied to a successful invocation of setupFirecracker. Additionally,
 // the virtual machine must not yet have been torn down (via teardownFirecracker).
 func formatFirecrackerCommand(spec CommandSpec, name, repoDir string, options Options) command {
+	options.CacheVolumes = firecrackerCacheVolumes(options.CacheVolumes)
 	rawOrDockerCommand := formatRawOrDockerCommand(spec, firecrackerContainerDir, options)
 
 	innerCommand := strings.Join(rawOrDockerCommand.Command, " ")
@@ -58,7 +65,8 @@ var igniteRunLock sync.Mutex
 
 // setupFirecracker invokes a set of commands to provision and prepare a Firecracker virtual
 // machine instance. If a startup script path (an executable file on the host) is supplied,
-// it will be mounted into the new virtual machine instance and executed.
+// it will be mounted into the new virtual machine instance and executed. The content of
+// the cache volumes is copied into the new virtual machine instance.
 func setupFirecracker(ctx context.Context, runner commandRunner, logger *Logger, name, repoDir string, options Options, operations *Operations) error {
 	// Start the VM and wait for the SSH server to become available
 	startCommand := command{
@@ -68,7 +76,7 @@ func setupFirecracker(ctx context.Context, runner commandRunner, logger *Logger,
 			"--runtime", "docker",
 			"--network-plugin", "cni",
 			firecrackerResourceFlags(options.ResourceOptions),
-			firecrackerCopyfileFlags(repoDir, options.FirecrackerOptions.VMStartupScriptPath),
+			firecrackerCopyfileFlags(repoDir, options.CacheVolumes, options.FirecrackerOptions.VMStartupScriptPath),
 			"--ssh",
 			"--name", name,
 			sanitizeImage(options.FirecrackerOptions.Image),
@@ -76,7 +84,18 @@ func setupFirecracker(ctx context.Context, runner commandRunner, logger *Logger,
 		Operation: operations.SetupFirecrackerStart,
 	}
 	igniteRunLock.Lock()
+	// Keep the content of the cache volumes from being replaced while it is copied into
+	// the VM.
+	volumeLocks := make([]*sync.RWMutex, 0, len(options.CacheVolumes))
+	for _, volume := range options.CacheVolumes {
+		lock := cacheVolumeLock(volume.HostPath)
+		lock.RLock()
+		volumeLocks = append(volumeLocks, lock)
+	}
 	err := errors.Wrap(runner.RunCommand(ctx, startCommand, logger), "failed to start firecracker vm")
+	for _, lock := range volumeLocks {
+		lock.RUnlock()
+	}
 	igniteRunLock.Unlock()
 	if err != nil {
 		return err
@@ -96,9 +115,15 @@ func setupFirecracker(ctx context.Context, runner commandRunner, logger *Logger,
 	return nil
 }
 
-// teardownFirecracker issues a stop and a remove request for the Firecracker VM with
-// the given name.
+// teardownFirecracker copies the content of the cache volumes out of the Firecracker VM
+// with the given name, then issues a stop and a remove request for it.
 func teardownFirecracker(ctx context.Context, runner commandRunner, logger *Logger, name string, options Options, operations *Operations) error {
+	for _, volume := range options.CacheVolumes {
+		if err := saveFirecrackerCacheVolume(ctx, runner, logger, name, volume, operations); err != nil {
+			log15.Error("Failed to save cache volume", "name", name, "volume", volume.Name, "err", err)
+		}
+	}
+
 	removeCommand := command{
 		Key:       "teardown.firecracker.remove",
 		Command:   flatten("ignite", "rm", "-f", name),
@@ -111,6 +136,96 @@ func teardownFirecracker(ctx context.Context, runner commandRunner, logger *Logg
 	return nil
 }
 
+// CacheVolumeStagingDir is the directory next to the cache volumes on the host into which
+// the content of cache volumes is copied out of virtual machines before it replaces the
+// content of the volumes. It is never a cache volume itself.
+const CacheVolumeStagingDir = ".staging"
+
+// cacheVolumeLocks guard the content of cache volumes on the host, keyed by their host
+// path. Replacing the content of a volume holds its write lock, while copying the content
+// into a virtual machine holds its read lock.
+var cacheVolumeLocks = struct {
+	sync.Mutex
+	locks map[string]*sync.RWMutex
+}{locks: map[string]*sync.RWMutex{}}
+
+func cacheVolumeLock(hostPath string) *sync.RWMutex {
+	cacheVolumeLocks.Lock()
+	defer cacheVolumeLocks.Unlock()
+
+	lock, ok := cacheVolumeLocks.locks[hostPath]
+	if !ok {
+		lock = &sync.RWMutex{}
+		cacheVolumeLocks.locks[hostPath] = lock
+	}
+	return lock
+}
+
+// saveFirecrackerCacheVolume replaces the content of the given cache volume on the host
+// with its content inside of the Firecracker VM with the given name. The content is
+// copied into the staging directory first, so that a failed copy leaves the volume intact
+// and the janitor doesn't mistake the copy for a volume.
+func saveFirecrackerCacheVolume(ctx context.Context, runner commandRunner, logger *Logger, name string, volume CacheVolume, operations *Operations) error {
+	stagingDir := filepath.Join(filepath.Dir(volume.HostPath), CacheVolumeStagingDir)
+	if err := os.MkdirAll(stagingDir, os.ModePerm); err != nil {
+		return err
+	}
+	tmpPath := filepath.Join(stagingDir, fmt.Sprintf("%s.%s", volume.Name, name))
+	oldPath := tmpPath + ".old"
+	if err := os.RemoveAll(tmpPath); err != nil {
+		return err
+	}
+
+	copyCommand := command{
+		Key:       fmt.Sprintf("teardown.cache-volume.%s", volume.Name),
+		Command:   flatten("ignite", "cp", fmt.Sprintf("%s:%s", name, filepath.Join(firecrackerCacheDir, volume.Name)), tmpPath),
+		Operation: operations.TeardownCacheVolume,
+	}
+	if err := runner.RunCommand(ctx, copyCommand, logger); err != nil {
+		_ = os.RemoveAll(tmpPath)
+		return err
+	}
+
+	lock := cacheVolumeLock(volume.HostPath)
+	lock.Lock()
+	err := swapCacheVolume(volume.HostPath, tmpPath, oldPath)
+	lock.Unlock()
+
+	// The previous content is no longer reachable through the volume, so it can be
+	// removed without holding the lock.
+	if removeErr := os.RemoveAll(oldPath); removeErr != nil && err == nil {
+		err = removeErr
+	}
+	return err
+}
+
+// swapCacheVolume moves the content at hostPath to oldPath and the content at tmpPath
+// to hostPath. If the latter fails, the previous content is restored.
+func swapCacheVolume(hostPath, tmpPath, oldPath string) error {
+	if err := os.Rename(hostPath, oldPath); err != nil && !os.IsNotExist(err) {
+		_ = os.RemoveAll(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, hostPath); err != nil {
+		_ = os.Rename(oldPath, hostPath)
+		_ = os.RemoveAll(tmpPath)
+		return err
+	}
+	return nil
+}
+
+// firecrackerCacheVolumes returns the given cache volumes with their host paths replaced
+// by the directories they are copied to inside of the virtual machine.
+func firecrackerCacheVolumes(cacheVolumes []CacheVolume) []CacheVolume {
+	vmCacheVolumes := make([]CacheVolume, 0, len(cacheVolumes))
+	for _, volume := range cacheVolumes {
+		volume.HostPath = filepath.Join(firecrackerCacheDir, volume.Name)
+		vmCacheVolumes = append(vmCacheVolumes, volume)
+	}
+
+	return vmCacheVolumes
+}
+
 func firecrackerResourceFlags(options ResourceOptions) []string {
 	return []string{
 		"--cpus", strconv.Itoa(options.NumCPUs),
@@ -119,11 +234,14 @@ func firecrackerResourceFlags(options ResourceOptions) []string {
 	}
 }
 
-func firecrackerCopyfileFlags(dir, vmStartupScriptPath string) []string {
-	copyfiles := make([]string, 0, 2)
+func firecrackerCopyfileFlags(dir string, cacheVolumes []CacheVolume, vmStartupScriptPath string) []string {
+	copyfiles := make([]string, 0, 2+len(cacheVolumes))
 	if dir != "" {
 		copyfiles = append(copyfiles, fmt.Sprintf("%s:%s", dir, firecrackerContainerDir))
 	}
+	for _, volume := range cacheVolumes {
+		copyfiles = append(copyfiles, fmt.Sprintf("%s:%s", volume.HostPath, filepath.Join(firecrackerCacheDir, volume.Name)))
+	}
 	if vmStartupScriptPath != "" {
 		copyfiles = append(copyfiles, fmt.Sprintf("%s:%s", vmStartupScriptPath, vmStartupScriptPath))
 	}
@@ -3,6 +3,8 @@ package command
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -166,6 +168,145 @@ func TestTeardownFirecracker(t *testing.T) {
 	}
 }
 
+func TestFormatFirecrackerCommandDockerScriptCacheVolumes(t *testing.T) {
+	actual := formatFirecrackerCommand(
+		CommandSpec{
+			Image:      "golang:latest",
+			ScriptPath: "myscript.sh",
+			Operation:  makeTestOperation(),
+		},
+		"deadbeef",
+		"/proj/src",
+		Options{
+			ResourceOptions: ResourceOptions{
+				NumCPUs: 4,
+				Memory:  "20G",
+			},
+			CacheVolumes: []CacheVolume{
+				{Name: "go-modules", HostPath: "/var/cache/go-modules", MountPath: "/go/pkg/mod"},
+			},
+		},
+	)
+
+	expected := command{
+		Command: []string{
+			"ignite", "exec", "deadbeef", "--",
+			strings.Join([]string{
+				"docker", "run", "--rm",
+				"--cpus", "4",
+				"--memory", "20G",
+				"-v", "/work:/data",
+				"-v", "/cache/go-modules:/go/pkg/mod",
+				"-w", "/data",
+				"--entrypoint /bin/sh",
+				"golang:latest",
+				"/data/.sourcegraph-executor/myscript.sh",
+			}, " "),
+		},
+	}
+	if diff := cmp.Diff(expected, actual, commandComparer); diff != "" {
+		t.Errorf("unexpected command (-want +got):\n%s", diff)
+	}
+}
+
+func TestSetupFirecrackerCacheVolumes(t *testing.T) {
+	runner := NewMockCommandRunner()
+	options := Options{
+		FirecrackerOptions: FirecrackerOptions{
+			Image: "ignite-ubuntu",
+		},
+		ResourceOptions: ResourceOptions{
+			NumCPUs:   4,
+			Memory:    "20G",
+			DiskSpace: "1T",
+		},
+		CacheVolumes: []CacheVolume{
+			{Name: "go-modules", HostPath: "/var/cache/go-modules", MountPath: "/go/pkg/mod"},
+		},
+	}
+	operations := NewOperations(&observation.TestContext)
+
+	if err := setupFirecracker(context.Background(), runner, nil, "deadbeef", "/proj", options, operations); err != nil {
+		t.Fatalf("unexpected error setting up virtual machine: %s", err)
+	}
+
+	var actual []string
+	for _, call := range runner.RunCommandFunc.History() {
+		actual = append(actual, strings.Join(call.Arg1.Command, " "))
+	}
+
+	expected := []string{
+		strings.Join([]string{
+			"ignite run",
+			"--runtime docker --network-plugin cni",
+			"--cpus 4 --memory 20G --size 1T",
+			"--copy-files /proj:/work",
+			"--copy-files /var/cache/go-modules:/cache/go-modules",
+			"--ssh --name deadbeef",
+			"ignite-ubuntu",
+		}, " "),
+	}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("unexpected commands (-want +got):\n%s", diff)
+	}
+}
+
+func TestTeardownFirecrackerCacheVolumes(t *testing.T) {
+	hostPath := filepath.Join(t.TempDir(), "go-modules")
+	if err := os.MkdirAll(hostPath, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	runner := NewMockCommandRunner()
+	runner.RunCommandFunc.SetDefaultHook(func(ctx context.Context, command command, logger *Logger) error {
+		if command.Command[1] != "cp" {
+			return nil
+		}
+
+		// Simulate the copy out of the virtual machine.
+		return os.WriteFile(command.Command[len(command.Command)-1], []byte("cached"), os.ModePerm)
+	})
+	options := Options{
+		CacheVolumes: []CacheVolume{
+			{Name: "go-modules", HostPath: hostPath, MountPath: "/go/pkg/mod"},
+		},
+	}
+	operations := NewOperations(&observation.TestContext)
+
+	if err := teardownFirecracker(context.Background(), runner, nil, "deadbeef", options, operations); err != nil {
+		t.Fatalf("unexpected error tearing down virtual machine: %s", err)
+	}
+
+	var actual []string
+	for _, call := range runner.RunCommandFunc.History() {
+		actual = append(actual, strings.Join(call.Arg1.Command, " "))
+	}
+
+	expected := []string{
+		"ignite cp deadbeef:/cache/go-modules " + filepath.Join(filepath.Dir(hostPath), CacheVolumeStagingDir, "go-modules.deadbeef"),
+		"ignite rm -f deadbeef",
+	}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("unexpected commands (-want +got):\n%s", diff)
+	}
+
+	content, err := os.ReadFile(hostPath)
+	if err != nil {
+		t.Fatalf("unexpected error reading cache volume: %s", err)
+	}
+	if string(content) != "cached" {
+		t.Errorf("unexpected cache volume content: %q", content)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(filepath.Dir(hostPath), CacheVolumeStagingDir))
+	if err != nil {
+		t.Fatalf("unexpected error reading staging directory: %s", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("unexpected leftovers in staging directory: %v", entries)
+	}
+}
+
 func TestSanitizeImage(t *testing.T) {
 	image := "sourcegraph/ignite-ubuntu"
 	tag := ":insiders"
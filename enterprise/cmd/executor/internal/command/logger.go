@@ -13,9 +13,11 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 )
 
-type executionLogEntryStore interface {
+// ExecutionLogEntryStore is the store the log entries of a job are written to.
+type ExecutionLogEntryStore interface {
 	AddExecutionLogEntry(ctx context.Context, id int, entry workerutil.ExecutionLogEntry) (int, error)
 	UpdateExecutionLogEntry(ctx context.Context, id, entryID int, entry workerutil.ExecutionLogEntry) error
+	AppendExecutionLogEntryOutput(ctx context.Context, id, entryID int, out string) error
 }
 
 // entryHandle is returned by (*Logger).Log and implements the io.WriteCloser
@@ -31,6 +33,8 @@ type entryHandle struct {
 
 	mu  sync.Mutex
 	buf *bytes.Buffer
+	// synced is the length of the prefix of buf that has been written to the store.
+	synced int
 }
 
 func (h *entryHandle) Write(p []byte) (n int, err error) {
@@ -57,10 +61,40 @@ func (h *entryHandle) CurrentLogEntry() workerutil.ExecutionLogEntry {
 	return logEntry
 }
 
+// initialLogEntry returns the current log entry and marks its output as synced.
+func (h *entryHandle) initialLogEntry() workerutil.ExecutionLogEntry {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	logEntry := h.logEntry
+	logEntry.Out = h.buf.String()
+	h.synced = h.buf.Len()
+	redact(&logEntry, h.replacer)
+	return logEntry
+}
+
+// unsyncedOutput returns the redacted output written since the last sync along
+// with the length of the output once it has been synced. Output is written line
+// by line, so the returned output never ends within a line.
+func (h *entryHandle) unsyncedOutput() (string, int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.replacer.Replace(string(h.buf.Bytes()[h.synced:])), h.buf.Len()
+}
+
+// markSynced marks the output up to the given length as synced.
+func (h *entryHandle) markSynced(synced int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.synced = synced
+}
+
 // Logger tracks command invocations and stores the command's output and
 // error stream values.
 type Logger struct {
-	store   executionLogEntryStore
+	store   ExecutionLogEntryStore
 	done    chan struct{}
 	handles chan *entryHandle
 
@@ -80,7 +114,7 @@ const logEntryBufsize = 50
 // replace with a non-sensitive value.
 // Each log message is written to the store in a goroutine. The Flush method
 // must be called to ensure all entries are written.
-func NewLogger(store executionLogEntryStore, job executor.Job, recordID int, replacements map[string]string) *Logger {
+func NewLogger(store ExecutionLogEntryStore, job executor.Job, recordID int, replacements map[string]string) *Logger {
 	oldnew := make([]string, 0, len(replacements)*2)
 	for k, v := range replacements {
 		oldnew = append(oldnew, k, v)
@@ -121,7 +155,7 @@ func (l *Logger) writeEntries() {
 	var wg sync.WaitGroup
 	for handle := range l.handles {
 
-		initialLogEntry := handle.CurrentLogEntry()
+		initialLogEntry := handle.initialLogEntry()
 		entryID, err := l.store.AddExecutionLogEntry(context.Background(), l.recordID, initialLogEntry)
 		if err != nil {
 			// If there is a timeout or cancellation error we don't want to skip
@@ -155,6 +189,13 @@ func (l *Logger) syncLogEntry(handle *entryHandle, entryID int, old workerutil.E
 		case <-time.After(syncLogEntryInterval):
 		}
 
+		if !lastWrite {
+			// While the command is running, only the output written since the last sync is
+			// appended to the entry, so it can be followed while the command is running.
+			l.appendLogEntryOutput(handle, entryID)
+			continue
+		}
+
 		current := handle.CurrentLogEntry()
 		if !entryWasUpdated(old, current) {
 			continue
@@ -179,27 +220,53 @@ func (l *Logger) syncLogEntry(handle *entryHandle, entryID int, old workerutil.E
 
 		log15.Info("Updating executor log entry", logArgs...)
 
+		// The final write replaces the entry as a whole, which also repairs output that
+		// failed to be appended earlier.
 		if err := l.store.UpdateExecutionLogEntry(context.Background(), l.recordID, entryID, current); err != nil {
-			logMethod := log15.Warn
-			if lastWrite {
-				logMethod = log15.Error
-			}
-
-			logMethod(
+			log15.Error(
 				"Failed to update executor log entry for job",
 				"jobID", l.job.ID,
 				"repositoryName", l.job.RepositoryName,
 				"commit", l.job.Commit,
 				"entryID", entryID,
-				"lastWrite", lastWrite,
 				"error", err,
 			)
-		} else {
-			old = current
 		}
 	}
 }
 
+// appendLogEntryOutput appends the output written since the last sync to the entry.
+func (l *Logger) appendLogEntryOutput(handle *entryHandle, entryID int) {
+	out, synced := handle.unsyncedOutput()
+	if out == "" {
+		return
+	}
+
+	log15.Info(
+		"Appending executor log entry output",
+		"jobID", l.job.ID,
+		"repositoryName", l.job.RepositoryName,
+		"commit", l.job.Commit,
+		"entryID", entryID,
+		"outLen", len(out),
+	)
+
+	if err := l.store.AppendExecutionLogEntryOutput(context.Background(), l.recordID, entryID, out); err != nil {
+		// The output is appended again with the next sync.
+		log15.Warn(
+			"Failed to append executor log entry output for job",
+			"jobID", l.job.ID,
+			"repositoryName", l.job.RepositoryName,
+			"commit", l.job.Commit,
+			"entryID", entryID,
+			"error", err,
+		)
+		return
+	}
+
+	handle.markSynced(synced)
+}
+
 // If old didn't have exit code or duration and current does, update; we're finished.
 // Otherwise, update if the log text has changed since the last write to the API.
 func entryWasUpdated(old, current workerutil.ExecutionLogEntry) bool {
@@ -0,0 +1,77 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+)
+
+func TestLoggerAppendLogEntryOutput(t *testing.T) {
+	store := &fakeLogEntryStore{}
+	logger := &Logger{store: store, recordID: 42}
+	handle := newTestEntryHandle(strings.NewReplacer("hunter2", "PASSWORD_REMOVED"))
+
+	fmt.Fprintf(handle, "stdout: cloning\n")
+	entryID, err := store.AddExecutionLogEntry(context.Background(), 42, handle.initialLogEntry())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fmt.Fprintf(handle, "stdout: password hunter2\n")
+	logger.appendLogEntryOutput(handle, entryID)
+	fmt.Fprintf(handle, "stderr: done\n")
+	logger.appendLogEntryOutput(handle, entryID)
+	logger.appendLogEntryOutput(handle, entryID)
+
+	expectedOut := "stdout: cloning\nstdout: password PASSWORD_REMOVED\nstderr: done\n"
+	if out := store.entries()[0].Out; out != expectedOut {
+		t.Errorf("unexpected output. want=%q have=%q", expectedOut, out)
+	}
+}
+
+func TestLoggerAppendLogEntryOutputError(t *testing.T) {
+	store := &failingAppendStore{}
+	logger := &Logger{store: store, recordID: 42}
+	handle := newTestEntryHandle(strings.NewReplacer())
+
+	handle.initialLogEntry()
+	fmt.Fprintf(handle, "stdout: a\n")
+	logger.appendLogEntryOutput(handle, 1)
+	fmt.Fprintf(handle, "stdout: b\n")
+	logger.appendLogEntryOutput(handle, 1)
+
+	// Output that failed to be appended is appended again with the next sync.
+	expected := []string{"stdout: a\n", "stdout: a\nstdout: b\n"}
+	if len(store.appended) != len(expected) {
+		t.Fatalf("unexpected number of appends. want=%d have=%d", len(expected), len(store.appended))
+	}
+	for i, out := range expected {
+		if store.appended[i] != out {
+			t.Errorf("unexpected output of append #%d. want=%q have=%q", i, out, store.appended[i])
+		}
+	}
+}
+
+func newTestEntryHandle(replacer *strings.Replacer) *entryHandle {
+	return &entryHandle{
+		logEntry: workerutil.ExecutionLogEntry{Key: "step.docker.0"},
+		replacer: replacer,
+		buf:      &bytes.Buffer{},
+		done:     make(chan struct{}),
+	}
+}
+
+type failingAppendStore struct {
+	fakeLogEntryStore
+	appended []string
+}
+
+func (s *failingAppendStore) AppendExecutionLogEntryOutput(ctx context.Context, id, entryID int, out string) error {
+	s.appended = append(s.appended, out)
+	return errors.New("oops")
+}
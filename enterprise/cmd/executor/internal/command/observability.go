@@ -15,6 +15,7 @@ type Operations struct {
 	SetupFirecrackerStart     *observation.Operation
 	SetupStartupScript        *observation.Operation
 	TeardownFirecrackerRemove *observation.Operation
+	TeardownCacheVolume       *observation.Operation
 	Exec                      *observation.Operation
 }
 
@@ -42,6 +43,7 @@ func NewOperations(observationContext *observation.Context) *Operations {
 		SetupFirecrackerStart:     op("setup.firecracker.start"),
 		SetupStartupScript:        op("setup.startup-script"),
 		TeardownFirecrackerRemove: op("teardown.firecracker.remove"),
+		TeardownCacheVolume:       op("teardown.cache-volume"),
 		Exec:                      op("exec"),
 	}
 }
@@ -0,0 +1,131 @@
+package command
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// formatProcessCommand constructs the command to run on the host in order to invoke
+// the given spec directly as a child process of the executor. If the spec specifies
+// an image, the image is ignored and the step script is run by the host shell in the
+// given working directory instead. Every command runs in its own process group, which
+// is killed once the command finishes, and is subject to the process limits in the
+// given options. If cgroups are enabled, the command is run in a transient systemd scope
+// subject to the resource limits specified in the given options.
+func formatProcessCommand(spec CommandSpec, dir string, options Options) command {
+	innerCommand := spec.Command
+	if spec.Image != "" {
+		innerCommand = []string{"/bin/sh", filepath.Join(dir, ScriptsPath, spec.ScriptPath)}
+	}
+
+	return command{
+		Key: spec.Key,
+		Command: flatten(
+			processRlimitFlags(options.ProcessOptions),
+			processCgroupFlags(options.ProcessOptions, options.ResourceOptions),
+			innerCommand,
+		),
+		Dir:          filepath.Join(dir, spec.Dir),
+		Env:          flatten(spec.Env, processEnv(options.ProcessOptions.AllowedEnvVars)),
+		ProcessGroup: true,
+		Wrapped:      true,
+		Operation:    spec.Operation,
+	}
+}
+
+// processWrappers are the binaries that the commands of the process runner may be
+// wrapped in. Each invocation ends with "--", after which the wrapped command follows.
+// These binaries are not allowed in commands of any other runner.
+var processWrappers = []string{"prlimit", "systemd-run"}
+
+// validateProcessCommand validates a command formatted by formatProcessCommand. The
+// prlimit and systemd-run invocations wrapping the command are skipped, and the wrapped
+// command must either run a step script with /bin/sh or be an allowed binary.
+func validateProcessCommand(command []string) error {
+	for len(command) > 0 && contains(processWrappers, command[0]) {
+		i := 1
+		for i < len(command) && command[i] != "--" {
+			// Anything but a flag or the value of a systemd-run property would be run as the
+			// wrapped command
+			if !strings.HasPrefix(command[i], "-") && command[i-1] != "-p" {
+				return ErrIllegalCommand
+			}
+			i++
+		}
+		if i == len(command) {
+			return ErrIllegalCommand
+		}
+		command = command[i+1:]
+	}
+
+	if len(command) > 0 && command[0] == "/bin/sh" {
+		// Only step scripts are run by the shell; inline payloads such as sh -c are rejected
+		if len(command) != 2 || filepath.Base(filepath.Dir(command[1])) != ScriptsPath {
+			return ErrIllegalCommand
+		}
+		return nil
+	}
+
+	return validateCommand(command)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// processEnv returns the values of the given host environment variables that are set.
+// The variables in forwardedHostEnvVars are always forwarded and need not be listed.
+func processEnv(allowedEnvVars []string) []string {
+	env := make([]string, 0, len(allowedEnvVars))
+	for _, k := range allowedEnvVars {
+		if v, ok := os.LookupEnv(k); ok {
+			env = append(env, fmt.Sprintf("%s=%s", k, v))
+		}
+	}
+
+	return env
+}
+
+// processRlimitFlags returns the prlimit invocation that applies the process limits in
+// the given options to the command following it, if any limits are set.
+func processRlimitFlags(processOptions ProcessOptions) []string {
+	var limits []string
+	if processOptions.MaxOpenFiles > 0 {
+		limits = append(limits, fmt.Sprintf("--nofile=%d", processOptions.MaxOpenFiles))
+	}
+	if processOptions.MaxProcesses > 0 {
+		limits = append(limits, fmt.Sprintf("--nproc=%d", processOptions.MaxProcesses))
+	}
+	if len(limits) == 0 {
+		return nil
+	}
+
+	return flatten("prlimit", limits, "--")
+}
+
+func processCgroupFlags(processOptions ProcessOptions, resourceOptions ResourceOptions) []string {
+	if !processOptions.UseCgroups {
+		return nil
+	}
+
+	return []string{
+		"systemd-run", "--scope", "--quiet", "--collect",
+		"-p", fmt.Sprintf("CPUQuota=%d%%", resourceOptions.NumCPUs*100),
+		"-p", fmt.Sprintf("MemoryMax=%s", systemdSize(resourceOptions.Memory)),
+		"--",
+	}
+}
+
+// systemdSize converts a docker-style size (e.g. 12g) into a size understood by
+// systemd (e.g. 12G).
+func systemdSize(size string) string {
+	return strings.TrimSuffix(strings.ToUpper(size), "B")
+}
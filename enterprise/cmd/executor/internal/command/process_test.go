@@ -0,0 +1,337 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/executor"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+)
+
+func TestFormatProcessCommandRaw(t *testing.T) {
+	t.Setenv("EXECUTOR_TEST_ALLOWED", "yes")
+
+	actual := formatProcessCommand(
+		CommandSpec{
+			Command:   []string{"src", "batch", "exec"},
+			Dir:       "subdir",
+			Env:       []string{"TEST=true"},
+			Operation: makeTestOperation(),
+		},
+		"/proj/src",
+		Options{
+			ProcessOptions: ProcessOptions{
+				Enabled:        true,
+				AllowedEnvVars: []string{"EXECUTOR_TEST_ALLOWED", "EXECUTOR_TEST_UNSET"},
+			},
+		},
+	)
+
+	expected := command{
+		Command: []string{"src", "batch", "exec"},
+		Dir:     "/proj/src/subdir",
+		Env:     []string{"TEST=true", "EXECUTOR_TEST_ALLOWED=yes"},
+	}
+	if diff := cmp.Diff(expected, actual, commandComparer); diff != "" {
+		t.Errorf("unexpected command (-want +got):\n%s", diff)
+	}
+}
+
+func TestFormatProcessCommandScript(t *testing.T) {
+	actual := formatProcessCommand(
+		CommandSpec{
+			Image:      "alpine:latest",
+			ScriptPath: "myscript.sh",
+			Dir:        "subdir",
+			Env:        []string{"TEST=true"},
+			Operation:  makeTestOperation(),
+		},
+		"/proj/src",
+		Options{
+			ProcessOptions: ProcessOptions{Enabled: true},
+		},
+	)
+
+	expected := command{
+		Command: []string{"/bin/sh", "/proj/src/.sourcegraph-executor/myscript.sh"},
+		Dir:     "/proj/src/subdir",
+		Env:     []string{"TEST=true"},
+	}
+	if diff := cmp.Diff(expected, actual, commandComparer); diff != "" {
+		t.Errorf("unexpected command (-want +got):\n%s", diff)
+	}
+}
+
+func TestFormatProcessCommandScriptCgroups(t *testing.T) {
+	actual := formatProcessCommand(
+		CommandSpec{
+			Image:      "alpine:latest",
+			ScriptPath: "myscript.sh",
+			Operation:  makeTestOperation(),
+		},
+		"/proj/src",
+		Options{
+			ProcessOptions: ProcessOptions{Enabled: true, UseCgroups: true},
+			ResourceOptions: ResourceOptions{
+				NumCPUs: 4,
+				Memory:  "20g",
+			},
+		},
+	)
+
+	expected := command{
+		Command: []string{
+			"systemd-run", "--scope", "--quiet", "--collect",
+			"-p", "CPUQuota=400%",
+			"-p", "MemoryMax=20G",
+			"--",
+			"/bin/sh", "/proj/src/.sourcegraph-executor/myscript.sh",
+		},
+		Dir: "/proj/src",
+		Env: []string{},
+	}
+	if diff := cmp.Diff(expected, actual, commandComparer); diff != "" {
+		t.Errorf("unexpected command (-want +got):\n%s", diff)
+	}
+}
+
+func TestFormatProcessCommandRawLimits(t *testing.T) {
+	actual := formatProcessCommand(
+		CommandSpec{
+			Command:   []string{"src", "batch", "exec"},
+			Operation: makeTestOperation(),
+		},
+		"/proj/src",
+		Options{
+			ProcessOptions: ProcessOptions{
+				Enabled:      true,
+				UseCgroups:   true,
+				MaxOpenFiles: 1024,
+				MaxProcesses: 512,
+			},
+			ResourceOptions: ResourceOptions{
+				NumCPUs: 2,
+				Memory:  "4g",
+			},
+		},
+	)
+
+	expected := command{
+		Command: []string{
+			"prlimit", "--nofile=1024", "--nproc=512", "--",
+			"systemd-run", "--scope", "--quiet", "--collect",
+			"-p", "CPUQuota=200%",
+			"-p", "MemoryMax=4G",
+			"--",
+			"src", "batch", "exec",
+		},
+		Dir: "/proj/src",
+		Env: []string{},
+	}
+	if diff := cmp.Diff(expected, actual, commandComparer); diff != "" {
+		t.Errorf("unexpected command (-want +got):\n%s", diff)
+	}
+	if !actual.ProcessGroup {
+		t.Error("expected command to run in its own process group")
+	}
+}
+
+func TestProcessRunnerIllegalCommand(t *testing.T) {
+	options := Options{ProcessOptions: ProcessOptions{Enabled: true, MaxOpenFiles: 1024}}
+	runner := NewRunner(t.TempDir(), nil, options, NewOperations(&observation.TestContext))
+
+	err := runner.Run(context.Background(), CommandSpec{
+		Command:   []string{"rm", "-rf", "/"},
+		Operation: makeTestOperation(),
+	})
+	if err != ErrIllegalCommand {
+		t.Fatalf("unexpected error. want=%q have=%q", ErrIllegalCommand, err)
+	}
+}
+
+func TestProcessRunnerKillsProcessGroup(t *testing.T) {
+	if _, err := os.Stat("/bin/sh"); err != nil {
+		t.Skip("/bin/sh is not available")
+	}
+
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, ScriptsPath), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	// The background process doesn't hold on to the output pipes, so the step finishes
+	// while it is still running.
+	script := "sleep 60 >/dev/null 2>&1 &\necho $! > pid\n"
+	if err := os.WriteFile(filepath.Join(dir, ScriptsPath, "step.sh"), []byte(script), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	logger := NewLogger(&fakeLogEntryStore{}, executor.Job{}, 42, nil)
+	options := Options{ProcessOptions: ProcessOptions{Enabled: true}}
+	runner := NewRunner(dir, logger, options, NewOperations(&observation.TestContext))
+
+	err := runner.Run(context.Background(), CommandSpec{
+		Key:        "step.docker.0",
+		Image:      "alpine:latest",
+		ScriptPath: "step.sh",
+		Operation:  makeTestOperation(),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error running command: %s", err)
+	}
+	logger.Flush()
+
+	content, err := os.ReadFile(filepath.Join(dir, "pid"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(content)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The killed background process is a zombie until its parent, which is gone, is
+	// replaced by init and reaps it.
+	for i := 0; i < 50; i++ {
+		if err := syscall.Kill(pid, 0); err == syscall.ESRCH {
+			return
+		}
+		if state, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid)); err == nil && strings.Contains(string(state), ") Z ") {
+			return
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	t.Errorf("background process %d is still running", pid)
+}
+
+func TestProcessRunner(t *testing.T) {
+	if _, err := os.Stat("/bin/sh"); err != nil {
+		t.Skip("/bin/sh is not available")
+	}
+
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, ScriptsPath), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	script := "echo \"$GREETING from $(basename $(pwd))\"\necho secret-token\n"
+	if err := os.WriteFile(filepath.Join(dir, ScriptsPath, "step.sh"), []byte(script), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	store := &fakeLogEntryStore{}
+	logger := NewLogger(store, executor.Job{}, 42, map[string]string{"secret-token": "REDACTED"})
+
+	options := Options{ProcessOptions: ProcessOptions{Enabled: true}}
+	runner := NewRunner(dir, logger, options, NewOperations(&observation.TestContext))
+	if _, ok := runner.(*processRunner); !ok {
+		t.Fatalf("unexpected runner type %T", runner)
+	}
+
+	err := runner.Run(context.Background(), CommandSpec{
+		Key:        "step.docker.0",
+		Image:      "alpine:latest",
+		ScriptPath: "step.sh",
+		Env:        []string{"GREETING=hello"},
+		Operation:  makeTestOperation(),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error running command: %s", err)
+	}
+	logger.Flush()
+
+	entries := store.entries()
+	if len(entries) != 1 {
+		t.Fatalf("unexpected number of log entries. want=%d have=%d", 1, len(entries))
+	}
+	if entries[0].ExitCode == nil || *entries[0].ExitCode != 0 {
+		t.Errorf("unexpected exit code %v", entries[0].ExitCode)
+	}
+
+	expectedOut := "stdout: hello from " + filepath.Base(dir) + "\nstdout: REDACTED\n"
+	if entries[0].Out != expectedOut {
+		t.Errorf("unexpected output. want=%q have=%q", expectedOut, entries[0].Out)
+	}
+}
+
+type fakeLogEntryStore struct {
+	mu         sync.Mutex
+	logEntries []workerutil.ExecutionLogEntry
+}
+
+func (s *fakeLogEntryStore) AddExecutionLogEntry(ctx context.Context, id int, entry workerutil.ExecutionLogEntry) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.logEntries = append(s.logEntries, entry)
+	return len(s.logEntries), nil
+}
+
+func (s *fakeLogEntryStore) UpdateExecutionLogEntry(ctx context.Context, id, entryID int, entry workerutil.ExecutionLogEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.logEntries[entryID-1] = entry
+	return nil
+}
+
+func (s *fakeLogEntryStore) AppendExecutionLogEntryOutput(ctx context.Context, id, entryID int, out string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.logEntries[entryID-1].Out += out
+	return nil
+}
+
+func (s *fakeLogEntryStore) entries() []workerutil.ExecutionLogEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]workerutil.ExecutionLogEntry(nil), s.logEntries...)
+}
+
+func TestValidateProcessCommand(t *testing.T) {
+	for _, tc := range []struct {
+		command []string
+		err     error
+	}{
+		{command: []string{"src", "batch", "exec"}},
+		{command: []string{"prlimit", "--nofile=1024", "--", "git", "fetch"}},
+		{command: []string{"prlimit", "--nofile=1024", "--", "systemd-run", "--scope", "-p", "MemoryMax=12G", "--", "src", "batch", "exec"}},
+		{command: []string{"prlimit", "--nofile=1024", "--", "/bin/sh", "/proj/src/.sourcegraph-executor/myscript.sh"}},
+		{command: []string{"prlimit", "--nofile=1024", "--", "rm", "-rf", "/"}, err: ErrIllegalCommand},
+		{command: []string{"systemd-run", "--scope", "--", "prlimit", "--nofile=1024", "--", "rm", "-rf", "/"}, err: ErrIllegalCommand},
+		{command: []string{"prlimit", "--nofile=1024", "rm", "--", "src"}, err: ErrIllegalCommand},
+		{command: []string{"prlimit", "--nofile=1024", "rm", "-rf", "/"}, err: ErrIllegalCommand},
+		{command: []string{"prlimit", "--nofile=1024", "--"}, err: ErrIllegalCommand},
+		{command: []string{"/bin/sh", "-c", "rm -rf /"}, err: ErrIllegalCommand},
+		{command: []string{"prlimit", "--nofile=1024", "--", "/bin/sh", "-c", "src; rm -rf /"}, err: ErrIllegalCommand},
+		{command: []string{"/bin/sh", "/tmp/myscript.sh"}, err: ErrIllegalCommand},
+	} {
+		if err := validateProcessCommand(tc.command); err != tc.err {
+			t.Errorf("unexpected error for %q. want=%v have=%v", tc.command, tc.err, err)
+		}
+	}
+}
+
+func TestProcessRunnerIllegalWrappedCommand(t *testing.T) {
+	options := Options{ProcessOptions: ProcessOptions{Enabled: true, MaxOpenFiles: 1024, UseCgroups: true}, ResourceOptions: ResourceOptions{NumCPUs: 1, Memory: "1g"}}
+	runner := NewRunner(t.TempDir(), nil, options, NewOperations(&observation.TestContext))
+
+	err := runner.Run(context.Background(), CommandSpec{
+		Command:   []string{"prlimit", "--nofile=1024", "--", "rm", "-rf", "/"},
+		Operation: makeTestOperation(),
+	})
+	if err != ErrIllegalCommand {
+		t.Fatalf("unexpected error. want=%q have=%q", ErrIllegalCommand, err)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"os/exec"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/cockroachdb/errors"
@@ -19,10 +20,21 @@ import (
 )
 
 type command struct {
-	Key       string
-	Command   []string
-	Dir       string
-	Env       []string
+	Key     string
+	Command []string
+	Dir     string
+	Env     []string
+
+	// ProcessGroup determines if the command runs in its own process group. The whole
+	// group is killed once the command exits or is canceled, so that no processes it
+	// started in the background outlive it.
+	ProcessGroup bool
+
+	// Wrapped determines if the command is a command of the process runner, which may be
+	// wrapped in prlimit and systemd-run invocations and may run a step script with
+	// /bin/sh. The wrapped command is validated like any other command.
+	Wrapped bool
+
 	Operation *observation.Operation
 }
 
@@ -41,7 +53,11 @@ func runCommand(ctx context.Context, command command, logger *Logger) (err error
 
 	log15.Info(fmt.Sprintf("Running command: %s", strings.Join(command.Command, " ")))
 
-	if err := validateCommand(command.Command); err != nil {
+	validate := validateCommand
+	if command.Wrapped {
+		validate = validateProcessCommand
+	}
+	if err := validate(command.Command); err != nil {
 		return err
 	}
 
@@ -137,6 +153,10 @@ func prepCommand(ctx context.Context, command command) (cmd *exec.Cmd, stdout, s
 
 	cmd.Env = env
 
+	if command.ProcessGroup {
+		cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	}
+
 	stdout, err = cmd.StdoutPipe()
 	if err != nil {
 		return nil, nil, nil, err
@@ -182,8 +202,17 @@ func monitorCommand(ctx context.Context, cmd *exec.Cmd, pipeReaderWaitGroup *syn
 		return 0, err
 	}
 
+	if cmd.SysProcAttr != nil && cmd.SysProcAttr.Setpgid {
+		// The process group has the ID of the command. Killing it after the command
+		// exited also kills the processes it left behind.
+		defer killProcessGroup(cmd.Process.Pid)
+	}
+
 	select {
 	case <-ctx.Done():
+		if cmd.SysProcAttr != nil && cmd.SysProcAttr.Setpgid {
+			killProcessGroup(cmd.Process.Pid)
+		}
 	case <-watchWaitGroup(pipeReaderWaitGroup):
 	}
 
@@ -197,6 +226,13 @@ func monitorCommand(ctx context.Context, cmd *exec.Cmd, pipeReaderWaitGroup *syn
 	return 0, nil
 }
 
+// killProcessGroup kills all processes in the process group with the given ID.
+func killProcessGroup(pgid int) {
+	if err := syscall.Kill(-pgid, syscall.SIGKILL); err != nil && err != syscall.ESRCH {
+		log15.Error("Failed to kill process group", "pgid", pgid, "error", err)
+	}
+}
+
 func watchWaitGroup(wg *sync.WaitGroup) <-chan struct{} {
 	ch := make(chan struct{})
 	go func() {
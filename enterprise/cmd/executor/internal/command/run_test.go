@@ -24,3 +24,19 @@ func TestRunCommandIllegalCommand(t *testing.T) {
 		t.Errorf("unexpected error. want=%q have=%q", ErrIllegalCommand, err)
 	}
 }
+
+func TestRunCommandProcessWrapper(t *testing.T) {
+	// The wrappers of the process runner are not allowed in any other command.
+	for _, c := range [][]string{
+		{"prlimit", "--nofile=1024", "--", "git", "fetch"},
+		{"/bin/sh", "/proj/src/.sourcegraph-executor/myscript.sh"},
+	} {
+		command := command{
+			Command:   c,
+			Operation: makeTestOperation(),
+		}
+		if err := runCommand(context.Background(), command, nil); err != ErrIllegalCommand {
+			t.Errorf("unexpected error. want=%q have=%q", ErrIllegalCommand, err)
+		}
+	}
+}
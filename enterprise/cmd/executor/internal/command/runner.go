@@ -41,9 +41,28 @@ type Options struct {
 	// FirecrackerOptions configures the behavior of Firecracker virtual machine creation.
 	FirecrackerOptions FirecrackerOptions
 
+	// ProcessOptions configures the behavior of commands run directly on the host.
+	ProcessOptions ProcessOptions
+
 	// ResourceOptions configures the resource limits of docker container and Firecracker
 	// virtual machines running on the executor.
 	ResourceOptions ResourceOptions
+
+	// CacheVolumes are mounted into every docker container run for the job.
+	CacheVolumes []CacheVolume
+}
+
+// CacheVolume is a directory on the host that is mounted into docker containers and
+// whose content outlives the job.
+type CacheVolume struct {
+	// Name identifies the volume on the host.
+	Name string
+
+	// HostPath is the directory on the host that holds the content of the volume.
+	HostPath string
+
+	// MountPath is the absolute path at which the volume is mounted into containers.
+	MountPath string
 }
 
 type FirecrackerOptions struct {
@@ -58,6 +77,27 @@ type FirecrackerOptions struct {
 	VMStartupScriptPath string
 }
 
+type ProcessOptions struct {
+	// Enabled determines if commands will be run directly as child processes on the host
+	// instead of in docker containers. Commands are not isolated from the host, so this
+	// must only be enabled in trusted environments. Firecracker takes precedence if enabled.
+	Enabled bool
+
+	// AllowedEnvVars are the names of host environment variables that are forwarded to
+	// commands in addition to HOME, PATH, and USER.
+	AllowedEnvVars []string
+
+	// UseCgroups determines if commands are run in a transient systemd scope subject to
+	// the CPU and memory limits in ResourceOptions. This requires systemd-run on the host.
+	UseCgroups bool
+
+	// MaxOpenFiles and MaxProcesses are the resource limits (RLIMIT_NOFILE and
+	// RLIMIT_NPROC) of commands. Zero means the limit of the executor is inherited.
+	// Setting either requires prlimit on the host.
+	MaxOpenFiles int
+	MaxProcesses int
+}
+
 type ResourceOptions struct {
 	// NumCPUs is the number of virtual CPUs a container or VM can use.
 	NumCPUs int
@@ -72,6 +112,10 @@ type ResourceOptions struct {
 // NewRunner creates a new runner with the given options.
 func NewRunner(dir string, logger *Logger, options Options, operations *Operations) Runner {
 	if !options.FirecrackerOptions.Enabled {
+		if options.ProcessOptions.Enabled {
+			return &processRunner{dir: dir, logger: logger, options: options}
+		}
+
 		return &dockerRunner{dir: dir, logger: logger, options: options}
 	}
 
@@ -104,6 +148,26 @@ func (r *dockerRunner) Run(ctx context.Context, command CommandSpec) error {
 	return runCommand(ctx, formatRawOrDockerCommand(command, r.dir, r.options), r.logger)
 }
 
+type processRunner struct {
+	dir     string
+	logger  *Logger
+	options Options
+}
+
+var _ Runner = &processRunner{}
+
+func (r *processRunner) Setup(ctx context.Context) error {
+	return nil
+}
+
+func (r *processRunner) Teardown(ctx context.Context) error {
+	return nil
+}
+
+func (r *processRunner) Run(ctx context.Context, command CommandSpec) error {
+	return runCommand(ctx, formatProcessCommand(command, r.dir, r.options), r.logger)
+}
+
 type firecrackerRunner struct {
 	name       string
 	dir        string
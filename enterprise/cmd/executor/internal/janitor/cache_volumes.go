@@ -0,0 +1,192 @@
+package janitor
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/command"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+)
+
+type cacheVolumeJanitor struct {
+	dir     string
+	maxSize int64
+	names   *NameSet
+	metrics *metrics
+}
+
+var _ goroutine.Handler = &cacheVolumeJanitor{}
+var _ goroutine.ErrorHandler = &cacheVolumeJanitor{}
+
+// NewCacheVolumeJanitor returns a background routine that periodically removes the least
+// recently used cache volumes in the given directory until their total size no longer
+// exceeds the given maximum size. Cache volumes in use by a job are never removed.
+func NewCacheVolumeJanitor(
+	dir string,
+	maxSize int64,
+	names *NameSet,
+	interval time.Duration,
+	metrics *metrics,
+) goroutine.BackgroundRoutine {
+	return goroutine.NewPeriodicGoroutine(context.Background(), interval, newCacheVolumeJanitor(
+		dir,
+		maxSize,
+		names,
+		metrics,
+	))
+}
+
+func newCacheVolumeJanitor(
+	dir string,
+	maxSize int64,
+	names *NameSet,
+	metrics *metrics,
+) *cacheVolumeJanitor {
+	return &cacheVolumeJanitor{
+		dir:     dir,
+		maxSize: maxSize,
+		names:   names,
+		metrics: metrics,
+	}
+}
+
+func (j *cacheVolumeJanitor) Handle(ctx context.Context) (err error) {
+	volumes, err := listCacheVolumes(j.dir)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range findEvictableCacheVolumes(volumes, j.names.Slice(), j.maxSize) {
+		log15.Info("Removing cache volume", "name", name)
+
+		if removeErr := os.RemoveAll(filepath.Join(j.dir, name)); removeErr != nil {
+			err = multierror.Append(err, removeErr)
+		} else {
+			j.metrics.numCacheVolumesRemoved.Inc()
+		}
+	}
+
+	return err
+}
+
+func (j *cacheVolumeJanitor) HandleError(err error) {
+	j.metrics.numErrors.Inc()
+	log15.Error("Failed to remove cache volumes", "error", err)
+}
+
+// cacheVolume describes a cache volume on the host.
+type cacheVolume struct {
+	name string
+	size int64
+	// lastUsed is the modification time of the volume directory, which is
+	// updated whenever a job starts using the volume.
+	lastUsed time.Time
+}
+
+// listCacheVolumes returns all cache volumes in the given directory along with
+// their size. The staging directory of the volumes is not a volume.
+func listCacheVolumes(dir string) ([]cacheVolume, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	volumes := make([]cacheVolume, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Name() == command.CacheVolumeStagingDir {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		size, err := dirSize(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		volumes = append(volumes, cacheVolume{
+			name:     entry.Name(),
+			size:     size,
+			lastUsed: info.ModTime(),
+		})
+	}
+
+	return volumes, nil
+}
+
+// dirSize returns the total size of the regular files in the given directory.
+func dirSize(dir string) (size int64, err error) {
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		size += info.Size()
+		return nil
+	})
+
+	return size, err
+}
+
+// findEvictableCacheVolumes returns the names of the least recently used cache volumes
+// that have to be removed so that the total size of all volumes doesn't exceed the given
+// maximum size. Volumes that are in use are never returned.
+func findEvictableCacheVolumes(volumes []cacheVolume, inUse []string, maxSize int64) []string {
+	inUseMap := make(map[string]struct{}, len(inUse))
+	for _, name := range inUse {
+		inUseMap[name] = struct{}{}
+	}
+
+	var totalSize int64
+	for _, volume := range volumes {
+		totalSize += volume.size
+	}
+
+	sorted := make([]cacheVolume, len(volumes))
+	copy(sorted, volumes)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].lastUsed.Before(sorted[j].lastUsed) })
+
+	var names []string
+	for _, volume := range sorted {
+		if totalSize <= maxSize {
+			break
+		}
+		if _, ok := inUseMap[volume.name]; ok {
+			continue
+		}
+
+		names = append(names, volume.name)
+		totalSize -= volume.size
+	}
+
+	return names
+}
@@ -0,0 +1,72 @@
+package janitor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/command"
+)
+
+func TestFindEvictableCacheVolumes(t *testing.T) {
+	now := time.Now()
+	volumes := []cacheVolume{
+		{name: "a", size: 100, lastUsed: now.Add(-3 * time.Hour)},
+		{name: "b", size: 200, lastUsed: now.Add(-4 * time.Hour)},
+		{name: "c", size: 300, lastUsed: now.Add(-2 * time.Hour)},
+		{name: "d", size: 400, lastUsed: now.Add(-1 * time.Hour)},
+	}
+
+	for name, tc := range map[string]struct {
+		inUse   []string
+		maxSize int64
+		want    []string
+	}{
+		"below maximum size":  {maxSize: 1000, want: nil},
+		"least recently used": {maxSize: 700, want: []string{"b", "a"}},
+		"in use":              {inUse: []string{"b"}, maxSize: 700, want: []string{"a", "c"}},
+		"all in use":          {inUse: []string{"a", "b", "c", "d"}, maxSize: 0, want: nil},
+	} {
+		t.Run(name, func(t *testing.T) {
+			have := findEvictableCacheVolumes(volumes, tc.inUse, tc.maxSize)
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Fatalf("unexpected evictable volumes (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestListCacheVolumes(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "go-modules", "github.com", "foo"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "go-modules", "github.com", "foo", "go.mod"), make([]byte, 10), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "go-modules", "cache.txt"), make([]byte, 5), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "maven"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, command.CacheVolumeStagingDir, "maven.deadbeef"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	volumes, err := listCacheVolumes(dir)
+	if err != nil {
+		t.Fatalf("unexpected error listing cache volumes: %s", err)
+	}
+
+	sizes := map[string]int64{}
+	for _, volume := range volumes {
+		sizes[volume.name] = volume.size
+	}
+	if diff := cmp.Diff(map[string]int64{"go-modules": 15, "maven": 0}, sizes); diff != "" {
+		t.Fatalf("unexpected volume sizes (-want +got):\n%s", diff)
+	}
+}
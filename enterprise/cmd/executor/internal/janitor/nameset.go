@@ -5,24 +5,30 @@ import (
 	"sync"
 )
 
+// NameSet is a set of names that are in use. A name is removed from the set
+// once it has been removed as often as it has been added.
 type NameSet struct {
 	sync.RWMutex
-	names map[string]struct{}
+	names map[string]int
 }
 
 func NewNameSet() *NameSet {
-	return &NameSet{names: map[string]struct{}{}}
+	return &NameSet{names: map[string]int{}}
 }
 
 func (s *NameSet) Add(name string) {
 	s.Lock()
-	s.names[name] = struct{}{}
+	s.names[name]++
 	s.Unlock()
 }
 
 func (s *NameSet) Remove(name string) {
 	s.Lock()
-	delete(s.names, name)
+	if s.names[name] <= 1 {
+		delete(s.names, name)
+	} else {
+		s.names[name]--
+	}
 	s.Unlock()
 }
 
@@ -7,8 +7,9 @@ import (
 )
 
 type metrics struct {
-	numVMsRemoved prometheus.Counter
-	numErrors     prometheus.Counter
+	numVMsRemoved          prometheus.Counter
+	numCacheVolumesRemoved prometheus.Counter
+	numErrors              prometheus.Counter
 }
 
 var NewMetrics = newMetrics
@@ -28,13 +29,18 @@ func newMetrics(observationContext *observation.Context) *metrics {
 		"src_executor_orphaned_vms_removed_total",
 		"The number of orphaned virtual machines removed from the host.",
 	)
+	numCacheVolumesRemoved := counter(
+		"src_executor_cache_volumes_removed_total",
+		"The number of least recently used cache volumes removed from the host.",
+	)
 	numErrors := counter(
 		"src_executor_janitor_errors_total",
 		"The number of errors that occur during the janitor job.",
 	)
 
 	return &metrics{
-		numVMsRemoved: numVMsRemoved,
-		numErrors:     numErrors,
+		numVMsRemoved:          numVMsRemoved,
+		numCacheVolumesRemoved: numCacheVolumesRemoved,
+		numErrors:              numErrors,
 	}
 }
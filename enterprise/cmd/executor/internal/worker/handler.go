@@ -19,15 +19,17 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/janitor"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/executor"
 	"github.com/sourcegraph/sourcegraph/internal/honey"
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 )
 
 type handler struct {
-	nameSet       *janitor.NameSet
-	store         workerutil.Store
-	options       Options
-	operations    *command.Operations
-	runnerFactory func(dir string, logger *command.Logger, options command.Options, operations *command.Operations) command.Runner
+	nameSet            *janitor.NameSet
+	cacheVolumeNameSet *janitor.NameSet
+	store              command.ExecutionLogEntryStore
+	options            Options
+	operations         *command.Operations
+	runnerFactory      func(dir string, logger *command.Logger, options command.Options, operations *command.Operations) command.Runner
 }
 
 var _ workerutil.Handler = &handler{}
@@ -60,7 +62,7 @@ func (h *handler) PreDequeue(ctx context.Context) (dequeueable bool, extraDequeu
 // Handle clones the target code into a temporary directory, invokes the target indexer in a
 // fresh docker container, and uploads the results to the external frontend API.
 func (h *handler) Handle(ctx context.Context, record workerutil.Record) (err error) {
-	job := record.(executor.Job)
+	job := jobFromRecord(record)
 	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(h.options.MaximumRuntimePerJob))
 	defer cancel()
 
@@ -136,10 +138,23 @@ func (h *handler) Handle(ctx context.Context, record workerutil.Record) (err err
 	h.nameSet.Add(name)
 	defer h.nameSet.Remove(name)
 
+	// Mark the cache volumes as in-use so that the janitor process evicting cache volumes
+	// doesn't remove the ones we're using for the current job.
+	cacheVolumes, err := h.prepareCacheVolumes(job.CacheVolumes)
+	if err != nil {
+		return wrapError(err, "failed to prepare cache volumes")
+	}
+	for _, volume := range cacheVolumes {
+		h.cacheVolumeNameSet.Add(volume.Name)
+		defer h.cacheVolumeNameSet.Remove(volume.Name)
+	}
+
 	options := command.Options{
 		ExecutorName:       name,
 		FirecrackerOptions: h.options.FirecrackerOptions,
+		ProcessOptions:     h.options.ProcessOptions,
 		ResourceOptions:    h.options.ResourceOptions,
+		CacheVolumes:       cacheVolumes,
 	}
 	runner := h.runnerFactory(workingDirectory, logger, options, h.operations)
 
@@ -203,11 +218,56 @@ func (h *handler) Handle(ctx context.Context, record workerutil.Record) (err err
 		if err := runner.Run(ctx, cliStepCommand); err != nil {
 			return wrapError(err, "failed to perform src-cli step")
 		}
+
+		// Step artifacts are informational, so failing to upload them doesn't fail the job.
+		if err := uploadStepArtifacts(ctx, workingDirectory, cliStep); err != nil {
+			logStepArtifactsError(logger, fmt.Sprintf("step.src.%d.artifacts", i), err)
+		}
 	}
 
 	return nil
 }
 
+var cacheVolumeNamePattern = lazyregexp.New(`^[a-zA-Z0-9_-]+$`)
+
+// prepareCacheVolumes creates the directories on the host that hold the content of the
+// given cache volumes and marks them as used. If cache volumes are disabled, no volumes
+// are returned.
+func (h *handler) prepareCacheVolumes(volumes []executor.CacheVolume) ([]command.CacheVolume, error) {
+	if h.options.CacheVolumesDir == "" {
+		return nil, nil
+	}
+
+	cacheVolumes := make([]command.CacheVolume, 0, len(volumes))
+	for _, volume := range volumes {
+		if !cacheVolumeNamePattern.MatchString(volume.Name) {
+			return nil, errors.Errorf("invalid cache volume name %q", volume.Name)
+		}
+		if !filepath.IsAbs(volume.Path) {
+			return nil, errors.Errorf("cache volume path %q is not absolute", volume.Path)
+		}
+
+		hostPath := filepath.Join(h.options.CacheVolumesDir, volume.Name)
+		if err := os.MkdirAll(hostPath, os.ModePerm); err != nil {
+			return nil, err
+		}
+
+		// The janitor evicts the least recently used volumes by modification time.
+		now := time.Now()
+		if err := os.Chtimes(hostPath, now, now); err != nil {
+			return nil, err
+		}
+
+		cacheVolumes = append(cacheVolumes, command.CacheVolume{
+			Name:      volume.Name,
+			HostPath:  hostPath,
+			MountPath: volume.Path,
+		})
+	}
+
+	return cacheVolumes, nil
+}
+
 var scriptPreamble = `
 set -x
 `
@@ -229,20 +289,34 @@ func union(a, b map[string]string) map[string]string {
 	return c
 }
 
+// jobFromRecord returns the job wrapped by the given record, which was dequeued either
+// from a single queue or from multiple queues.
+func jobFromRecord(record workerutil.Record) executor.Job {
+	if job, ok := record.(queuedJob); ok {
+		return job.Job
+	}
+
+	return record.(executor.Job)
+}
+
 func scriptNameFromJobStep(job executor.Job, i int) string {
 	return fmt.Sprintf("%d.%d_%s@%s.sh", job.ID, i, strings.ReplaceAll(job.RepositoryName, "/", "_"), job.Commit)
 }
 
 func createHoneyEvent(ctx context.Context, job executor.Job, err error, duration time.Duration) *libhoney.Event {
 	fields := map[string]interface{}{
-		"duration_ms":    duration.Milliseconds(),
-		"recordID":       job.RecordID(),
-		"repositoryName": job.RepositoryName,
-		"commit":         job.Commit,
-		"numDockerSteps": len(job.DockerSteps),
-		"numCliSteps":    len(job.CliSteps),
+		"duration_ms":     duration.Milliseconds(),
+		"recordID":        job.RecordID(),
+		"repositoryName":  job.RepositoryName,
+		"commit":          job.Commit,
+		"numDockerSteps":  len(job.DockerSteps),
+		"numCliSteps":     len(job.CliSteps),
+		"numCacheVolumes": len(job.CacheVolumes),
 	}
 
+	if job.Queue != "" {
+		fields["queue"] = job.Queue
+	}
 	if err != nil {
 		fields["error"] = err.Error()
 	}
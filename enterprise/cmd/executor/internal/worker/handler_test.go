@@ -59,9 +59,10 @@ func TestHandle(t *testing.T) {
 	}
 
 	handler := &handler{
-		nameSet:    janitor.NewNameSet(),
-		options:    Options{},
-		operations: command.NewOperations(&observation.TestContext),
+		nameSet:            janitor.NewNameSet(),
+		cacheVolumeNameSet: janitor.NewNameSet(),
+		options:            Options{},
+		operations:         command.NewOperations(&observation.TestContext),
 		runnerFactory: func(dir string, logger *command.Logger, options command.Options, operations *command.Operations) command.Runner {
 			if dir == "" {
 				// The handler allocates a temporary runner to invoke the git commands,
@@ -109,3 +110,52 @@ func TestHandle(t *testing.T) {
 		t.Errorf("unexpected commands (-want +got):\n%s", diff)
 	}
 }
+
+func TestPrepareCacheVolumes(t *testing.T) {
+	cacheDir := t.TempDir()
+	handler := &handler{options: Options{CacheVolumesDir: cacheDir}}
+
+	cacheVolumes, err := handler.prepareCacheVolumes([]executor.CacheVolume{
+		{Name: "go-modules", Path: "/go/pkg/mod"},
+		{Name: "maven", Path: "/root/.m2"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error preparing cache volumes: %s", err)
+	}
+
+	expected := []command.CacheVolume{
+		{Name: "go-modules", HostPath: filepath.Join(cacheDir, "go-modules"), MountPath: "/go/pkg/mod"},
+		{Name: "maven", HostPath: filepath.Join(cacheDir, "maven"), MountPath: "/root/.m2"},
+	}
+	if diff := cmp.Diff(expected, cacheVolumes); diff != "" {
+		t.Errorf("unexpected cache volumes (-want +got):\n%s", diff)
+	}
+	for _, volume := range cacheVolumes {
+		if info, err := os.Stat(volume.HostPath); err != nil || !info.IsDir() {
+			t.Errorf("expected cache volume directory %q to exist", volume.HostPath)
+		}
+	}
+
+	for name, volume := range map[string]executor.CacheVolume{
+		"path traversal": {Name: "../etc", Path: "/root/.m2"},
+		"relative path":  {Name: "maven", Path: ".m2"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := handler.prepareCacheVolumes([]executor.CacheVolume{volume}); err == nil {
+				t.Error("unexpected nil error")
+			}
+		})
+	}
+}
+
+func TestPrepareCacheVolumesDisabled(t *testing.T) {
+	handler := &handler{options: Options{}}
+
+	cacheVolumes, err := handler.prepareCacheVolumes([]executor.CacheVolume{{Name: "go-modules", Path: "/go/pkg/mod"}})
+	if err != nil {
+		t.Fatalf("unexpected error preparing cache volumes: %s", err)
+	}
+	if len(cacheVolumes) != 0 {
+		t.Errorf("unexpected cache volumes: %v", cacheVolumes)
+	}
+}
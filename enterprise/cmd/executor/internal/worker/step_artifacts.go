@@ -0,0 +1,137 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/hashicorp/go-multierror"
+	"golang.org/x/net/context/ctxhttp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/command"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/executor"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+// uploadStepArtifacts uploads the artifacts of batch spec steps that src-cli copied to
+// batcheslib.StepArtifactsDir while running the given step to the Sourcegraph instance
+// that src-cli talks to. The artifacts are uploaded with the access token of the step,
+// which belongs to the user executing the batch spec, and removed once uploaded. An
+// artifact that fails to upload doesn't keep the remaining artifacts from being uploaded.
+func uploadStepArtifacts(ctx context.Context, workingDirectory string, cliStep executor.CliStep) error {
+	root := filepath.Join(workingDirectory, cliStep.Dir, batcheslib.StepArtifactsDir)
+	if _, err := os.Stat(root); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	var endpoint, token string
+	for _, kv := range cliStep.Env {
+		if v := strings.TrimPrefix(kv, "SRC_ENDPOINT="); v != kv {
+			endpoint = v
+		}
+		if v := strings.TrimPrefix(kv, "SRC_ACCESS_TOKEN="); v != kv {
+			token = v
+		}
+	}
+	if endpoint == "" || token == "" {
+		return errors.New("src-cli step has no endpoint or access token to upload step artifacts with")
+	}
+
+	uploadURL, err := url.Parse(endpoint)
+	if err != nil {
+		return err
+	}
+	// 🚨 SECURITY: The artifacts are uploaded as the user executing the batch spec, so
+	// the basic auth credentials of the endpoint must not be sent along.
+	uploadURL.User = nil
+	uploadURL.Path = path.Join(uploadURL.Path, batcheslib.StepArtifactsPath)
+
+	var uploadErr error
+	err = filepath.Walk(root, func(file string, info os.FileInfo, err error) error {
+		if err != nil || !info.Mode().IsRegular() {
+			return err
+		}
+
+		rel, err := filepath.Rel(root, file)
+		if err != nil {
+			return err
+		}
+		workspaceID, step, artifactPath, err := batcheslib.ParseStepArtifactFile(filepath.ToSlash(rel))
+		if err != nil {
+			uploadErr = multierror.Append(uploadErr, err)
+			return nil
+		}
+
+		u := *uploadURL
+		u.RawQuery = url.Values{
+			"workspace": {workspaceID},
+			"step":      {strconv.Itoa(step)},
+			"path":      {artifactPath},
+		}.Encode()
+		if err := uploadStepArtifact(ctx, u.String(), token, file); err != nil {
+			uploadErr = multierror.Append(uploadErr, errors.Wrapf(err, "uploading artifact %q of step %d", artifactPath, step))
+			return nil
+		}
+
+		return os.Remove(file)
+	})
+	if err != nil {
+		return err
+	}
+	return uploadErr
+}
+
+// logStepArtifactsError adds an entry with the given key and error to the execution log
+// of the job, so that the user executing the batch spec learns which artifacts are missing.
+func logStepArtifactsError(logger *command.Logger, key string, err error) {
+	exitCode := 1
+	handle := logger.Log(&workerutil.ExecutionLogEntry{
+		Key:       key,
+		StartTime: time.Now(),
+		ExitCode:  &exitCode,
+	})
+	defer handle.Close()
+
+	fmt.Fprintf(handle, "stderr: failed to upload step artifacts: %s\n", err)
+}
+
+func uploadStepArtifact(ctx context.Context, uploadURL, token, file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	req, err := http.NewRequest("POST", uploadURL, f)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "token "+token)
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	resp, err := ctxhttp.Do(ctx, httpcli.ExternalClient, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return errors.Errorf("unexpected status code %d: %s", resp.StatusCode, body)
+	}
+	return nil
+}
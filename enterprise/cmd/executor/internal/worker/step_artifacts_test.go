@@ -0,0 +1,115 @@
+package worker
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/executor"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+func TestUploadStepArtifacts(t *testing.T) {
+	type upload struct {
+		Authorization string
+		Query         string
+		Content       string
+	}
+	var uploads []upload
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != batcheslib.StepArtifactsPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		content, _ := io.ReadAll(r.Body)
+		uploads = append(uploads, upload{
+			Authorization: r.Header.Get("Authorization"),
+			Query:         r.URL.RawQuery,
+			Content:       string(content),
+		})
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer ts.Close()
+
+	workingDirectory := t.TempDir()
+	artifactsDir := filepath.Join(workingDirectory, batcheslib.StepArtifactsDir)
+	file := filepath.Join(artifactsDir, filepath.FromSlash(batcheslib.StepArtifactFile("d29ya3NwYWNl", 2, "reports/test.json")))
+	if err := os.MkdirAll(filepath.Dir(file), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(file, []byte("{}"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	cliStep := executor.CliStep{
+		Dir: ".",
+		Env: []string{"SRC_ENDPOINT=" + ts.URL, "SRC_ACCESS_TOKEN=hunter2"},
+	}
+	if err := uploadStepArtifacts(context.Background(), workingDirectory, cliStep); err != nil {
+		t.Fatalf("unexpected error uploading step artifacts: %s", err)
+	}
+
+	want := []upload{{
+		Authorization: "token hunter2",
+		Query:         "path=reports%2Ftest.json&step=2&workspace=d29ya3NwYWNl",
+		Content:       "{}",
+	}}
+	if diff := cmp.Diff(want, uploads); diff != "" {
+		t.Errorf("unexpected uploads (-want +got):\n%s", diff)
+	}
+	if _, err := os.Stat(file); !os.IsNotExist(err) {
+		t.Errorf("expected uploaded artifact to be removed, got %v", err)
+	}
+
+	// Nothing is uploaded twice.
+	if err := uploadStepArtifacts(context.Background(), workingDirectory, cliStep); err != nil {
+		t.Fatalf("unexpected error uploading step artifacts: %s", err)
+	}
+	if len(uploads) != 1 {
+		t.Errorf("unexpected number of uploads: %d", len(uploads))
+	}
+}
+
+func TestUploadStepArtifactsContinuesAfterFailure(t *testing.T) {
+	var uploaded []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := r.URL.Query().Get("path")
+		if path == "a.log" {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			return
+		}
+		uploaded = append(uploaded, path)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer ts.Close()
+
+	workingDirectory := t.TempDir()
+	artifactsDir := filepath.Join(workingDirectory, batcheslib.StepArtifactsDir)
+	for _, artifactPath := range []string{"a.log", "b.log"} {
+		file := filepath.Join(artifactsDir, filepath.FromSlash(batcheslib.StepArtifactFile("d29ya3NwYWNl", 1, artifactPath)))
+		if err := os.MkdirAll(filepath.Dir(file), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte("log"), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cliStep := executor.CliStep{
+		Dir: ".",
+		Env: []string{"SRC_ENDPOINT=" + ts.URL, "SRC_ACCESS_TOKEN=hunter2"},
+	}
+	if err := uploadStepArtifacts(context.Background(), workingDirectory, cliStep); err == nil {
+		t.Fatalf("expected error uploading step artifacts")
+	}
+	if diff := cmp.Diff([]string{"b.log"}, uploaded); diff != "" {
+		t.Errorf("unexpected uploads (-want +got):\n%s", diff)
+	}
+}
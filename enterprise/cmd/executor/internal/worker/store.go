@@ -2,9 +2,12 @@ package worker
 
 import (
 	"context"
+	"sort"
+	"sync"
 
 	"github.com/cockroachdb/errors"
 
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/command"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/executor"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 )
@@ -18,13 +21,21 @@ type QueueStore interface {
 	Dequeue(ctx context.Context, queueName string, payload *executor.Job) (bool, error)
 	AddExecutionLogEntry(ctx context.Context, queueName string, jobID int, entry workerutil.ExecutionLogEntry) (int, error)
 	UpdateExecutionLogEntry(ctx context.Context, queueName string, jobID, entryID int, entry workerutil.ExecutionLogEntry) error
+	AppendExecutionLogEntryOutput(ctx context.Context, queueName string, jobID, entryID int, out string) error
 	MarkComplete(ctx context.Context, queueName string, jobID int) error
 	MarkErrored(ctx context.Context, queueName string, jobID int, errorMessage string) error
 	MarkFailed(ctx context.Context, queueName string, jobID int, errorMessage string) error
 	Heartbeat(ctx context.Context, queueName string, jobIDs []int) (knownIDs []int, err error)
 }
 
-var _ workerutil.Store = &storeShim{}
+// jobStore is the store the worker dequeues jobs from and the handler writes
+// the log entries of the jobs to.
+type jobStore interface {
+	workerutil.Store
+	command.ExecutionLogEntryStore
+}
+
+var _ jobStore = &storeShim{}
 
 func (s *storeShim) QueuedCount(ctx context.Context, extraArguments interface{}) (int, error) {
 	return 0, errors.New("unimplemented")
@@ -52,6 +63,10 @@ func (s *storeShim) UpdateExecutionLogEntry(ctx context.Context, jobID, entryID
 	return s.queueStore.UpdateExecutionLogEntry(ctx, s.queueName, jobID, entryID, entry)
 }
 
+func (s *storeShim) AppendExecutionLogEntryOutput(ctx context.Context, jobID, entryID int, out string) error {
+	return s.queueStore.AppendExecutionLogEntryOutput(ctx, s.queueName, jobID, entryID, out)
+}
+
 func (s *storeShim) MarkComplete(ctx context.Context, id int) (bool, error) {
 	return true, s.queueStore.MarkComplete(ctx, s.queueName, id)
 }
@@ -63,3 +78,184 @@ func (s *storeShim) MarkErrored(ctx context.Context, id int, errorMessage string
 func (s *storeShim) MarkFailed(ctx context.Context, id int, errorMessage string) (bool, error) {
 	return true, s.queueStore.MarkFailed(ctx, s.queueName, id, errorMessage)
 }
+
+// multiQueueStoreShim dequeues jobs from one of multiple queues at once. As job identifiers
+// are only unique within a queue, the worker tracks jobs by record identifiers assigned by
+// the shim, which translates them back into the queue and job identifier for every request.
+type multiQueueStoreShim struct {
+	queues     []executor.WeightedQueue
+	queueStore MultiQueueStore
+
+	mu           sync.Mutex
+	lastRecordID int
+	jobs         map[int]queuedJob
+}
+
+type MultiQueueStore interface {
+	QueueStore
+	DequeueFromQueues(ctx context.Context, queues []executor.WeightedQueue, payload *executor.Job) (bool, error)
+}
+
+var _ jobStore = &multiQueueStoreShim{}
+
+func newMultiQueueStoreShim(queues []executor.WeightedQueue, queueStore MultiQueueStore) *multiQueueStoreShim {
+	return &multiQueueStoreShim{
+		queues:     queues,
+		queueStore: queueStore,
+		jobs:       map[int]queuedJob{},
+	}
+}
+
+// queuedJob is a job dequeued by a multiQueueStoreShim.
+type queuedJob struct {
+	executor.Job
+	recordID int
+}
+
+func (j queuedJob) RecordID() int {
+	return j.recordID
+}
+
+func (s *multiQueueStoreShim) QueuedCount(ctx context.Context, extraArguments interface{}) (int, error) {
+	return 0, errors.New("unimplemented")
+}
+
+func (s *multiQueueStoreShim) Dequeue(ctx context.Context, workerHostname string, extraArguments interface{}) (workerutil.Record, bool, error) {
+	var job executor.Job
+	dequeued, err := s.queueStore.DequeueFromQueues(ctx, s.queues, &job)
+	if err != nil || !dequeued {
+		return nil, false, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastRecordID++
+	record := queuedJob{Job: job, recordID: s.lastRecordID}
+	s.jobs[record.recordID] = record
+
+	return record, true, nil
+}
+
+func (s *multiQueueStoreShim) Heartbeat(ctx context.Context, ids []int) (knownIDs []int, err error) {
+	recordIDsByQueue := map[string]map[int]int{}
+	for _, id := range ids {
+		job, ok := s.job(id)
+		if !ok {
+			continue
+		}
+
+		if _, ok := recordIDsByQueue[job.Queue]; !ok {
+			recordIDsByQueue[job.Queue] = map[int]int{}
+		}
+		recordIDsByQueue[job.Queue][job.ID] = id
+	}
+
+	for queueName, recordIDs := range recordIDsByQueue {
+		jobIDs := make([]int, 0, len(recordIDs))
+		for jobID := range recordIDs {
+			jobIDs = append(jobIDs, jobID)
+		}
+		sort.Ints(jobIDs)
+
+		knownJobIDs, err := s.queueStore.Heartbeat(ctx, queueName, jobIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, jobID := range knownJobIDs {
+			knownIDs = append(knownIDs, recordIDs[jobID])
+		}
+	}
+	sort.Ints(knownIDs)
+
+	return knownIDs, nil
+}
+
+func (s *multiQueueStoreShim) AddExecutionLogEntry(ctx context.Context, id int, entry workerutil.ExecutionLogEntry) (int, error) {
+	job, ok := s.job(id)
+	if !ok {
+		return 0, errUnknownRecord
+	}
+
+	return s.queueStore.AddExecutionLogEntry(ctx, job.Queue, job.ID, entry)
+}
+
+func (s *multiQueueStoreShim) UpdateExecutionLogEntry(ctx context.Context, jobID, entryID int, entry workerutil.ExecutionLogEntry) error {
+	job, ok := s.job(jobID)
+	if !ok {
+		return errUnknownRecord
+	}
+
+	return s.queueStore.UpdateExecutionLogEntry(ctx, job.Queue, job.ID, entryID, entry)
+}
+
+func (s *multiQueueStoreShim) AppendExecutionLogEntryOutput(ctx context.Context, jobID, entryID int, out string) error {
+	job, ok := s.job(jobID)
+	if !ok {
+		return errUnknownRecord
+	}
+
+	return s.queueStore.AppendExecutionLogEntryOutput(ctx, job.Queue, job.ID, entryID, out)
+}
+
+func (s *multiQueueStoreShim) MarkComplete(ctx context.Context, id int) (bool, error) {
+	job, ok := s.finish(id)
+	if !ok {
+		return false, errUnknownRecord
+	}
+
+	return true, s.queueStore.MarkComplete(ctx, job.Queue, job.ID)
+}
+
+func (s *multiQueueStoreShim) MarkErrored(ctx context.Context, id int, errorMessage string) (bool, error) {
+	job, ok := s.finish(id)
+	if !ok {
+		return false, errUnknownRecord
+	}
+
+	return true, s.queueStore.MarkErrored(ctx, job.Queue, job.ID, errorMessage)
+}
+
+func (s *multiQueueStoreShim) MarkFailed(ctx context.Context, id int, errorMessage string) (bool, error) {
+	job, ok := s.finish(id)
+	if !ok {
+		return false, errUnknownRecord
+	}
+
+	return true, s.queueStore.MarkFailed(ctx, job.Queue, job.ID, errorMessage)
+}
+
+// recordID returns the record identifier of the job with the given identifier in the
+// given queue.
+func (s *multiQueueStoreShim) recordID(queueName string, jobID int) (int, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for recordID, job := range s.jobs {
+		if job.Queue == queueName && job.ID == jobID {
+			return recordID, true
+		}
+	}
+
+	return 0, false
+}
+
+func (s *multiQueueStoreShim) job(recordID int) (queuedJob, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[recordID]
+	return job, ok
+}
+
+// finish returns the job with the given record identifier and forgets about it.
+func (s *multiQueueStoreShim) finish(recordID int) (queuedJob, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[recordID]
+	delete(s.jobs, recordID)
+	return job, ok
+}
+
+var errUnknownRecord = errors.New("unknown record")
@@ -0,0 +1,121 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/executor"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+)
+
+func TestMultiQueueStoreShim(t *testing.T) {
+	queues := []executor.WeightedQueue{{Name: "codeintel", Weight: 1}, {Name: "batches", Weight: 2}}
+	queueStore := &fakeMultiQueueStore{
+		jobs: []executor.Job{
+			{ID: 42, Queue: "codeintel"},
+			{ID: 42, Queue: "batches"},
+			{ID: 43, Queue: "batches"},
+		},
+		knownIDs: map[string][]int{
+			"codeintel": {42},
+			"batches":   {43},
+		},
+	}
+	store := newMultiQueueStoreShim(queues, queueStore)
+
+	var records []workerutil.Record
+	for {
+		record, dequeued, err := store.Dequeue(context.Background(), "", nil)
+		if err != nil {
+			t.Fatalf("unexpected error dequeueing job: %s", err)
+		}
+		if !dequeued {
+			break
+		}
+		records = append(records, record)
+	}
+
+	var recordIDs []int
+	for _, record := range records {
+		recordIDs = append(recordIDs, record.RecordID())
+	}
+	if diff := cmp.Diff([]int{1, 2, 3}, recordIDs); diff != "" {
+		t.Errorf("unexpected record ids (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(queues, queueStore.dequeuedQueues); diff != "" {
+		t.Errorf("unexpected queues (-want +got):\n%s", diff)
+	}
+
+	knownIDs, err := store.Heartbeat(context.Background(), []int{1, 2, 3, 4})
+	if err != nil {
+		t.Fatalf("unexpected error heartbeating: %s", err)
+	}
+	if diff := cmp.Diff([]int{1, 3}, knownIDs); diff != "" {
+		t.Errorf("unexpected known ids (-want +got):\n%s", diff)
+	}
+
+	if recordID, ok := store.recordID("batches", 42); !ok || recordID != 2 {
+		t.Errorf("unexpected record id. want=%d have=%d", 2, recordID)
+	}
+
+	if _, err := store.MarkComplete(context.Background(), 2); err != nil {
+		t.Fatalf("unexpected error marking job complete: %s", err)
+	}
+	if _, err := store.MarkErrored(context.Background(), 3, "oops"); err != nil {
+		t.Fatalf("unexpected error marking job errored: %s", err)
+	}
+	if diff := cmp.Diff([]string{"complete batches 42", "errored batches 43"}, queueStore.marked); diff != "" {
+		t.Errorf("unexpected marked jobs (-want +got):\n%s", diff)
+	}
+
+	if _, ok := store.recordID("batches", 42); ok {
+		t.Errorf("expected finished job to be forgotten")
+	}
+	if _, err := store.MarkFailed(context.Background(), 2, "oops"); err != errUnknownRecord {
+		t.Errorf("unexpected error. want=%q have=%q", errUnknownRecord, err)
+	}
+}
+
+type fakeMultiQueueStore struct {
+	QueueStore
+	jobs           []executor.Job
+	knownIDs       map[string][]int
+	dequeuedQueues []executor.WeightedQueue
+	marked         []string
+}
+
+func (s *fakeMultiQueueStore) DequeueFromQueues(ctx context.Context, queues []executor.WeightedQueue, payload *executor.Job) (bool, error) {
+	s.dequeuedQueues = queues
+	if len(s.jobs) == 0 {
+		return false, nil
+	}
+
+	*payload, s.jobs = s.jobs[0], s.jobs[1:]
+	return true, nil
+}
+
+func (s *fakeMultiQueueStore) Heartbeat(ctx context.Context, queueName string, jobIDs []int) ([]int, error) {
+	var knownIDs []int
+	for _, jobID := range jobIDs {
+		for _, knownID := range s.knownIDs[queueName] {
+			if jobID == knownID {
+				knownIDs = append(knownIDs, jobID)
+			}
+		}
+	}
+
+	return knownIDs, nil
+}
+
+func (s *fakeMultiQueueStore) MarkComplete(ctx context.Context, queueName string, jobID int) error {
+	s.marked = append(s.marked, fmt.Sprintf("complete %s %d", queueName, jobID))
+	return nil
+}
+
+func (s *fakeMultiQueueStore) MarkErrored(ctx context.Context, queueName string, jobID int, errorMessage string) error {
+	s.marked = append(s.marked, fmt.Sprintf("errored %s %d", queueName, jobID))
+	return nil
+}
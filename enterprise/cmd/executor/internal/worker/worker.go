@@ -13,6 +13,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/apiclient"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/command"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/janitor"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/executor"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
@@ -33,6 +34,11 @@ type Options struct {
 	// horizontal scaling factors while still uniformly processing events.
 	QueueName string
 
+	// Queues are the names of the queues to process work from along with their weights.
+	// If set, QueueName is ignored and jobs are dequeued from one of these queues, where
+	// a queue with a higher weight is tried first proportionally more often.
+	Queues []executor.WeightedQueue
+
 	// GitServicePath is the path to the internal git service API proxy in the frontend.
 	// This path should contain the endpoints info/refs and git-upload-pack.
 	GitServicePath string
@@ -52,33 +58,51 @@ type Options struct {
 	// FirecrackerOptions configures the behavior of Firecracker virtual machine creation.
 	FirecrackerOptions command.FirecrackerOptions
 
+	// ProcessOptions configures the behavior of commands run directly on the host when
+	// neither Firecracker nor docker isolation is used.
+	ProcessOptions command.ProcessOptions
+
 	// ResourceOptions configures the resource limits of docker container and Firecracker
 	// virtual machines running on the executor.
 	ResourceOptions command.ResourceOptions
 
 	// MaximumRuntimePerJob is the maximum wall time that can be spent on a single job.
 	MaximumRuntimePerJob time.Duration
+
+	// CacheVolumesDir is the directory on the host that holds the cache volumes requested
+	// by jobs. If empty, cache volumes are disabled and jobs start with empty caches.
+	CacheVolumesDir string
 }
 
 // NewWorker creates a worker that polls a remote job queue API for work. The returned
 // routine contains both a worker that periodically polls for new work to perform, as well
 // as a heartbeat routine that will periodically hit the remote API with the work that is
 // currently being performed, which is necessary so the job queue API doesn't hand out jobs
-// it thinks may have been dropped.
-func NewWorker(nameSet *janitor.NameSet, options Options, observationContext *observation.Context) (worker goroutine.WaitableBackgroundRoutine, canceler goroutine.BackgroundRoutine) {
+// it thinks may have been dropped. The names of the VMs and cache volumes in use by a job
+// are added to the given name sets, so the janitors don't remove them.
+func NewWorker(nameSet, cacheVolumeNameSet *janitor.NameSet, options Options, observationContext *observation.Context) (worker goroutine.WaitableBackgroundRoutine, canceler goroutine.BackgroundRoutine) {
 	queueStore := apiclient.New(options.ClientOptions, observationContext)
-	store := &storeShim{queueName: options.QueueName, queueStore: queueStore}
+
+	var store jobStore
+	var multiQueueStore *multiQueueStoreShim
+	if len(options.Queues) > 0 {
+		multiQueueStore = newMultiQueueStoreShim(options.Queues, queueStore)
+		store = multiQueueStore
+	} else {
+		store = &storeShim{queueName: options.QueueName, queueStore: queueStore}
+	}
 
 	if !connectToFrontend(queueStore, options) {
 		os.Exit(1)
 	}
 
 	handler := &handler{
-		nameSet:       nameSet,
-		store:         store,
-		options:       options,
-		operations:    command.NewOperations(observationContext),
-		runnerFactory: command.NewRunner,
+		nameSet:            nameSet,
+		cacheVolumeNameSet: cacheVolumeNameSet,
+		store:              store,
+		options:            options,
+		operations:         command.NewOperations(observationContext),
+		runnerFactory:      command.NewRunner,
 	}
 
 	ctx := context.Background()
@@ -88,13 +112,30 @@ func NewWorker(nameSet *janitor.NameSet, options Options, observationContext *ob
 		ctx,
 		canceledJobsPollInterval,
 		goroutine.NewHandlerWithErrorMessage("executor.worker.pollCanceled", func(ctx context.Context) error {
-			canceled, err := queueStore.Canceled(ctx, options.QueueName)
-			if err != nil {
-				return err
+			if multiQueueStore == nil {
+				canceled, err := queueStore.Canceled(ctx, options.QueueName)
+				if err != nil {
+					return err
+				}
+
+				for _, id := range canceled {
+					w.Cancel(id)
+				}
+
+				return nil
 			}
 
-			for _, id := range canceled {
-				w.Cancel(id)
+			for _, queue := range options.Queues {
+				canceled, err := queueStore.Canceled(ctx, queue.Name)
+				if err != nil {
+					return err
+				}
+
+				for _, id := range canceled {
+					if recordID, ok := multiQueueStore.recordID(queue.Name, id); ok {
+						w.Cancel(recordID)
+					}
+				}
 			}
 
 			return nil
@@ -120,7 +161,7 @@ func connectToFrontend(queueStore *apiclient.Client, options Options) bool {
 	defer signal.Stop(signals)
 
 	for {
-		err := queueStore.Ping(context.Background(), options.QueueName, nil)
+		err := queueStore.Ping(context.Background(), pingQueueName(options), nil)
 		if err == nil {
 			log15.Info("Connected to Sourcegraph instance")
 			return true
@@ -143,3 +184,13 @@ func connectToFrontend(queueStore *apiclient.Client, options Options) bool {
 		}
 	}
 }
+
+// pingQueueName returns the name of the queue used to check the connection to the
+// Sourcegraph instance.
+func pingQueueName(options Options) string {
+	if len(options.Queues) > 0 {
+		return options.Queues[0].Name
+	}
+
+	return options.QueueName
+}
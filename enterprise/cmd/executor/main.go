@@ -47,19 +47,30 @@ func main() {
 	go debugserver.NewServerRoutine(ready).Start()
 
 	nameSet := janitor.NewNameSet()
+	cacheVolumeNameSet := janitor.NewNameSet()
 	ctx, cancel := context.WithCancel(context.Background())
-	worker, canceler := worker.NewWorker(nameSet, config.APIWorkerOptions(), observationContext)
+	worker, canceler := worker.NewWorker(nameSet, cacheVolumeNameSet, config.APIWorkerOptions(), observationContext)
+	janitorMetrics := janitor.NewMetrics(observationContext)
 
 	routines := []goroutine.BackgroundRoutine{
 		worker,
 		canceler,
 	}
+	if config.CacheVolumesDir != "" {
+		routines = append(routines, janitor.NewCacheVolumeJanitor(
+			config.CacheVolumesDir,
+			int64(config.CacheVolumesMaxSize)*1024*1024,
+			cacheVolumeNameSet,
+			config.CleanupTaskInterval,
+			janitorMetrics,
+		))
+	}
 	if config.UseFirecracker {
 		routines = append(routines, janitor.NewOrphanedVMJanitor(
 			config.VMPrefix,
 			nameSet,
 			config.CleanupTaskInterval,
-			janitor.NewMetrics(observationContext),
+			janitorMetrics,
 		))
 
 		mustRegisterVMCountMetric(observationContext, config.VMPrefix)
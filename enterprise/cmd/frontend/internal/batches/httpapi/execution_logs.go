@@ -0,0 +1,127 @@
+package httpapi
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/batches/resolvers"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/logstream"
+)
+
+// ExecutionLogsHandler streams the execution logs of the job that executes a
+// batch spec workspace as server-sent events, so users can follow the steps
+// of the workspace while they run.
+//
+// The events are those written by logstream.Follow.
+type ExecutionLogsHandler struct {
+	Store *store.Store
+
+	// PollInterval is the time between two reads of the execution logs.
+	PollInterval time.Duration
+}
+
+// NewExecutionLogsHandler returns a new ExecutionLogsHandler.
+func NewExecutionLogsHandler(store *store.Store) *ExecutionLogsHandler {
+	return &ExecutionLogsHandler{Store: store, PollInterval: time.Second}
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *ExecutionLogsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var workspaceID int64
+	if err := unmarshalID(r.URL.Query().Get("workspace"), resolvers.BatchSpecWorkspaceIDKind, &workspaceID); err != nil {
+		respond(w, http.StatusBadRequest, errors.New("invalid workspace"))
+		return
+	}
+
+	ws, err := h.Store.GetBatchSpecWorkspace(ctx, store.GetBatchSpecWorkspaceOpts{ID: workspaceID})
+	if err != nil {
+		if err == store.ErrNoResults {
+			respond(w, http.StatusNotFound, errors.New("workspace not found"))
+			return
+		}
+		respond(w, http.StatusInternalServerError, err)
+		return
+	}
+	batchSpec, err := h.Store.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: ws.BatchSpecID})
+	if err != nil {
+		respond(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	// 🚨 SECURITY: Only site-admins and the creator of the batch spec may read
+	// the execution logs, and only if they can access the repository of the
+	// workspace.
+	if err := backend.CheckSiteAdminOrSameUser(ctx, h.Store.DB(), batchSpec.UserID); err != nil {
+		respond(w, http.StatusForbidden, err)
+		return
+	}
+	if _, err := database.Repos(h.Store.DB()).Get(ctx, ws.RepoID); err != nil {
+		if errcode.IsNotFound(err) {
+			respond(w, http.StatusNotFound, errors.New("workspace not found"))
+			return
+		}
+		respond(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	job, err := h.Store.GetBatchSpecWorkspaceExecutionJob(ctx, store.GetBatchSpecWorkspaceExecutionJobOpts{BatchSpecWorkspaceID: ws.ID})
+	if err != nil {
+		if err == store.ErrNoResults {
+			respond(w, http.StatusNotFound, errors.New("workspace is not being executed"))
+			return
+		}
+		respond(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	eventWriter, err := streamhttp.NewWriter(w)
+	if err != nil {
+		respond(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	jobID := job.ID
+	err = logstream.Follow(ctx, eventWriter, h.PollInterval, executionJobLogs(job), func(ctx context.Context) (logstream.Job, error) {
+		job, err := h.Store.GetBatchSpecWorkspaceExecutionJob(ctx, store.GetBatchSpecWorkspaceExecutionJobOpts{ID: jobID})
+		if err != nil {
+			return logstream.Job{}, err
+		}
+		return executionJobLogs(job), nil
+	})
+	if err != nil {
+		log15.Error("Reading batch spec workspace execution job failed", "id", jobID, "error", err)
+	}
+}
+
+func executionJobLogs(job *btypes.BatchSpecWorkspaceExecutionJob) logstream.Job {
+	return logstream.Job{
+		ExecutionLogs:  job.ExecutionLogs,
+		Finished:       executionJobFinished(job.State),
+		State:          job.State.ToGraphQL(),
+		FailureMessage: job.FailureMessage,
+	}
+}
+
+// executionJobFinished returns true if the job is in a terminal state. Errored
+// jobs are retried, so their logs may still grow.
+func executionJobFinished(state btypes.BatchSpecWorkspaceExecutionJobState) bool {
+	switch state {
+	case btypes.BatchSpecWorkspaceExecutionJobStateCompleted,
+		btypes.BatchSpecWorkspaceExecutionJobStateFailed:
+		return true
+	default:
+		return false
+	}
+}
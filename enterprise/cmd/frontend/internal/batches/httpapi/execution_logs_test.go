@@ -0,0 +1,93 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/batches/resolvers"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+)
+
+func TestExecutionLogsHandler(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	db := dbtest.NewDB(t, "")
+	s := store.New(db, &observation.TestContext, nil)
+	h := NewExecutionLogsHandler(s)
+	h.PollInterval = time.Millisecond
+
+	user := ct.CreateTestUser(t, db, false)
+	otherUser := ct.CreateTestUser(t, db, false)
+	repo, _ := ct.CreateTestRepo(t, ctx, db)
+
+	batchSpec := ct.CreateBatchSpec(t, ctx, s, "logs", user.ID)
+	ws := &btypes.BatchSpecWorkspace{BatchSpecID: batchSpec.ID, RepoID: repo.ID}
+	if err := s.CreateBatchSpecWorkspace(ctx, ws); err != nil {
+		t.Fatal(err)
+	}
+
+	stream := func(userID int32) *httptest.ResponseRecorder {
+		q := url.Values{"workspace": {string(relay.MarshalID(resolvers.BatchSpecWorkspaceIDKind, ws.ID))}}
+		req := httptest.NewRequest(http.MethodGet, "/.api/batches/logs/stream?"+q.Encode(), nil)
+		req = req.WithContext(actor.WithActor(ctx, actor.FromUser(userID)))
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := stream(user.ID); rec.Code != http.StatusNotFound {
+		t.Fatalf("unexpected status code: have=%d want=%d", rec.Code, http.StatusNotFound)
+	}
+
+	job := &btypes.BatchSpecWorkspaceExecutionJob{BatchSpecWorkspaceID: ws.ID}
+	if err := s.CreateBatchSpecWorkspaceExecutionJob(ctx, job); err != nil {
+		t.Fatal(err)
+	}
+	exitCode := 0
+	entry, err := json.Marshal(workerutil.ExecutionLogEntry{
+		Key:      "step.src.0",
+		Command:  []string{"src", "batch", "exec"},
+		Out:      "stdout: hello\n",
+		ExitCode: &exitCode,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Exec(ctx, sqlf.Sprintf("UPDATE batch_spec_workspace_execution_jobs SET state = 'completed', execution_logs = ARRAY[%s::json] WHERE id = %s", string(entry), job.ID)); err != nil {
+		t.Fatal(err)
+	}
+
+	if rec := stream(otherUser.ID); rec.Code != http.StatusForbidden {
+		t.Fatalf("unexpected status code: have=%d want=%d", rec.Code, http.StatusForbidden)
+	}
+
+	rec := stream(user.ID)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unexpected status code: have=%d want=%d", rec.Code, http.StatusOK)
+	}
+	body := rec.Body.String()
+	for _, want := range []string{"event: log", `"out":"stdout: hello\n"`, "event: done", `"state":"COMPLETED"`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected body to contain %q, have %q", want, body)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package httpapi
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+
+	"github.com/cockroachdb/errors"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/batches/resolvers"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+// StepArtifactsHandler serves the endpoint that src-cli uploads the artifacts
+// declared by the steps of a batch spec to, and that users download them from.
+type StepArtifactsHandler struct {
+	Store *store.Store
+}
+
+// NewStepArtifactsHandler returns a new StepArtifactsHandler.
+func NewStepArtifactsHandler(store *store.Store) *StepArtifactsHandler {
+	return &StepArtifactsHandler{Store: store}
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *StepArtifactsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.download(w, r)
+	case http.MethodPost:
+		h.upload(w, r)
+	default:
+		respond(w, http.StatusMethodNotAllowed, nil)
+	}
+}
+
+// upload stores the request body as the artifact with the path given in the
+// query parameters. The path has to be declared in the artifacts of the step.
+func (h *StepArtifactsHandler) upload(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	q := r.URL.Query()
+
+	var workspaceID int64
+	if err := unmarshalID(q.Get("workspace"), resolvers.BatchSpecWorkspaceIDKind, &workspaceID); err != nil {
+		respond(w, http.StatusBadRequest, errors.New("invalid workspace"))
+		return
+	}
+	step, err := strconv.Atoi(q.Get("step"))
+	if err != nil {
+		respond(w, http.StatusBadRequest, errors.New("invalid step"))
+		return
+	}
+	artifactPath := q.Get("path")
+
+	ws, err := h.Store.GetBatchSpecWorkspace(ctx, store.GetBatchSpecWorkspaceOpts{ID: workspaceID})
+	if err != nil {
+		if err == store.ErrNoResults {
+			respond(w, http.StatusNotFound, errors.New("workspace not found"))
+			return
+		}
+		respond(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	batchSpec, err := h.Store.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: ws.BatchSpecID})
+	if err != nil {
+		respond(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	// 🚨 SECURITY: Only the user that executes the batch spec is allowed to
+	// upload artifacts of its workspaces. The executor authenticates with an
+	// internal access token of that user.
+	if a := actor.FromContext(ctx); !a.IsAuthenticated() || a.UID != batchSpec.UserID {
+		respond(w, http.StatusForbidden, errors.New("must be the creator of the batch spec"))
+		return
+	}
+
+	declared, ok := findStepArtifact(ws, step, artifactPath)
+	if !ok {
+		respond(w, http.StatusBadRequest, errors.Errorf("step %d doesn't declare an artifact with path %q", step, artifactPath))
+		return
+	}
+
+	content, err := io.ReadAll(io.LimitReader(r.Body, btypes.BatchSpecWorkspaceStepArtifactMaxSize+1))
+	if err != nil {
+		respond(w, http.StatusInternalServerError, err)
+		return
+	}
+	if len(content) > btypes.BatchSpecWorkspaceStepArtifactMaxSize {
+		respond(w, http.StatusRequestEntityTooLarge, errors.Errorf("artifact exceeds maximum size of %d bytes", btypes.BatchSpecWorkspaceStepArtifactMaxSize))
+		return
+	}
+
+	artifact := &btypes.BatchSpecWorkspaceStepArtifact{
+		BatchSpecWorkspaceID: ws.ID,
+		Step:                 step,
+		Name:                 declared.DisplayName(),
+		Path:                 declared.Path,
+		Content:              content,
+	}
+	if err := h.Store.CreateBatchSpecWorkspaceStepArtifact(ctx, artifact); err != nil {
+		respond(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	respond(w, http.StatusCreated, nil)
+}
+
+// download writes the content of the artifact with the GraphQL ID given in
+// the id query parameter.
+func (h *StepArtifactsHandler) download(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var artifactID int64
+	if err := unmarshalID(r.URL.Query().Get("id"), resolvers.BatchSpecWorkspaceStepArtifactIDKind, &artifactID); err != nil {
+		respond(w, http.StatusBadRequest, errors.New("invalid id"))
+		return
+	}
+
+	artifact, err := h.Store.GetBatchSpecWorkspaceStepArtifact(ctx, store.GetBatchSpecWorkspaceStepArtifactOpts{ID: artifactID})
+	if err != nil {
+		if err == store.ErrNoResults {
+			respond(w, http.StatusNotFound, errors.New("artifact not found"))
+			return
+		}
+		respond(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	ws, err := h.Store.GetBatchSpecWorkspace(ctx, store.GetBatchSpecWorkspaceOpts{ID: artifact.BatchSpecWorkspaceID})
+	if err != nil {
+		respond(w, http.StatusInternalServerError, err)
+		return
+	}
+	batchSpec, err := h.Store.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: ws.BatchSpecID})
+	if err != nil {
+		respond(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	// 🚨 SECURITY: Only site-admins and the creator of the batch spec may
+	// download artifacts, and only if they can access the repository of the
+	// workspace.
+	if err := backend.CheckSiteAdminOrSameUser(ctx, h.Store.DB(), batchSpec.UserID); err != nil {
+		respond(w, http.StatusForbidden, err)
+		return
+	}
+	if _, err := database.Repos(h.Store.DB()).Get(ctx, ws.RepoID); err != nil {
+		if errcode.IsNotFound(err) {
+			respond(w, http.StatusNotFound, errors.New("artifact not found"))
+			return
+		}
+		respond(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Length", strconv.Itoa(len(artifact.Content)))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": path.Base(artifact.Path)}))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(artifact.Content); err != nil {
+		log15.Error("Writing step artifact failed", "id", artifact.ID, "error", err)
+	}
+}
+
+// findStepArtifact returns the artifact with the given path declared by the
+// given one-based step of the workspace. The path matches either as declared
+// or relative to the directory the step ran in.
+func findStepArtifact(ws *btypes.BatchSpecWorkspace, step int, artifactPath string) (batcheslib.StepArtifact, bool) {
+	if step < 1 || step > len(ws.Steps) {
+		return batcheslib.StepArtifact{}, false
+	}
+	for _, a := range ws.Steps[step-1].Artifacts {
+		if a.Path == artifactPath || a.RelativePath() == artifactPath {
+			return a, true
+		}
+	}
+	return batcheslib.StepArtifact{}, false
+}
+
+func unmarshalID(id, kind string, v *int64) error {
+	if relay.UnmarshalKind(graphql.ID(id)) != kind {
+		return errors.Errorf("expected ID of kind %q", kind)
+	}
+	if err := relay.UnmarshalSpec(graphql.ID(id), v); err != nil {
+		return err
+	}
+	if *v == 0 {
+		return errors.New("empty ID")
+	}
+	return nil
+}
+
+func respond(w http.ResponseWriter, code int, err error) {
+	if err == nil {
+		w.WriteHeader(code)
+		return
+	}
+	if code >= http.StatusInternalServerError {
+		log15.Error(err.Error())
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(code)
+	fmt.Fprintf(w, "%v", err)
+}
@@ -0,0 +1,140 @@
+package httpapi
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/batches/resolvers"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+func TestStepArtifactsHandler(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	db := dbtest.NewDB(t, "")
+	s := store.New(db, &observation.TestContext, nil)
+	h := NewStepArtifactsHandler(s)
+
+	user := ct.CreateTestUser(t, db, false)
+	otherUser := ct.CreateTestUser(t, db, false)
+	repo, _ := ct.CreateTestRepo(t, ctx, db)
+
+	batchSpec := ct.CreateBatchSpec(t, ctx, s, "artifacts", user.ID)
+	ws := &btypes.BatchSpecWorkspace{
+		BatchSpecID: batchSpec.ID,
+		RepoID:      repo.ID,
+		Steps: []batcheslib.Step{
+			{Run: "make test", Container: "golang", Artifacts: []batcheslib.StepArtifact{{Path: "report.xml", Name: "report"}}},
+		},
+	}
+	if err := s.CreateBatchSpecWorkspace(ctx, ws); err != nil {
+		t.Fatal(err)
+	}
+
+	upload := func(t *testing.T, userID int32, step, path string, body []byte) int {
+		t.Helper()
+
+		q := url.Values{
+			"workspace": {string(relay.MarshalID(resolvers.BatchSpecWorkspaceIDKind, ws.ID))},
+			"step":      {step},
+			"path":      {path},
+		}
+		req := httptest.NewRequest(http.MethodPost, batcheslib.StepArtifactsPath+"?"+q.Encode(), bytes.NewReader(body))
+		req = req.WithContext(actor.WithActor(ctx, actor.FromUser(userID)))
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	t.Run("upload", func(t *testing.T) {
+		for name, tc := range map[string]struct {
+			userID int32
+			step   string
+			path   string
+			body   []byte
+			want   int
+		}{
+			"other user": {
+				userID: otherUser.ID, step: "1", path: "report.xml", body: []byte("<testsuites/>"),
+				want: http.StatusForbidden,
+			},
+			"undeclared path": {
+				userID: user.ID, step: "1", path: "secrets.txt", body: []byte("hunter2"),
+				want: http.StatusBadRequest,
+			},
+			"step out of range": {
+				userID: user.ID, step: "2", path: "report.xml", body: []byte("<testsuites/>"),
+				want: http.StatusBadRequest,
+			},
+			"too large": {
+				userID: user.ID, step: "1", path: "report.xml", body: bytes.Repeat([]byte("a"), btypes.BatchSpecWorkspaceStepArtifactMaxSize+1),
+				want: http.StatusRequestEntityTooLarge,
+			},
+			"success": {
+				userID: user.ID, step: "1", path: "report.xml", body: []byte("<testsuites/>"),
+				want: http.StatusCreated,
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				if have := upload(t, tc.userID, tc.step, tc.path, tc.body); have != tc.want {
+					t.Fatalf("unexpected status code: have=%d want=%d", have, tc.want)
+				}
+			})
+		}
+	})
+
+	t.Run("download", func(t *testing.T) {
+		artifacts, err := s.ListBatchSpecWorkspaceStepArtifacts(ctx, store.ListBatchSpecWorkspaceStepArtifactsOpts{BatchSpecWorkspaceID: ws.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(artifacts) != 1 {
+			t.Fatalf("unexpected number of artifacts: %d", len(artifacts))
+		}
+		if have, want := artifacts[0].Name, "report"; have != want {
+			t.Fatalf("unexpected name: have=%q want=%q", have, want)
+		}
+
+		download := func(userID int32) *httptest.ResponseRecorder {
+			q := url.Values{"id": {string(relay.MarshalID(resolvers.BatchSpecWorkspaceStepArtifactIDKind, artifacts[0].ID))}}
+			req := httptest.NewRequest(http.MethodGet, batcheslib.StepArtifactsPath+"?"+q.Encode(), nil)
+			req = req.WithContext(actor.WithActor(ctx, actor.FromUser(userID)))
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			return rec
+		}
+
+		rec := download(user.ID)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("unexpected status code: have=%d want=%d", rec.Code, http.StatusOK)
+		}
+		if have, want := rec.Body.String(), "<testsuites/>"; have != want {
+			t.Fatalf("unexpected content: have=%q want=%q", have, want)
+		}
+		if have := rec.Header().Get("Content-Disposition"); !strings.Contains(have, "report.xml") {
+			t.Fatalf("unexpected content disposition: %q", have)
+		}
+
+		if rec := download(otherUser.ID); rec.Code != http.StatusForbidden {
+			t.Fatalf("unexpected status code: have=%d want=%d", rec.Code, http.StatusForbidden)
+		}
+	})
+}
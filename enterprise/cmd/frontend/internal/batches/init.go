@@ -8,6 +8,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/enterprise"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/batches/httpapi"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/batches/migrations"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/batches/resolvers"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/batches/webhooks"
@@ -23,13 +24,16 @@ import (
 
 // Init initializes the given enterpriseServices to include the required
 // resolvers for Batch Changes and sets up webhook handlers for changeset
-// events.
+// events and the handler for step artifacts.
 func Init(ctx context.Context, db dbutil.DB, outOfBandMigrationRunner *oobmigration.Runner, enterpriseServices *enterprise.Services) error {
 	// Validate site configuration.
 	conf.ContributeValidator(func(c conf.Unified) (problems conf.Problems) {
 		if _, err := window.NewConfiguration(c.BatchChangesRolloutWindows); err != nil {
 			problems = append(problems, conf.NewSiteProblem(err.Error()))
 		}
+		if _, err := window.NewAutoMergeConfiguration(c.BatchChangesAutoMergeWindows); err != nil {
+			problems = append(problems, conf.NewSiteProblem(err.Error()))
+		}
 
 		return
 	})
@@ -47,7 +51,10 @@ func Init(ctx context.Context, db dbutil.DB, outOfBandMigrationRunner *oobmigrat
 	enterpriseServices.BatchChangesResolver = resolvers.New(cstore)
 	enterpriseServices.GitHubWebhook = webhooks.NewGitHubWebhook(cstore)
 	enterpriseServices.BitbucketServerWebhook = webhooks.NewBitbucketServerWebhook(cstore)
+	enterpriseServices.BitbucketCloudWebhook = webhooks.NewBitbucketCloudWebhook(cstore)
 	enterpriseServices.GitLabWebhook = webhooks.NewGitLabWebhook(cstore)
+	enterpriseServices.BatchesStepArtifactsHandler = httpapi.NewStepArtifactsHandler(cstore)
+	enterpriseServices.BatchesExecutionLogsHandler = httpapi.NewExecutionLogsHandler(cstore)
 
 	// Register Batch Changes OOB migrations.
 	return migrations.Register(cstore, outOfBandMigrationRunner)
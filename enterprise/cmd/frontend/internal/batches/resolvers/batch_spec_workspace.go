@@ -15,10 +15,12 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 )
 
-const batchSpecWorkspaceIDKind = "BatchSpecWorkspace"
+// BatchSpecWorkspaceIDKind is the kind of the GraphQL IDs of batch spec
+// workspaces, which the batches HTTP API accepts as well.
+const BatchSpecWorkspaceIDKind = "BatchSpecWorkspace"
 
 func marshalBatchSpecWorkspaceID(id int64) graphql.ID {
-	return relay.MarshalID(batchSpecWorkspaceIDKind, id)
+	return relay.MarshalID(BatchSpecWorkspaceIDKind, id)
 }
 
 func unmarshalBatchSpecWorkspaceID(id graphql.ID) (batchSpecWorkspaceID int64, err error) {
@@ -97,7 +99,7 @@ func (r *batchSpecWorkspaceResolver) Steps(ctx context.Context) ([]graphqlbacken
 			// Step hasn't run yet.
 			si = &btypes.StepInfo{}
 		}
-		resolvers = append(resolvers, &batchSpecWorkspaceStepResolver{index: idx, step: step, stepInfo: si, store: r.store, repo: repo, baseRev: r.workspace.Commit})
+		resolvers = append(resolvers, &batchSpecWorkspaceStepResolver{workspaceID: r.workspace.ID, index: idx, step: step, stepInfo: si, store: r.store, repo: repo, baseRev: r.workspace.Commit})
 	}
 
 	return resolvers, nil
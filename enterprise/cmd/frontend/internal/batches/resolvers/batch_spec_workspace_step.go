@@ -10,12 +10,13 @@ import (
 )
 
 type batchSpecWorkspaceStepResolver struct {
-	store    *store.Store
-	repo     *graphqlbackend.RepositoryResolver
-	baseRev  string
-	index    int
-	step     batcheslib.Step
-	stepInfo *btypes.StepInfo
+	store       *store.Store
+	repo        *graphqlbackend.RepositoryResolver
+	baseRev     string
+	workspaceID int64
+	index       int
+	step        batcheslib.Step
+	stepInfo    *btypes.StepInfo
 }
 
 func (r *batchSpecWorkspaceStepResolver) Run() string {
@@ -154,3 +155,19 @@ func (r *batchSpecWorkspaceOutputVariableResolver) Name() string {
 func (r *batchSpecWorkspaceOutputVariableResolver) Value() graphqlbackend.JSONValue {
 	return graphqlbackend.JSONValue{Value: r.value}
 }
+
+func (r *batchSpecWorkspaceStepResolver) Artifacts(ctx context.Context) ([]graphqlbackend.BatchSpecWorkspaceStepArtifactResolver, error) {
+	artifacts, err := r.store.ListBatchSpecWorkspaceStepArtifacts(ctx, store.ListBatchSpecWorkspaceStepArtifactsOpts{
+		BatchSpecWorkspaceID: r.workspaceID,
+		Step:                 r.index + 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.BatchSpecWorkspaceStepArtifactResolver, 0, len(artifacts))
+	for _, a := range artifacts {
+		resolvers = append(resolvers, &batchSpecWorkspaceStepArtifactResolver{artifact: a})
+	}
+	return resolvers, nil
+}
@@ -0,0 +1,50 @@
+package resolvers
+
+import (
+	"net/url"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+// BatchSpecWorkspaceStepArtifactIDKind is the kind of the GraphQL IDs of step
+// artifacts, which the batches HTTP API accepts to download them.
+const BatchSpecWorkspaceStepArtifactIDKind = "BatchSpecWorkspaceStepArtifact"
+
+func marshalBatchSpecWorkspaceStepArtifactID(id int64) graphql.ID {
+	return relay.MarshalID(BatchSpecWorkspaceStepArtifactIDKind, id)
+}
+
+type batchSpecWorkspaceStepArtifactResolver struct {
+	artifact *btypes.BatchSpecWorkspaceStepArtifact
+}
+
+var _ graphqlbackend.BatchSpecWorkspaceStepArtifactResolver = &batchSpecWorkspaceStepArtifactResolver{}
+
+func (r *batchSpecWorkspaceStepArtifactResolver) ID() graphql.ID {
+	return marshalBatchSpecWorkspaceStepArtifactID(r.artifact.ID)
+}
+
+func (r *batchSpecWorkspaceStepArtifactResolver) Name() string {
+	return r.artifact.Name
+}
+
+func (r *batchSpecWorkspaceStepArtifactResolver) Path() string {
+	return r.artifact.Path
+}
+
+func (r *batchSpecWorkspaceStepArtifactResolver) ByteSize() int32 {
+	return int32(r.artifact.Size)
+}
+
+func (r *batchSpecWorkspaceStepArtifactResolver) URL() string {
+	return batcheslib.StepArtifactsPath + "?" + url.Values{"id": {string(r.ID())}}.Encode()
+}
+
+func (r *batchSpecWorkspaceStepArtifactResolver) CreatedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.artifact.CreatedAt}
+}
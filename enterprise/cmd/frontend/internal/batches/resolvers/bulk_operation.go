@@ -117,6 +117,8 @@ func changesetJobTypeToBulkOperationType(t btypes.ChangesetJobType) (string, err
 		return "REENQUEUE", nil
 	case btypes.ChangesetJobTypeMerge:
 		return "MERGE", nil
+	case btypes.ChangesetJobTypeAutoMerge:
+		return "AUTO_MERGE", nil
 	case btypes.ChangesetJobTypeClose:
 		return "CLOSE", nil
 	case btypes.ChangesetJobTypePublish:
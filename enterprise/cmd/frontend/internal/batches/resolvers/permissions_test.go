@@ -750,6 +750,12 @@ func TestPermissionLevels(t *testing.T) {
 					return fmt.Sprintf(`mutation { mergeChangesets(batchChange: %q, changesets: [%q]) { id } }`, batchChangeID, changesetID)
 				},
 			},
+			{
+				name: "autoMergeChangesets",
+				mutationFunc: func(batchChangeID, changesetID, batchSpecID string) string {
+					return fmt.Sprintf(`mutation { autoMergeChangesets(batchChange: %q, changesets: [%q]) { id } }`, batchChangeID, changesetID)
+				},
+			},
 			{
 				name: "closeChangesets",
 				mutationFunc: func(batchChangeID, changesetID, batchSpecID string) string {
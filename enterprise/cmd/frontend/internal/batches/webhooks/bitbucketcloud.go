@@ -0,0 +1,202 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/cockroachdb/errors"
+	"github.com/hashicorp/go-multierror"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+type BitbucketCloudWebhook struct {
+	*Webhook
+}
+
+func NewBitbucketCloudWebhook(store *store.Store) *BitbucketCloudWebhook {
+	return &BitbucketCloudWebhook{
+		Webhook: &Webhook{store, extsvc.TypeBitbucketCloud},
+	}
+}
+
+func (h *BitbucketCloudWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e, extSvc, hErr := h.parseEvent(r)
+	if hErr != nil {
+		respond(w, hErr.code, hErr)
+		return
+	}
+
+	externalServiceID, err := extractExternalServiceID(extSvc)
+	if err != nil {
+		respond(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	prs, ev := h.convertEvent(r.Context(), externalServiceID, e)
+
+	m := new(multierror.Error)
+	for _, pr := range prs {
+		if pr == (PR{}) {
+			log15.Warn("Dropping Bitbucket Cloud webhook event", "type", fmt.Sprintf("%T", e))
+			continue
+		}
+
+		err := h.upsertChangesetEvent(r.Context(), externalServiceID, pr, ev)
+		if err != nil {
+			m = multierror.Append(m, err)
+		}
+	}
+	if m.ErrorOrNil() != nil {
+		respond(w, http.StatusInternalServerError, m)
+	}
+}
+
+func (h *BitbucketCloudWebhook) parseEvent(r *http.Request) (interface{}, *types.ExternalService, *httpError) {
+	payload, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, nil, &httpError{http.StatusInternalServerError, err}
+	}
+
+	externalServiceID, err := strconv.ParseInt(r.FormValue(extsvc.IDParam), 10, 64)
+	if err != nil {
+		return nil, nil, &httpError{http.StatusBadRequest, errors.Wrap(err, "invalid external service id")}
+	}
+
+	es, err := h.Store.ExternalServices().List(r.Context(), database.ExternalServicesListOptions{
+		IDs:   []int64{externalServiceID},
+		Kinds: []string{extsvc.KindBitbucketCloud},
+	})
+	if err != nil {
+		return nil, nil, &httpError{http.StatusInternalServerError, err}
+	}
+	if len(es) != 1 {
+		return nil, nil, &httpError{http.StatusUnauthorized, errExternalServiceNotFound}
+	}
+	extSvc := es[0]
+
+	// 🚨 SECURITY: Bitbucket Cloud doesn't sign webhook payloads, so the
+	// shared secret is passed as a query parameter of the webhook URL and
+	// verified against the external service configuration.
+	if ok, err := validateBitbucketCloudSecret(extSvc, r.URL.Query().Get("secret")); err != nil {
+		return nil, nil, &httpError{http.StatusInternalServerError, errors.Wrap(err, "validating the shared secret")}
+	} else if !ok {
+		return nil, nil, &httpError{http.StatusUnauthorized, errors.New("shared secret is incorrect")}
+	}
+
+	e, err := bitbucketcloud.ParseWebhookEvent(bitbucketcloud.WebhookEventKey(r), payload)
+	if err != nil {
+		return nil, nil, &httpError{http.StatusBadRequest, errors.Wrap(err, "parsing webhook")}
+	}
+	return e, extSvc, nil
+}
+
+func (h *BitbucketCloudWebhook) convertEvent(ctx context.Context, externalServiceID string, theirs interface{}) (prs []PR, ours keyer) {
+	log15.Debug("Bitbucket Cloud webhook received", "type", fmt.Sprintf("%T", theirs))
+
+	switch e := theirs.(type) {
+	case *bitbucketcloud.PullRequestApprovedEvent:
+		prs = append(prs, bitbucketCloudPR(&e.PullRequestEvent))
+		return prs, bitbucketCloudParticipant(e.Approval, bitbucketcloud.ParticipantStateApproved)
+	case *bitbucketcloud.PullRequestUnapprovedEvent:
+		prs = append(prs, bitbucketCloudPR(&e.PullRequestEvent))
+		return prs, bitbucketCloudParticipant(e.Approval, "")
+	case *bitbucketcloud.PullRequestChangesRequestCreatedEvent:
+		prs = append(prs, bitbucketCloudPR(&e.PullRequestEvent))
+		return prs, bitbucketCloudParticipant(e.ChangesRequest, bitbucketcloud.ParticipantStateChangesRequested)
+	case *bitbucketcloud.PullRequestChangesRequestRemovedEvent:
+		prs = append(prs, bitbucketCloudPR(&e.PullRequestEvent))
+		return prs, bitbucketCloudParticipant(e.ChangesRequest, "")
+	case *bitbucketcloud.PullRequestFulfilledEvent:
+		prs = append(prs, bitbucketCloudPR(&e.PullRequestEvent))
+		return prs, e
+	case *bitbucketcloud.PullRequestRejectedEvent:
+		prs = append(prs, bitbucketCloudPR(&e.PullRequestEvent))
+		return prs, e
+	case *bitbucketcloud.RepoCommitStatusEvent:
+		// A commit status event doesn't reference pull requests, so we need to
+		// find all changesets on the branch the status was reported on.
+		if e.CommitStatus.RefName == "" {
+			return nil, nil
+		}
+
+		spec := api.ExternalRepoSpec{
+			ID:          e.Repository.UUID,
+			ServiceID:   externalServiceID,
+			ServiceType: extsvc.TypeBitbucketCloud,
+		}
+
+		ids, err := h.Store.GetChangesetExternalIDs(ctx, spec, []string{git.EnsureRefPrefix(e.CommitStatus.RefName)})
+		if err != nil {
+			log15.Error("Error executing GetChangesetExternalIDs", "err", err)
+			return nil, nil
+		}
+
+		for _, id := range ids {
+			i, err := strconv.ParseInt(id, 10, 64)
+			if err != nil {
+				log15.Error("Error parsing external id", "err", err)
+				continue
+			}
+			prs = append(prs, PR{ID: i, RepoExternalID: e.Repository.UUID})
+		}
+
+		status := e.CommitStatus
+		return prs, &status
+	}
+
+	return
+}
+
+func bitbucketCloudPR(e *bitbucketcloud.PullRequestEvent) PR {
+	return PR{ID: e.PullRequest.ID, RepoExternalID: e.Repository.UUID}
+}
+
+// bitbucketCloudParticipant converts an approval or change request in a
+// webhook event into the participant it results in. A removed approval or
+// change request is represented by a participant without state.
+func bitbucketCloudParticipant(a bitbucketcloud.Approval, state bitbucketcloud.ParticipantState) *bitbucketcloud.Participant {
+	return &bitbucketcloud.Participant{
+		User:           a.User,
+		Role:           "PARTICIPANT",
+		Approved:       state == bitbucketcloud.ParticipantStateApproved,
+		State:          state,
+		ParticipatedOn: a.Date,
+	}
+}
+
+func validateBitbucketCloudSecret(extSvc *types.ExternalService, secret string) (bool, error) {
+	// An empty secret never succeeds.
+	if secret == "" {
+		return false, nil
+	}
+
+	c, err := extSvc.Configuration()
+	if err != nil {
+		return false, errors.Wrap(err, "getting external service configuration")
+	}
+
+	config, ok := c.(*schema.BitbucketCloudConnection)
+	if !ok {
+		return false, errExternalServiceWrongKind
+	}
+
+	for _, webhook := range config.Webhooks {
+		if subtle.ConstantTimeCompare([]byte(webhook.Secret), []byte(secret)) == 1 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
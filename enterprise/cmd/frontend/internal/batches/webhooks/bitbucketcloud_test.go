@@ -0,0 +1,127 @@
+package webhooks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestBitbucketCloudWebhookConvertEvent(t *testing.T) {
+	t.Parallel()
+
+	h := NewBitbucketCloudWebhook(nil)
+	date := time.Date(2021, 9, 1, 10, 0, 0, 0, time.UTC)
+	common := bitbucketcloud.PullRequestEvent{
+		Repository:  bitbucketcloud.Repo{UUID: "{repo}"},
+		PullRequest: bitbucketcloud.PullRequest{ID: 7},
+	}
+	approval := bitbucketcloud.Approval{Date: date, User: bitbucketcloud.Account{UUID: "{alice}"}}
+
+	for name, tc := range map[string]struct {
+		event interface{}
+		want  keyer
+	}{
+		"approved": {
+			event: &bitbucketcloud.PullRequestApprovedEvent{PullRequestEvent: common, Approval: approval},
+			want: &bitbucketcloud.Participant{
+				User:           approval.User,
+				Role:           "PARTICIPANT",
+				Approved:       true,
+				State:          bitbucketcloud.ParticipantStateApproved,
+				ParticipatedOn: date,
+			},
+		},
+		"unapproved": {
+			event: &bitbucketcloud.PullRequestUnapprovedEvent{PullRequestEvent: common, Approval: approval},
+			want: &bitbucketcloud.Participant{
+				User:           approval.User,
+				Role:           "PARTICIPANT",
+				ParticipatedOn: date,
+			},
+		},
+		"changes requested": {
+			event: &bitbucketcloud.PullRequestChangesRequestCreatedEvent{PullRequestEvent: common, ChangesRequest: approval},
+			want: &bitbucketcloud.Participant{
+				User:           approval.User,
+				Role:           "PARTICIPANT",
+				State:          bitbucketcloud.ParticipantStateChangesRequested,
+				ParticipatedOn: date,
+			},
+		},
+		"merged": {
+			event: &bitbucketcloud.PullRequestFulfilledEvent{PullRequestEvent: common},
+			want:  &bitbucketcloud.PullRequestFulfilledEvent{PullRequestEvent: common},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			prs, ev := h.convertEvent(context.Background(), "https://bitbucket.org/", tc.event)
+			if diff := cmp.Diff([]PR{{ID: 7, RepoExternalID: "{repo}"}}, prs); diff != "" {
+				t.Errorf("unexpected PRs (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want, ev); diff != "" {
+				t.Errorf("unexpected event (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateBitbucketCloudSecret(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty secret", func(t *testing.T) {
+		ok, err := validateBitbucketCloudSecret(nil, "")
+		if ok {
+			t.Errorf("unexpected ok: %v", ok)
+		}
+		if err != nil {
+			t.Errorf("unexpected non-nil error: %+v", err)
+		}
+	})
+
+	t.Run("not a Bitbucket Cloud connection", func(t *testing.T) {
+		es := &types.ExternalService{Kind: extsvc.KindGitHub}
+		ok, err := validateBitbucketCloudSecret(es, "secret")
+		if ok {
+			t.Errorf("unexpected ok: %v", ok)
+		}
+		if err != errExternalServiceWrongKind {
+			t.Errorf("unexpected error: have %+v; want %+v", err, errExternalServiceWrongKind)
+		}
+	})
+
+	t.Run("valid webhooks", func(t *testing.T) {
+		for secret, want := range map[string]bool{
+			"not secret": false,
+			"secret":     true,
+			"super":      true,
+		} {
+			t.Run(secret, func(t *testing.T) {
+				es := &types.ExternalService{
+					Kind: extsvc.KindBitbucketCloud,
+					Config: ct.MarshalJSON(t, &schema.BitbucketCloudConnection{
+						Webhooks: []*schema.BitbucketCloudWebhook{
+							{Secret: "super"},
+							{Secret: "secret"},
+						},
+					}),
+				}
+
+				ok, err := validateBitbucketCloudSecret(es, secret)
+				if ok != want {
+					t.Errorf("unexpected ok: have %v; want %v", ok, want)
+				}
+				if err != nil {
+					t.Errorf("unexpected non-nil error: %+v", err)
+				}
+			})
+		}
+	})
+}
@@ -72,6 +72,8 @@ func extractExternalServiceID(extSvc *types.ExternalService) (string, error) {
 		serviceID = c.Url
 	case *schema.BitbucketServerConnection:
 		serviceID = c.Url
+	case *schema.BitbucketCloudConnection:
+		serviceID = c.Url
 	case *schema.GitLabConnection:
 		serviceID = c.Url
 	}
@@ -174,6 +176,14 @@ func (h Webhook) upsertChangesetEvent(
 		return err
 	}
 
+	// Wake up a pending auto-merge of the changeset, if the event made it
+	// ready to be merged.
+	if cs.AutoMergeJobID != 0 && cs.ReadyToMerge() {
+		if err := tx.EnqueueAutoMergeChangesetJob(ctx, cs); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -11,6 +11,7 @@ type DBStore interface {
 	Done(err error) error
 
 	GetUploadByID(ctx context.Context, uploadID int) (dbstore.Upload, bool, error)
+	GetIndexByID(ctx context.Context, id int) (dbstore.Index, bool, error)
 	InsertUpload(ctx context.Context, upload dbstore.Upload) (int, error)
 	AddUploadPart(ctx context.Context, uploadID, partIndex int) error
 	MarkQueued(ctx context.Context, id int, uploadSize *int64) error
@@ -0,0 +1,98 @@
+package httpapi
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/inconshreveable/log15"
+
+	store "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
+	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/logstream"
+)
+
+type IndexLogsHandler struct {
+	dbStore      DBStore
+	pollInterval time.Duration
+}
+
+// NewIndexLogsHandler returns a handler that streams the execution logs of an
+// auto-indexing job as server-sent events while it runs. The events are those
+// written by logstream.Follow.
+func NewIndexLogsHandler(dbStore DBStore) http.Handler {
+	handler := &IndexLogsHandler{
+		dbStore:      dbStore,
+		pollInterval: time.Second,
+	}
+
+	return http.HandlerFunc(handler.handleStream)
+}
+
+// GET /lsif/indexes/logs/stream
+func (h *IndexLogsHandler) handleStream(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 🚨 SECURITY: Only site admins may see auto-indexing jobs, as in the GraphQL API.
+	if !isSiteAdmin(ctx) {
+		http.Error(w, "Only site admins may read the logs of auto-indexing jobs", http.StatusUnauthorized)
+		return
+	}
+
+	id := graphql.ID(getQuery(r, "index"))
+	var indexID int64
+	if relay.UnmarshalKind(id) != "LSIFIndex" || relay.UnmarshalSpec(id, &indexID) != nil {
+		http.Error(w, "Invalid index", http.StatusBadRequest)
+		return
+	}
+
+	index, exists, err := h.dbStore.GetIndexByID(ctx, int(indexID))
+	if err != nil {
+		log15.Error("codeintel.httpapi: failed to get index", "id", indexID, "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !exists {
+		http.Error(w, "Index not found", http.StatusNotFound)
+		return
+	}
+
+	eventWriter, err := streamhttp.NewWriter(w)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	err = logstream.Follow(ctx, eventWriter, h.pollInterval, indexLogs(index), func(ctx context.Context) (logstream.Job, error) {
+		index, exists, err := h.dbStore.GetIndexByID(ctx, int(indexID))
+		if err != nil {
+			return logstream.Job{}, err
+		}
+		if !exists {
+			return logstream.Job{}, clientError("index %d was deleted", indexID)
+		}
+		return indexLogs(index), nil
+	})
+	if err != nil {
+		log15.Error("codeintel.httpapi: failed to get index", "id", indexID, "error", err)
+	}
+}
+
+// indexLogs returns the snapshot of the given index followed by logstream.Follow.
+// Indexes are not retried, so errored indexes have finished as well.
+func indexLogs(index store.Index) logstream.Job {
+	state := strings.ToUpper(index.State)
+	if state == "FAILED" {
+		state = "ERRORED"
+	}
+
+	return logstream.Job{
+		ExecutionLogs:  index.ExecutionLogs,
+		Finished:       state == "COMPLETED" || state == "ERRORED",
+		State:          state,
+		FailureMessage: index.FailureMessage,
+	}
+}
@@ -0,0 +1,109 @@
+package httpapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	store "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+)
+
+func TestIndexLogsHandler(t *testing.T) {
+	setupSiteAdminMock(t, true)
+
+	startTime := time.Unix(1587396557, 0).UTC()
+	entry := workerutil.ExecutionLogEntry{Key: "step.docker.0", StartTime: startTime, Out: "stdout: a\n"}
+
+	mockDBStore := NewMockDBStore()
+	mockDBStore.GetIndexByIDFunc.PushReturn(store.Index{ID: 42, State: "processing", ExecutionLogs: []workerutil.ExecutionLogEntry{entry}}, true, nil)
+	entry.Out += "stdout: b\n"
+	mockDBStore.GetIndexByIDFunc.PushReturn(store.Index{ID: 42, State: "completed", ExecutionLogs: []workerutil.ExecutionLogEntry{entry}}, true, nil)
+
+	handler := &IndexLogsHandler{dbStore: mockDBStore, pollInterval: time.Millisecond}
+
+	w := httptest.NewRecorder()
+	handler.handleStream(w, newIndexLogsRequest(t, string(relay.MarshalID("LSIFIndex", 42))))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected status code. want=%d have=%d", http.StatusOK, w.Code)
+	}
+
+	expected := "event: log\n" +
+		`data: {"entry":0,"key":"step.docker.0","startTime":"2020-04-20T15:29:17Z","offset":0,"out":"stdout: a\n"}` + "\n\n" +
+		"event: log\n" +
+		`data: {"entry":0,"offset":10,"out":"stdout: b\n"}` + "\n\n" +
+		"event: done\n" +
+		`data: {"state":"COMPLETED"}` + "\n\n"
+	if diff := cmp.Diff(expected, w.Body.String()); diff != "" {
+		t.Errorf("unexpected events (-want +got):\n%s", diff)
+	}
+
+	for _, call := range mockDBStore.GetIndexByIDFunc.History() {
+		if call.Arg1 != 42 {
+			t.Errorf("unexpected index id. want=%d have=%d", 42, call.Arg1)
+		}
+	}
+}
+
+func TestIndexLogsHandlerNotSiteAdmin(t *testing.T) {
+	setupSiteAdminMock(t, false)
+
+	mockDBStore := NewMockDBStore()
+	handler := &IndexLogsHandler{dbStore: mockDBStore, pollInterval: time.Millisecond}
+
+	w := httptest.NewRecorder()
+	handler.handleStream(w, newIndexLogsRequest(t, string(relay.MarshalID("LSIFIndex", 42))))
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("unexpected status code. want=%d have=%d", http.StatusUnauthorized, w.Code)
+	}
+	if len(mockDBStore.GetIndexByIDFunc.History()) != 0 {
+		t.Errorf("unexpected call to GetIndexByID")
+	}
+}
+
+func TestIndexLogsHandlerInvalidID(t *testing.T) {
+	setupSiteAdminMock(t, true)
+
+	handler := &IndexLogsHandler{dbStore: NewMockDBStore(), pollInterval: time.Millisecond}
+
+	w := httptest.NewRecorder()
+	handler.handleStream(w, newIndexLogsRequest(t, string(relay.MarshalID("LSIFUpload", 42))))
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("unexpected status code. want=%d have=%d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func newIndexLogsRequest(t *testing.T, id string) *http.Request {
+	testURL, err := url.Parse("http://test.com/lsif/indexes/logs/stream")
+	if err != nil {
+		t.Fatalf("unexpected error constructing url: %s", err)
+	}
+	testURL.RawQuery = (url.Values{"index": []string{id}}).Encode()
+
+	r, err := http.NewRequest("GET", testURL.String(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error constructing request: %s", err)
+	}
+	return r
+}
+
+func setupSiteAdminMock(t testing.TB, siteAdmin bool) {
+	t.Cleanup(func() {
+		database.Mocks.Users.GetByCurrentAuthUser = nil
+	})
+
+	database.Mocks.Users.GetByCurrentAuthUser = func(ctx context.Context) (*types.User, error) {
+		return &types.User{SiteAdmin: siteAdmin}, nil
+	}
+}
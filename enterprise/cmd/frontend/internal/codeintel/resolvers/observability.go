@@ -19,8 +19,10 @@ type operations struct {
 	definitions               *observation.Operation
 	diagnostics               *observation.Operation
 	hover                     *observation.Operation
+	implementations           *observation.Operation
 	ranges                    *observation.Operation
 	references                *observation.Operation
+	typeDefinitions           *observation.Operation
 	documentationPage         *observation.Operation
 	documentationPathInfo     *observation.Operation
 	documentationIDsToPathIDs *observation.Operation
@@ -60,8 +62,10 @@ func newOperations(observationContext *observation.Context) *operations {
 		definitions:               op("Definitions"),
 		diagnostics:               op("Diagnostics"),
 		hover:                     op("Hover"),
+		implementations:           op("Implementations"),
 		ranges:                    op("Ranges"),
 		references:                op("References"),
+		typeDefinitions:           op("TypeDefinitions"),
 		documentationPage:         op("DocumentationPage"),
 		documentationPathInfo:     op("DocumentationPathInfo"),
 		documentationIDsToPathIDs: op("DocumentationIDsToPathIDs"),
@@ -35,6 +35,14 @@ func (r *queryResolver) References(ctx context.Context, line, character, limit i
 	})
 	defer endObservation()
 
+	return r.pagedLocations(ctx, traceLog, "references", line, character, limit, rawCursor)
+}
+
+// pagedLocations returns a page of the source locations stored in the given table (either references
+// or implementations) for the symbol at the given position. Locations are gathered first by traversing
+// the LSIF graph of each visible upload, then by performing a moniker search over uploads that define
+// or reference the symbol. The returned cursor can be used to fetch the subsequent page.
+func (r *queryResolver) pagedLocations(ctx context.Context, traceLog observation.TraceLogger, tableName string, line, character, limit int, rawCursor string) ([]AdjustedLocation, string, error) {
 	// Maintain a map from identifers to hydrated upload records from the database. We use
 	// this map as a quick lookup when constructing the resulting location set. Any additional
 	// upload records pulled back from the database while processing this page will be added
@@ -96,14 +104,14 @@ func (r *queryResolver) References(ctx context.Context, line, character, limit i
 	}
 
 	// Query a single page of location results
-	locations, hasMore, err := r.pageReferences(ctx, adjustedUploads, orderedMonikers, definitionUploadIDs, uploadsByID, &cursor, limit)
+	locations, hasMore, err := r.pageLocations(ctx, tableName, adjustedUploads, orderedMonikers, definitionUploadIDs, uploadsByID, &cursor, limit)
 	if err != nil {
 		return nil, "", err
 	}
 	traceLog(log.Int("numLocations", len(locations)))
 
 	// Adjust the locations back to the appropriate range in the target commits. This adjusts
-	// locations within the repository the user is browsing so that it appears all references
+	// locations within the repository the user is browsing so that it appears all locations
 	// are occurring at the same commit they are looking at.
 
 	adjustedLocations, err := r.adjustLocations(ctx, uploadsByID, locations)
@@ -234,10 +242,10 @@ func (r *queryResolver) definitionUploadIDsFromCursor(ctx context.Context, adjus
 	return definitionUploadIDs, definitionUploads, nil
 }
 
-// pageReferences returns a slice of the result set denoted by the given cursor. The given cursor will be
+// pageLocations returns a slice of the result set denoted by the given cursor. The given cursor will be
 // adjusted to reflect the offsets required to resolve the next page of results. If there are no more pages
 // left in the result set, a false-valued flag is returned.
-func (r *queryResolver) pageReferences(ctx context.Context, adjustedUploads []adjustedUpload, orderedMonikers []precise.QualifiedMonikerData, definitionUploadIDs []int, uploadsByID map[int]dbstore.Dump, cursor *referencesCursor, limit int) ([]lsifstore.Location, bool, error) {
+func (r *queryResolver) pageLocations(ctx context.Context, tableName string, adjustedUploads []adjustedUpload, orderedMonikers []precise.QualifiedMonikerData, definitionUploadIDs []int, uploadsByID map[int]dbstore.Dump, cursor *referencesCursor, limit int) ([]lsifstore.Location, bool, error) {
 	var locations []lsifstore.Location
 
 	// Phase 1: Gather all "local" locations via LSIF graph traversal. We'll continue to request additional
@@ -246,7 +254,7 @@ func (r *queryResolver) pageReferences(ctx context.Context, adjustedUploads []ad
 
 	if !cursor.RemotePhase {
 		for len(locations) < limit {
-			localLocations, hasMore, err := r.pageLocalReferences(ctx, adjustedUploads, cursor, limit-len(locations))
+			localLocations, hasMore, err := r.pageLocalLocations(ctx, tableName, adjustedUploads, cursor, limit-len(locations))
 			if err != nil {
 				return nil, false, err
 			}
@@ -266,7 +274,7 @@ func (r *queryResolver) pageReferences(ctx context.Context, adjustedUploads []ad
 
 	if cursor.RemotePhase {
 		for len(locations) < limit {
-			remoteLocations, hasMore, err := r.pageRemoteReferences(ctx, adjustedUploads, orderedMonikers, definitionUploadIDs, uploadsByID, cursor, limit-len(locations))
+			remoteLocations, hasMore, err := r.pageRemoteLocations(ctx, tableName, adjustedUploads, orderedMonikers, definitionUploadIDs, uploadsByID, cursor, limit-len(locations))
 			if err != nil {
 				return nil, false, err
 			}
@@ -281,11 +289,16 @@ func (r *queryResolver) pageReferences(ctx context.Context, adjustedUploads []ad
 	return locations, true, nil
 }
 
-// pageLocalReferences returns a slice of the (local) result set denoted by the given cursor fulfilled by
+// pageLocalLocations returns a slice of the (local) result set denoted by the given cursor fulfilled by
 // traversing the LSIF graph. The given cursor will be adjusted to reflect the offsets required to resolve
 // the next page of results. If there are no more pages left in the result set, a false-valued flag is
 // returned.
-func (r *queryResolver) pageLocalReferences(ctx context.Context, adjustedUploads []adjustedUpload, cursor *referencesCursor, limit int) ([]lsifstore.Location, bool, error) {
+func (r *queryResolver) pageLocalLocations(ctx context.Context, tableName string, adjustedUploads []adjustedUpload, cursor *referencesCursor, limit int) ([]lsifstore.Location, bool, error) {
+	getLocations, operationName := r.lsifStore.References, "lsifstore.References"
+	if tableName == "implementations" {
+		getLocations, operationName = r.lsifStore.Implementations, "lsifstore.Implementations"
+	}
+
 	var allLocations []lsifstore.Location
 	for i := range adjustedUploads {
 		if len(allLocations) >= limit {
@@ -297,7 +310,7 @@ func (r *queryResolver) pageLocalReferences(ctx context.Context, adjustedUploads
 			continue
 		}
 
-		locations, totalCount, err := r.lsifStore.References(
+		locations, totalCount, err := getLocations(
 			ctx,
 			adjustedUploads[i].Upload.ID,
 			adjustedUploads[i].AdjustedPathInBundle,
@@ -307,7 +320,7 @@ func (r *queryResolver) pageLocalReferences(ctx context.Context, adjustedUploads
 			cursor.LocalOffset,
 		)
 		if err != nil {
-			return nil, false, errors.Wrap(err, "lsifstore.References")
+			return nil, false, errors.Wrap(err, operationName)
 		}
 
 		cursor.LocalOffset += len(locations)
@@ -328,11 +341,11 @@ func (r *queryResolver) pageLocalReferences(ctx context.Context, adjustedUploads
 // that can be passed to a single moniker search query.
 const maximumIndexesPerMonikerSearch = 50
 
-// pageRemoteReferences returns a slice of the (remote) result set denoted by the given cursor fulfilled by
+// pageRemoteLocations returns a slice of the (remote) result set denoted by the given cursor fulfilled by
 // performing a moniker search over a group of indexes. The given cursor will be adjusted to reflect the
 // offsets required to resolve the next page of results. If there are no more pages left in the result set,
 // a false-valued flag is returned.
-func (r *queryResolver) pageRemoteReferences(ctx context.Context, adjustedUploads []adjustedUpload, orderedMonikers []precise.QualifiedMonikerData, definitionUploadIDs []int, uploadsByID map[int]dbstore.Dump, cursor *referencesCursor, limit int) ([]lsifstore.Location, bool, error) {
+func (r *queryResolver) pageRemoteLocations(ctx context.Context, tableName string, adjustedUploads []adjustedUpload, orderedMonikers []precise.QualifiedMonikerData, definitionUploadIDs []int, uploadsByID map[int]dbstore.Dump, cursor *referencesCursor, limit int) ([]lsifstore.Location, bool, error) {
 	for len(cursor.BatchIDs) == 0 {
 		if cursor.RemoteBatchOffset < 0 {
 			// No more batches
@@ -364,7 +377,7 @@ func (r *queryResolver) pageRemoteReferences(ctx context.Context, adjustedUpload
 	}
 
 	// Perform the moniker search
-	locations, totalCount, err := r.monikerLocations(ctx, monikerSearchUploads, orderedMonikers, "references", limit, cursor.RemoteOffset)
+	locations, totalCount, err := r.monikerLocations(ctx, monikerSearchUploads, orderedMonikers, tableName, limit, cursor.RemoteOffset)
 	if err != nil {
 		return nil, false, err
 	}
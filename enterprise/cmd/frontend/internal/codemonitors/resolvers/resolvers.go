@@ -16,6 +16,7 @@ import (
 	cm "github.com/sourcegraph/sourcegraph/enterprise/internal/codemonitors"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codemonitors/email"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 )
 
 // NewResolver returns a new Resolver that uses the given database
@@ -115,6 +116,10 @@ func (r *Resolver) CreateCodeMonitor(ctx context.Context, args *graphqlbackend.C
 	if err != nil {
 		return nil, err
 	}
+	err = cm.ValidateQuery(args.Trigger.Query)
+	if err != nil {
+		return nil, err
+	}
 	var mo *cm.Monitor
 	mo, err = r.store.CreateCodeMonitor(ctx, args)
 	if err != nil {
@@ -162,6 +167,11 @@ func (r *Resolver) UpdateCodeMonitor(ctx context.Context, args *graphqlbackend.U
 		return nil, errors.Errorf("update namespace: %w", err)
 	}
 
+	err = cm.ValidateQuery(args.Trigger.Update.Query)
+	if err != nil {
+		return nil, err
+	}
+
 	var monitorID int64
 	err = relay.UnmarshalSpec(args.Monitor.Id, &monitorID)
 	if err != nil {
@@ -175,6 +185,9 @@ func (r *Resolver) UpdateCodeMonitor(ctx context.Context, args *graphqlbackend.U
 	}
 
 	toCreate, toDelete, err := splitActionIDs(ctx, args, actionIDs)
+	if err != nil {
+		return nil, err
+	}
 	if len(toDelete) == len(actionIDs) {
 		return nil, errors.Errorf("you tried to delete all actions, but every monitor must be connected to at least 1 action")
 	}
@@ -187,7 +200,7 @@ func (r *Resolver) UpdateCodeMonitor(ctx context.Context, args *graphqlbackend.U
 	}
 	defer func() { err = tx.store.Done(err) }()
 
-	err = tx.store.DeleteActionsInt64(ctx, toDelete, monitorID)
+	err = tx.deleteActions(ctx, monitorID, toDelete)
 	if err != nil {
 		return nil, err
 	}
@@ -283,6 +296,44 @@ func (r *Resolver) actionIDsForMonitorIDInt64(ctx context.Context, monitorID int
 		}
 		after = cur
 	}
+
+	// Webhooks and Slack webhooks.
+	after = nil
+	for {
+		ws, err := r.store.ListActionWebhooks(ctx, monitorID, &graphqlbackend.ListActionArgs{
+			First: int32(limit),
+			After: after,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, w := range ws {
+			ids = append(ids, (&monitorWebhook{MonitorWebhook: w}).ID())
+		}
+		if len(ws) < limit {
+			break
+		}
+		cur := string(ids[len(ids)-1])
+		after = &cur
+	}
+	after = nil
+	for {
+		ws, err := r.store.ListActionSlackWebhooks(ctx, monitorID, &graphqlbackend.ListActionArgs{
+			First: int32(limit),
+			After: after,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, w := range ws {
+			ids = append(ids, (&monitorSlackWebhook{MonitorSlackWebhook: w}).ID())
+		}
+		if len(ws) < limit {
+			break
+		}
+		cur := string(ids[len(ids)-1])
+		after = &cur
+	}
 	return ids, nil
 }
 
@@ -314,35 +365,78 @@ func (r *Resolver) actionIDsForMonitorIDINT64SinglePage(ctx context.Context, q *
 
 // splitActionIDs splits actions into three buckets: create, delete and update.
 // Note: args is mutated. After splitActionIDs, args only contains actions to be updated.
-func splitActionIDs(ctx context.Context, args *graphqlbackend.UpdateCodeMonitorArgs, actionIDs []graphql.ID) (toCreate []*graphqlbackend.CreateActionArgs, toDelete []int64, err error) {
+func splitActionIDs(ctx context.Context, args *graphqlbackend.UpdateCodeMonitorArgs, actionIDs []graphql.ID) (toCreate []*graphqlbackend.CreateActionArgs, toDelete []graphql.ID, err error) {
 	aMap := make(map[graphql.ID]struct{}, len(actionIDs))
 	for _, id := range actionIDs {
 		aMap[id] = struct{}{}
 	}
 	var toUpdateActions []*graphqlbackend.EditActionArgs
 	for _, a := range args.Actions {
-		if a.Email.Id == nil {
-			toCreate = append(toCreate, &graphqlbackend.CreateActionArgs{Email: a.Email.Update})
-			continue
+		var id *graphql.ID
+		switch {
+		case a.Email != nil:
+			if a.Email.Id == nil {
+				toCreate = append(toCreate, &graphqlbackend.CreateActionArgs{Email: a.Email.Update})
+				continue
+			}
+			id = a.Email.Id
+		case a.Webhook != nil:
+			if a.Webhook.Id == nil {
+				toCreate = append(toCreate, &graphqlbackend.CreateActionArgs{Webhook: a.Webhook.Update})
+				continue
+			}
+			id = a.Webhook.Id
+		case a.SlackWebhook != nil:
+			if a.SlackWebhook.Id == nil {
+				toCreate = append(toCreate, &graphqlbackend.CreateActionArgs{SlackWebhook: a.SlackWebhook.Update})
+				continue
+			}
+			id = a.SlackWebhook.Id
+		default:
+			return nil, nil, errors.New("action must be one of email, webhook or slackWebhook")
 		}
-		if _, ok := aMap[*a.Email.Id]; !ok {
-			return nil, nil, errors.Errorf("unknown ID=%s for action", *a.Email.Id)
+		if _, ok := aMap[*id]; !ok {
+			return nil, nil, errors.Errorf("unknown ID=%s for action", *id)
 		}
 		toUpdateActions = append(toUpdateActions, a)
-		delete(aMap, *a.Email.Id)
+		delete(aMap, *id)
 	}
-	var actionID int64
 	for k := range aMap {
-		err = relay.UnmarshalSpec(k, &actionID)
-		if err != nil {
-			return nil, nil, err
-		}
-		toDelete = append(toDelete, actionID)
+		toDelete = append(toDelete, k)
 	}
 	args.Actions = toUpdateActions
 	return toCreate, toDelete, nil
 }
 
+// deleteActions deletes the actions with the given IDs. The kind of each ID
+// determines which type of action is deleted.
+func (r *Resolver) deleteActions(ctx context.Context, monitorID int64, ids []graphql.ID) error {
+	var emailIDs, webhookIDs, slackWebhookIDs []int64
+	for _, id := range ids {
+		var actionID int64
+		if err := relay.UnmarshalSpec(id, &actionID); err != nil {
+			return err
+		}
+		switch relay.UnmarshalKind(id) {
+		case monitorActionEmailKind:
+			emailIDs = append(emailIDs, actionID)
+		case monitorActionWebhookKind:
+			webhookIDs = append(webhookIDs, actionID)
+		case monitorActionSlackWebhookKind:
+			slackWebhookIDs = append(slackWebhookIDs, actionID)
+		default:
+			return errors.Errorf("unknown action kind for ID=%s", id)
+		}
+	}
+	if err := r.store.DeleteActionsInt64(ctx, emailIDs, monitorID); err != nil {
+		return err
+	}
+	if err := r.store.DeleteActionWebhooksInt64(ctx, webhookIDs, monitorID); err != nil {
+		return err
+	}
+	return r.store.DeleteActionSlackWebhooksInt64(ctx, slackWebhookIDs, monitorID)
+}
+
 func (r *Resolver) updateCodeMonitor(ctx context.Context, args *graphqlbackend.UpdateCodeMonitorArgs) (m graphqlbackend.MonitorResolver, err error) {
 	// Update monitor.
 	var mo *cm.Monitor
@@ -365,24 +459,36 @@ func (r *Resolver) updateCodeMonitor(ctx context.Context, args *graphqlbackend.U
 	var emailID int64
 	var e *cm.MonitorEmail
 	for i, action := range args.Actions {
-		if action.Email == nil {
-			return nil, errors.Errorf("missing email object for action %d", i)
-		}
-		err = relay.UnmarshalSpec(*action.Email.Id, &emailID)
-		if err != nil {
-			return nil, err
-		}
-		err = r.store.DeleteRecipients(ctx, emailID)
-		if err != nil {
-			return nil, err
-		}
-		e, err = r.store.UpdateActionEmail(ctx, mo.ID, action)
-		if err != nil {
-			return nil, err
-		}
-		err = r.store.CreateRecipients(ctx, action.Email.Update.Recipients, e.Id)
-		if err != nil {
-			return nil, err
+		switch {
+		case action.Email != nil:
+			err = relay.UnmarshalSpec(*action.Email.Id, &emailID)
+			if err != nil {
+				return nil, err
+			}
+			err = r.store.DeleteRecipients(ctx, emailID)
+			if err != nil {
+				return nil, err
+			}
+			e, err = r.store.UpdateActionEmail(ctx, mo.ID, action)
+			if err != nil {
+				return nil, err
+			}
+			err = r.store.CreateRecipients(ctx, action.Email.Update.Recipients, e.Id)
+			if err != nil {
+				return nil, err
+			}
+		case action.Webhook != nil:
+			_, err = r.store.UpdateActionWebhook(ctx, mo.ID, action)
+			if err != nil {
+				return nil, err
+			}
+		case action.SlackWebhook != nil:
+			_, err = r.store.UpdateActionSlackWebhook(ctx, mo.ID, action)
+			if err != nil {
+				return nil, err
+			}
+		default:
+			return nil, errors.Errorf("missing action object for action %d", i)
 		}
 	}
 	return &monitor{
@@ -526,6 +632,8 @@ const (
 	monitorTriggerQueryKind         = "CodeMonitorTriggerQuery"
 	monitorTriggerEventKind         = "CodeMonitorTriggerEvent"
 	monitorActionEmailKind          = "CodeMonitorActionEmail"
+	monitorActionWebhookKind        = "CodeMonitorActionWebhook"
+	monitorActionSlackWebhookKind   = "CodeMonitorActionSlackWebhook"
 	monitorActionEventKind          = "CodeMonitorActionEmailEvent"
 	monitorActionEmailRecipientKind = "CodeMonitorActionEmailRecipient"
 )
@@ -572,36 +680,105 @@ func (m *monitor) Actions(ctx context.Context, args *graphqlbackend.ListActionAr
 }
 
 func (r *Resolver) actionConnectionResolverWithTriggerID(ctx context.Context, triggerEventID *int, monitorID int64, args *graphqlbackend.ListActionArgs) (graphqlbackend.MonitorActionConnectionResolver, error) {
-	// For now, we only support emails as actions. Once we add other actions such as
-	// webhooks, we have to query those tables here too.
-	q, err := r.store.ReadActionEmailQuery(ctx, monitorID, args)
-	if err != nil {
-		return nil, err
+	// Actions are listed in the order emails, webhooks, Slack webhooks. The kind
+	// of the cursor tells us which type of action the previous page ended with.
+	var afterKind string
+	if args.After != nil {
+		afterKind = relay.UnmarshalKind(graphql.ID(*args.After))
+	}
+	afterFor := func(kind string) *string {
+		if afterKind == kind {
+			return args.After
+		}
+		return nil
 	}
-	rows, err := r.store.Query(ctx, q)
+	limit := int(args.First)
+	actions := make([]graphqlbackend.MonitorAction, 0, limit)
+
+	if afterKind == "" || afterKind == monitorActionEmailKind {
+		q, err := r.store.ReadActionEmailQuery(ctx, monitorID, &graphqlbackend.ListActionArgs{First: args.First, After: afterFor(monitorActionEmailKind)})
+		if err != nil {
+			return nil, err
+		}
+		rows, err := r.store.Query(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+		es, err := cm.ScanEmails(rows)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range es {
+			actions = append(actions, &action{
+				email: &monitorEmail{
+					Resolver:       r,
+					MonitorEmail:   e,
+					triggerEventID: triggerEventID,
+				},
+			})
+		}
+	}
+
+	if len(actions) < limit && afterKind != monitorActionSlackWebhookKind {
+		ws, err := r.store.ListActionWebhooks(ctx, monitorID, &graphqlbackend.ListActionArgs{
+			First: int32(limit - len(actions)),
+			After: afterFor(monitorActionWebhookKind),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, w := range ws {
+			actions = append(actions, &action{
+				webhook: &monitorWebhook{
+					Resolver:       r,
+					MonitorWebhook: w,
+					triggerEventID: triggerEventID,
+				},
+			})
+		}
+	}
+
+	if len(actions) < limit {
+		ws, err := r.store.ListActionSlackWebhooks(ctx, monitorID, &graphqlbackend.ListActionArgs{
+			First: int32(limit - len(actions)),
+			After: afterFor(monitorActionSlackWebhookKind),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, w := range ws {
+			actions = append(actions, &action{
+				slackWebhook: &monitorSlackWebhook{
+					Resolver:            r,
+					MonitorSlackWebhook: w,
+					triggerEventID:      triggerEventID,
+				},
+			})
+		}
+	}
+
+	totalCount, err := r.totalCountActions(ctx, monitorID)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
-	es, err := cm.ScanEmails(rows)
+	return &monitorActionConnection{actions: actions, totalCount: totalCount}, nil
+}
+
+func (r *Resolver) totalCountActions(ctx context.Context, monitorID int64) (int32, error) {
+	emails, err := r.store.TotalCountActionEmails(ctx, monitorID)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
-	totalCount, err := r.store.TotalCountActionEmails(ctx, monitorID)
+	webhooks, err := r.store.TotalCountActionWebhooks(ctx, monitorID)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
-	actions := make([]graphqlbackend.MonitorAction, 0, len(es))
-	for _, e := range es {
-		actions = append(actions, &action{
-			email: &monitorEmail{
-				Resolver:       r,
-				MonitorEmail:   e,
-				triggerEventID: triggerEventID,
-			},
-		})
+	slackWebhooks, err := r.store.TotalCountActionSlackWebhooks(ctx, monitorID)
+	if err != nil {
+		return 0, err
 	}
-	return &monitorActionConnection{actions: actions, totalCount: totalCount}, nil
+	return emails + webhooks + slackWebhooks, nil
 }
 
 //
@@ -743,20 +920,36 @@ func (a *monitorActionConnection) PageInfo(ctx context.Context) (*graphqlutil.Pa
 	if email, ok := last.ToMonitorEmail(); ok {
 		return graphqlutil.NextPageCursor(string(email.ID())), nil
 	}
-	return nil, errors.Errorf("we only support email actions for now")
+	if webhook, ok := last.ToMonitorWebhook(); ok {
+		return graphqlutil.NextPageCursor(string(webhook.ID())), nil
+	}
+	if slackWebhook, ok := last.ToMonitorSlackWebhook(); ok {
+		return graphqlutil.NextPageCursor(string(slackWebhook.ID())), nil
+	}
+	return nil, errors.Errorf("unknown action type")
 }
 
 //
 // Action <<UNION>>
 //
 type action struct {
-	email graphqlbackend.MonitorEmailResolver
+	email        graphqlbackend.MonitorEmailResolver
+	webhook      graphqlbackend.MonitorWebhookResolver
+	slackWebhook graphqlbackend.MonitorSlackWebhookResolver
 }
 
 func (a *action) ToMonitorEmail() (graphqlbackend.MonitorEmailResolver, bool) {
 	return a.email, a.email != nil
 }
 
+func (a *action) ToMonitorWebhook() (graphqlbackend.MonitorWebhookResolver, bool) {
+	return a.webhook, a.webhook != nil
+}
+
+func (a *action) ToMonitorSlackWebhook() (graphqlbackend.MonitorSlackWebhookResolver, bool) {
+	return a.slackWebhook, a.slackWebhook != nil
+}
+
 //
 // Email
 //
@@ -831,11 +1024,91 @@ func (m *monitorEmail) Events(ctx context.Context, args *graphqlbackend.ListEven
 	if err != nil {
 		return nil, err
 	}
+	return m.newActionEventConnection(ajs, totalCount), nil
+}
+
+//
+// Webhook
+//
+type monitorWebhook struct {
+	*Resolver
+	*cm.MonitorWebhook
+
+	// If triggerEventID == nil, all events of this action will be returned.
+	// Otherwise, only those events of this action which are related to the specified
+	// trigger event will be returned.
+	triggerEventID *int
+}
+
+func (m *monitorWebhook) ID() graphql.ID {
+	return relay.MarshalID(monitorActionWebhookKind, m.Id)
+}
+
+func (m *monitorWebhook) Enabled() bool {
+	return m.MonitorWebhook.Enabled
+}
+
+func (m *monitorWebhook) URL() string {
+	return m.MonitorWebhook.URL
+}
+
+func (m *monitorWebhook) Events(ctx context.Context, args *graphqlbackend.ListEventsArgs) (graphqlbackend.MonitorActionEventConnectionResolver, error) {
+	ajs, err := m.store.ReadActionWebhookEvents(ctx, m.Id, m.triggerEventID, args)
+	if err != nil {
+		return nil, err
+	}
+	totalCount, err := m.store.TotalActionWebhookEvents(ctx, m.Id, m.triggerEventID)
+	if err != nil {
+		return nil, err
+	}
+	return m.newActionEventConnection(ajs, totalCount), nil
+}
+
+//
+// SlackWebhook
+//
+type monitorSlackWebhook struct {
+	*Resolver
+	*cm.MonitorSlackWebhook
+
+	// If triggerEventID == nil, all events of this action will be returned.
+	// Otherwise, only those events of this action which are related to the specified
+	// trigger event will be returned.
+	triggerEventID *int
+}
+
+func (m *monitorSlackWebhook) ID() graphql.ID {
+	return relay.MarshalID(monitorActionSlackWebhookKind, m.Id)
+}
+
+func (m *monitorSlackWebhook) Enabled() bool {
+	return m.MonitorSlackWebhook.Enabled
+}
+
+// URL returns a placeholder instead of the URL, since the URL of a Slack
+// incoming webhook is a secret that grants permission to post to a channel.
+func (m *monitorSlackWebhook) URL() string {
+	return types.RedactedSecret
+}
+
+func (m *monitorSlackWebhook) Events(ctx context.Context, args *graphqlbackend.ListEventsArgs) (graphqlbackend.MonitorActionEventConnectionResolver, error) {
+	ajs, err := m.store.ReadActionSlackWebhookEvents(ctx, m.Id, m.triggerEventID, args)
+	if err != nil {
+		return nil, err
+	}
+	totalCount, err := m.store.TotalActionSlackWebhookEvents(ctx, m.Id, m.triggerEventID)
+	if err != nil {
+		return nil, err
+	}
+	return m.newActionEventConnection(ajs, totalCount), nil
+}
+
+func (r *Resolver) newActionEventConnection(ajs []*cm.ActionJob, totalCount int32) *monitorActionEventConnection {
 	events := make([]graphqlbackend.MonitorActionEventResolver, len(ajs))
 	for i, aj := range ajs {
-		events[i] = &monitorActionEvent{Resolver: m.Resolver, ActionJob: aj}
+		events[i] = &monitorActionEvent{Resolver: r, ActionJob: aj}
 	}
-	return &monitorActionEventConnection{events: events, totalCount: totalCount}, nil
+	return &monitorActionEventConnection{events: events, totalCount: totalCount}
 }
 
 //
@@ -93,6 +93,22 @@ func (h *handler) updateExecutionLogEntry(ctx context.Context, executorName stri
 	return err
 }
 
+// appendExecutionLogEntryOutput calls AppendExecutionLogEntryOutput for the given job and entry.
+func (h *handler) appendExecutionLogEntryOutput(ctx context.Context, executorName string, jobID int, entryID int, out string) error {
+	err := h.Store.AppendExecutionLogEntryOutput(ctx, jobID, entryID, out, store.ExecutionLogEntryOptions{
+		// We pass the WorkerHostname, so the store enforces the record to be owned by this executor. When
+		// the previous executor didn't report heartbeats anymore, but is still alive and reporting logs,
+		// both executors that ever got the job would be writing to the same record. This prevents it.
+		WorkerHostname: executorName,
+		// We pass state to enforce adding log entries is only possible while the record is still dequeued.
+		State: "processing",
+	})
+	if err == store.ErrExecutionLogEntryNotUpdated {
+		return ErrUnknownJob
+	}
+	return err
+}
+
 // markComplete calls MarkComplete for the given job.
 func (h *handler) markComplete(ctx context.Context, executorName string, jobID int) error {
 	ok, err := h.Store.MarkComplete(ctx, jobID, store.MarkFinalOptions{
@@ -178,6 +178,42 @@ func TestUpdateExecutionLogEntryUnknownJob(t *testing.T) {
 	}
 }
 
+func TestAppendExecutionLogEntryOutput(t *testing.T) {
+	store := workerstoremocks.NewMockStore()
+	handler := newHandler(QueueOptions{Store: store})
+
+	if err := handler.appendExecutionLogEntryOutput(context.Background(), "deadbeef", 42, 99, "stdout: hello\n"); err != nil {
+		t.Fatalf("unexpected error appending log output: %s", err)
+	}
+
+	if value := len(store.AppendExecutionLogEntryOutputFunc.History()); value != 1 {
+		t.Fatalf("unexpected number of calls to AppendExecutionLogEntryOutput. want=%d have=%d", 1, value)
+	}
+	call := store.AppendExecutionLogEntryOutputFunc.History()[0]
+	if call.Arg1 != 42 {
+		t.Errorf("unexpected job identifier. want=%d have=%d", 42, call.Arg1)
+	}
+	if call.Arg2 != 99 {
+		t.Errorf("unexpected entry ID. want=%d have=%d", 99, call.Arg2)
+	}
+	if call.Arg3 != "stdout: hello\n" {
+		t.Errorf("unexpected output. want=%q have=%q", "stdout: hello\n", call.Arg3)
+	}
+	if diff := cmp.Diff(workerstore.ExecutionLogEntryOptions{WorkerHostname: "deadbeef", State: "processing"}, call.Arg4); diff != "" {
+		t.Errorf("unexpected options (-want +got):\n%s", diff)
+	}
+}
+
+func TestAppendExecutionLogEntryOutputUnknownJob(t *testing.T) {
+	store := workerstoremocks.NewMockStore()
+	store.AppendExecutionLogEntryOutputFunc.SetDefaultReturn(workerstore.ErrExecutionLogEntryNotUpdated)
+	handler := newHandler(QueueOptions{Store: store})
+
+	if err := handler.appendExecutionLogEntryOutput(context.Background(), "deadbeef", 42, 99, "stdout: hello\n"); err != ErrUnknownJob {
+		t.Fatalf("unexpected error. want=%q have=%q", ErrUnknownJob, err)
+	}
+}
+
 func TestMarkComplete(t *testing.T) {
 	store := workerstoremocks.NewMockStore()
 	store.DequeueFunc.SetDefaultReturn(testRecord{ID: 42}, true, nil)
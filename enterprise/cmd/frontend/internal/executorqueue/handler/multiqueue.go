@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"context"
+	"math/rand"
+
+	"github.com/cockroachdb/errors"
+
+	apiclient "github.com/sourcegraph/sourcegraph/enterprise/internal/executor"
+)
+
+// multiQueueHandler dequeues jobs for executors that process jobs from multiple queues.
+type multiQueueHandler struct {
+	handlers map[string]*handler
+	intn     func(n int) int
+}
+
+func newMultiQueueHandler(handlers map[string]*handler) *multiQueueHandler {
+	return &multiQueueHandler{handlers: handlers, intn: rand.Intn}
+}
+
+// validateQueues returns an error if the given queues are empty, contain a queue more than
+// once, contain an unknown queue, or contain a queue without a positive weight.
+func (h *multiQueueHandler) validateQueues(queues []apiclient.WeightedQueue) error {
+	if len(queues) == 0 {
+		return errors.New("no queues given")
+	}
+
+	seen := make(map[string]struct{}, len(queues))
+	for _, queue := range queues {
+		if _, ok := h.handlers[queue.Name]; !ok {
+			return errors.Errorf("unknown queue %q", queue.Name)
+		}
+		if _, ok := seen[queue.Name]; ok {
+			return errors.Errorf("duplicate queue %q", queue.Name)
+		}
+		seen[queue.Name] = struct{}{}
+
+		if queue.Weight <= 0 {
+			return errors.Errorf("weight of queue %q must be positive", queue.Name)
+		}
+	}
+
+	return nil
+}
+
+// dequeue tries to dequeue a job from each of the given queues in weighted random order and
+// returns the first one. The job is tagged with the name of its queue, which the executor uses
+// for all subsequent requests about the job. If no queue has a job available for processing, a
+// false-valued flag is returned.
+func (h *multiQueueHandler) dequeue(ctx context.Context, executorName, executorHostname string, queues []apiclient.WeightedQueue) (_ apiclient.Job, dequeued bool, _ error) {
+	for _, name := range h.weightedOrder(queues) {
+		job, dequeued, err := h.handlers[name].dequeue(ctx, executorName, executorHostname)
+		if err != nil {
+			return apiclient.Job{}, false, err
+		}
+		if dequeued {
+			job.Queue = name
+			return job, true, nil
+		}
+	}
+
+	return apiclient.Job{}, false, nil
+}
+
+// weightedOrder returns the names of the given queues in random order. Each position is taken
+// by one of the remaining queues with a probability proportional to its weight, so that busy
+// queues are served in proportion to their weights while idle queues don't block the others.
+func (h *multiQueueHandler) weightedOrder(queues []apiclient.WeightedQueue) []string {
+	remaining := make([]apiclient.WeightedQueue, len(queues))
+	copy(remaining, queues)
+
+	totalWeight := 0
+	for _, queue := range remaining {
+		totalWeight += queue.Weight
+	}
+
+	names := make([]string, 0, len(queues))
+	for len(remaining) > 0 {
+		n := h.intn(totalWeight)
+
+		i := 0
+		for n >= remaining[i].Weight {
+			n -= remaining[i].Weight
+			i++
+		}
+
+		names = append(names, remaining[i].Name)
+		totalWeight -= remaining[i].Weight
+		remaining = append(remaining[:i], remaining[i+1:]...)
+	}
+
+	return names
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	apiclient "github.com/sourcegraph/sourcegraph/enterprise/internal/executor"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	workerstoremocks "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store/mocks"
+)
+
+func TestMultiQueueDequeue(t *testing.T) {
+	emptyStore := workerstoremocks.NewMockStore()
+
+	store := workerstoremocks.NewMockStore()
+	store.DequeueFunc.SetDefaultReturn(testRecord{ID: 42}, true, nil)
+	recordTransformer := func(ctx context.Context, record workerutil.Record) (apiclient.Job, error) {
+		return apiclient.Job{ID: record.RecordID()}, nil
+	}
+
+	handler := newMultiQueueHandler(map[string]*handler{
+		"codeintel": newHandler(QueueOptions{Store: emptyStore}),
+		"batches":   newHandler(QueueOptions{Store: store, RecordTransformer: recordTransformer}),
+	})
+	// Always pick the first remaining queue.
+	handler.intn = func(n int) int { return 0 }
+
+	job, dequeued, err := handler.dequeue(context.Background(), "deadbeef", "test", []apiclient.WeightedQueue{
+		{Name: "codeintel", Weight: 3},
+		{Name: "batches", Weight: 1},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error dequeueing job: %s", err)
+	}
+	if !dequeued {
+		t.Fatalf("expected job to be dequeued")
+	}
+	if diff := cmp.Diff(apiclient.Job{ID: 42, Queue: "batches"}, job); diff != "" {
+		t.Errorf("unexpected job (-want +got):\n%s", diff)
+	}
+	if value := len(emptyStore.DequeueFunc.History()); value != 1 {
+		t.Errorf("unexpected number of dequeue calls to empty queue. want=%d have=%d", 1, value)
+	}
+}
+
+func TestMultiQueueDequeueNoRecord(t *testing.T) {
+	handler := newMultiQueueHandler(map[string]*handler{
+		"codeintel": newHandler(QueueOptions{Store: workerstoremocks.NewMockStore()}),
+		"batches":   newHandler(QueueOptions{Store: workerstoremocks.NewMockStore()}),
+	})
+
+	_, dequeued, err := handler.dequeue(context.Background(), "deadbeef", "test", []apiclient.WeightedQueue{
+		{Name: "codeintel", Weight: 1},
+		{Name: "batches", Weight: 1},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error dequeueing job: %s", err)
+	}
+	if dequeued {
+		t.Fatalf("did not expect a job to be dequeued")
+	}
+}
+
+func TestMultiQueueWeightedOrder(t *testing.T) {
+	queues := []apiclient.WeightedQueue{
+		{Name: "a", Weight: 1},
+		{Name: "b", Weight: 3},
+		{Name: "c", Weight: 2},
+	}
+
+	for name, tc := range map[string]struct {
+		picks []int
+		want  []string
+	}{
+		"lowest picks":  {picks: []int{0, 0, 0}, want: []string{"a", "b", "c"}},
+		"highest picks": {picks: []int{5, 3, 0}, want: []string{"c", "b", "a"}},
+		"middle picks":  {picks: []int{3, 1, 0}, want: []string{"b", "c", "a"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var totals []int
+			handler := newMultiQueueHandler(nil)
+			handler.intn = func(n int) int {
+				totals = append(totals, n)
+				pick := tc.picks[0]
+				tc.picks = tc.picks[1:]
+				return pick
+			}
+
+			if diff := cmp.Diff(tc.want, handler.weightedOrder(queues)); diff != "" {
+				t.Errorf("unexpected order (-want +got):\n%s", diff)
+			}
+			if totals[0] != 6 {
+				t.Errorf("unexpected total weight. want=%d have=%d", 6, totals[0])
+			}
+		})
+	}
+}
+
+func TestMultiQueueValidateQueues(t *testing.T) {
+	handler := newMultiQueueHandler(map[string]*handler{
+		"codeintel": newHandler(QueueOptions{}),
+		"batches":   newHandler(QueueOptions{}),
+	})
+
+	if err := handler.validateQueues([]apiclient.WeightedQueue{{Name: "codeintel", Weight: 1}, {Name: "batches", Weight: 2}}); err != nil {
+		t.Errorf("unexpected error validating queues: %s", err)
+	}
+
+	for name, queues := range map[string][]apiclient.WeightedQueue{
+		"no queues":       nil,
+		"unknown queue":   {{Name: "unknown", Weight: 1}},
+		"duplicate queue": {{Name: "codeintel", Weight: 1}, {Name: "codeintel", Weight: 1}},
+		"zero weight":     {{Name: "codeintel", Weight: 0}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if err := handler.validateQueues(queues); err == nil {
+				t.Error("unexpected nil error")
+			}
+		})
+	}
+}
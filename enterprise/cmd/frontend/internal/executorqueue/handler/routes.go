@@ -15,33 +15,56 @@ import (
 )
 
 // SetupRoutes registers all route handlers required for all configured executor
-// queues with the given router.
+// queues with the given router, as well as the dequeue route shared by all queues.
 func SetupRoutes(queueOptionsMap map[string]QueueOptions, router *mux.Router) {
+	handlers := make(map[string]*handler, len(queueOptionsMap))
 	for name, queueOptions := range queueOptionsMap {
 		h := newHandler(queueOptions)
+		handlers[name] = h
 
 		subRouter := router.PathPrefix(fmt.Sprintf("/{queueName:(?:%s)}/", regexp.QuoteMeta(name))).Subrouter()
 		routes := map[string]func(w http.ResponseWriter, r *http.Request){
-			"dequeue":                 h.handleDequeue,
-			"addExecutionLogEntry":    h.handleAddExecutionLogEntry,
-			"updateExecutionLogEntry": h.handleUpdateExecutionLogEntry,
-			"markComplete":            h.handleMarkComplete,
-			"markErrored":             h.handleMarkErrored,
-			"markFailed":              h.handleMarkFailed,
-			"heartbeat":               h.handleHeartbeat,
-			"canceled":                h.handleCanceled,
+			"dequeue":                       h.handleDequeue,
+			"addExecutionLogEntry":          h.handleAddExecutionLogEntry,
+			"updateExecutionLogEntry":       h.handleUpdateExecutionLogEntry,
+			"appendExecutionLogEntryOutput": h.handleAppendExecutionLogEntryOutput,
+			"markComplete":                  h.handleMarkComplete,
+			"markErrored":                   h.handleMarkErrored,
+			"markFailed":                    h.handleMarkFailed,
+			"heartbeat":                     h.handleHeartbeat,
+			"canceled":                      h.handleCanceled,
 		}
 		for path, handler := range routes {
 			subRouter.Path(fmt.Sprintf("/%s", path)).Methods("POST").HandlerFunc(handler)
 		}
 	}
+
+	router.Path("/dequeue").Methods("POST").HandlerFunc(newMultiQueueHandler(handlers).handleDequeue)
+}
+
+// POST /dequeue
+func (h *multiQueueHandler) handleDequeue(w http.ResponseWriter, r *http.Request) {
+	var payload apiclient.DequeueRequest
+
+	wrapHandler(w, r, &payload, func() (int, interface{}, error) {
+		if err := h.validateQueues(payload.Queues); err != nil {
+			return http.StatusBadRequest, errorResponse{Error: err.Error()}, nil
+		}
+
+		job, dequeued, err := h.dequeue(r.Context(), payload.ExecutorName, payload.ExecutorHostname, payload.Queues)
+		if !dequeued {
+			return http.StatusNoContent, nil, err
+		}
+
+		return http.StatusOK, job, err
+	})
 }
 
 // POST /{queueName}/dequeue
 func (h *handler) handleDequeue(w http.ResponseWriter, r *http.Request) {
 	var payload apiclient.DequeueRequest
 
-	h.wrapHandler(w, r, &payload, func() (int, interface{}, error) {
+	wrapHandler(w, r, &payload, func() (int, interface{}, error) {
 		job, dequeued, err := h.dequeue(r.Context(), payload.ExecutorName, payload.ExecutorHostname)
 		if !dequeued {
 			return http.StatusNoContent, nil, err
@@ -55,7 +78,7 @@ func (h *handler) handleDequeue(w http.ResponseWriter, r *http.Request) {
 func (h *handler) handleAddExecutionLogEntry(w http.ResponseWriter, r *http.Request) {
 	var payload apiclient.AddExecutionLogEntryRequest
 
-	h.wrapHandler(w, r, &payload, func() (int, interface{}, error) {
+	wrapHandler(w, r, &payload, func() (int, interface{}, error) {
 		id, err := h.addExecutionLogEntry(r.Context(), payload.ExecutorName, payload.JobID, payload.ExecutionLogEntry)
 		return http.StatusOK, id, err
 	})
@@ -65,17 +88,31 @@ func (h *handler) handleAddExecutionLogEntry(w http.ResponseWriter, r *http.Requ
 func (h *handler) handleUpdateExecutionLogEntry(w http.ResponseWriter, r *http.Request) {
 	var payload apiclient.UpdateExecutionLogEntryRequest
 
-	h.wrapHandler(w, r, &payload, func() (int, interface{}, error) {
+	wrapHandler(w, r, &payload, func() (int, interface{}, error) {
 		err := h.updateExecutionLogEntry(r.Context(), payload.ExecutorName, payload.JobID, payload.EntryID, payload.ExecutionLogEntry)
 		return http.StatusNoContent, nil, err
 	})
 }
 
+// POST /{queueName}/appendExecutionLogEntryOutput
+func (h *handler) handleAppendExecutionLogEntryOutput(w http.ResponseWriter, r *http.Request) {
+	var payload apiclient.AppendExecutionLogEntryOutputRequest
+
+	wrapHandler(w, r, &payload, func() (int, interface{}, error) {
+		err := h.appendExecutionLogEntryOutput(r.Context(), payload.ExecutorName, payload.JobID, payload.EntryID, payload.Out)
+		if err == ErrUnknownJob {
+			return http.StatusNotFound, nil, nil
+		}
+
+		return http.StatusNoContent, nil, err
+	})
+}
+
 // POST /{queueName}/markComplete
 func (h *handler) handleMarkComplete(w http.ResponseWriter, r *http.Request) {
 	var payload apiclient.MarkCompleteRequest
 
-	h.wrapHandler(w, r, &payload, func() (int, interface{}, error) {
+	wrapHandler(w, r, &payload, func() (int, interface{}, error) {
 		err := h.markComplete(r.Context(), payload.ExecutorName, payload.JobID)
 		if err == ErrUnknownJob {
 			return http.StatusNotFound, nil, nil
@@ -89,7 +126,7 @@ func (h *handler) handleMarkComplete(w http.ResponseWriter, r *http.Request) {
 func (h *handler) handleMarkErrored(w http.ResponseWriter, r *http.Request) {
 	var payload apiclient.MarkErroredRequest
 
-	h.wrapHandler(w, r, &payload, func() (int, interface{}, error) {
+	wrapHandler(w, r, &payload, func() (int, interface{}, error) {
 		err := h.markErrored(r.Context(), payload.ExecutorName, payload.JobID, payload.ErrorMessage)
 		if err == ErrUnknownJob {
 			return http.StatusNotFound, nil, nil
@@ -103,7 +140,7 @@ func (h *handler) handleMarkErrored(w http.ResponseWriter, r *http.Request) {
 func (h *handler) handleMarkFailed(w http.ResponseWriter, r *http.Request) {
 	var payload apiclient.MarkErroredRequest
 
-	h.wrapHandler(w, r, &payload, func() (int, interface{}, error) {
+	wrapHandler(w, r, &payload, func() (int, interface{}, error) {
 		err := h.markFailed(r.Context(), payload.ExecutorName, payload.JobID, payload.ErrorMessage)
 		if err == ErrUnknownJob {
 			return http.StatusNotFound, nil, nil
@@ -117,7 +154,7 @@ func (h *handler) handleMarkFailed(w http.ResponseWriter, r *http.Request) {
 func (h *handler) handleHeartbeat(w http.ResponseWriter, r *http.Request) {
 	var payload apiclient.HeartbeatRequest
 
-	h.wrapHandler(w, r, &payload, func() (int, interface{}, error) {
+	wrapHandler(w, r, &payload, func() (int, interface{}, error) {
 		unknownIDs, err := h.heartbeat(r.Context(), payload.ExecutorName, payload.JobIDs)
 		return http.StatusOK, unknownIDs, err
 	})
@@ -127,7 +164,7 @@ func (h *handler) handleHeartbeat(w http.ResponseWriter, r *http.Request) {
 func (h *handler) handleCanceled(w http.ResponseWriter, r *http.Request) {
 	var payload apiclient.CanceledRequest
 
-	h.wrapHandler(w, r, &payload, func() (int, interface{}, error) {
+	wrapHandler(w, r, &payload, func() (int, interface{}, error) {
 		canceledIDs, err := h.canceled(r.Context(), payload.ExecutorName)
 		return http.StatusOK, canceledIDs, err
 	})
@@ -143,7 +180,7 @@ type errorResponse struct {
 // is returned. Otherwise, the response status will match the status code value returned from the
 // handler, and the payload value returned from the handler is encoded and written to the
 // response body.
-func (h *handler) wrapHandler(w http.ResponseWriter, r *http.Request, payload interface{}, handler func() (int, interface{}, error)) {
+func wrapHandler(w http.ResponseWriter, r *http.Request, payload interface{}, handler func() (int, interface{}, error)) {
 	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
 		http.Error(w, fmt.Sprintf("Failed to unmarshal payload: %s", err.Error()), http.StatusBadRequest)
 		return
@@ -7,6 +7,7 @@ import (
 	"net/url"
 
 	"github.com/cockroachdb/errors"
+	"github.com/graph-gophers/graphql-go/relay"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
@@ -17,6 +18,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/batches/template"
 )
 
 const (
@@ -49,10 +51,39 @@ type batchesStore interface {
 	GetBatchSpecWorkspace(context.Context, store.GetBatchSpecWorkspaceOpts) (*btypes.BatchSpecWorkspace, error)
 	GetBatchSpec(context.Context, store.GetBatchSpecOpts) (*btypes.BatchSpec, error)
 	SetBatchSpecWorkspaceExecutionJobAccessToken(ctx context.Context, jobID, tokenID int64) (err error)
+	ListBatchSpecExecutionCacheEntries(ctx context.Context, opts store.ListBatchSpecExecutionCacheEntriesOpts) ([]*btypes.BatchSpecExecutionCacheEntry, error)
+	MarkUsedBatchSpecExecutionCacheEntries(ctx context.Context, ids []int64) error
 
 	DB() dbutil.DB
 }
 
+// findCachedStepResult returns the cached result of the longest run of leading
+// steps of the workspace that the user already executed with the same inputs
+// in a batch change with the same name and description, or nil if there is
+// none. The cache entries that are used are marked as such, so that they don't
+// expire.
+func findCachedStepResult(ctx context.Context, s batchesStore, batchSpec *btypes.BatchSpec, workspace *btypes.BatchSpecWorkspace) (*batcheslib.AfterStepResult, error) {
+	var used []int64
+	batchChange := template.BatchChangeAttributes{Name: batchSpec.Spec.Name, Description: batchSpec.Spec.Description}
+	result, err := workspace.WalkStepResults(batchChange, func(stepIndex int, key string) (*batcheslib.AfterStepResult, error) {
+		entries, err := s.ListBatchSpecExecutionCacheEntries(ctx, store.ListBatchSpecExecutionCacheEntriesOpts{
+			UserID: batchSpec.UserID,
+			Keys:   []string{key},
+		})
+		if err != nil || len(entries) == 0 {
+			return nil, err
+		}
+
+		used = append(used, entries[0].ID)
+		return entries[0].AfterStepResult()
+	})
+	if err != nil || result == nil {
+		return nil, err
+	}
+
+	return result, s.MarkUsedBatchSpecExecutionCacheEntries(ctx, used)
+}
+
 // transformBatchSpecWorkspaceExecutionJobRecord transforms a *btypes.BatchSpecWorkspaceExecutionJob into an apiclient.Job.
 func transformBatchSpecWorkspaceExecutionJobRecord(ctx context.Context, s batchesStore, job *btypes.BatchSpecWorkspaceExecutionJob, config *Config) (apiclient.Job, error) {
 	// MAYBE: We could create a view in which batch_spec and repo are joined
@@ -84,10 +115,16 @@ func transformBatchSpecWorkspaceExecutionJobRecord(ctx context.Context, s batche
 		return apiclient.Job{}, errors.Wrap(err, "creating internal access token")
 	}
 
+	cachedResult, err := findCachedStepResult(ctx, s, batchSpec, workspace)
+	if err != nil {
+		return apiclient.Job{}, errors.Wrap(err, "looking up cached step results")
+	}
+
 	executionInput := batcheslib.WorkspacesExecutionInput{
 		RawSpec: batchSpec.RawSpec,
 		Workspaces: []*batcheslib.Workspace{
 			{
+				ID: string(relay.MarshalID("BatchSpecWorkspace", workspace.ID)),
 				Repository: batcheslib.WorkspaceRepo{
 					ID:   string(graphqlbackend.MarshalRepositoryID(repo.ID)),
 					Name: string(repo.Name),
@@ -103,6 +140,7 @@ func transformBatchSpecWorkspaceExecutionJobRecord(ctx context.Context, s batche
 			},
 		},
 	}
+	executionInput.Workspaces[0].CachedStepResult = cachedResult
 
 	frontendURL := conf.Get().ExternalURL
 
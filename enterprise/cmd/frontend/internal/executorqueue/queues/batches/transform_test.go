@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/graph-gophers/graphql-go/relay"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/executorqueue/config"
@@ -19,6 +20,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/batches/template"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
 
@@ -46,9 +48,15 @@ func TestTransformBatchSpecWorkspaceExecutionJobRecord(t *testing.T) {
 		},
 	}
 
-	batchSpec := &btypes.BatchSpec{UserID: 123, NamespaceUserID: 123, RawSpec: "horse"}
+	batchSpec := &btypes.BatchSpec{
+		UserID:          123,
+		NamespaceUserID: 123,
+		RawSpec:         "horse",
+		Spec:            &batcheslib.BatchSpec{Name: "horse", Description: "A horse"},
+	}
 
 	workspace := &btypes.BatchSpecWorkspace{
+		ID:                 7,
 		BatchSpecID:        batchSpec.ID,
 		ChangesetSpecIDs:   []int64{},
 		RepoID:             5678,
@@ -71,6 +79,7 @@ func TestTransformBatchSpecWorkspaceExecutionJobRecord(t *testing.T) {
 		RawSpec: batchSpec.RawSpec,
 		Workspaces: []*batcheslib.Workspace{
 			{
+				ID: string(relay.MarshalID("BatchSpecWorkspace", workspace.ID)),
 				Repository: batcheslib.WorkspaceRepo{
 					ID:   string(graphqlbackend.MarshalRepositoryID(workspace.RepoID)),
 					Name: "github.com/sourcegraph/sourcegraph",
@@ -128,14 +137,60 @@ func TestTransformBatchSpecWorkspaceExecutionJobRecord(t *testing.T) {
 	if store.accessTokenID != accessTokenID {
 		t.Errorf("wrong access token ID set on execution job: %d", store.accessTokenID)
 	}
+
+	t.Run("with cached step result", func(t *testing.T) {
+		batchChange := template.BatchChangeAttributes{Name: "horse", Description: "A horse"}
+		key, err := workspace.StepCacheKey(batchChange, 0, "", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		cachedResult := &batcheslib.AfterStepResult{
+			StepIndex: 0,
+			Diff:      "diff --git a/readme.md b/readme.md",
+			Outputs:   map[string]interface{}{"lol": "lol"},
+		}
+		entry, err := btypes.NewBatchSpecExecutionCacheEntry(batchSpec.UserID, key, cachedResult)
+		if err != nil {
+			t.Fatal(err)
+		}
+		entry.ID = 7
+
+		// Entries of other users are never used.
+		otherEntry, err := btypes.NewBatchSpecExecutionCacheEntry(batchSpec.UserID+1, key, cachedResult)
+		if err != nil {
+			t.Fatal(err)
+		}
+		otherEntry.ID = 8
+
+		store.cacheEntries = []*btypes.BatchSpecExecutionCacheEntry{otherEntry, entry}
+
+		job, err := transformBatchSpecWorkspaceExecutionJobRecord(context.Background(), store, workspaceExecutionJob, config)
+		if err != nil {
+			t.Fatalf("unexpected error transforming record: %s", err)
+		}
+
+		var input batcheslib.WorkspacesExecutionInput
+		if err := json.Unmarshal([]byte(job.VirtualMachineFiles["input.json"]), &input); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(cachedResult, input.Workspaces[0].CachedStepResult); diff != "" {
+			t.Errorf("unexpected cached step result (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff([]int64{7}, store.usedCacheEntryIDs); diff != "" {
+			t.Errorf("unexpected used cache entries (-want +got):\n%s", diff)
+		}
+	})
 }
 
 type dummyBatchesStore struct {
 	dbHandle           dbutil.DB
 	batchSpec          *btypes.BatchSpec
 	batchSpecWorkspace *btypes.BatchSpecWorkspace
+	cacheEntries       []*btypes.BatchSpecExecutionCacheEntry
 
-	accessTokenID int64
+	accessTokenID     int64
+	usedCacheEntryIDs []int64
 }
 
 func (db *dummyBatchesStore) GetBatchSpecWorkspace(context.Context, store.GetBatchSpecWorkspaceOpts) (*btypes.BatchSpecWorkspace, error) {
@@ -144,6 +199,21 @@ func (db *dummyBatchesStore) GetBatchSpecWorkspace(context.Context, store.GetBat
 func (db *dummyBatchesStore) GetBatchSpec(context.Context, store.GetBatchSpecOpts) (*btypes.BatchSpec, error) {
 	return db.batchSpec, nil
 }
+func (db *dummyBatchesStore) ListBatchSpecExecutionCacheEntries(ctx context.Context, opts store.ListBatchSpecExecutionCacheEntriesOpts) ([]*btypes.BatchSpecExecutionCacheEntry, error) {
+	var entries []*btypes.BatchSpecExecutionCacheEntry
+	for _, e := range db.cacheEntries {
+		for _, key := range opts.Keys {
+			if e.UserID == opts.UserID && e.Key == key {
+				entries = append(entries, e)
+			}
+		}
+	}
+	return entries, nil
+}
+func (db *dummyBatchesStore) MarkUsedBatchSpecExecutionCacheEntries(ctx context.Context, ids []int64) error {
+	db.usedCacheEntryIDs = append(db.usedCacheEntryIDs, ids...)
+	return nil
+}
 func (db *dummyBatchesStore) DB() dbutil.DB { return db.dbHandle }
 func (db *dummyBatchesStore) SetBatchSpecWorkspaceExecutionJobAccessToken(ctx context.Context, jobID, tokenID int64) (err error) {
 	db.accessTokenID = tokenID
@@ -14,6 +14,21 @@ import (
 const defaultOutfile = "dump.lsif"
 const uploadRoute = "/.executors/lsif/upload"
 
+// cacheVolumesByImage maps the images of the indexers used by auto-indexing to the cache
+// volumes that hold the dependencies they download, so that repeat jobs on the same
+// executor host don't download them again. The volumes are kept per repository, as they
+// can hold private dependencies and credentials (e.g. in the Maven settings) of a
+// repository that jobs for other repositories must not see.
+var cacheVolumesByImage = map[string][]apiclient.CacheVolume{
+	"sourcegraph/lsif-go": {
+		{Name: "go-modules", Path: "/go/pkg/mod"},
+	},
+	"sourcegraph/lsif-java": {
+		{Name: "maven", Path: "/root/.m2"},
+		{Name: "coursier", Path: "/root/.cache/coursier"},
+	},
+}
+
 func transformRecord(index store.Index, config *Config) (apiclient.Job, error) {
 	dockerSteps := make([]apiclient.DockerStep, 0, len(index.DockerSteps)+2)
 	for _, dockerStep := range index.DockerSteps {
@@ -61,6 +76,7 @@ func transformRecord(index store.Index, config *Config) (apiclient.Job, error) {
 		Commit:         index.Commit,
 		RepositoryName: index.RepositoryName,
 		DockerSteps:    dockerSteps,
+		CacheVolumes:   cacheVolumes(index.RepositoryID, dockerSteps),
 		CliSteps: []apiclient.CliStep{
 			{
 				Commands: []string{
@@ -95,6 +111,39 @@ func transformRecord(index store.Index, config *Config) (apiclient.Job, error) {
 	}, nil
 }
 
+// cacheVolumes returns the cache volumes of the given repository used by the images of the
+// given docker steps.
+func cacheVolumes(repositoryID int, dockerSteps []apiclient.DockerStep) []apiclient.CacheVolume {
+	var volumes []apiclient.CacheVolume
+	seen := map[string]struct{}{}
+	for _, dockerStep := range dockerSteps {
+		for _, volume := range cacheVolumesByImage[imageName(dockerStep.Image)] {
+			if _, ok := seen[volume.Name]; ok {
+				continue
+			}
+			seen[volume.Name] = struct{}{}
+			volumes = append(volumes, apiclient.CacheVolume{
+				Name: fmt.Sprintf("%s-%d", volume.Name, repositoryID),
+				Path: volume.Path,
+			})
+		}
+	}
+
+	return volumes
+}
+
+// imageName returns the given docker image without its tag and digest.
+func imageName(image string) string {
+	if i := strings.Index(image, "@"); i >= 0 {
+		image = image[:i]
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image = image[:i]
+	}
+
+	return image
+}
+
 func makeURL(base, username, password string) (string, error) {
 	u, err := url.Parse(base)
 	if err != nil {
@@ -170,3 +170,57 @@ func TestTransformRecordWithoutIndexer(t *testing.T) {
 		t.Errorf("unexpected job (-want +got):\n%s", diff)
 	}
 }
+
+func TestTransformRecordCacheVolumes(t *testing.T) {
+	index := store.Index{
+		ID:             42,
+		Commit:         "deadbeef",
+		RepositoryID:   23,
+		RepositoryName: "linux",
+		DockerSteps: []store.DockerStep{
+			{
+				Image:    "sourcegraph/lsif-go:latest",
+				Commands: []string{"go mod download"},
+			},
+		},
+		Indexer:     "sourcegraph/lsif-go@sha256:e54a802a8bec44492deee944acc560e4e0a98f6ffa9a5038f0abac1af677e134",
+		IndexerArgs: []string{"lsif-go", "--no-animation"},
+	}
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{ExternalURL: "https://test.io"}})
+	t.Cleanup(func() {
+		conf.Mock(nil)
+	})
+	config := &Config{
+		Shared: &config.SharedConfig{
+			FrontendUsername: "test*",
+			FrontendPassword: "hunter2",
+		},
+	}
+
+	job, err := transformRecord(index, config)
+	if err != nil {
+		t.Fatalf("unexpected error transforming record: %s", err)
+	}
+
+	expected := []apiclient.CacheVolume{
+		{Name: "go-modules-23", Path: "/go/pkg/mod"},
+	}
+	if diff := cmp.Diff(expected, job.CacheVolumes); diff != "" {
+		t.Errorf("unexpected cache volumes (-want +got):\n%s", diff)
+	}
+}
+
+func TestImageName(t *testing.T) {
+	for image, want := range map[string]string{
+		"sourcegraph/lsif-go":                    "sourcegraph/lsif-go",
+		"sourcegraph/lsif-go:latest":             "sourcegraph/lsif-go",
+		"sourcegraph/lsif-go@sha256:deadbeef":    "sourcegraph/lsif-go",
+		"sourcegraph/lsif-go:v1@sha256:deadbeef": "sourcegraph/lsif-go",
+		"registry.example.com:5000/lsif-go:v1":   "registry.example.com:5000/lsif-go",
+		"registry.example.com:5000/lsif-go":      "registry.example.com:5000/lsif-go",
+	} {
+		if have := imageName(image); have != want {
+			t.Errorf("unexpected image name for %q: want=%q have=%q", image, want, have)
+		}
+	}
+}
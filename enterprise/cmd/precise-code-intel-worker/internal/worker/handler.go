@@ -1,6 +1,7 @@
 package worker
 
 import (
+	"bufio"
 	"compress/gzip"
 	"context"
 	"fmt"
@@ -26,7 +27,9 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/conversion"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/pathexistence"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/scip"
 )
 
 type handler struct {
@@ -113,9 +116,9 @@ func (h *handler) handle(ctx context.Context, upload store.Upload) (requeued boo
 	}
 
 	return false, withUploadData(ctx, h.uploadStore, upload.ID, func(r io.Reader) (err error) {
-		groupedBundleData, err := conversion.Correlate(ctx, r, upload.Root, getChildren)
+		groupedBundleData, err := correlate(ctx, r, upload.Root, getChildren)
 		if err != nil {
-			return errors.Wrap(err, "conversion.Correlate")
+			return err
 		}
 
 		// Note: this is writing to a different database than the block below, so we need to use a
@@ -229,9 +232,36 @@ func requeueIfCloning(ctx context.Context, workerStore dbworkerstore.Store, uplo
 	return false, nil
 }
 
+// scipPrefixSize is the number of bytes of an upload inspected to determine if it is a SCIP index.
+const scipPrefixSize = 1024
+
+// correlate converts the given raw upload data into the format written to the code intelligence
+// database. The upload data may be either an LSIF graph or a SCIP index.
+func correlate(ctx context.Context, r io.Reader, root string, getChildren pathexistence.GetChildrenFunc) (*precise.GroupedBundleDataChans, error) {
+	br := bufio.NewReaderSize(r, scipPrefixSize)
+	prefix, err := br.Peek(scipPrefixSize)
+	if err != nil && err != io.EOF {
+		return nil, errors.Wrap(err, "bufio.Peek")
+	}
+
+	if scip.IsIndex(prefix) {
+		groupedBundleData, err := conversion.CorrelateSCIP(ctx, br, root, getChildren)
+		if err != nil {
+			return nil, errors.Wrap(err, "conversion.CorrelateSCIP")
+		}
+		return groupedBundleData, nil
+	}
+
+	groupedBundleData, err := conversion.Correlate(ctx, br, root, getChildren)
+	if err != nil {
+		return nil, errors.Wrap(err, "conversion.Correlate")
+	}
+	return groupedBundleData, nil
+}
+
 // withUploadData will invoke the given function with a reader of the upload's raw data. The
-// consumer should expect raw newline-delimited JSON content. If the function returns without
-// an error, the upload file will be deleted.
+// consumer should expect either raw newline-delimited JSON content or a SCIP index. If the
+// function returns without an error, the upload file will be deleted.
 func withUploadData(ctx context.Context, uploadStore uploadstore.Store, id int, fn func(r io.Reader) error) error {
 	uploadFilename := fmt.Sprintf("upload-%d.lsif.gz", id)
 
@@ -282,6 +312,9 @@ func writeData(ctx context.Context, lsifStore LSIFStore, upload dbstore.Upload,
 	if err := tx.WriteReferences(ctx, upload.ID, groupedBundleData.References); err != nil {
 		return errors.Wrap(err, "store.WriteReferences")
 	}
+	if err := tx.WriteImplementations(ctx, upload.ID, groupedBundleData.Implementations); err != nil {
+		return errors.Wrap(err, "store.WriteImplementations")
+	}
 	if err := tx.WriteDocumentationPages(ctx, upload, repo, isDefaultBranch, groupedBundleData.DocumentationPages); err != nil {
 		return errors.Wrap(err, "store.WriteDocumentationPages")
 	}
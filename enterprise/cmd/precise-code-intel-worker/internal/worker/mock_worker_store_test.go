@@ -21,6 +21,10 @@ type MockWorkerStore struct {
 	// AddExecutionLogEntryFunc is an instance of a mock function object
 	// controlling the behavior of the method AddExecutionLogEntry.
 	AddExecutionLogEntryFunc *WorkerStoreAddExecutionLogEntryFunc
+	// AppendExecutionLogEntryOutputFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// AppendExecutionLogEntryOutput.
+	AppendExecutionLogEntryOutputFunc *WorkerStoreAppendExecutionLogEntryOutputFunc
 	// DequeueFunc is an instance of a mock function object controlling the
 	// behavior of the method Dequeue.
 	DequeueFunc *WorkerStoreDequeueFunc
@@ -65,6 +69,11 @@ func NewMockWorkerStore() *MockWorkerStore {
 				return 0, nil
 			},
 		},
+		AppendExecutionLogEntryOutputFunc: &WorkerStoreAppendExecutionLogEntryOutputFunc{
+			defaultHook: func(context.Context, int, int, string, store.ExecutionLogEntryOptions) error {
+				return nil
+			},
+		},
 		DequeueFunc: &WorkerStoreDequeueFunc{
 			defaultHook: func(context.Context, string, []*sqlf.Query) (workerutil.Record, bool, error) {
 				return nil, false, nil
@@ -131,6 +140,9 @@ func NewMockWorkerStoreFrom(i store.Store) *MockWorkerStore {
 		AddExecutionLogEntryFunc: &WorkerStoreAddExecutionLogEntryFunc{
 			defaultHook: i.AddExecutionLogEntry,
 		},
+		AppendExecutionLogEntryOutputFunc: &WorkerStoreAppendExecutionLogEntryOutputFunc{
+			defaultHook: i.AppendExecutionLogEntryOutput,
+		},
 		DequeueFunc: &WorkerStoreDequeueFunc{
 			defaultHook: i.Dequeue,
 		},
@@ -285,6 +297,125 @@ func (c WorkerStoreAddExecutionLogEntryFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// WorkerStoreAppendExecutionLogEntryOutputFunc describes the behavior when
+// the AppendExecutionLogEntryOutput method of the parent MockWorkerStore
+// instance is invoked.
+type WorkerStoreAppendExecutionLogEntryOutputFunc struct {
+	defaultHook func(context.Context, int, int, string, store.ExecutionLogEntryOptions) error
+	hooks       []func(context.Context, int, int, string, store.ExecutionLogEntryOptions) error
+	history     []WorkerStoreAppendExecutionLogEntryOutputFuncCall
+	mutex       sync.Mutex
+}
+
+// AppendExecutionLogEntryOutput delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockWorkerStore) AppendExecutionLogEntryOutput(v0 context.Context, v1 int, v2 int, v3 string, v4 store.ExecutionLogEntryOptions) error {
+	r0 := m.AppendExecutionLogEntryOutputFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.AppendExecutionLogEntryOutputFunc.appendCall(WorkerStoreAppendExecutionLogEntryOutputFuncCall{v0, v1, v2, v3, v4, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// AppendExecutionLogEntryOutput method of the parent MockWorkerStore
+// instance is invoked and the hook queue is empty.
+func (f *WorkerStoreAppendExecutionLogEntryOutputFunc) SetDefaultHook(hook func(context.Context, int, int, string, store.ExecutionLogEntryOptions) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// AppendExecutionLogEntryOutput method of the parent MockWorkerStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *WorkerStoreAppendExecutionLogEntryOutputFunc) PushHook(hook func(context.Context, int, int, string, store.ExecutionLogEntryOptions) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *WorkerStoreAppendExecutionLogEntryOutputFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, int, string, store.ExecutionLogEntryOptions) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *WorkerStoreAppendExecutionLogEntryOutputFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, int, string, store.ExecutionLogEntryOptions) error {
+		return r0
+	})
+}
+
+func (f *WorkerStoreAppendExecutionLogEntryOutputFunc) nextHook() func(context.Context, int, int, string, store.ExecutionLogEntryOptions) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *WorkerStoreAppendExecutionLogEntryOutputFunc) appendCall(r0 WorkerStoreAppendExecutionLogEntryOutputFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// WorkerStoreAppendExecutionLogEntryOutputFuncCall objects describing the
+// invocations of this function.
+func (f *WorkerStoreAppendExecutionLogEntryOutputFunc) History() []WorkerStoreAppendExecutionLogEntryOutputFuncCall {
+	f.mutex.Lock()
+	history := make([]WorkerStoreAppendExecutionLogEntryOutputFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// WorkerStoreAppendExecutionLogEntryOutputFuncCall is an object that
+// describes an invocation of method AppendExecutionLogEntryOutput on an
+// instance of MockWorkerStore.
+type WorkerStoreAppendExecutionLogEntryOutputFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 store.ExecutionLogEntryOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c WorkerStoreAppendExecutionLogEntryOutputFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c WorkerStoreAppendExecutionLogEntryOutputFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // WorkerStoreDequeueFunc describes the behavior when the Dequeue method of
 // the parent MockWorkerStore instance is invoked.
 type WorkerStoreDequeueFunc struct {
@@ -10,6 +10,7 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/graph-gophers/graphql-go"
 	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/inconshreveable/log15"
 	"github.com/keegancsmith/sqlf"
 	"github.com/lib/pq"
 
@@ -22,6 +23,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
 	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
 	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/batches/template"
 )
 
 // batchSpecWorkspaceExecutionJobStalledJobMaximumAge is the maximum allowable
@@ -184,6 +186,11 @@ func (s *batchSpecWorkspaceExecutionWorkerStore) MarkComplete(ctx context.Contex
 		return false, tx.Done(err)
 	}
 
+	// Caching the step results is best-effort and must not fail the job.
+	if err := cacheStepResults(ctx, tx, job); err != nil {
+		log15.Error("Failed to cache step results", "job", id, "err", err)
+	}
+
 	ok, err := s.Store.With(tx).MarkComplete(ctx, id, options)
 	return ok, tx.Done(err)
 }
@@ -246,6 +253,91 @@ func loadAndExtractChangesetSpecIDs(ctx context.Context, s *store.Store, id int6
 	return job, ids, nil
 }
 
+// cacheStepResults stores the results of the steps that the job executed in
+// the execution cache of the user that created the batch spec, so that the
+// steps don't have to be executed again as long as their inputs don't change.
+// The cache entries are written in a savepoint of the given transaction, which
+// is rolled back on error, so that the transaction can still be committed.
+func cacheStepResults(ctx context.Context, s *store.Store, job *btypes.BatchSpecWorkspaceExecutionJob) (err error) {
+	var logLines []*batcheslib.LogEvent
+	for _, e := range job.ExecutionLogs {
+		if e.Key == "step.src.0" {
+			logLines = btypes.ParseJSONLogsFromOutput(e.Out)
+			break
+		}
+	}
+	if len(logLines) == 0 {
+		return nil
+	}
+
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	// Steps are numbered starting at 1 in the logs.
+	infos := btypes.ParseLogLines(logLines)
+	skippedByCondition := make(map[int]bool)
+	for _, l := range logLines {
+		if m, ok := l.Metadata.(*batcheslib.TaskStepSkippedMetadata); ok {
+			skippedByCondition[m.Step-1] = true
+		}
+	}
+
+	workspace, err := tx.GetBatchSpecWorkspace(ctx, store.GetBatchSpecWorkspaceOpts{ID: job.BatchSpecWorkspaceID})
+	if err != nil {
+		// The repository of the workspace was deleted in the meantime.
+		if err == store.ErrNoResults {
+			return nil
+		}
+		return err
+	}
+	batchSpec, err := tx.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: workspace.BatchSpecID})
+	if err != nil {
+		return err
+	}
+
+	var previous *batcheslib.AfterStepResult
+	batchChange := template.BatchChangeAttributes{Name: batchSpec.Spec.Name, Description: batchSpec.Spec.Description}
+	_, err = workspace.WalkStepResults(batchChange, func(stepIndex int, key string) (*batcheslib.AfterStepResult, error) {
+		var result *batcheslib.AfterStepResult
+		if info, ok := infos[stepIndex+1]; ok && info.Diff != nil {
+			result = &batcheslib.AfterStepResult{StepIndex: stepIndex, Diff: *info.Diff, Outputs: info.OutputVariables}
+		} else if skippedByCondition[stepIndex] {
+			// A step that was skipped because of its condition doesn't change
+			// the result of the previous steps.
+			result = &batcheslib.AfterStepResult{StepIndex: stepIndex}
+			if previous != nil {
+				result.Diff, result.Outputs = previous.Diff, previous.Outputs
+			}
+		} else {
+			// The step was skipped because its result was already cached, or
+			// it wasn't executed at all.
+			entries, err := tx.ListBatchSpecExecutionCacheEntries(ctx, store.ListBatchSpecExecutionCacheEntriesOpts{
+				UserID: batchSpec.UserID,
+				Keys:   []string{key},
+			})
+			if err != nil || len(entries) == 0 {
+				return nil, err
+			}
+			previous, err = entries[0].AfterStepResult()
+			return previous, err
+		}
+
+		entry, err := btypes.NewBatchSpecExecutionCacheEntry(batchSpec.UserID, key, result)
+		if err != nil {
+			return nil, err
+		}
+		if err := tx.CreateBatchSpecExecutionCacheEntry(ctx, entry); err != nil {
+			return nil, err
+		}
+		previous = result
+		return result, nil
+	})
+	return err
+}
+
 var ErrNoChangesetSpecIDs = errors.New("no changeset ids found in execution logs")
 
 func extractChangesetSpecRandIDs(logs []workerutil.ExecutionLogEntry) ([]string, error) {
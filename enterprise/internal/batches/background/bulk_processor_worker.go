@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"time"
 
+	"github.com/inconshreveable/log15"
 	"github.com/keegancsmith/sqlf"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/processor"
@@ -12,6 +13,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
@@ -35,7 +37,7 @@ func newBulkOperationWorker(
 	sourcer sources.Sourcer,
 	metrics batchChangesMetrics,
 ) *workerutil.Worker {
-	r := &bulkProcessorWorker{sourcer: sourcer, store: s}
+	r := &bulkProcessorWorker{sourcer: sourcer, store: s, workerStore: workerStore}
 
 	options := workerutil.WorkerOptions{
 		Name:              "batches_bulk_processor",
@@ -90,21 +92,37 @@ func scanFirstChangesetJobRecord(rows *sql.Rows, err error) (workerutil.Record,
 // bulkProcessorWorker is a wrapper for the workerutil handlerfunc to create a
 // bulkProcessor with a source and store.
 type bulkProcessorWorker struct {
-	store   *store.Store
-	sourcer sources.Sourcer
+	store       *store.Store
+	workerStore dbworkerstore.Store
+	sourcer     sources.Sourcer
 }
 
 func (b *bulkProcessorWorker) HandlerFunc() workerutil.HandlerFunc {
 	return func(ctx context.Context, record workerutil.Record) (err error) {
 		job := record.(*btypes.ChangesetJob)
 
+		// Once an auto-merge job fails for good, the changeset is no longer
+		// pending merge. This can't happen in the transaction below, since it
+		// is rolled back when the job fails.
+		defer func() {
+			if err == nil || job.JobType != btypes.ChangesetJobTypeAutoMerge {
+				return
+			}
+			if !errcode.IsNonRetryable(err) && job.NumFailures+1 < bulkProcessorMaxNumRetries {
+				return
+			}
+			if err := b.store.ClearChangesetAutoMergeJobID(ctx, job.ChangesetID, job.ID); err != nil {
+				log15.Error("ClearChangesetAutoMergeJobID", "changesetID", job.ChangesetID, "err", err)
+			}
+		}()
+
 		tx, err := b.store.Transact(ctx)
 		if err != nil {
 			return err
 		}
 		defer func() { err = tx.Done(err) }()
 
-		p := processor.New(tx, b.sourcer)
+		p := processor.New(tx, b.workerStore, b.sourcer)
 
 		return p.Process(ctx, job)
 	}
@@ -26,6 +26,9 @@ func newSpecExpireJob(ctx context.Context, cstore *store.Store) goroutine.Backgr
 			if err := cstore.DeleteExpiredBatchSpecs(ctx); err != nil {
 				return errors.Wrap(err, "DeleteExpiredBatchSpecs")
 			}
+			if err := cstore.DeleteExpiredBatchSpecExecutionCacheEntries(ctx); err != nil {
+				return errors.Wrap(err, "DeleteExpiredBatchSpecExecutionCacheEntries")
+			}
 			return nil
 		}),
 	)
@@ -3,6 +3,8 @@ package processor
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/inconshreveable/log15"
@@ -13,9 +15,12 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/state"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types/scheduler/window"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
 )
 
 // unknownJobTypeErr is returned when a ChangesetJob record is of an unknown type
@@ -34,10 +39,20 @@ func (e unknownJobTypeErr) NonRetryable() bool {
 
 var changesetIsProcessingErr = errors.New("cannot update a changeset that is currently being processed; will retry")
 
-func New(tx *store.Store, sourcer sources.Sourcer) BulkProcessor {
+// autoMergeRecheckInterval is the time after which an auto-merge job checks
+// again whether its changeset can be merged, if it hasn't been woken up by a
+// sync of the changeset before.
+const autoMergeRecheckInterval = 10 * time.Minute
+
+// autoMergeMaxAge is the time after which an auto-merge job whose changeset
+// still isn't ready to be merged gives up.
+const autoMergeMaxAge = 14 * 24 * time.Hour
+
+func New(tx *store.Store, workerStore dbworkerstore.Store, sourcer sources.Sourcer) BulkProcessor {
 	return &bulkProcessor{
-		tx:      tx,
-		sourcer: sourcer,
+		tx:          tx,
+		workerStore: workerStore,
+		sourcer:     sourcer,
 	}
 }
 
@@ -46,8 +61,9 @@ type BulkProcessor interface {
 }
 
 type bulkProcessor struct {
-	tx      *store.Store
-	sourcer sources.Sourcer
+	tx          *store.Store
+	workerStore dbworkerstore.Store
+	sourcer     sources.Sourcer
 
 	css  sources.ChangesetSource
 	repo *types.Repo
@@ -97,6 +113,8 @@ func (b *bulkProcessor) Process(ctx context.Context, job *btypes.ChangesetJob) (
 		return b.reenqueueChangeset(ctx, job)
 	case btypes.ChangesetJobTypeMerge:
 		return b.mergeChangeset(ctx, job)
+	case btypes.ChangesetJobTypeAutoMerge:
+		return b.autoMergeChangeset(ctx, job)
 	case btypes.ChangesetJobTypeClose:
 		return b.closeChangeset(ctx, job)
 	case btypes.ChangesetJobTypePublish:
@@ -161,11 +179,78 @@ func (b *bulkProcessor) mergeChangeset(ctx context.Context, job *btypes.Changese
 		return errors.Errorf("invalid payload type for changeset_job, want=%T have=%T", &btypes.ChangesetJobMergePayload{}, job.Payload)
 	}
 
+	return b.merge(ctx, typedPayload.Squash)
+}
+
+func (b *bulkProcessor) autoMergeChangeset(ctx context.Context, job *btypes.ChangesetJob) (err error) {
+	typedPayload, ok := job.Payload.(*btypes.ChangesetJobAutoMergePayload)
+	if !ok {
+		return errors.Errorf("invalid payload type for changeset_job, want=%T have=%T", &btypes.ChangesetJobAutoMergePayload{}, job.Payload)
+	}
+
+	// Failing the job rolls back the transaction, so the auto-merge marker of
+	// the changeset is cleared by the worker once this job fails for good,
+	// see ClearChangesetAutoMergeJobID.
+	switch b.ch.ExternalState {
+	case btypes.ChangesetExternalStateMerged:
+		// Someone was faster than us, nothing left to do.
+		return b.setAutoMergeJobID(ctx, 0)
+	case btypes.ChangesetExternalStateOpen:
+		break
+	default:
+		return errcode.MakeNonRetryable(errors.Newf("changeset is %s and cannot be merged", strings.ToLower(string(b.ch.ExternalState))))
+	}
+
+	now := time.Now()
+	if now.Sub(job.CreatedAt) > autoMergeMaxAge {
+		return errcode.MakeNonRetryable(errors.Newf("changeset was not ready to be merged within %s", autoMergeMaxAge))
+	}
+
+	// Mark the changeset as pending merge, so that syncs of the changeset
+	// wake this job up once the changeset is ready to be merged.
+	if err := b.setAutoMergeJobID(ctx, job.ID); err != nil {
+		return err
+	}
+
+	cfg, err := window.NewAutoMergeConfiguration(conf.Get().BatchChangesAutoMergeWindows)
+	if err != nil {
+		return errors.Wrap(err, "parsing auto-merge windows")
+	}
+
+	// Requeued jobs aren't marked as completed once we return.
+	if !b.ch.ReadyToMerge() {
+		return b.workerStore.Requeue(ctx, int(job.ID), now.Add(autoMergeRecheckInterval))
+	}
+	if next := cfg.NextOpen(now); next.After(now) {
+		return b.workerStore.Requeue(ctx, int(job.ID), next)
+	}
+
+	if err := b.merge(ctx, typedPayload.Squash); err != nil {
+		return err
+	}
+
+	return b.setAutoMergeJobID(ctx, 0)
+}
+
+// setAutoMergeJobID updates the auto-merge marker of the changeset, if it
+// differs from the given job ID.
+func (b *bulkProcessor) setAutoMergeJobID(ctx context.Context, id int64) error {
+	if b.ch.AutoMergeJobID == id {
+		return nil
+	}
+
+	b.ch.AutoMergeJobID = id
+	return errors.Wrap(b.tx.UpdateChangesetAutoMergeJobID(ctx, b.ch), "updating auto-merge job of changeset")
+}
+
+// merge merges the changeset on the code host and updates its state from the
+// response.
+func (b *bulkProcessor) merge(ctx context.Context, squash bool) error {
 	cs := &sources.Changeset{
 		Changeset: b.ch,
 		Repo:      b.repo,
 	}
-	if err := b.css.MergeChangeset(ctx, cs, typedPayload.Squash); err != nil {
+	if err := b.css.MergeChangeset(ctx, cs, squash); err != nil {
 		return err
 	}
 
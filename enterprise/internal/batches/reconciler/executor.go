@@ -19,6 +19,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
 )
 
 // executePlan executes the given reconciler plan.
@@ -75,6 +76,9 @@ func (e *executor) Run(ctx context.Context, plan *Plan) (err error) {
 		case btypes.ReconcilerOperationPush:
 			err = e.pushChangesetPatch(ctx)
 
+		case btypes.ReconcilerOperationRebase:
+			err = e.rebaseChangeset(ctx)
+
 		case btypes.ReconcilerOperationPublish:
 			err = e.publishChangeset(ctx, false)
 
@@ -157,6 +161,79 @@ func (e *executor) pushChangesetPatch(ctx context.Context) (err error) {
 	return e.pushCommit(ctx, opts)
 }
 
+// rebaseChangeset re-applies the diff of the changeset spec on the latest
+// commit of the base branch and force-pushes the result, unless the changeset
+// was already rebased on that commit. Every rebase is recorded as a changeset
+// event. A diff that doesn't apply on the new commit doesn't fail the
+// reconciliation, since only a new changeset spec can fix that: the error is
+// recorded in the event instead.
+//
+// After a successful push, the changeset is synced like after a push of a new
+// changeset spec, so that it reflects the new commit.
+func (e *executor) rebaseChangeset(ctx context.Context) (err error) {
+	baseRev, err := git.ResolveRevision(ctx, e.repo.Name, e.spec.Spec.BaseRef, git.ResolveRevisionOptions{})
+	if err != nil {
+		return errors.Wrap(err, "resolving base branch")
+	}
+
+	// The diff was created on this commit, so there's nothing to rebase.
+	if string(baseRev) == e.spec.Spec.BaseRev {
+		return nil
+	}
+
+	// We only try to rebase on each commit of the base branch once.
+	rebases, _, err := e.tx.ListChangesetEvents(ctx, store.ListChangesetEventsOpts{
+		ChangesetIDs: []int64{e.ch.ID},
+		Kinds:        []btypes.ChangesetEventKind{btypes.ChangesetEventKindBatchChangesRebased},
+	})
+	if err != nil {
+		return err
+	}
+	for _, rebase := range rebases {
+		if rebase.Key == string(baseRev) {
+			return nil
+		}
+	}
+
+	pushConf, err := e.css.GitserverPushConfig(ctx, e.tx.ExternalServices(), e.repo)
+	if err != nil {
+		return err
+	}
+	opts, err := buildCommitOpts(e.repo, e.spec, pushConf)
+	if err != nil {
+		return err
+	}
+	opts.BaseCommit = baseRev
+
+	rebase := &btypes.ChangesetRebase{
+		BaseRef:   e.spec.Spec.BaseRef,
+		BaseRev:   string(baseRev),
+		CreatedAt: e.tx.Clock()(),
+	}
+	if _, err := e.gitserverClient.CreateCommitFromPatch(ctx, opts); err != nil {
+		var patchErr *protocol.CreateCommitFromPatchError
+		if !errors.As(err, &patchErr) {
+			return err
+		}
+		rebase.Error = describeCreateCommitFromPatchError(patchErr).Error()
+	}
+
+	if err := e.tx.UpsertChangesetEvents(ctx, &btypes.ChangesetEvent{
+		ChangesetID: e.ch.ID,
+		Kind:        btypes.ChangesetEventKindBatchChangesRebased,
+		Key:         rebase.BaseRev,
+		Metadata:    rebase,
+	}); err != nil {
+		return err
+	}
+
+	if rebase.Error != "" {
+		return nil
+	}
+	e.sleep()
+	return e.syncChangeset(ctx)
+}
+
 // publishChangeset creates the given changeset on its code host.
 func (e *executor) publishChangeset(ctx context.Context, asDraft bool) (err error) {
 	cs := &sources.Changeset{
@@ -164,6 +241,9 @@ func (e *executor) publishChangeset(ctx context.Context, asDraft bool) (err erro
 		Body:      e.spec.Spec.Body,
 		BaseRef:   e.spec.Spec.BaseRef,
 		HeadRef:   e.spec.Spec.HeadRef,
+		Reviewers: e.spec.Spec.Reviewers,
+		Assignees: e.spec.Spec.Assignees,
+		Labels:    e.spec.Spec.Labels,
 		Repo:      e.repo,
 		Changeset: e.ch,
 	}
@@ -254,6 +334,9 @@ func (e *executor) updateChangeset(ctx context.Context) (err error) {
 		Body:      e.spec.Spec.Body,
 		BaseRef:   e.spec.Spec.BaseRef,
 		HeadRef:   e.spec.Spec.HeadRef,
+		Reviewers: e.spec.Spec.Reviewers,
+		Assignees: e.spec.Spec.Assignees,
+		Labels:    e.spec.Spec.Labels,
 		Repo:      e.repo,
 		Changeset: e.ch,
 	}
@@ -326,6 +409,9 @@ func (e *executor) undraftChangeset(ctx context.Context) (err error) {
 		Body:      e.spec.Spec.Body,
 		BaseRef:   e.spec.Spec.BaseRef,
 		HeadRef:   e.spec.Spec.HeadRef,
+		Reviewers: e.spec.Spec.Reviewers,
+		Assignees: e.spec.Spec.Assignees,
+		Labels:    e.spec.Spec.Labels,
 		Repo:      e.repo,
 		Changeset: e.ch,
 	}
@@ -393,13 +479,7 @@ func (e *executor) pushCommit(ctx context.Context, opts protocol.CreateCommitFro
 	if err != nil {
 		var e *protocol.CreateCommitFromPatchError
 		if errors.As(err, &e) {
-			return errors.Errorf(
-				"creating commit from patch for repository %q: %s\n"+
-					"```\n"+
-					"$ %s\n"+
-					"%s\n"+
-					"```",
-				e.RepositoryName, e.InternalError, e.Command, strings.TrimSpace(e.CombinedOutput))
+			return describeCreateCommitFromPatchError(e)
 		}
 		return err
 	}
@@ -407,6 +487,16 @@ func (e *executor) pushCommit(ctx context.Context, opts protocol.CreateCommitFro
 	return nil
 }
 
+func describeCreateCommitFromPatchError(e *protocol.CreateCommitFromPatchError) error {
+	return errors.Errorf(
+		"creating commit from patch for repository %q: %s\n"+
+			"```\n"+
+			"$ %s\n"+
+			"%s\n"+
+			"```",
+		e.RepositoryName, e.InternalError, e.Command, strings.TrimSpace(e.CombinedOutput))
+}
+
 func buildCommitOpts(repo *types.Repo, spec *btypes.ChangesetSpec, pushOpts *protocol.PushConfig) (opts protocol.CreateCommitFromPatchRequest, err error) {
 	desc := spec.Spec
 
@@ -12,6 +12,7 @@ import (
 
 var operationPrecedence = map[btypes.ReconcilerOperation]int{
 	btypes.ReconcilerOperationPush:         0,
+	btypes.ReconcilerOperationRebase:       0,
 	btypes.ReconcilerOperationDetach:       0,
 	btypes.ReconcilerOperationArchive:      0,
 	btypes.ReconcilerOperationImport:       1,
@@ -242,6 +243,37 @@ func DeterminePlan(previousSpec, currentSpec *btypes.ChangesetSpec, ch *btypes.C
 	return pl, nil
 }
 
+// wantRebase returns whether the changeset of the plan can be rebased on the
+// latest commit of its base branch, provided that the owning batch change
+// opted into that and the code host reports the changeset as stale. This is
+// the case if the changeset is published, it is still attached to the owning
+// batch change, and the plan doesn't already push a new commit or close the
+// changeset.
+func wantRebase(pl *Plan) bool {
+	ch := pl.Changeset
+	if pl.ChangesetSpec == nil || !ch.Published() {
+		return false
+	}
+
+	attached := false
+	for _, assoc := range ch.BatchChanges {
+		if assoc.BatchChangeID == ch.OwnedByBatchChangeID {
+			attached = !assoc.Detach && !assoc.Archive && !assoc.IsArchived
+		}
+	}
+	if !attached {
+		return false
+	}
+
+	for _, op := range pl.Ops {
+		if op == btypes.ReconcilerOperationPush || op == btypes.ReconcilerOperationClose {
+			return false
+		}
+	}
+
+	return true
+}
+
 func reopenAfterDetach(ch *btypes.Changeset) bool {
 	closed := ch.ExternalState == btypes.ChangesetExternalStateClosed
 	if !closed {
@@ -279,6 +311,15 @@ func compareChangesetSpecs(previous, current *btypes.ChangesetSpec, uiPublicatio
 	if previous.Spec.BaseRef != current.Spec.BaseRef {
 		delta.BaseRefChanged = true
 	}
+	if !stringsEqual(previous.Spec.Reviewers, current.Spec.Reviewers) {
+		delta.ReviewersChanged = true
+	}
+	if !stringsEqual(previous.Spec.Assignees, current.Spec.Assignees) {
+		delta.AssigneesChanged = true
+	}
+	if !stringsEqual(previous.Spec.Labels, current.Spec.Labels) {
+		delta.LabelsChanged = true
+	}
 
 	// If was set to "draft" and now "true", need to undraft the changeset.
 	// We currently ignore going from "true" to "draft".
@@ -352,6 +393,9 @@ type ChangesetSpecDelta struct {
 	CommitMessageChanged bool
 	AuthorNameChanged    bool
 	AuthorEmailChanged   bool
+	ReviewersChanged     bool
+	AssigneesChanged     bool
+	LabelsChanged        bool
 }
 
 func (d *ChangesetSpecDelta) String() string { return fmt.Sprintf("%#v", d) }
@@ -361,9 +405,21 @@ func (d *ChangesetSpecDelta) NeedCommitUpdate() bool {
 }
 
 func (d *ChangesetSpecDelta) NeedCodeHostUpdate() bool {
-	return d.TitleChanged || d.BodyChanged || d.BaseRefChanged
+	return d.TitleChanged || d.BodyChanged || d.BaseRefChanged || d.ReviewersChanged || d.AssigneesChanged || d.LabelsChanged
 }
 
 func (d *ChangesetSpecDelta) AttributesChanged() bool {
 	return d.NeedCommitUpdate() || d.NeedCodeHostUpdate()
 }
+
+func stringsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
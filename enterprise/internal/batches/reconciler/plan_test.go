@@ -6,6 +6,7 @@ import (
 	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 )
 
 func TestDetermineReconcilerPlan(t *testing.T) {
@@ -206,6 +207,24 @@ func TestDetermineReconcilerPlan(t *testing.T) {
 			},
 			wantOperations: Operations{btypes.ReconcilerOperationUpdate},
 		},
+		{
+			name:         "reviewers changed on published changeset",
+			previousSpec: &ct.TestSpecOpts{Published: true, Reviewers: []string{"alice"}},
+			currentSpec:  &ct.TestSpecOpts{Published: true, Reviewers: []string{"alice", "bob"}},
+			changeset: ct.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+			},
+			wantOperations: Operations{btypes.ReconcilerOperationUpdate},
+		},
+		{
+			name:         "labels changed on published changeset",
+			previousSpec: &ct.TestSpecOpts{Published: true, Labels: []string{"bug"}},
+			currentSpec:  &ct.TestSpecOpts{Published: true, Labels: []string{"enhancement"}},
+			changeset: ct.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+			},
+			wantOperations: Operations{btypes.ReconcilerOperationUpdate},
+		},
 		{
 			name:         "commit diff changed on published changeset",
 			previousSpec: &ct.TestSpecOpts{Published: true, CommitDiff: "testDiff"},
@@ -423,3 +442,69 @@ func TestDetermineReconcilerPlan(t *testing.T) {
 func uiPublicationStatePtr(state btypes.ChangesetUiPublicationState) *btypes.ChangesetUiPublicationState {
 	return &state
 }
+
+func TestWantRebase(t *testing.T) {
+	t.Parallel()
+
+	stale := func() *btypes.Changeset {
+		return &btypes.Changeset{
+			PublicationState:     btypes.ChangesetPublicationStatePublished,
+			ExternalState:        btypes.ChangesetExternalStateOpen,
+			OwnedByBatchChangeID: 1,
+			BatchChanges:         []btypes.BatchChangeAssoc{{BatchChangeID: 1}},
+			Metadata:             &github.PullRequest{Mergeable: "CONFLICTING"},
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		plan *Plan
+		want bool
+	}{
+		"stale": {
+			plan: &Plan{Changeset: stale(), ChangesetSpec: &btypes.ChangesetSpec{}},
+			want: true,
+		},
+		"no spec": {
+			plan: &Plan{Changeset: stale()},
+			want: false,
+		},
+		"unpublished": {
+			plan: func() *Plan {
+				ch := stale()
+				ch.PublicationState = btypes.ChangesetPublicationStateUnpublished
+				return &Plan{Changeset: ch, ChangesetSpec: &btypes.ChangesetSpec{}}
+			}(),
+			want: false,
+		},
+		"detaching": {
+			plan: func() *Plan {
+				ch := stale()
+				ch.BatchChanges = []btypes.BatchChangeAssoc{{BatchChangeID: 1, Detach: true}}
+				return &Plan{Changeset: ch, ChangesetSpec: &btypes.ChangesetSpec{}}
+			}(),
+			want: false,
+		},
+		"archived": {
+			plan: func() *Plan {
+				ch := stale()
+				ch.BatchChanges = []btypes.BatchChangeAssoc{{BatchChangeID: 1, IsArchived: true}}
+				return &Plan{Changeset: ch, ChangesetSpec: &btypes.ChangesetSpec{}}
+			}(),
+			want: false,
+		},
+		"pushing": {
+			plan: &Plan{
+				Changeset:     stale(),
+				ChangesetSpec: &btypes.ChangesetSpec{},
+				Ops:           Operations{btypes.ReconcilerOperationPush},
+			},
+			want: false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if have := wantRebase(tc.plan); have != tc.want {
+				t.Errorf("unexpected result: have=%v want=%v", have, tc.want)
+			}
+		})
+	}
+}
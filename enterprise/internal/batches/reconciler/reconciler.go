@@ -79,6 +79,18 @@ func (r *Reconciler) process(ctx context.Context, tx *store.Store, ch *btypes.Ch
 		return err
 	}
 
+	// Rebasing is opt-in per batch change, so we only look that up if the
+	// changeset could be rebased at all.
+	if wantRebase(plan) {
+		enabled, baseRev, err := tx.GetChangesetRebaseState(ctx, ch.ID)
+		if err != nil {
+			return err
+		}
+		if enabled && ch.NeedsRebase(baseRev) {
+			plan.AddOp(btypes.ReconcilerOperationRebase)
+		}
+	}
+
 	log15.Info("Reconciler processing changeset", "changeset", ch.ID, "operations", plan.Ops)
 
 	return executePlan(
@@ -104,6 +104,9 @@ func (s BitbucketServerSource) CreateChangeset(ctx context.Context, c *Changeset
 	repo := c.Repo.Metadata.(*bitbucketserver.Repo)
 
 	pr := &bitbucketserver.PullRequest{Title: c.Title, Description: c.Body}
+	for _, name := range c.Reviewers {
+		pr.Reviewers = append(pr.Reviewers, bitbucketserver.Reviewer{User: &bitbucketserver.User{Name: name}})
+	}
 
 	pr.ToRef.Repository.Slug = repo.Slug
 	pr.ToRef.Repository.ID = repo.ID
@@ -220,6 +223,18 @@ func (s BitbucketServerSource) UpdateChangeset(ctx context.Context, c *Changeset
 	update.ToRef.Repository.Slug = pr.ToRef.Repository.Slug
 	update.ToRef.Repository.Project.Key = pr.ToRef.Repository.Project.Key
 
+	if len(c.Reviewers) > 0 {
+		// Bitbucket Server replaces the reviewers of the pull request, but we
+		// don't want to withdraw review requests, so we keep the current
+		// reviewers.
+		for _, r := range pr.Reviewers {
+			if r.User != nil {
+				update.Reviewers = append(update.Reviewers, r.User.Name)
+			}
+		}
+		update.Reviewers = dedupeUsernames(append(update.Reviewers, c.Reviewers...))
+	}
+
 	updated, err := s.client.UpdatePullRequest(ctx, update)
 	if err != nil {
 		return err
@@ -228,6 +243,19 @@ func (s BitbucketServerSource) UpdateChangeset(ctx context.Context, c *Changeset
 	return c.Changeset.SetMetadata(updated)
 }
 
+func dedupeUsernames(names []string) []string {
+	seen := make(map[string]struct{}, len(names))
+	deduped := names[:0]
+	for _, name := range names {
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		deduped = append(deduped, name)
+	}
+	return deduped
+}
+
 // ReopenChangeset reopens the *Changeset on the code host and updates the
 // Metadata column in the *batches.Changeset.
 func (s BitbucketServerSource) ReopenChangeset(ctx context.Context, c *Changeset) error {
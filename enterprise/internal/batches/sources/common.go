@@ -97,6 +97,14 @@ type Changeset struct {
 	HeadRef string
 	BaseRef string
 
+	// Reviewers, Assignees and Labels are left unchanged on the code host
+	// when empty. Non-empty Assignees and Labels replace the ones on the code
+	// host, so clearing them in the spec doesn't remove them from the
+	// changeset.
+	Reviewers []string
+	Assignees []string
+	Labels    []string
+
 	*btypes.Changeset
 	*types.Repo
 }
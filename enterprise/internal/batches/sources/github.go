@@ -140,6 +140,11 @@ func (s GithubSource) createChangeset(ctx context.Context, c *Changeset, prInput
 		exists = true
 	}
 
+	pr, err = s.updateAssigneesLabelsAndReviewers(ctx, c, pr)
+	if err != nil {
+		return exists, err
+	}
+
 	if err := c.SetMetadata(pr); err != nil {
 		return false, errors.Wrap(err, "setting changeset metadata")
 	}
@@ -147,6 +152,82 @@ func (s GithubSource) createChangeset(ctx context.Context, c *Changeset, prInput
 	return exists, nil
 }
 
+// updateAssigneesLabelsAndReviewers sets the assignees and labels of the
+// changeset on the given pull request, and requests reviews from its
+// reviewers. The pull request is returned unchanged if the changeset has
+// neither.
+func (s GithubSource) updateAssigneesLabelsAndReviewers(ctx context.Context, c *Changeset, pr *github.PullRequest) (*github.PullRequest, error) {
+	if len(c.Assignees) > 0 || len(c.Labels) > 0 {
+		input, err := s.buildUpdatePullRequestInput(ctx, c, pr)
+		if err != nil {
+			return nil, err
+		}
+		pr, err = s.client.UpdatePullRequest(ctx, input)
+		if err != nil {
+			return nil, errors.Wrap(err, "setting assignees and labels")
+		}
+	}
+
+	if err := s.requestReviews(ctx, c, pr); err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
+
+// buildUpdatePullRequestInput returns the input to update the given pull
+// request to the changeset. Non-empty assignees and labels replace the ones of
+// the pull request, empty ones leave them unchanged, so assignees and labels
+// removed from the spec are only removed if others remain.
+func (s GithubSource) buildUpdatePullRequestInput(ctx context.Context, c *Changeset, pr *github.PullRequest) (*github.UpdatePullRequestInput, error) {
+	input := &github.UpdatePullRequestInput{
+		PullRequestID: pr.ID,
+		Title:         c.Title,
+		Body:          c.Body,
+		BaseRefName:   git.AbbreviateRef(c.BaseRef),
+	}
+
+	if len(c.Assignees) > 0 {
+		ids, err := s.client.GetUserIDs(ctx, c.Assignees)
+		if err != nil {
+			return nil, errors.Wrap(err, "resolving assignees")
+		}
+		input.AssigneeIDs = ids
+	}
+
+	if len(c.Labels) > 0 {
+		repo := c.Repo.Metadata.(*github.Repository)
+		owner, name, err := github.SplitRepositoryNameWithOwner(repo.NameWithOwner)
+		if err != nil {
+			return nil, errors.Wrap(err, "getting repo owner and name")
+		}
+		ids, err := s.client.GetLabelIDs(ctx, owner, name, c.Labels)
+		if err != nil {
+			return nil, errors.Wrap(err, "resolving labels")
+		}
+		input.LabelIDs = ids
+	}
+
+	return input, nil
+}
+
+// requestReviews requests reviews from the reviewers of the changeset on the
+// given pull request. Reviews that were already requested are kept.
+func (s GithubSource) requestReviews(ctx context.Context, c *Changeset, pr *github.PullRequest) error {
+	if len(c.Reviewers) == 0 {
+		return nil
+	}
+
+	ids, err := s.client.GetUserIDs(ctx, c.Reviewers)
+	if err != nil {
+		return errors.Wrap(err, "resolving reviewers")
+	}
+	if err := s.client.RequestReviews(ctx, pr, ids); err != nil {
+		return errors.Wrap(err, "requesting reviews")
+	}
+	return nil
+}
+
 // CloseChangeset closes the given *Changeset on the code host and updates the
 // Metadata column in the *batches.Changeset to the newly closed pull request.
 func (s GithubSource) CloseChangeset(ctx context.Context, c *Changeset) error {
@@ -212,17 +293,20 @@ func (s GithubSource) UpdateChangeset(ctx context.Context, c *Changeset) error {
 		return errors.New("Changeset is not a GitHub pull request")
 	}
 
-	updated, err := s.client.UpdatePullRequest(ctx, &github.UpdatePullRequestInput{
-		PullRequestID: pr.ID,
-		Title:         c.Title,
-		Body:          c.Body,
-		BaseRefName:   git.AbbreviateRef(c.BaseRef),
-	})
+	input, err := s.buildUpdatePullRequestInput(ctx, c, pr)
+	if err != nil {
+		return err
+	}
 
+	updated, err := s.client.UpdatePullRequest(ctx, input)
 	if err != nil {
 		return err
 	}
 
+	if err := s.requestReviews(ctx, c, updated); err != nil {
+		return err
+	}
+
 	return c.Changeset.SetMetadata(updated)
 }
 
@@ -1,19 +1,25 @@
 package sources
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
 	"strings"
 	"testing"
 
 	"github.com/cockroachdb/errors"
+	"github.com/google/go-cmp/cmp"
 	"github.com/inconshreveable/log15"
 
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/rcache"
 	"github.com/sourcegraph/sourcegraph/internal/testutil"
 	"github.com/sourcegraph/sourcegraph/internal/types"
@@ -379,6 +385,147 @@ func TestGithubSource_UpdateChangeset(t *testing.T) {
 	}
 }
 
+func TestGithubSource_UpdateChangeset_AssigneesLabelsAndReviewers(t *testing.T) {
+	// fakeGitHub answers the GraphQL requests of the GithubSource, resolving
+	// every user and label that exists to an ID derived from its name, and
+	// records the inputs of the mutations.
+	type graphQLRequest struct {
+		Query     string
+		Variables map[string]json.RawMessage
+	}
+	newFakeGitHub := func(t *testing.T, existing map[string]bool) (*httpcli.Factory, map[string]string) {
+		mutations := map[string]string{}
+		doer := httpcli.DoerFunc(func(req *http.Request) (*http.Response, error) {
+			var gqlReq graphQLRequest
+			if err := json.NewDecoder(req.Body).Decode(&gqlReq); err != nil {
+				t.Fatal(err)
+			}
+
+			var data interface{}
+			switch {
+			case strings.HasPrefix(gqlReq.Query, "query GetUserIDs"), strings.HasPrefix(gqlReq.Query, "query GetLabelIDs"):
+				// The variables loginN and labelN are resolved by the fields
+				// userN and labelN.
+				ids := map[string]interface{}{}
+				for name, value := range gqlReq.Variables {
+					var v string
+					if err := json.Unmarshal(value, &v); err != nil {
+						t.Fatal(err)
+					}
+					if name != "owner" && name != "name" && existing[v] {
+						ids[strings.Replace(name, "login", "user", 1)] = map[string]string{"id": "ID_" + v}
+					}
+				}
+				if strings.HasPrefix(gqlReq.Query, "query GetLabelIDs") {
+					data = map[string]interface{}{"repository": ids}
+				} else {
+					data = ids
+				}
+			case strings.Contains(gqlReq.Query, "UpdatePullRequest("):
+				mutations["updatePullRequest"] = string(gqlReq.Variables["input"])
+				data = map[string]interface{}{"updatePullRequest": map[string]interface{}{"pullRequest": map[string]string{"id": "PR_1", "title": "This is a new title"}}}
+			case strings.Contains(gqlReq.Query, "requestReviews("):
+				mutations["requestReviews"] = string(gqlReq.Variables["input"])
+				data = map[string]interface{}{"requestReviews": map[string]interface{}{"pullRequest": map[string]string{"id": "PR_1"}}}
+			default:
+				t.Fatalf("unexpected query: %s", gqlReq.Query)
+			}
+
+			body, err := json.Marshal(map[string]interface{}{"data": data})
+			if err != nil {
+				t.Fatal(err)
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(bytes.NewReader(body)),
+			}, nil
+		})
+
+		return httpcli.NewFactory(func(httpcli.Doer) httpcli.Doer { return doer }), mutations
+	}
+
+	newChangeset := func() *Changeset {
+		return &Changeset{
+			Title:   "This is a new title",
+			Body:    "This is a new body",
+			BaseRef: "refs/heads/master",
+			Changeset: &btypes.Changeset{
+				Metadata: &github.PullRequest{ID: "PR_1"},
+			},
+			Repo: &types.Repo{
+				Metadata: &github.Repository{NameWithOwner: "sourcegraph/automation-testing"},
+			},
+		}
+	}
+
+	newSource := func(t *testing.T, cf *httpcli.Factory) *GithubSource {
+		svc := &types.ExternalService{
+			Kind: extsvc.KindGitHub,
+			Config: marshalJSON(t, &schema.GitHubConnection{
+				Url:   "https://github.com",
+				Token: "token",
+			}),
+		}
+		src, err := NewGithubSource(svc, cf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return src
+	}
+
+	existing := map[string]bool{"alice": true, "bob": true, "batch-change": true}
+
+	t.Run("set", func(t *testing.T) {
+		cf, mutations := newFakeGitHub(t, existing)
+		cs := newChangeset()
+		cs.Assignees = []string{"alice"}
+		cs.Labels = []string{"batch-change"}
+		cs.Reviewers = []string{"bob"}
+
+		if err := newSource(t, cf).UpdateChangeset(context.Background(), cs); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		want := map[string]string{
+			"updatePullRequest": `{"pullRequestId":"PR_1","baseRefName":"master","title":"This is a new title","body":"This is a new body","assigneeIds":["ID_alice"],"labelIds":["ID_batch-change"]}`,
+			"requestReviews":    `{"pullRequestId":"PR_1","userIds":["ID_bob"],"union":true}`,
+		}
+		if diff := cmp.Diff(want, mutations); diff != "" {
+			t.Errorf("unexpected mutations (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("empty leaves them unchanged", func(t *testing.T) {
+		cf, mutations := newFakeGitHub(t, existing)
+
+		if err := newSource(t, cf).UpdateChangeset(context.Background(), newChangeset()); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		want := map[string]string{
+			"updatePullRequest": `{"pullRequestId":"PR_1","baseRefName":"master","title":"This is a new title","body":"This is a new body"}`,
+		}
+		if diff := cmp.Diff(want, mutations); diff != "" {
+			t.Errorf("unexpected mutations (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("unknown label", func(t *testing.T) {
+		cf, mutations := newFakeGitHub(t, existing)
+		cs := newChangeset()
+		cs.Labels = []string{"does-not-exist"}
+
+		err := newSource(t, cf).UpdateChangeset(context.Background(), cs)
+		if have, want := fmt.Sprint(err), `resolving labels: label "does-not-exist" not found in repository sourcegraph/automation-testing`; have != want {
+			t.Errorf("error:\nhave: %q\nwant: %q", have, want)
+		}
+		if len(mutations) != 0 {
+			t.Errorf("unexpected mutations: %v", mutations)
+		}
+	})
+}
+
 func TestGithubSource_LoadChangeset(t *testing.T) {
 	testCases := []struct {
 		name string
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/cockroachdb/errors"
 
@@ -110,11 +111,23 @@ func (s *GitLabSource) CreateChangeset(ctx context.Context, c *Changeset) (bool,
 	source := git.AbbreviateRef(c.HeadRef)
 	target := git.AbbreviateRef(c.BaseRef)
 
+	assigneeIDs, err := s.getUserIDs(ctx, c.Assignees)
+	if err != nil {
+		return exists, errors.Wrap(err, "resolving assignees")
+	}
+	reviewerIDs, err := s.getUserIDs(ctx, c.Reviewers)
+	if err != nil {
+		return exists, errors.Wrap(err, "resolving reviewers")
+	}
+
 	mr, err := s.client.CreateMergeRequest(ctx, project, gitlab.CreateMergeRequestOpts{
 		SourceBranch: source,
 		TargetBranch: target,
 		Title:        c.Title,
 		Description:  c.Body,
+		Labels:       strings.Join(c.Labels, ","),
+		AssigneeIDs:  assigneeIDs,
+		ReviewerIDs:  reviewerIDs,
 	})
 	if err != nil {
 		if err == gitlab.ErrMergeRequestAlreadyExists {
@@ -399,10 +412,33 @@ func (s *GitLabSource) UpdateChangeset(ctx context.Context, c *Changeset) error
 		title = gitlab.SetWIP(c.Title)
 	}
 
+	// Non-empty assignees and labels replace the ones of the merge request,
+	// empty ones leave them unchanged, so assignees and labels removed from
+	// the spec are only removed if others remain.
+	assigneeIDs, err := s.getUserIDs(ctx, c.Assignees)
+	if err != nil {
+		return errors.Wrap(err, "resolving assignees")
+	}
+	reviewerIDs, err := s.getUserIDs(ctx, c.Reviewers)
+	if err != nil {
+		return errors.Wrap(err, "resolving reviewers")
+	}
+	if len(reviewerIDs) > 0 {
+		// GitLab replaces the reviewers of the merge request, but we don't
+		// want to withdraw review requests, so we keep the current reviewers.
+		for _, r := range mr.Reviewers {
+			reviewerIDs = append(reviewerIDs, r.ID)
+		}
+		reviewerIDs = dedupeUserIDs(reviewerIDs)
+	}
+
 	updated, err := s.client.UpdateMergeRequest(ctx, project, mr, gitlab.UpdateMergeRequestOpts{
 		Title:        title,
 		Description:  c.Body,
 		TargetBranch: git.AbbreviateRef(c.BaseRef),
+		Labels:       strings.Join(c.Labels, ","),
+		AssigneeIDs:  assigneeIDs,
+		ReviewerIDs:  reviewerIDs,
 	})
 	if err != nil {
 		return errors.Wrap(err, "updating GitLab merge request")
@@ -457,3 +493,36 @@ func (s *GitLabSource) MergeChangeset(ctx context.Context, c *Changeset, squash
 
 	return c.Changeset.SetMetadata(updated)
 }
+
+// getUserIDs returns the IDs of the GitLab users with the given usernames, in
+// the same order.
+func (s *GitLabSource) getUserIDs(ctx context.Context, usernames []string) ([]int32, error) {
+	ids := make([]int32, 0, len(usernames))
+	for _, username := range usernames {
+		q := make(url.Values)
+		q.Set("username", username)
+
+		users, _, err := s.client.ListUsers(ctx, "users?"+q.Encode())
+		if err != nil {
+			return nil, err
+		}
+		if len(users) == 0 {
+			return nil, errors.Errorf("user %q not found", username)
+		}
+		ids = append(ids, users[0].ID)
+	}
+	return ids, nil
+}
+
+func dedupeUserIDs(ids []int32) []int32 {
+	seen := make(map[int32]struct{}, len(ids))
+	deduped := ids[:0]
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		deduped = append(deduped, id)
+	}
+	return deduped
+}
@@ -610,6 +610,54 @@ func TestGitLabSource_ChangesetSource(t *testing.T) {
 		})
 	})
 
+	t.Run("UpdateChangeset assignees, labels and reviewers", func(t *testing.T) {
+		in := &gitlab.MergeRequest{IID: 2, Reviewers: []gitlab.User{{ID: 3, Username: "carol"}}}
+		out := &gitlab.MergeRequest{}
+
+		p := newGitLabChangesetSourceTestProvider(t)
+		p.changeset.Changeset.Metadata = in
+		p.changeset.Assignees = []string{"alice"}
+		p.changeset.Reviewers = []string{"bob", "carol"}
+		p.changeset.Labels = []string{"batch-change", "needs-review"}
+
+		p.mockListUsers(map[string]int32{"alice": 1, "bob": 2, "carol": 3})
+
+		oldMock := gitlab.MockUpdateMergeRequest
+		t.Cleanup(func() { gitlab.MockUpdateMergeRequest = oldMock })
+		gitlab.MockUpdateMergeRequest = func(c *gitlab.Client, ctx context.Context, project *gitlab.Project, mr *gitlab.MergeRequest, opts gitlab.UpdateMergeRequestOpts) (*gitlab.MergeRequest, error) {
+			if diff := cmp.Diff([]int32{1}, opts.AssigneeIDs); diff != "" {
+				t.Errorf("unexpected assignee IDs (-want +have):\n%s", diff)
+			}
+			if diff := cmp.Diff([]int32{2, 3}, opts.ReviewerIDs); diff != "" {
+				t.Errorf("unexpected reviewer IDs (-want +have):\n%s", diff)
+			}
+			if have, want := opts.Labels, "batch-change,needs-review"; have != want {
+				t.Errorf("unexpected labels: have=%q want=%q", have, want)
+			}
+			return out, nil
+		}
+
+		p.mockGetMergeRequestNotes(in.IID, nil, 20, nil)
+		p.mockGetMergeRequestResourceStateEvents(in.IID, nil, 20, nil)
+		p.mockGetMergeRequestPipelines(in.IID, nil, 20, nil)
+
+		if err := p.source.UpdateChangeset(p.ctx, p.changeset); err != nil {
+			t.Errorf("unexpected non-nil error: %+v", err)
+		}
+	})
+
+	t.Run("UpdateChangeset unknown user", func(t *testing.T) {
+		p := newGitLabChangesetSourceTestProvider(t)
+		p.changeset.Changeset.Metadata = &gitlab.MergeRequest{IID: 2}
+		p.changeset.Assignees = []string{"mallory"}
+
+		p.mockListUsers(map[string]int32{})
+
+		if err := p.source.UpdateChangeset(p.ctx, p.changeset); err == nil {
+			t.Error("unexpected nil error")
+		}
+	})
+
 	t.Run("UpdateChangeset draft", func(t *testing.T) {
 		// We won't test the full set of UpdateChangeset scenarios; instead
 		// we'll just make sure the title is appropriately munged.
@@ -968,6 +1016,22 @@ func (p *gitLabChangesetSourceTestProvider) mockUpdateMergeRequest(expectedMR, u
 	}
 }
 
+// mockListUsers mocks gitlab.ListUsers calls that look up a user by username
+// in the given map of usernames to IDs.
+func (p *gitLabChangesetSourceTestProvider) mockListUsers(ids map[string]int32) {
+	gitlab.MockListUsers = func(client *gitlab.Client, ctx context.Context, urlStr string) ([]*gitlab.User, *string, error) {
+		u, err := url.Parse(urlStr)
+		if err != nil {
+			p.t.Fatal(err)
+		}
+		username := u.Query().Get("username")
+		if id, ok := ids[username]; ok {
+			return []*gitlab.User{{ID: id, Username: username}}, nil, nil
+		}
+		return []*gitlab.User{}, nil, nil
+	}
+}
+
 func (p *gitLabChangesetSourceTestProvider) mockCreateComment(expected string, mr *gitlab.MergeRequest, err error) {
 	gitlab.MockCreateMergeRequestNote = func(client *gitlab.Client, ctx context.Context, project *gitlab.Project, mr *gitlab.MergeRequest, body string) error {
 		p.testCommonParams(ctx, client, project)
@@ -987,6 +1051,7 @@ func (p *gitLabChangesetSourceTestProvider) unmock() {
 	gitlab.MockGetOpenMergeRequestByRefs = nil
 	gitlab.MockUpdateMergeRequest = nil
 	gitlab.MockCreateMergeRequestNote = nil
+	gitlab.MockListUsers = nil
 }
 
 // panicDoer provides a httpcli.Doer implementation that panics if any attempt
@@ -12,6 +12,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtesting"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
@@ -436,6 +437,23 @@ func TestGitserverPushConfig(t *testing.T) {
 				Passphrase: "passphrase",
 			},
 		},
+		{
+			name:                "Bitbucket cloud HTTPS with authenticator",
+			externalServiceType: extsvc.TypeBitbucketCloud,
+			config:              `{"url": "https://bitbucket.org", "username": "user", "appPassword": "app-password"}`,
+			authenticator:       &basicHTTPSAuthenticator,
+			repoMetadata: &bitbucketcloud.Repo{
+				FullName: "sourcegraph/sourcegraph",
+				Links: bitbucketcloud.Links{
+					Clone: bitbucketcloud.CloneLinks{
+						{Name: "https", Href: "https://user@bitbucket.org/sourcegraph/sourcegraph.git"},
+					},
+				},
+			},
+			wantPushConfig: &protocol.PushConfig{
+				RemoteURL: "https://basic:pw@bitbucket.org/sourcegraph/sourcegraph.git",
+			},
+		},
 		// Errors
 		{
 			name:                "Bitbucket server SSH no keypair",
@@ -9,6 +9,7 @@ import (
 
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
@@ -276,6 +277,83 @@ func TestComputeBitbucketBuildStatus(t *testing.T) {
 	}
 }
 
+func TestComputeBitbucketCloudBuildStatus(t *testing.T) {
+	t.Parallel()
+
+	now := timeutil.Now()
+	sha := "abcdef0123456789"
+	statusEvent := func(minutesSinceSync int, commit, key string, state bitbucketcloud.PullRequestStatusState) *btypes.ChangesetEvent {
+		return &btypes.ChangesetEvent{
+			Kind: btypes.ChangesetEventKindBitbucketCloudCommitStatus,
+			Metadata: &bitbucketcloud.PullRequestStatus{
+				StatusKey: key,
+				State:     state,
+				Commit:    bitbucketcloud.PullRequestCommit{Hash: commit},
+				UpdatedOn: now.Add(time.Duration(minutesSinceSync) * time.Minute),
+			},
+		}
+	}
+
+	lastSynced := now.Add(-1 * time.Minute)
+	pr := &bitbucketcloud.PullRequest{
+		// The API only returns the abbreviated hash of the head commit.
+		Source: bitbucketcloud.PullRequestEndpoint{Commit: bitbucketcloud.PullRequestCommit{Hash: sha[:12]}},
+		Statuses: []*bitbucketcloud.PullRequestStatus{
+			{StatusKey: "ctx1", State: bitbucketcloud.PullRequestStatusStateInProgress, Commit: bitbucketcloud.PullRequestCommit{Hash: sha}},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		events []*btypes.ChangesetEvent
+		want   btypes.ChangesetCheckState
+	}{
+		{
+			name:   "synced status only",
+			events: nil,
+			want:   btypes.ChangesetCheckStatePending,
+		},
+		{
+			name: "event overrides synced status",
+			events: []*btypes.ChangesetEvent{
+				statusEvent(1, sha, "ctx1", bitbucketcloud.PullRequestStatusStateSuccessful),
+			},
+			want: btypes.ChangesetCheckStatePassed,
+		},
+		{
+			name: "stopped is failed",
+			events: []*btypes.ChangesetEvent{
+				statusEvent(1, sha, "ctx1", bitbucketcloud.PullRequestStatusStateSuccessful),
+				statusEvent(1, sha, "ctx2", bitbucketcloud.PullRequestStatusStateStopped),
+			},
+			want: btypes.ChangesetCheckStateFailed,
+		},
+		{
+			name: "events before last sync are ignored",
+			events: []*btypes.ChangesetEvent{
+				statusEvent(-2, sha, "ctx1", bitbucketcloud.PullRequestStatusStateFailed),
+			},
+			want: btypes.ChangesetCheckStatePending,
+		},
+		{
+			name: "events on other commits are ignored",
+			events: []*btypes.ChangesetEvent{
+				statusEvent(1, "0123456789ab", "ctx1", bitbucketcloud.PullRequestStatusStateFailed),
+			},
+			want: btypes.ChangesetCheckStatePending,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			have := computeBitbucketCloudBuildStatus(lastSynced, pr, tc.events)
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
 func TestComputeGitLabCheckState(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,163 @@
+package store
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"github.com/opentracing/opentracing-go/log"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// batchSpecExecutionCacheEntryColumns are the columns of
+// batch_spec_execution_cache_entries that are read and written by the Store.
+var batchSpecExecutionCacheEntryColumns = SQLColumns{
+	"batch_spec_execution_cache_entries.id",
+	"batch_spec_execution_cache_entries.user_id",
+	"batch_spec_execution_cache_entries.key",
+	"batch_spec_execution_cache_entries.value",
+	"batch_spec_execution_cache_entries.last_used_at",
+	"batch_spec_execution_cache_entries.created_at",
+}
+
+// CreateBatchSpecExecutionCacheEntry creates the given cache entry. If the
+// user already has an entry with the same key, its value is overwritten.
+func (s *Store) CreateBatchSpecExecutionCacheEntry(ctx context.Context, ce *btypes.BatchSpecExecutionCacheEntry) (err error) {
+	ctx, endObservation := s.operations.createBatchSpecExecutionCacheEntry.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("Key", ce.Key),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if ce.CreatedAt.IsZero() {
+		ce.CreatedAt = s.now()
+	}
+
+	q := sqlf.Sprintf(
+		createBatchSpecExecutionCacheEntryQueryFmtstr,
+		ce.UserID,
+		ce.Key,
+		ce.Value,
+		&dbutil.NullTime{Time: &ce.LastUsedAt},
+		ce.CreatedAt,
+		sqlf.Join(batchSpecExecutionCacheEntryColumns.ToSqlf(), ", "),
+	)
+
+	return s.query(ctx, q, func(sc scanner) error {
+		return scanBatchSpecExecutionCacheEntry(ce, sc)
+	})
+}
+
+var createBatchSpecExecutionCacheEntryQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_spec_execution_cache_entries.go:CreateBatchSpecExecutionCacheEntry
+INSERT INTO batch_spec_execution_cache_entries
+	(user_id, key, value, last_used_at, created_at)
+VALUES
+	(%s, %s, %s, %s, %s)
+ON CONFLICT (user_id, key) DO UPDATE SET
+	value = EXCLUDED.value,
+	created_at = EXCLUDED.created_at
+RETURNING %s
+`
+
+// ListBatchSpecExecutionCacheEntriesOpts captures the query options needed
+// for listing batch spec execution cache entries.
+type ListBatchSpecExecutionCacheEntriesOpts struct {
+	UserID int32
+	Keys   []string
+}
+
+// ListBatchSpecExecutionCacheEntries lists the cache entries of the given
+// user with the given keys.
+func (s *Store) ListBatchSpecExecutionCacheEntries(ctx context.Context, opts ListBatchSpecExecutionCacheEntriesOpts) (cs []*btypes.BatchSpecExecutionCacheEntry, err error) {
+	ctx, endObservation := s.operations.listBatchSpecExecutionCacheEntries.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("Count", len(opts.Keys)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := listBatchSpecExecutionCacheEntriesQuery(opts)
+
+	cs = make([]*btypes.BatchSpecExecutionCacheEntry, 0, len(opts.Keys))
+	err = s.query(ctx, q, func(sc scanner) error {
+		var c btypes.BatchSpecExecutionCacheEntry
+		if err := scanBatchSpecExecutionCacheEntry(&c, sc); err != nil {
+			return err
+		}
+		cs = append(cs, &c)
+		return nil
+	})
+
+	return cs, err
+}
+
+var listBatchSpecExecutionCacheEntriesQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_spec_execution_cache_entries.go:ListBatchSpecExecutionCacheEntries
+SELECT %s FROM batch_spec_execution_cache_entries
+WHERE %s
+ORDER BY id ASC
+`
+
+func listBatchSpecExecutionCacheEntriesQuery(opts ListBatchSpecExecutionCacheEntriesOpts) *sqlf.Query {
+	preds := []*sqlf.Query{
+		sqlf.Sprintf("batch_spec_execution_cache_entries.user_id = %s", opts.UserID),
+		sqlf.Sprintf("batch_spec_execution_cache_entries.key = ANY (%s)", pq.Array(opts.Keys)),
+	}
+
+	return sqlf.Sprintf(
+		listBatchSpecExecutionCacheEntriesQueryFmtstr,
+		sqlf.Join(batchSpecExecutionCacheEntryColumns.ToSqlf(), ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+}
+
+// MarkUsedBatchSpecExecutionCacheEntries sets the last_used_at of the cache
+// entries with the given IDs to now, so that they don't expire.
+func (s *Store) MarkUsedBatchSpecExecutionCacheEntries(ctx context.Context, ids []int64) (err error) {
+	ctx, endObservation := s.operations.markUsedBatchSpecExecutionCacheEntries.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("count", len(ids)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(markUsedBatchSpecExecutionCacheEntriesQueryFmtstr, s.now(), pq.Array(ids))
+	return s.Store.Exec(ctx, q)
+}
+
+var markUsedBatchSpecExecutionCacheEntriesQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_spec_execution_cache_entries.go:MarkUsedBatchSpecExecutionCacheEntries
+UPDATE batch_spec_execution_cache_entries
+SET last_used_at = %s
+WHERE id = ANY (%s)
+`
+
+// DeleteExpiredBatchSpecExecutionCacheEntries deletes the cache entries that
+// haven't been created or used within BatchSpecExecutionCacheEntryTTL.
+func (s *Store) DeleteExpiredBatchSpecExecutionCacheEntries(ctx context.Context) (err error) {
+	ctx, endObservation := s.operations.deleteExpiredBatchSpecExecutionCacheEntries.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	expirationTime := s.now().Add(-btypes.BatchSpecExecutionCacheEntryTTL)
+	q := sqlf.Sprintf(deleteExpiredBatchSpecExecutionCacheEntriesQueryFmtstr, expirationTime)
+
+	return s.Store.Exec(ctx, q)
+}
+
+var deleteExpiredBatchSpecExecutionCacheEntriesQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_spec_execution_cache_entries.go:DeleteExpiredBatchSpecExecutionCacheEntries
+DELETE FROM
+  batch_spec_execution_cache_entries
+WHERE
+  GREATEST(created_at, last_used_at) < %s
+`
+
+func scanBatchSpecExecutionCacheEntry(c *btypes.BatchSpecExecutionCacheEntry, s scanner) error {
+	return s.Scan(
+		&c.ID,
+		&c.UserID,
+		&c.Key,
+		&c.Value,
+		&dbutil.NullTime{Time: &c.LastUsedAt},
+		&c.CreatedAt,
+	)
+}
@@ -0,0 +1,118 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+)
+
+func testStoreBatchSpecExecutionCacheEntries(t *testing.T, ctx context.Context, s *Store, clock ct.Clock) {
+	user := ct.CreateTestUser(t, s.DB(), false)
+	otherUser := ct.CreateTestUser(t, s.DB(), false)
+
+	entries := []*btypes.BatchSpecExecutionCacheEntry{
+		{UserID: user.ID, Key: "key-1", Value: `{"stepIndex":0}`},
+		{UserID: user.ID, Key: "key-2", Value: `{"stepIndex":1}`},
+		{UserID: otherUser.ID, Key: "key-1", Value: `{"stepIndex":0}`},
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		for _, e := range entries {
+			if err := s.CreateBatchSpecExecutionCacheEntry(ctx, e); err != nil {
+				t.Fatal(err)
+			}
+
+			if e.ID == 0 {
+				t.Fatal("ID should not be zero")
+			}
+			if have, want := e.CreatedAt, clock.Now(); !have.Equal(want) {
+				t.Fatalf("unexpected created at: have=%s want=%s", have, want)
+			}
+		}
+	})
+
+	t.Run("Create existing key", func(t *testing.T) {
+		e := &btypes.BatchSpecExecutionCacheEntry{UserID: user.ID, Key: "key-1", Value: `{"stepIndex":0,"diff":"new"}`}
+		if err := s.CreateBatchSpecExecutionCacheEntry(ctx, e); err != nil {
+			t.Fatal(err)
+		}
+
+		if have, want := e.ID, entries[0].ID; have != want {
+			t.Fatalf("unexpected ID: have=%d want=%d", have, want)
+		}
+		entries[0] = e
+	})
+
+	t.Run("List", func(t *testing.T) {
+		have, err := s.ListBatchSpecExecutionCacheEntries(ctx, ListBatchSpecExecutionCacheEntriesOpts{
+			UserID: user.ID,
+			Keys:   []string{"key-1", "key-2", "key-3"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(entries[:2], have); diff != "" {
+			t.Fatalf("unexpected entries (-want +have):\n%s", diff)
+		}
+
+		have, err = s.ListBatchSpecExecutionCacheEntries(ctx, ListBatchSpecExecutionCacheEntriesOpts{
+			UserID: otherUser.ID,
+			Keys:   []string{"key-2"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(have) != 0 {
+			t.Fatalf("unexpected entries of other user: %+v", have)
+		}
+	})
+
+	t.Run("MarkUsed", func(t *testing.T) {
+		if err := s.MarkUsedBatchSpecExecutionCacheEntries(ctx, []int64{entries[1].ID}); err != nil {
+			t.Fatal(err)
+		}
+
+		have, err := s.ListBatchSpecExecutionCacheEntries(ctx, ListBatchSpecExecutionCacheEntriesOpts{
+			UserID: user.ID,
+			Keys:   []string{"key-2"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(have) != 1 {
+			t.Fatalf("unexpected number of entries: %d", len(have))
+		}
+		if want := clock.Now(); !have[0].LastUsedAt.Equal(want) {
+			t.Fatalf("unexpected last used at: have=%s want=%s", have[0].LastUsedAt, want)
+		}
+	})
+
+	t.Run("DeleteExpired", func(t *testing.T) {
+		// Only the entry that was used recently is kept.
+		clock.Add(btypes.BatchSpecExecutionCacheEntryTTL - time.Minute)
+		if err := s.MarkUsedBatchSpecExecutionCacheEntries(ctx, []int64{entries[1].ID}); err != nil {
+			t.Fatal(err)
+		}
+		clock.Add(2 * time.Minute)
+
+		if err := s.DeleteExpiredBatchSpecExecutionCacheEntries(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		have, err := s.ListBatchSpecExecutionCacheEntries(ctx, ListBatchSpecExecutionCacheEntriesOpts{
+			UserID: user.ID,
+			Keys:   []string{"key-1", "key-2"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(have) != 1 || have[0].ID != entries[1].ID {
+			t.Fatalf("unexpected entries: %+v", have)
+		}
+	})
+}
@@ -0,0 +1,175 @@
+package store
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/opentracing/opentracing-go/log"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// batchSpecWorkspaceStepArtifactColumns are the columns of
+// batch_spec_workspace_step_artifacts that are read when listing artifacts.
+// The content is only read when getting a single artifact.
+var batchSpecWorkspaceStepArtifactColumns = SQLColumns{
+	"batch_spec_workspace_step_artifacts.id",
+	"batch_spec_workspace_step_artifacts.batch_spec_workspace_id",
+	"batch_spec_workspace_step_artifacts.step",
+	"batch_spec_workspace_step_artifacts.name",
+	"batch_spec_workspace_step_artifacts.path",
+	"octet_length(batch_spec_workspace_step_artifacts.content)",
+	"batch_spec_workspace_step_artifacts.created_at",
+}
+
+// CreateBatchSpecWorkspaceStepArtifact creates the given step artifact. If the
+// step already has an artifact with the same path, it is overwritten.
+func (s *Store) CreateBatchSpecWorkspaceStepArtifact(ctx context.Context, a *btypes.BatchSpecWorkspaceStepArtifact) (err error) {
+	ctx, endObservation := s.operations.createBatchSpecWorkspaceStepArtifact.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("BatchSpecWorkspaceID", int(a.BatchSpecWorkspaceID)),
+		log.Int("Step", a.Step),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if a.CreatedAt.IsZero() {
+		a.CreatedAt = s.now()
+	}
+
+	q := sqlf.Sprintf(
+		createBatchSpecWorkspaceStepArtifactQueryFmtstr,
+		a.BatchSpecWorkspaceID,
+		a.Step,
+		a.Name,
+		a.Path,
+		a.Content,
+		a.CreatedAt,
+		sqlf.Join(batchSpecWorkspaceStepArtifactColumns.ToSqlf(), ", "),
+	)
+
+	return s.query(ctx, q, func(sc scanner) error {
+		return scanBatchSpecWorkspaceStepArtifact(a, sc)
+	})
+}
+
+var createBatchSpecWorkspaceStepArtifactQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_spec_workspace_step_artifacts.go:CreateBatchSpecWorkspaceStepArtifact
+INSERT INTO batch_spec_workspace_step_artifacts
+	(batch_spec_workspace_id, step, name, path, content, created_at)
+VALUES
+	(%s, %s, %s, %s, %s, %s)
+ON CONFLICT (batch_spec_workspace_id, step, path) DO UPDATE SET
+	name = EXCLUDED.name,
+	content = EXCLUDED.content,
+	created_at = EXCLUDED.created_at
+RETURNING %s
+`
+
+// GetBatchSpecWorkspaceStepArtifactOpts captures the query options needed for
+// getting a BatchSpecWorkspaceStepArtifact.
+type GetBatchSpecWorkspaceStepArtifactOpts struct {
+	ID int64
+}
+
+// GetBatchSpecWorkspaceStepArtifact gets the step artifact matching the given
+// options, including its content.
+func (s *Store) GetBatchSpecWorkspaceStepArtifact(ctx context.Context, opts GetBatchSpecWorkspaceStepArtifactOpts) (a *btypes.BatchSpecWorkspaceStepArtifact, err error) {
+	ctx, endObservation := s.operations.getBatchSpecWorkspaceStepArtifact.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(opts.ID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(
+		getBatchSpecWorkspaceStepArtifactQueryFmtstr,
+		sqlf.Join(batchSpecWorkspaceStepArtifactColumns.ToSqlf(), ", "),
+		opts.ID,
+	)
+
+	var artifact btypes.BatchSpecWorkspaceStepArtifact
+	err = s.query(ctx, q, func(sc scanner) error {
+		return scanBatchSpecWorkspaceStepArtifact(&artifact, sc, &artifact.Content)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if artifact.ID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &artifact, nil
+}
+
+var getBatchSpecWorkspaceStepArtifactQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_spec_workspace_step_artifacts.go:GetBatchSpecWorkspaceStepArtifact
+SELECT %s, batch_spec_workspace_step_artifacts.content
+FROM batch_spec_workspace_step_artifacts
+WHERE batch_spec_workspace_step_artifacts.id = %s
+`
+
+// ListBatchSpecWorkspaceStepArtifactsOpts captures the query options needed
+// for listing step artifacts.
+type ListBatchSpecWorkspaceStepArtifactsOpts struct {
+	BatchSpecWorkspaceID int64
+	// Step is the one-based number of the step. If zero, the artifacts of all
+	// steps are listed.
+	Step int
+}
+
+// ListBatchSpecWorkspaceStepArtifacts lists the step artifacts matching the
+// given options, without their content.
+func (s *Store) ListBatchSpecWorkspaceStepArtifacts(ctx context.Context, opts ListBatchSpecWorkspaceStepArtifactsOpts) (as []*btypes.BatchSpecWorkspaceStepArtifact, err error) {
+	ctx, endObservation := s.operations.listBatchSpecWorkspaceStepArtifacts.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("BatchSpecWorkspaceID", int(opts.BatchSpecWorkspaceID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := listBatchSpecWorkspaceStepArtifactsQuery(opts)
+
+	as = make([]*btypes.BatchSpecWorkspaceStepArtifact, 0)
+	err = s.query(ctx, q, func(sc scanner) error {
+		var a btypes.BatchSpecWorkspaceStepArtifact
+		if err := scanBatchSpecWorkspaceStepArtifact(&a, sc); err != nil {
+			return err
+		}
+		as = append(as, &a)
+		return nil
+	})
+
+	return as, err
+}
+
+var listBatchSpecWorkspaceStepArtifactsQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_spec_workspace_step_artifacts.go:ListBatchSpecWorkspaceStepArtifacts
+SELECT %s FROM batch_spec_workspace_step_artifacts
+WHERE %s
+ORDER BY step ASC, name ASC, id ASC
+`
+
+func listBatchSpecWorkspaceStepArtifactsQuery(opts ListBatchSpecWorkspaceStepArtifactsOpts) *sqlf.Query {
+	preds := []*sqlf.Query{
+		sqlf.Sprintf("batch_spec_workspace_step_artifacts.batch_spec_workspace_id = %s", opts.BatchSpecWorkspaceID),
+	}
+
+	if opts.Step != 0 {
+		preds = append(preds, sqlf.Sprintf("batch_spec_workspace_step_artifacts.step = %s", opts.Step))
+	}
+
+	return sqlf.Sprintf(
+		listBatchSpecWorkspaceStepArtifactsQueryFmtstr,
+		sqlf.Join(batchSpecWorkspaceStepArtifactColumns.ToSqlf(), ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+}
+
+func scanBatchSpecWorkspaceStepArtifact(a *btypes.BatchSpecWorkspaceStepArtifact, s scanner, extra ...interface{}) error {
+	return s.Scan(append([]interface{}{
+		&a.ID,
+		&a.BatchSpecWorkspaceID,
+		&a.Step,
+		&a.Name,
+		&a.Path,
+		&a.Size,
+		&a.CreatedAt,
+	}, extra...)...)
+}
@@ -0,0 +1,103 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+)
+
+func testStoreBatchSpecWorkspaceStepArtifacts(t *testing.T, ctx context.Context, s *Store, clock ct.Clock) {
+	artifacts := []*btypes.BatchSpecWorkspaceStepArtifact{
+		{BatchSpecWorkspaceID: 1, Step: 1, Name: "report", Path: "reports/junit.xml", Content: []byte("<testsuites/>")},
+		{BatchSpecWorkspaceID: 1, Step: 2, Name: "log", Path: "migration.log", Content: []byte("migrated")},
+		{BatchSpecWorkspaceID: 2, Step: 1, Name: "report", Path: "reports/junit.xml", Content: []byte("<testsuites></testsuites>")},
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		for _, a := range artifacts {
+			if err := s.CreateBatchSpecWorkspaceStepArtifact(ctx, a); err != nil {
+				t.Fatal(err)
+			}
+
+			if a.ID == 0 {
+				t.Fatal("ID should not be zero")
+			}
+			if have, want := a.Size, int64(len(a.Content)); have != want {
+				t.Fatalf("unexpected size: have=%d want=%d", have, want)
+			}
+		}
+	})
+
+	t.Run("Create existing path", func(t *testing.T) {
+		a := &btypes.BatchSpecWorkspaceStepArtifact{BatchSpecWorkspaceID: 1, Step: 2, Name: "migration log", Path: "migration.log", Content: []byte("migrated again")}
+		if err := s.CreateBatchSpecWorkspaceStepArtifact(ctx, a); err != nil {
+			t.Fatal(err)
+		}
+
+		if have, want := a.ID, artifacts[1].ID; have != want {
+			t.Fatalf("unexpected ID: have=%d want=%d", have, want)
+		}
+		artifacts[1] = a
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		for _, want := range artifacts {
+			have, err := s.GetBatchSpecWorkspaceStepArtifact(ctx, GetBatchSpecWorkspaceStepArtifactOpts{ID: want.ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(want, have); diff != "" {
+				t.Fatalf("unexpected artifact (-want +have):\n%s", diff)
+			}
+		}
+
+		if _, err := s.GetBatchSpecWorkspaceStepArtifact(ctx, GetBatchSpecWorkspaceStepArtifactOpts{ID: 0xdeadbeef}); err != ErrNoResults {
+			t.Fatalf("unexpected error: have=%v want=%v", err, ErrNoResults)
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		withoutContent := func(as ...*btypes.BatchSpecWorkspaceStepArtifact) []*btypes.BatchSpecWorkspaceStepArtifact {
+			var res []*btypes.BatchSpecWorkspaceStepArtifact
+			for _, a := range as {
+				c := *a
+				c.Content = nil
+				res = append(res, &c)
+			}
+			return res
+		}
+
+		for name, tc := range map[string]struct {
+			opts ListBatchSpecWorkspaceStepArtifactsOpts
+			want []*btypes.BatchSpecWorkspaceStepArtifact
+		}{
+			"all steps": {
+				opts: ListBatchSpecWorkspaceStepArtifactsOpts{BatchSpecWorkspaceID: 1},
+				want: withoutContent(artifacts[0], artifacts[1]),
+			},
+			"single step": {
+				opts: ListBatchSpecWorkspaceStepArtifactsOpts{BatchSpecWorkspaceID: 1, Step: 2},
+				want: withoutContent(artifacts[1]),
+			},
+			"no artifacts": {
+				opts: ListBatchSpecWorkspaceStepArtifactsOpts{BatchSpecWorkspaceID: 3},
+				want: withoutContent(),
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				have, err := s.ListBatchSpecWorkspaceStepArtifacts(ctx, tc.opts)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if diff := cmp.Diff(tc.want, have, cmpopts.EquateEmpty()); diff != "" {
+					t.Fatalf("unexpected artifacts (-want +have):\n%s", diff)
+				}
+			})
+		}
+	})
+}
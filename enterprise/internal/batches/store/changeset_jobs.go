@@ -167,6 +167,40 @@ func getChangesetJobQuery(opts *GetChangesetJobOpts) *sqlf.Query {
 	)
 }
 
+// EnqueueAutoMergeChangesetJob moves the pending auto-merge job of the given
+// changeset to the front of the queue, so that the changeset is merged without
+// waiting for the next scheduled attempt. It does nothing if the changeset has
+// no pending auto-merge job.
+func (s *Store) EnqueueAutoMergeChangesetJob(ctx context.Context, cs *btypes.Changeset) (err error) {
+	ctx, endObservation := s.operations.enqueueAutoMergeChangesetJob.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("changesetID", int(cs.ID)),
+		log.Int("jobID", int(cs.AutoMergeJobID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if cs.AutoMergeJobID == 0 {
+		return nil
+	}
+
+	return s.Exec(ctx, sqlf.Sprintf(
+		enqueueAutoMergeChangesetJobQueryFmtstr,
+		s.now(),
+		cs.AutoMergeJobID,
+		btypes.ChangesetJobTypeAutoMerge,
+		btypes.ChangesetJobStateQueued.ToDB(),
+	))
+}
+
+var enqueueAutoMergeChangesetJobQueryFmtstr = `
+-- source: enterprise/internal/batches/store/changeset_jobs.go:EnqueueAutoMergeChangesetJob
+UPDATE changeset_jobs
+SET process_after = NULL, updated_at = %s
+WHERE
+	id = %s
+	AND job_type = %s
+	AND state = %s
+`
+
 func scanChangesetJob(c *btypes.ChangesetJob, s scanner) error {
 	var raw json.RawMessage
 	if err := s.Scan(
@@ -202,6 +236,8 @@ func scanChangesetJob(c *btypes.ChangesetJob, s scanner) error {
 		c.Payload = new(btypes.ChangesetJobClosePayload)
 	case btypes.ChangesetJobTypePublish:
 		c.Payload = new(btypes.ChangesetJobPublishPayload)
+	case btypes.ChangesetJobTypeAutoMerge:
+		c.Payload = new(btypes.ChangesetJobAutoMergePayload)
 	default:
 		return errors.Errorf("unknown job type %q", c.JobType)
 	}
@@ -22,6 +22,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
@@ -64,6 +65,7 @@ var ChangesetColumns = []*sqlf.Query{
 	sqlf.Sprintf("changesets.num_failures"),
 	sqlf.Sprintf("changesets.closing"),
 	sqlf.Sprintf("changesets.syncer_error"),
+	sqlf.Sprintf("changesets.auto_merge_job_id"),
 }
 
 // changesetInsertColumns is the list of changeset columns that are modified in
@@ -747,6 +749,36 @@ func (s *Store) UpdateChangesetUiPublicationState(ctx context.Context, cs *btype
 	return s.updateChangesetColumn(ctx, cs, "ui_publication_state", uiPublicationState)
 }
 
+// UpdateChangesetAutoMergeJobID updates only the `auto_merge_job_id` &
+// `updated_at` columns of the given Changeset.
+func (s *Store) UpdateChangesetAutoMergeJobID(ctx context.Context, cs *btypes.Changeset) (err error) {
+	ctx, endObservation := s.operations.updateChangesetAutoMergeJobID.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(cs.ID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.updateChangesetColumn(ctx, cs, "auto_merge_job_id", nullInt64Column(cs.AutoMergeJobID))
+}
+
+// ClearChangesetAutoMergeJobID unsets the `auto_merge_job_id` of the changeset
+// with the given ID, if it is still set to the given auto-merge job.
+func (s *Store) ClearChangesetAutoMergeJobID(ctx context.Context, changesetID, jobID int64) (err error) {
+	ctx, endObservation := s.operations.clearChangesetAutoMergeJobID.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(changesetID)),
+		log.Int("jobID", int(jobID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.Exec(ctx, sqlf.Sprintf(clearChangesetAutoMergeJobIDQueryFmtstr, s.now(), changesetID, jobID))
+}
+
+var clearChangesetAutoMergeJobIDQueryFmtstr = `
+-- source: enterprise/internal/batches/store/changesets.go:ClearChangesetAutoMergeJobID
+UPDATE changesets
+SET auto_merge_job_id = NULL, updated_at = %s
+WHERE id = %s AND auto_merge_job_id = %s
+`
+
 // updateChangesetColumn updates the column with the given name, setting it to
 // the given value, and updating the updated_at column.
 func (s *Store) updateChangesetColumn(ctx context.Context, cs *btypes.Changeset, name string, val interface{}) error {
@@ -874,6 +906,51 @@ func (s *Store) GetChangesetExternalIDs(ctx context.Context, spec api.ExternalRe
 	return basestore.ScanStrings(s.Store.Query(ctx, q))
 }
 
+// GetChangesetRebaseState returns whether the batch spec of the batch change
+// that owns the given changeset opts into rebasing its changesets when they
+// fall behind their base branch, and the commit of the base branch the diff of
+// the changeset was last applied on: the commit of the latest rebase since its
+// current changeset spec was created, or else the base commit of that spec.
+// Unowned changesets are never rebased.
+func (s *Store) GetChangesetRebaseState(ctx context.Context, id int64) (enabled bool, baseRev string, err error) {
+	ctx, endObservation := s.operations.getChangesetRebaseState.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(id)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(getChangesetRebaseStateQueryFmtstr, btypes.ChangesetEventKindBatchChangesRebased, id)
+	err = s.QueryRow(ctx, q).Scan(&enabled, &baseRev)
+	if err == sql.ErrNoRows {
+		return false, "", nil
+	}
+	return enabled, baseRev, err
+}
+
+var getChangesetRebaseStateQueryFmtstr = `
+-- source: enterprise/internal/batches/store/changesets.go:GetChangesetRebaseState
+SELECT
+	COALESCE((batch_specs.spec->'changesetTemplate'->>'rebase')::boolean, FALSE),
+	COALESCE(
+		(
+			SELECT changeset_events.key
+			FROM changeset_events
+			WHERE
+				changeset_events.changeset_id = changesets.id
+				AND changeset_events.kind = %s
+				AND changeset_events.created_at > changeset_specs.created_at
+			ORDER BY changeset_events.created_at DESC
+			LIMIT 1
+		),
+		changeset_specs.spec->>'baseRev',
+		''
+	)
+FROM changesets
+JOIN batch_changes ON batch_changes.id = changesets.owned_by_batch_change_id
+JOIN batch_specs ON batch_specs.id = batch_changes.batch_spec_id
+LEFT JOIN changeset_specs ON changeset_specs.id = changesets.current_spec_id
+WHERE changesets.id = %s
+`
+
 // CanceledChangesetFailureMessage is set on changesets as the FailureMessage
 // by CancelQueuedBatchChangeChangesets which is called at the beginning of
 // ApplyBatchChange to stop enqueued changesets being processed while we're
@@ -1149,6 +1226,7 @@ func scanChangeset(t *btypes.Changeset, s scanner) error {
 		&t.NumFailures,
 		&t.Closing,
 		&dbutil.NullString{S: &syncErrorMessage},
+		&dbutil.NullInt64{N: &t.AutoMergeJobID},
 	)
 	if err != nil {
 		return errors.Wrap(err, "scanning changeset")
@@ -1170,6 +1248,8 @@ func scanChangeset(t *btypes.Changeset, s scanner) error {
 		t.Metadata = new(github.PullRequest)
 	case extsvc.TypeBitbucketServer:
 		t.Metadata = new(bitbucketserver.PullRequest)
+	case extsvc.TypeBitbucketCloud:
+		t.Metadata = new(bitbucketcloud.PullRequest)
 	case extsvc.TypeGitLab:
 		t.Metadata = new(gitlab.MergeRequest)
 	default:
@@ -37,6 +37,8 @@ func TestIntegration(t *testing.T) {
 		t.Run("BatchSpecWorkspaces", storeTest(db, nil, testStoreBatchSpecWorkspaces))
 		t.Run("BatchSpecWorkspaceExecutionJobs", storeTest(db, nil, testStoreBatchSpecWorkspaceExecutionJobs))
 		t.Run("BatchSpecResolutionJobs", storeTest(db, nil, testStoreBatchSpecResolutionJobs))
+		t.Run("BatchSpecExecutionCacheEntries", storeTest(db, nil, testStoreBatchSpecExecutionCacheEntries))
+		t.Run("BatchSpecWorkspaceStepArtifacts", storeTest(db, nil, testStoreBatchSpecWorkspaceStepArtifacts))
 
 		for name, key := range map[string]encryption.Key{
 			"no key":   nil,
@@ -189,8 +189,9 @@ type operations struct {
 	countChangesetEvents  *observation.Operation
 	upsertChangesetEvents *observation.Operation
 
-	createChangesetJob *observation.Operation
-	getChangesetJob    *observation.Operation
+	createChangesetJob           *observation.Operation
+	getChangesetJob              *observation.Operation
+	enqueueAutoMergeChangesetJob *observation.Operation
 
 	createChangesetSpec                      *observation.Operation
 	updateChangesetSpec                      *observation.Operation
@@ -212,8 +213,11 @@ type operations struct {
 	updateChangeset                   *observation.Operation
 	updateChangesetBatchChanges       *observation.Operation
 	updateChangesetUIPublicationState *observation.Operation
+	updateChangesetAutoMergeJobID     *observation.Operation
+	clearChangesetAutoMergeJobID      *observation.Operation
 	updateChangesetCodeHostState      *observation.Operation
 	getChangesetExternalIDs           *observation.Operation
+	getChangesetRebaseState           *observation.Operation
 	cancelQueuedBatchChangeChangesets *observation.Operation
 	enqueueChangesetsToClose          *observation.Operation
 	getChangesetsStats                *observation.Operation
@@ -234,6 +238,15 @@ type operations struct {
 	getBatchSpecWorkspace    *observation.Operation
 	listBatchSpecWorkspaces  *observation.Operation
 
+	createBatchSpecExecutionCacheEntry          *observation.Operation
+	listBatchSpecExecutionCacheEntries          *observation.Operation
+	markUsedBatchSpecExecutionCacheEntries      *observation.Operation
+	deleteExpiredBatchSpecExecutionCacheEntries *observation.Operation
+
+	createBatchSpecWorkspaceStepArtifact *observation.Operation
+	getBatchSpecWorkspaceStepArtifact    *observation.Operation
+	listBatchSpecWorkspaceStepArtifacts  *observation.Operation
+
 	createBatchSpecWorkspaceExecutionJob  *observation.Operation
 	createBatchSpecWorkspaceExecutionJobs *observation.Operation
 	getBatchSpecWorkspaceExecutionJob     *observation.Operation
@@ -312,8 +325,9 @@ func newOperations(observationContext *observation.Context) *operations {
 			countChangesetEvents:  op("CountChangesetEvents"),
 			upsertChangesetEvents: op("UpsertChangesetEvents"),
 
-			createChangesetJob: op("CreateChangesetJob"),
-			getChangesetJob:    op("GetChangesetJob"),
+			createChangesetJob:           op("CreateChangesetJob"),
+			getChangesetJob:              op("GetChangesetJob"),
+			enqueueAutoMergeChangesetJob: op("EnqueueAutoMergeChangesetJob"),
 
 			createChangesetSpec:                      op("CreateChangesetSpec"),
 			updateChangesetSpec:                      op("UpdateChangesetSpec"),
@@ -335,8 +349,11 @@ func newOperations(observationContext *observation.Context) *operations {
 			updateChangeset:                   op("UpdateChangeset"),
 			updateChangesetBatchChanges:       op("UpdateChangesetBatchChanges"),
 			updateChangesetUIPublicationState: op("UpdateChangesetUIPublicationState"),
+			updateChangesetAutoMergeJobID:     op("UpdateChangesetAutoMergeJobID"),
+			clearChangesetAutoMergeJobID:      op("ClearChangesetAutoMergeJobID"),
 			updateChangesetCodeHostState:      op("UpdateChangesetCodeHostState"),
 			getChangesetExternalIDs:           op("GetChangesetExternalIDs"),
+			getChangesetRebaseState:           op("GetChangesetRebaseState"),
 			cancelQueuedBatchChangeChangesets: op("CancelQueuedBatchChangeChangesets"),
 			enqueueChangesetsToClose:          op("EnqueueChangesetsToClose"),
 			getChangesetsStats:                op("GetChangesetsStats"),
@@ -357,6 +374,15 @@ func newOperations(observationContext *observation.Context) *operations {
 			getBatchSpecWorkspace:    op("GetBatchSpecWorkspace"),
 			listBatchSpecWorkspaces:  op("ListBatchSpecWorkspaces"),
 
+			createBatchSpecExecutionCacheEntry:          op("CreateBatchSpecExecutionCacheEntry"),
+			listBatchSpecExecutionCacheEntries:          op("ListBatchSpecExecutionCacheEntries"),
+			markUsedBatchSpecExecutionCacheEntries:      op("MarkUsedBatchSpecExecutionCacheEntries"),
+			deleteExpiredBatchSpecExecutionCacheEntries: op("DeleteExpiredBatchSpecExecutionCacheEntries"),
+
+			createBatchSpecWorkspaceStepArtifact: op("CreateBatchSpecWorkspaceStepArtifact"),
+			getBatchSpecWorkspaceStepArtifact:    op("GetBatchSpecWorkspaceStepArtifact"),
+			listBatchSpecWorkspaceStepArtifacts:  op("ListBatchSpecWorkspaceStepArtifacts"),
+
 			createBatchSpecWorkspaceExecutionJob:  op("CreateBatchSpecWorkspaceExecutionJob"),
 			createBatchSpecWorkspaceExecutionJobs: op("CreateBatchSpecWorkspaceExecutionJobs"),
 			getBatchSpecWorkspaceExecutionJob:     op("GetBatchSpecWorkspaceExecutionJob"),
@@ -30,6 +30,9 @@ type MockSyncStore struct {
 	// GetChangesetFunc is an instance of a mock function object controlling
 	// the behavior of the method GetChangeset.
 	GetChangesetFunc *SyncStoreGetChangesetFunc
+	// GetChangesetRebaseStateFunc is an instance of a mock function object
+	// controlling the behavior of the method GetChangesetRebaseState.
+	GetChangesetRebaseStateFunc *SyncStoreGetChangesetRebaseStateFunc
 	// GetExternalServiceIDsFunc is an instance of a mock function object
 	// controlling the behavior of the method GetExternalServiceIDs.
 	GetExternalServiceIDsFunc *SyncStoreGetExternalServiceIDsFunc
@@ -84,6 +87,11 @@ func NewMockSyncStore() *MockSyncStore {
 				return nil, nil
 			},
 		},
+		GetChangesetRebaseStateFunc: &SyncStoreGetChangesetRebaseStateFunc{
+			defaultHook: func(context.Context, int64) (bool, string, error) {
+				return false, "", nil
+			},
+		},
 		GetExternalServiceIDsFunc: &SyncStoreGetExternalServiceIDsFunc{
 			defaultHook: func(context.Context, store.GetExternalServiceIDsOpts) ([]int64, error) {
 				return nil, nil
@@ -148,6 +156,9 @@ func NewMockSyncStoreFrom(i SyncStore) *MockSyncStore {
 		GetChangesetFunc: &SyncStoreGetChangesetFunc{
 			defaultHook: i.GetChangeset,
 		},
+		GetChangesetRebaseStateFunc: &SyncStoreGetChangesetRebaseStateFunc{
+			defaultHook: i.GetChangesetRebaseState,
+		},
 		GetExternalServiceIDsFunc: &SyncStoreGetExternalServiceIDsFunc{
 			defaultHook: i.GetExternalServiceIDs,
 		},
@@ -585,6 +596,121 @@ func (c SyncStoreGetChangesetFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// SyncStoreGetChangesetRebaseStateFunc describes the behavior when the
+// GetChangesetRebaseState method of the parent MockSyncStore instance is
+// invoked.
+type SyncStoreGetChangesetRebaseStateFunc struct {
+	defaultHook func(context.Context, int64) (bool, string, error)
+	hooks       []func(context.Context, int64) (bool, string, error)
+	history     []SyncStoreGetChangesetRebaseStateFuncCall
+	mutex       sync.Mutex
+}
+
+// GetChangesetRebaseState delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockSyncStore) GetChangesetRebaseState(v0 context.Context, v1 int64) (bool, string, error) {
+	r0, r1, r2 := m.GetChangesetRebaseStateFunc.nextHook()(v0, v1)
+	m.GetChangesetRebaseStateFunc.appendCall(SyncStoreGetChangesetRebaseStateFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the
+// GetChangesetRebaseState method of the parent MockSyncStore instance is
+// invoked and the hook queue is empty.
+func (f *SyncStoreGetChangesetRebaseStateFunc) SetDefaultHook(hook func(context.Context, int64) (bool, string, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetChangesetRebaseState method of the parent MockSyncStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *SyncStoreGetChangesetRebaseStateFunc) PushHook(hook func(context.Context, int64) (bool, string, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *SyncStoreGetChangesetRebaseStateFunc) SetDefaultReturn(r0 bool, r1 string, r2 error) {
+	f.SetDefaultHook(func(context.Context, int64) (bool, string, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *SyncStoreGetChangesetRebaseStateFunc) PushReturn(r0 bool, r1 string, r2 error) {
+	f.PushHook(func(context.Context, int64) (bool, string, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *SyncStoreGetChangesetRebaseStateFunc) nextHook() func(context.Context, int64) (bool, string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SyncStoreGetChangesetRebaseStateFunc) appendCall(r0 SyncStoreGetChangesetRebaseStateFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SyncStoreGetChangesetRebaseStateFuncCall
+// objects describing the invocations of this function.
+func (f *SyncStoreGetChangesetRebaseStateFunc) History() []SyncStoreGetChangesetRebaseStateFuncCall {
+	f.mutex.Lock()
+	history := make([]SyncStoreGetChangesetRebaseStateFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SyncStoreGetChangesetRebaseStateFuncCall is an object that describes an
+// invocation of method GetChangesetRebaseState on an instance of
+// MockSyncStore.
+type SyncStoreGetChangesetRebaseStateFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 bool
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 string
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SyncStoreGetChangesetRebaseStateFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SyncStoreGetChangesetRebaseStateFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // SyncStoreGetExternalServiceIDsFunc describes the behavior when the
 // GetExternalServiceIDs method of the parent MockSyncStore instance is
 // invoked.
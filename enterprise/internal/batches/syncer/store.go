@@ -17,6 +17,7 @@ type SyncStore interface {
 	UpdateChangesetCodeHostState(ctx context.Context, cs *btypes.Changeset) error
 	UpsertChangesetEvents(ctx context.Context, cs ...*btypes.ChangesetEvent) error
 	GetSiteCredential(ctx context.Context, opts store.GetSiteCredentialOpts) (*btypes.SiteCredential, error)
+	GetChangesetRebaseState(ctx context.Context, id int64) (enabled bool, baseRev string, err error)
 	Transact(context.Context) (*store.Store, error)
 	Repos() *database.RepoStore
 	ExternalServices() *database.ExternalServiceStore
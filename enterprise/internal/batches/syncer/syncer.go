@@ -10,6 +10,7 @@ import (
 	"github.com/inconshreveable/log15"
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/global"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/state"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
@@ -19,6 +20,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
 )
 
 // externalServiceSyncerInterval is the time in between synchronizations with the
@@ -477,6 +479,16 @@ func SyncChangeset(ctx context.Context, syncStore SyncStore, source sources.Chan
 	}
 	state.SetDerivedState(ctx, syncStore.Repos(), c, events)
 
+	// Stale changesets are rebased by the reconciler, if the batch change that
+	// owns them opted into that. The base branch is resolved before opening the
+	// transaction, so that it isn't held open while gitserver is queried.
+	var rebase bool
+	if c.OwnedByBatchChangeID != 0 && c.ReconcilerState == btypes.ReconcilerStateCompleted {
+		if rebase, err = needsRebase(ctx, syncStore, repo, c); err != nil {
+			return err
+		}
+	}
+
 	tx, err := syncStore.Transact(ctx)
 	if err != nil {
 		return err
@@ -491,9 +503,52 @@ func SyncChangeset(ctx context.Context, syncStore SyncStore, source sources.Chan
 		return err
 	}
 
+	if rebase {
+		if err := tx.EnqueueChangeset(ctx, c, global.DefaultReconcilerEnqueueState(), btypes.ReconcilerStateCompleted); err != nil {
+			// The changeset was enqueued concurrently, so the reconciler will
+			// look at it anyway.
+			log15.Warn("Could not enqueue stale changeset for rebase", "changeset", c.ID, "err", err)
+		}
+	}
+
+	// If the changeset is pending auto-merge and is now ready to be merged,
+	// wake up the auto-merge job instead of waiting for its next check.
+	if c.AutoMergeJobID != 0 && c.ReadyToMerge() {
+		if err := tx.EnqueueAutoMergeChangesetJob(ctx, c); err != nil {
+			return err
+		}
+	}
+
 	return tx.UpsertChangesetEvents(ctx, events...)
 }
 
+// needsRebase returns whether the given stale changeset should be enqueued for
+// the reconciler to rebase it, which is the case unless its diff was already
+// applied on the latest commit of its base branch. The reconciler tries to
+// rebase on each commit only once, so enqueueing it again on every sync would
+// be pointless.
+func needsRebase(ctx context.Context, syncStore SyncStore, repo *types.Repo, c *btypes.Changeset) (bool, error) {
+	enabled, baseRev, err := syncStore.GetChangesetRebaseState(ctx, c.ID)
+	if err != nil {
+		return false, err
+	}
+	if !enabled || !c.NeedsRebase(baseRev) {
+		return false, nil
+	}
+
+	baseRef, err := c.BaseRef()
+	if err != nil {
+		return false, err
+	}
+	latest, err := git.ResolveRevision(ctx, repo.Name, baseRef, git.ResolveRevisionOptions{})
+	if err != nil {
+		// The next sync tries again.
+		log15.Warn("Could not resolve base branch of stale changeset", "changeset", c.ID, "err", err)
+		return false, nil
+	}
+	return string(latest) != baseRev, nil
+}
+
 func loadChangesetSource(ctx context.Context, cf *httpcli.Factory, syncStore SyncStore, repo *types.Repo) (sources.ChangesetSource, error) {
 	srcer := sources.NewSourcer(cf)
 	// This is a ChangesetSource authenticated with the external service
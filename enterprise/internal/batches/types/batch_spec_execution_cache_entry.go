@@ -0,0 +1,54 @@
+package types
+
+import (
+	"encoding/json"
+	"time"
+
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+// BatchSpecExecutionCacheEntryTTL is the amount of time after which a cache
+// entry that hasn't been used is deleted.
+const BatchSpecExecutionCacheEntryTTL = 7 * 24 * time.Hour
+
+// BatchSpecExecutionCacheEntry is the cached result of executing the steps of
+// a batch spec workspace up to and including one of the steps. The Key is the
+// BatchSpecWorkspace.StepCacheKey of that step and the Value is the
+// marshaled batcheslib.AfterStepResult.
+//
+// Cache entries are scoped to the user that executed the steps, since the
+// results can contain data from repositories that other users can't access.
+type BatchSpecExecutionCacheEntry struct {
+	ID     int64
+	UserID int32
+
+	Key   string
+	Value string
+
+	LastUsedAt time.Time
+	CreatedAt  time.Time
+}
+
+// NewBatchSpecExecutionCacheEntry returns a cache entry that holds the given
+// result under the given key.
+func NewBatchSpecExecutionCacheEntry(userID int32, key string, result *batcheslib.AfterStepResult) (*BatchSpecExecutionCacheEntry, error) {
+	value, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BatchSpecExecutionCacheEntry{
+		UserID: userID,
+		Key:    key,
+		Value:  string(value),
+	}, nil
+}
+
+// AfterStepResult unmarshals the result held by the cache entry.
+func (e *BatchSpecExecutionCacheEntry) AfterStepResult() (*batcheslib.AfterStepResult, error) {
+	var result batcheslib.AfterStepResult
+	if err := json.Unmarshal([]byte(e.Value), &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -1,10 +1,17 @@
 package types
 
 import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"sort"
 	"time"
 
+	"github.com/cockroachdb/errors"
+
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/batches/template"
 )
 
 type BatchSpecWorkspace struct {
@@ -24,3 +31,101 @@ type BatchSpecWorkspace struct {
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
+
+// stepCacheKeyVersion is part of every step cache key, so that changing what
+// goes into the keys or the cached results invalidates all existing cache
+// entries.
+const stepCacheKeyVersion = 2
+
+// stepCacheKey holds everything that the result of executing the steps of a
+// workspace up to and including one step depends on.
+type stepCacheKey struct {
+	Version int `json:"version"`
+
+	// BatchChange is part of the key, because steps can reference the name
+	// and description of the batch change in their templates.
+	BatchChange template.BatchChangeAttributes `json:"batchChange"`
+
+	RepoID             api.RepoID `json:"repoID"`
+	Commit             string     `json:"commit"`
+	Path               string     `json:"path"`
+	OnlyFetchWorkspace bool       `json:"onlyFetchWorkspace"`
+	FileMatches        []string   `json:"fileMatches"`
+
+	StepIndex int             `json:"stepIndex"`
+	Step      batcheslib.Step `json:"step"`
+
+	PreviousKey     string                 `json:"previousKey"`
+	PreviousOutputs map[string]interface{} `json:"previousOutputs"`
+}
+
+// StepCacheKey returns the key under which the result of executing the steps
+// of the workspace up to and including the step at stepIndex is cached. The
+// key covers the name and description of the batch change, the repository,
+// commit and path of the workspace, the container, run script, environment and
+// files of the step, and the outputs of the previous steps. The previous steps themselves are covered by previousKey,
+// which is the key of the previous step and empty for the first one.
+func (w *BatchSpecWorkspace) StepCacheKey(batchChange template.BatchChangeAttributes, stepIndex int, previousKey string, previousOutputs map[string]interface{}) (string, error) {
+	if stepIndex < 0 || stepIndex >= len(w.Steps) {
+		return "", errors.Newf("step %d out of range", stepIndex)
+	}
+
+	fileMatches := make([]string, len(w.FileMatches))
+	copy(fileMatches, w.FileMatches)
+	sort.Strings(fileMatches)
+
+	raw, err := json.Marshal(&stepCacheKey{
+		Version:            stepCacheKeyVersion,
+		BatchChange:        batchChange,
+		RepoID:             w.RepoID,
+		Commit:             w.Commit,
+		Path:               w.Path,
+		OnlyFetchWorkspace: w.OnlyFetchWorkspace,
+		FileMatches:        fileMatches,
+		StepIndex:          stepIndex,
+		Step:               w.Steps[stepIndex],
+		PreviousKey:        previousKey,
+		PreviousOutputs:    previousOutputs,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(raw)
+	return base64.RawURLEncoding.EncodeToString(hash[:]), nil
+}
+
+// WalkStepResults computes the cache keys of the steps of the workspace in the
+// batch change with the given attributes in order. For every step, fn is
+// called with the index and the key of the step and returns the result of
+// executing the steps up to and including it, or nil if that result isn't
+// known, which ends the walk. The last result that fn returned is returned, or
+// nil if there is none.
+func (w *BatchSpecWorkspace) WalkStepResults(batchChange template.BatchChangeAttributes, fn func(stepIndex int, key string) (*batcheslib.AfterStepResult, error)) (*batcheslib.AfterStepResult, error) {
+	var (
+		last        *batcheslib.AfterStepResult
+		previousKey string
+	)
+	for i := range w.Steps {
+		var previousOutputs map[string]interface{}
+		if last != nil {
+			previousOutputs = last.Outputs
+		}
+
+		key, err := w.StepCacheKey(batchChange, i, previousKey, previousOutputs)
+		if err != nil {
+			return nil, err
+		}
+
+		result, err := fn(i, key)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			break
+		}
+
+		last, previousKey = result, key
+	}
+	return last, nil
+}
@@ -0,0 +1,27 @@
+package types
+
+import "time"
+
+// BatchSpecWorkspaceStepArtifactMaxSize is the maximum size in bytes of the
+// content of a step artifact.
+const BatchSpecWorkspaceStepArtifactMaxSize = 10 * 1024 * 1024
+
+// BatchSpecWorkspaceStepArtifact is a file that was produced by a step of a
+// batch spec workspace and uploaded after the step finished.
+type BatchSpecWorkspaceStepArtifact struct {
+	ID int64
+
+	BatchSpecWorkspaceID int64
+	// Step is the one-based number of the step in the workspace.
+	Step int
+
+	Name string
+	Path string
+
+	// Content is only loaded when getting a single artifact. Size is always
+	// set.
+	Content []byte
+	Size    int64
+
+	CreatedAt time.Time
+}
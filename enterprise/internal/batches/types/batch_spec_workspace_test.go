@@ -0,0 +1,152 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/batches/template"
+)
+
+var testBatchChange = template.BatchChangeAttributes{Name: "my-batch-change", Description: "My batch change"}
+
+func TestBatchSpecWorkspace_StepCacheKey(t *testing.T) {
+	newWorkspace := func() *BatchSpecWorkspace {
+		return &BatchSpecWorkspace{
+			RepoID:      1,
+			Commit:      "d34db33f",
+			Path:        "a/b",
+			FileMatches: []string{"a/b/c.go", "a/b/a.go"},
+			Steps: []batcheslib.Step{
+				{Run: "echo 1 >> README.md", Container: "alpine:3"},
+				{Run: "echo 2 >> README.md", Container: "alpine:3"},
+			},
+		}
+	}
+
+	key := func(t *testing.T, w *BatchSpecWorkspace, stepIndex int, previousKey string, previousOutputs map[string]interface{}) string {
+		t.Helper()
+		k, err := w.StepCacheKey(testBatchChange, stepIndex, previousKey, previousOutputs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return k
+	}
+
+	base := key(t, newWorkspace(), 0, "", nil)
+
+	t.Run("stable", func(t *testing.T) {
+		w := newWorkspace()
+		// The order of the file matches doesn't matter.
+		w.FileMatches = []string{"a/b/a.go", "a/b/c.go"}
+		// Neither does the branch, only the commit.
+		w.Branch = "refs/heads/other"
+
+		if have := key(t, w, 0, "", nil); have != base {
+			t.Errorf("unexpected key: have=%q want=%q", have, base)
+		}
+	})
+
+	for name, change := range map[string]func(w *BatchSpecWorkspace){
+		"repo":         func(w *BatchSpecWorkspace) { w.RepoID = 2 },
+		"commit":       func(w *BatchSpecWorkspace) { w.Commit = "f00b4r" },
+		"path":         func(w *BatchSpecWorkspace) { w.Path = "a" },
+		"container":    func(w *BatchSpecWorkspace) { w.Steps[0].Container = "alpine:4" },
+		"run":          func(w *BatchSpecWorkspace) { w.Steps[0].Run = "echo 3 >> README.md" },
+		"files":        func(w *BatchSpecWorkspace) { w.Steps[0].Files = map[string]string{"a.txt": "a"} },
+		"file matches": func(w *BatchSpecWorkspace) { w.FileMatches = []string{"a/b/c.go"} },
+		"env": func(w *BatchSpecWorkspace) {
+			if err := json.Unmarshal([]byte(`{"A":"b"}`), &w.Steps[0].Env); err != nil {
+				t.Fatal(err)
+			}
+		},
+	} {
+		t.Run("changed "+name, func(t *testing.T) {
+			w := newWorkspace()
+			change(w)
+			if have := key(t, w, 0, "", nil); have == base {
+				t.Errorf("key didn't change")
+			}
+		})
+	}
+
+	for name, bc := range map[string]template.BatchChangeAttributes{
+		"name":        {Name: "other-batch-change", Description: testBatchChange.Description},
+		"description": {Name: testBatchChange.Name, Description: "Other batch change"},
+	} {
+		t.Run("changed batch change "+name, func(t *testing.T) {
+			k, err := newWorkspace().StepCacheKey(bc, 0, "", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if k == base {
+				t.Errorf("key didn't change")
+			}
+		})
+	}
+
+	t.Run("previous steps", func(t *testing.T) {
+		w := newWorkspace()
+		second := key(t, w, 1, base, map[string]interface{}{"a": "b"})
+
+		if have := key(t, w, 1, "other", map[string]interface{}{"a": "b"}); have == second {
+			t.Errorf("key didn't change with previous key")
+		}
+		if have := key(t, w, 1, base, map[string]interface{}{"a": "c"}); have == second {
+			t.Errorf("key didn't change with previous outputs")
+		}
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+		if _, err := newWorkspace().StepCacheKey(testBatchChange, 2, "", nil); err == nil {
+			t.Error("unexpected nil error")
+		}
+	})
+}
+
+func TestBatchSpecWorkspace_WalkStepResults(t *testing.T) {
+	w := &BatchSpecWorkspace{
+		RepoID: 1,
+		Commit: "d34db33f",
+		Steps: []batcheslib.Step{
+			{Run: "echo 1 >> README.md", Container: "alpine:3"},
+			{Run: "echo 2 >> README.md", Container: "alpine:3"},
+			{Run: "echo 3 >> README.md", Container: "alpine:3"},
+		},
+	}
+
+	first := &batcheslib.AfterStepResult{StepIndex: 0, Diff: "1", Outputs: map[string]interface{}{"step": 1}}
+	second := &batcheslib.AfterStepResult{StepIndex: 1, Diff: "2", Outputs: map[string]interface{}{"step": 2}}
+
+	firstKey, err := w.StepCacheKey(testBatchChange, 0, "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secondKey, err := w.StepCacheKey(testBatchChange, 1, firstKey, first.Outputs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cache := map[string]*batcheslib.AfterStepResult{
+		firstKey:  first,
+		secondKey: second,
+	}
+
+	var keys []string
+	have, err := w.WalkStepResults(testBatchChange, func(stepIndex int, key string) (*batcheslib.AfterStepResult, error) {
+		keys = append(keys, key)
+		return cache[key], nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(second, have); diff != "" {
+		t.Errorf("unexpected result (-want +have):\n%s", diff)
+	}
+	if len(keys) != 3 || keys[0] != firstKey || keys[1] != secondKey {
+		t.Errorf("unexpected keys: %v", keys)
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
@@ -284,6 +285,11 @@ type Changeset struct {
 	// Closing is set to true (along with the ReocncilerState) when the
 	// reconciler should close the changeset.
 	Closing bool
+
+	// AutoMergeJobID is the ID of the auto-merge ChangesetJob that merges the
+	// changeset once it is ready to be merged. The merge is pending as long
+	// as that job hasn't finished. This is 0 if no auto-merge was requested.
+	AutoMergeJobID int64
 }
 
 // RecordID is needed to implement the workerutil.Record interface.
@@ -310,6 +316,39 @@ func (c *Changeset) Complete() bool {
 		c.ExternalState != ChangesetExternalStateDraft
 }
 
+// ReadyToMerge returns whether the Changeset is open, approved and all of its
+// checks have passed. Pending auto-merges wait for this to be true.
+func (c *Changeset) ReadyToMerge() bool {
+	return c.ExternalState == ChangesetExternalStateOpen &&
+		c.ExternalReviewState == ChangesetReviewStateApproved &&
+		c.ExternalCheckState == ChangesetCheckStatePassed
+}
+
+// NeedsRebase returns whether the code host reports the Changeset as open and
+// either behind its base branch or conflicting with it. baseRev is the commit
+// of the base branch the diff of the Changeset was last applied on. Bitbucket
+// Server doesn't report whether a pull request is behind, so its target branch
+// is compared against baseRev instead. Bitbucket Cloud reports neither.
+func (c *Changeset) NeedsRebase(baseRev string) bool {
+	if c.ExternalState != ChangesetExternalStateOpen && c.ExternalState != ChangesetExternalStateDraft {
+		return false
+	}
+
+	switch m := c.Metadata.(type) {
+	case *github.PullRequest:
+		return m.MergeStateStatus == "BEHIND" || m.Mergeable == "CONFLICTING"
+	case *gitlab.MergeRequest:
+		return m.HasConflicts || m.DivergedCommitsCount > 0
+	case *bitbucketserver.PullRequest:
+		if m.Properties.MergeResult.Outcome == bitbucketserver.PullRequestMergeResultConflicted {
+			return true
+		}
+		return baseRev != "" && m.ToRef.LatestCommit != "" && m.ToRef.LatestCommit != baseRev
+	default:
+		return false
+	}
+}
+
 // Published returns whether the Changeset's PublicationState is Published.
 func (c *Changeset) Published() bool { return c.PublicationState.Published() }
 
@@ -373,6 +412,12 @@ func (c *Changeset) SetMetadata(meta interface{}) error {
 		c.ExternalServiceType = extsvc.TypeBitbucketServer
 		c.ExternalBranch = git.EnsureRefPrefix(pr.FromRef.ID)
 		c.ExternalUpdatedAt = unixMilliToTime(int64(pr.UpdatedDate))
+	case *bitbucketcloud.PullRequest:
+		c.Metadata = pr
+		c.ExternalID = strconv.FormatInt(pr.ID, 10)
+		c.ExternalServiceType = extsvc.TypeBitbucketCloud
+		c.ExternalBranch = git.EnsureRefPrefix(pr.Source.Branch.Name)
+		c.ExternalUpdatedAt = pr.UpdatedOn
 	case *gitlab.MergeRequest:
 		c.Metadata = pr
 		c.ExternalID = strconv.FormatInt(int64(pr.IID), 10)
@@ -402,6 +447,8 @@ func (c *Changeset) Title() (string, error) {
 		return m.Title, nil
 	case *bitbucketserver.PullRequest:
 		return m.Title, nil
+	case *bitbucketcloud.PullRequest:
+		return m.Title, nil
 	case *gitlab.MergeRequest:
 		return m.Title, nil
 	default:
@@ -419,6 +466,8 @@ func (c *Changeset) AuthorName() (string, error) {
 			return "", nil
 		}
 		return m.Author.User.Name, nil
+	case *bitbucketcloud.PullRequest:
+		return m.Author.Nickname, nil
 	case *gitlab.MergeRequest:
 		return m.Author.Username, nil
 	default:
@@ -442,6 +491,9 @@ func (c *Changeset) AuthorEmail() (string, error) {
 			return "", nil
 		}
 		return m.Author.User.EmailAddress, nil
+	case *bitbucketcloud.PullRequest:
+		// Bitbucket Cloud doesn't expose the email addresses of other users.
+		return "", nil
 	case *gitlab.MergeRequest:
 		return m.Author.Email, nil
 	default:
@@ -458,6 +510,8 @@ func (c *Changeset) ExternalCreatedAt() time.Time {
 		return m.CreatedAt
 	case *bitbucketserver.PullRequest:
 		return unixMilliToTime(int64(m.CreatedDate))
+	case *bitbucketcloud.PullRequest:
+		return m.CreatedOn
 	case *gitlab.MergeRequest:
 		return m.CreatedAt.Time
 	default:
@@ -472,6 +526,8 @@ func (c *Changeset) Body() (string, error) {
 		return m.Body, nil
 	case *bitbucketserver.PullRequest:
 		return m.Description, nil
+	case *bitbucketcloud.PullRequest:
+		return m.Description, nil
 	case *gitlab.MergeRequest:
 		return m.Description, nil
 	default:
@@ -509,6 +565,8 @@ func (c *Changeset) URL() (s string, err error) {
 		}
 		selfLink := m.Links.Self[0]
 		return selfLink.Href, nil
+	case *bitbucketcloud.PullRequest:
+		return m.Links.HTML.Href, nil
 	case *gitlab.MergeRequest:
 		return m.WebURL, nil
 	default:
@@ -600,6 +658,39 @@ func (c *Changeset) Events() (events []*ChangesetEvent, err error) {
 			}
 		}
 
+	case *bitbucketcloud.PullRequest:
+		events = make([]*ChangesetEvent, 0, len(m.Participants)+len(m.Statuses))
+
+		addEvent := func(e Keyer) error {
+			kind, err := ChangesetEventKindFor(e)
+			if err != nil {
+				return err
+			}
+
+			appendEvent(&ChangesetEvent{
+				ChangesetID: c.ID,
+				Key:         e.Key(),
+				Kind:        kind,
+				Metadata:    e,
+			})
+			return nil
+		}
+		for i := range m.Participants {
+			// Participants without a review state have only commented, and
+			// don't need to be tracked as events.
+			if m.Participants[i].State == "" {
+				continue
+			}
+			if err = addEvent(&m.Participants[i]); err != nil {
+				return
+			}
+		}
+		for _, s := range m.Statuses {
+			if err = addEvent(s); err != nil {
+				return
+			}
+		}
+
 	case *gitlab.MergeRequest:
 		events = make([]*ChangesetEvent, 0, len(m.Notes)+len(m.ResourceStateEvents)+len(m.Pipelines))
 		var kind ChangesetEventKind
@@ -656,6 +747,10 @@ func (c *Changeset) HeadRefOid() (string, error) {
 		return m.HeadRefOid, nil
 	case *bitbucketserver.PullRequest:
 		return "", nil
+	case *bitbucketcloud.PullRequest:
+		// Bitbucket Cloud only returns abbreviated hashes, which we can't use
+		// to identify the commit.
+		return "", nil
 	case *gitlab.MergeRequest:
 		return m.DiffRefs.HeadSHA, nil
 	default:
@@ -671,6 +766,8 @@ func (c *Changeset) HeadRef() (string, error) {
 		return "refs/heads/" + m.HeadRefName, nil
 	case *bitbucketserver.PullRequest:
 		return m.FromRef.ID, nil
+	case *bitbucketcloud.PullRequest:
+		return "refs/heads/" + m.Source.Branch.Name, nil
 	case *gitlab.MergeRequest:
 		return "refs/heads/" + m.SourceBranch, nil
 	default:
@@ -687,6 +784,10 @@ func (c *Changeset) BaseRefOid() (string, error) {
 		return m.BaseRefOid, nil
 	case *bitbucketserver.PullRequest:
 		return "", nil
+	case *bitbucketcloud.PullRequest:
+		// Bitbucket Cloud only returns abbreviated hashes, which we can't use
+		// to identify the commit.
+		return "", nil
 	case *gitlab.MergeRequest:
 		return m.DiffRefs.BaseSHA, nil
 	default:
@@ -702,6 +803,8 @@ func (c *Changeset) BaseRef() (string, error) {
 		return "refs/heads/" + m.BaseRefName, nil
 	case *bitbucketserver.PullRequest:
 		return m.ToRef.ID, nil
+	case *bitbucketcloud.PullRequest:
+		return "refs/heads/" + m.Destination.Branch.Name, nil
 	case *gitlab.MergeRequest:
 		return "refs/heads/" + m.TargetBranch, nil
 	default:
@@ -964,6 +1067,21 @@ func ChangesetEventKindFor(e interface{}) (ChangesetEventKind, error) {
 		return ChangesetEventKind("bitbucketserver:participant_status:" + strings.ToLower(string(e.Action))), nil
 	case *bitbucketserver.CommitStatus:
 		return ChangesetEventKindBitbucketServerCommitStatus, nil
+	case *bitbucketcloud.Participant:
+		switch e.State {
+		case bitbucketcloud.ParticipantStateApproved:
+			return ChangesetEventKindBitbucketCloudApproved, nil
+		case bitbucketcloud.ParticipantStateChangesRequested:
+			return ChangesetEventKindBitbucketCloudChangesRequested, nil
+		default:
+			return ChangesetEventKindBitbucketCloudUnapproved, nil
+		}
+	case *bitbucketcloud.PullRequestStatus:
+		return ChangesetEventKindBitbucketCloudCommitStatus, nil
+	case *bitbucketcloud.PullRequestFulfilledEvent:
+		return ChangesetEventKindBitbucketCloudMerged, nil
+	case *bitbucketcloud.PullRequestRejectedEvent:
+		return ChangesetEventKindBitbucketCloudDeclined, nil
 	case *gitlab.Pipeline:
 		return ChangesetEventKindGitLabPipeline, nil
 	case *gitlab.ReviewApprovedEvent:
@@ -999,6 +1117,19 @@ func NewChangesetEventMetadata(k ChangesetEventKind) (interface{}, error) {
 		default:
 			return new(bitbucketserver.Activity), nil
 		}
+	case strings.HasPrefix(string(k), "bitbucketcloud"):
+		switch k {
+		case ChangesetEventKindBitbucketCloudApproved,
+			ChangesetEventKindBitbucketCloudChangesRequested,
+			ChangesetEventKindBitbucketCloudUnapproved:
+			return new(bitbucketcloud.Participant), nil
+		case ChangesetEventKindBitbucketCloudCommitStatus:
+			return new(bitbucketcloud.PullRequestStatus), nil
+		case ChangesetEventKindBitbucketCloudMerged:
+			return new(bitbucketcloud.PullRequestFulfilledEvent), nil
+		case ChangesetEventKindBitbucketCloudDeclined:
+			return new(bitbucketcloud.PullRequestRejectedEvent), nil
+		}
 	case strings.HasPrefix(string(k), "github"):
 		switch k {
 		case ChangesetEventKindGitHubAssigned:
@@ -1061,6 +1192,8 @@ func NewChangesetEventMetadata(k ChangesetEventKind) (interface{}, error) {
 		case ChangesetEventKindGitLabReopened:
 			return new(gitlab.MergeRequestReopenedEvent), nil
 		}
+	case k == ChangesetEventKindBatchChangesRebased:
+		return new(ChangesetRebase), nil
 	}
 	return nil, errors.Errorf("unknown changeset event kind %q", k)
 }
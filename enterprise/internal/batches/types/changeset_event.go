@@ -9,6 +9,7 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/inconshreveable/log15"
 
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
@@ -69,6 +70,16 @@ const (
 	// clearly convey that it only occurs when a request for changes has been dismissed.
 	ChangesetEventKindBitbucketServerDismissed ChangesetEventKind = "bitbucketserver:participant_status:unapproved"
 
+	ChangesetEventKindBitbucketCloudApproved         ChangesetEventKind = "bitbucketcloud:approved"
+	ChangesetEventKindBitbucketCloudChangesRequested ChangesetEventKind = "bitbucketcloud:changes_requested"
+	ChangesetEventKindBitbucketCloudCommitStatus     ChangesetEventKind = "bitbucketcloud:commit_status"
+	ChangesetEventKindBitbucketCloudDeclined         ChangesetEventKind = "bitbucketcloud:declined"
+	ChangesetEventKindBitbucketCloudMerged           ChangesetEventKind = "bitbucketcloud:merged"
+
+	// Bitbucket Cloud participants that neither approve nor request changes
+	// anymore have no review state, which is what this kind represents.
+	ChangesetEventKindBitbucketCloudUnapproved ChangesetEventKind = "bitbucketcloud:unapproved"
+
 	ChangesetEventKindGitLabApproved             ChangesetEventKind = "gitlab:approved"
 	ChangesetEventKindGitLabClosed               ChangesetEventKind = "gitlab:closed"
 	ChangesetEventKindGitLabMerged               ChangesetEventKind = "gitlab:merged"
@@ -78,6 +89,8 @@ const (
 	ChangesetEventKindGitLabMarkWorkInProgress   ChangesetEventKind = "gitlab:mark_wip"
 	ChangesetEventKindGitLabUnmarkWorkInProgress ChangesetEventKind = "gitlab:unmark_wip"
 
+	ChangesetEventKindBatchChangesRebased ChangesetEventKind = "batchchanges:rebased"
+
 	ChangesetEventKindInvalid ChangesetEventKind = "invalid"
 )
 
@@ -93,6 +106,19 @@ type ChangesetEvent struct {
 	Metadata    interface{}
 }
 
+// ChangesetRebase is the metadata of a ChangesetEventKindBatchChangesRebased
+// event. These events are recorded by the reconciler whenever it re-applies the
+// diff of a changeset on a newer commit of its base branch, keyed by that
+// commit.
+type ChangesetRebase struct {
+	BaseRef string
+	// BaseRev is the commit of the base branch the diff was applied on.
+	BaseRev string
+	// Error is set if the diff couldn't be applied on BaseRev.
+	Error     string `json:",omitempty"`
+	CreatedAt time.Time
+}
+
 // Clone returns a clone of a ChangesetEvent.
 func (e *ChangesetEvent) Clone() *ChangesetEvent {
 	ee := *e
@@ -115,6 +141,9 @@ func (e *ChangesetEvent) ReviewAuthor() string {
 	case *bitbucketserver.ParticipantStatusEvent:
 		return meta.User.Name
 
+	case *bitbucketcloud.Participant:
+		return meta.User.UUID
+
 	case *gitlab.ReviewApprovedEvent:
 		return meta.Author.Username
 
@@ -130,12 +159,14 @@ func (e *ChangesetEvent) ReviewAuthor() string {
 func (e *ChangesetEvent) ReviewState() (ChangesetReviewState, error) {
 	switch e.Kind {
 	case ChangesetEventKindBitbucketServerApproved,
+		ChangesetEventKindBitbucketCloudApproved,
 		ChangesetEventKindGitLabApproved:
 		return ChangesetReviewStateApproved, nil
 
 	// BitbucketServer's "REVIEWED" activity is created when someone clicks
 	// the "Needs work" button in the UI, which is why we map it to "Changes Requested"
-	case ChangesetEventKindBitbucketServerReviewed:
+	case ChangesetEventKindBitbucketServerReviewed,
+		ChangesetEventKindBitbucketCloudChangesRequested:
 		return ChangesetReviewStateChangesRequested, nil
 
 	case ChangesetEventKindGitHubReviewed:
@@ -155,6 +186,7 @@ func (e *ChangesetEvent) ReviewState() (ChangesetReviewState, error) {
 	case ChangesetEventKindGitHubReviewDismissed,
 		ChangesetEventKindBitbucketServerUnapproved,
 		ChangesetEventKindBitbucketServerDismissed,
+		ChangesetEventKindBitbucketCloudUnapproved,
 		ChangesetEventKindGitLabUnapproved:
 		return ChangesetReviewStateDismissed, nil
 
@@ -221,6 +253,14 @@ func (e *ChangesetEvent) Timestamp() time.Time {
 		t = unixMilliToTime(int64(ev.CreatedDate))
 	case *bitbucketserver.CommitStatus:
 		t = unixMilliToTime(ev.Status.DateAdded)
+	case *bitbucketcloud.Participant:
+		t = ev.ParticipatedOn
+	case *bitbucketcloud.PullRequestStatus:
+		t = ev.UpdatedOn
+	case *bitbucketcloud.PullRequestFulfilledEvent:
+		t = ev.PullRequest.UpdatedOn
+	case *bitbucketcloud.PullRequestRejectedEvent:
+		t = ev.PullRequest.UpdatedOn
 	case *gitlab.ReviewApprovedEvent:
 		t = ev.CreatedAt.Time
 	case *gitlab.ReviewUnapprovedEvent:
@@ -235,6 +275,8 @@ func (e *ChangesetEvent) Timestamp() time.Time {
 		t = ev.CreatedAt.Time
 	case *gitlab.MergeRequestMergedEvent:
 		t = ev.CreatedAt.Time
+	case *ChangesetRebase:
+		t = ev.CreatedAt
 	case *gitlabwebhooks.PipelineEvent:
 		// These events do not inherently have timestamps from GitLab, so we
 		// fall back to the event record we created when we received the
@@ -602,6 +644,26 @@ func (e *ChangesetEvent) Update(o *ChangesetEvent) error {
 		// We always get the full event, so safe to replace it
 		*e = *o
 
+	case *bitbucketcloud.Participant:
+		o := o.Metadata.(*bitbucketcloud.Participant)
+		// We always get the full participant, so safe to replace it
+		*e = *o
+
+	case *bitbucketcloud.PullRequestStatus:
+		o := o.Metadata.(*bitbucketcloud.PullRequestStatus)
+		// We always get the full status, so safe to replace it
+		*e = *o
+
+	case *bitbucketcloud.PullRequestFulfilledEvent:
+		o := o.Metadata.(*bitbucketcloud.PullRequestFulfilledEvent)
+		// We always get the full event, so safe to replace it
+		*e = *o
+
+	case *bitbucketcloud.PullRequestRejectedEvent:
+		o := o.Metadata.(*bitbucketcloud.PullRequestRejectedEvent)
+		// We always get the full event, so safe to replace it
+		*e = *o
+
 	case *github.CheckRun:
 		o := o.Metadata.(*github.CheckRun)
 		if e.Status == "" {
@@ -722,6 +784,10 @@ func (e *ChangesetEvent) Update(o *ChangesetEvent) error {
 		// We always get the full event, so safe to replace it
 		*e = *o
 
+	case *ChangesetRebase:
+		o := o.Metadata.(*ChangesetRebase)
+		*e = *o
+
 	default:
 		return errors.Errorf("unknown changeset event metadata %T", e)
 	}
@@ -47,6 +47,7 @@ var (
 	ChangesetJobTypeMerge     ChangesetJobType = "merge"
 	ChangesetJobTypeClose     ChangesetJobType = "close"
 	ChangesetJobTypePublish   ChangesetJobType = "publish"
+	ChangesetJobTypeAutoMerge ChangesetJobType = "automerge"
 )
 
 type ChangesetJobCommentPayload struct {
@@ -67,6 +68,10 @@ type ChangesetJobPublishPayload struct {
 	Draft bool `json:"draft"`
 }
 
+type ChangesetJobAutoMergePayload struct {
+	Squash bool `json:"squash,omitempty"`
+}
+
 // ChangesetJob describes a one-time action to be taken on a changeset.
 type ChangesetJob struct {
 	ID int64
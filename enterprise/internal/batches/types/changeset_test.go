@@ -620,3 +620,116 @@ func TestChangeset_ResetReconcilerState(t *testing.T) {
 		})
 	}
 }
+
+func TestChangeset_NeedsRebase(t *testing.T) {
+	conflictingBBS := &bitbucketserver.PullRequest{}
+	conflictingBBS.Properties.MergeResult.Outcome = bitbucketserver.PullRequestMergeResultConflicted
+	bbsOnBase := &bitbucketserver.PullRequest{ToRef: bitbucketserver.Ref{LatestCommit: "base"}}
+	bbsBehind := &bitbucketserver.PullRequest{ToRef: bitbucketserver.Ref{LatestCommit: "newer"}}
+
+	for name, tc := range map[string]struct {
+		changeset *Changeset
+		baseRev   string
+		want      bool
+	}{
+		"github mergeable": {
+			changeset: &Changeset{
+				ExternalState: ChangesetExternalStateOpen,
+				Metadata:      &github.PullRequest{Mergeable: "MERGEABLE"},
+			},
+			want: false,
+		},
+		"github behind": {
+			changeset: &Changeset{
+				ExternalState: ChangesetExternalStateOpen,
+				Metadata:      &github.PullRequest{Mergeable: "MERGEABLE", MergeStateStatus: "BEHIND"},
+			},
+			want: true,
+		},
+		"github conflicting": {
+			changeset: &Changeset{
+				ExternalState: ChangesetExternalStateOpen,
+				Metadata:      &github.PullRequest{Mergeable: "CONFLICTING"},
+			},
+			want: true,
+		},
+		"github conflicting draft": {
+			changeset: &Changeset{
+				ExternalState: ChangesetExternalStateDraft,
+				Metadata:      &github.PullRequest{Mergeable: "CONFLICTING"},
+			},
+			want: true,
+		},
+		"github conflicting closed": {
+			changeset: &Changeset{
+				ExternalState: ChangesetExternalStateClosed,
+				Metadata:      &github.PullRequest{Mergeable: "CONFLICTING"},
+			},
+			want: false,
+		},
+		"gitlab up to date": {
+			changeset: &Changeset{
+				ExternalState: ChangesetExternalStateOpen,
+				Metadata:      &gitlab.MergeRequest{},
+			},
+			want: false,
+		},
+		"gitlab behind": {
+			changeset: &Changeset{
+				ExternalState: ChangesetExternalStateOpen,
+				Metadata:      &gitlab.MergeRequest{DivergedCommitsCount: 2},
+			},
+			want: true,
+		},
+		"gitlab conflicting": {
+			changeset: &Changeset{
+				ExternalState: ChangesetExternalStateOpen,
+				Metadata:      &gitlab.MergeRequest{HasConflicts: true},
+			},
+			want: true,
+		},
+		"bitbucketserver clean": {
+			changeset: &Changeset{
+				ExternalState: ChangesetExternalStateOpen,
+				Metadata:      &bitbucketserver.PullRequest{},
+			},
+			want: false,
+		},
+		"bitbucketserver conflicting": {
+			changeset: &Changeset{
+				ExternalState: ChangesetExternalStateOpen,
+				Metadata:      conflictingBBS,
+			},
+			want: true,
+		},
+		"bitbucketserver on base": {
+			changeset: &Changeset{
+				ExternalState: ChangesetExternalStateOpen,
+				Metadata:      bbsOnBase,
+			},
+			baseRev: "base",
+			want:    false,
+		},
+		"bitbucketserver behind": {
+			changeset: &Changeset{
+				ExternalState: ChangesetExternalStateOpen,
+				Metadata:      bbsBehind,
+			},
+			baseRev: "base",
+			want:    true,
+		},
+		"bitbucketserver unknown base": {
+			changeset: &Changeset{
+				ExternalState: ChangesetExternalStateOpen,
+				Metadata:      bbsBehind,
+			},
+			want: false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if have := tc.changeset.NeedsRebase(tc.baseRev); have != tc.want {
+				t.Errorf("unexpected result: have=%v want=%v", have, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package window
+
+import (
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// NewAutoMergeConfiguration constructs a Configuration based on the auto-merge
+// windows in the site configuration. Auto-merge windows don't have a rate, so
+// they are handled as unlimited rollout windows.
+func NewAutoMergeConfiguration(raw *[]*schema.BatchChangeAutoMergeWindow) (*Configuration, error) {
+	if raw == nil {
+		return NewConfiguration(nil)
+	}
+
+	rollout := make([]*schema.BatchChangeRolloutWindow, 0, len(*raw))
+	for _, w := range *raw {
+		if w == nil {
+			continue
+		}
+		rollout = append(rollout, &schema.BatchChangeRolloutWindow{
+			Days:  w.Days,
+			End:   w.End,
+			Rate:  "unlimited",
+			Start: w.Start,
+		})
+	}
+
+	return NewConfiguration(&rollout)
+}
+
+// NextOpen returns the earliest time at or after the given time at which one
+// of the windows is open. If no windows are defined, at is returned.
+func (cfg *Configuration) NextOpen(at time.Time) time.Time {
+	if !cfg.HasRolloutWindows() {
+		return at
+	}
+
+	var next *time.Time
+	for i := range cfg.windows {
+		open := cfg.windows[i].NextOpenAfter(at)
+		if next == nil || open.Before(*next) {
+			next = &open
+		}
+	}
+	return *next
+}
@@ -0,0 +1,87 @@
+package window
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestNewAutoMergeConfiguration(t *testing.T) {
+	t.Run("nil", func(t *testing.T) {
+		cfg, err := NewAutoMergeConfiguration(nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cfg.HasRolloutWindows() {
+			t.Error("unexpected windows")
+		}
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		cfg, err := NewAutoMergeConfiguration(&[]*schema.BatchChangeAutoMergeWindow{
+			{Days: []string{"monday"}, Start: "10:00", End: "12:00"},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if have := len(cfg.windows); have != 1 {
+			t.Fatalf("unexpected number of windows: %d", have)
+		}
+		if have := cfg.windows[0].rate; !have.IsUnlimited() {
+			t.Errorf("unexpected rate: %+v", have)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		if _, err := NewAutoMergeConfiguration(&[]*schema.BatchChangeAutoMergeWindow{
+			{Days: []string{"someday"}},
+		}); err == nil {
+			t.Error("unexpected nil error")
+		}
+	})
+}
+
+func TestConfiguration_NextOpen(t *testing.T) {
+	// 2021-09-06 is a Monday.
+	monday := time.Date(2021, 9, 6, 11, 0, 0, 0, time.UTC)
+
+	t.Run("no windows", func(t *testing.T) {
+		cfg := &Configuration{}
+		if have := cfg.NextOpen(monday); have != monday {
+			t.Errorf("unexpected next open: have=%v want=%v", have, monday)
+		}
+	})
+
+	cfg, err := NewAutoMergeConfiguration(&[]*schema.BatchChangeAutoMergeWindow{
+		{Days: []string{"wednesday"}, Start: "09:00", End: "17:00"},
+		{Days: []string{"monday"}, Start: "10:00", End: "12:00"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		at   time.Time
+		want time.Time
+	}{
+		"open": {
+			at:   monday,
+			want: monday,
+		},
+		"later the same week": {
+			at:   monday.Add(2 * time.Hour),
+			want: time.Date(2021, 9, 8, 9, 0, 0, 0, time.UTC),
+		},
+		"next week": {
+			at:   time.Date(2021, 9, 8, 18, 0, 0, 0, time.UTC),
+			want: time.Date(2021, 9, 13, 10, 0, 0, 0, time.UTC),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if have := cfg.NextOpen(tc.at); have != tc.want {
+				t.Errorf("unexpected next open: have=%v want=%v", have, tc.want)
+			}
+		})
+	}
+}
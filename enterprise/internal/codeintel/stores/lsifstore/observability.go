@@ -14,11 +14,13 @@ type operations struct {
 	diagnostics                   *observation.Operation
 	exists                        *observation.Operation
 	hover                         *observation.Operation
+	implementations               *observation.Operation
 	monikerResults                *observation.Operation
 	monikersByPosition            *observation.Operation
 	packageInformation            *observation.Operation
 	ranges                        *observation.Operation
 	references                    *observation.Operation
+	typeDefinitions               *observation.Operation
 	documentationPage             *observation.Operation
 	documentationPathInfo         *observation.Operation
 	documentationIDsToPathIDs     *observation.Operation
@@ -29,6 +31,7 @@ type operations struct {
 	documentationAtPosition       *observation.Operation
 	writeDefinitions              *observation.Operation
 	writeDocuments                *observation.Operation
+	writeImplementations          *observation.Operation
 	writeMeta                     *observation.Operation
 	writeReferences               *observation.Operation
 	writeResultChunks             *observation.Operation
@@ -73,11 +76,13 @@ func newOperations(observationContext *observation.Context) *operations {
 		diagnostics:                   op("Diagnostics"),
 		exists:                        op("Exists"),
 		hover:                         op("Hover"),
+		implementations:               op("Implementations"),
 		monikerResults:                op("MonikerResults"),
 		monikersByPosition:            op("MonikersByPosition"),
 		packageInformation:            op("PackageInformation"),
 		ranges:                        op("Ranges"),
 		references:                    op("References"),
+		typeDefinitions:               op("TypeDefinitions"),
 		documentationPage:             op("DocumentationPage"),
 		documentationPathInfo:         op("DocumentationPathInfo"),
 		documentationIDsToPathIDs:     op("DocumentationIDsToPathIDs"),
@@ -88,6 +93,7 @@ func newOperations(observationContext *observation.Context) *operations {
 		documentationAtPosition:       op("DocumentationAtPosition"),
 		writeDefinitions:              op("WriteDefinitions"),
 		writeDocuments:                op("WriteDocuments"),
+		writeImplementations:          op("WriteImplementations"),
 		writeMeta:                     op("WriteMeta"),
 		writeReferences:               op("WriteReferences"),
 		writeResultChunks:             op("WriteResultChunks"),
@@ -23,15 +23,17 @@ func TestDocumentData(t *testing.T) {
 				MonikerIDs:            nil,
 			},
 			precise.ID("8265"): {
-				StartLine:             266,
-				StartCharacter:        10,
-				EndLine:               266,
-				EndCharacter:          16,
-				DefinitionResultID:    precise.ID("311"),
-				ReferenceResultID:     precise.ID("15500"),
-				HoverResultID:         precise.ID("317"),
-				DocumentationResultID: precise.ID("3372"),
-				MonikerIDs:            []precise.ID{precise.ID("314")},
+				StartLine:              266,
+				StartCharacter:         10,
+				EndLine:                266,
+				EndCharacter:           16,
+				DefinitionResultID:     precise.ID("311"),
+				ReferenceResultID:      precise.ID("15500"),
+				HoverResultID:          precise.ID("317"),
+				DocumentationResultID:  precise.ID("3372"),
+				ImplementationResultID: precise.ID("15512"),
+				TypeDefinitionResultID: precise.ID("15513"),
+				MonikerIDs:             []precise.ID{precise.ID("314")},
 			},
 		},
 		HoverResults: map[precise.ID]string{
@@ -0,0 +1,287 @@
+package uploadstore
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/hashicorp/go-multierror"
+	"github.com/inconshreveable/log15"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+type blobstoreStore struct {
+	dir          string
+	ttl          time.Duration
+	manageBucket bool
+	operations   *operations
+	expireOnce   sync.Once
+	expirer      *goroutine.PeriodicGoroutine
+}
+
+var _ Store = &blobstoreStore{}
+
+type BlobstoreConfig struct {
+	Dir string
+}
+
+func (c *BlobstoreConfig) load(parent *env.BaseConfig) {
+	c.Dir = parent.Get("PRECISE_CODE_INTEL_UPLOAD_BLOBSTORE_DIR", "/data/uploadstore", "The local directory in which the bucket directory is created.")
+}
+
+// blobstoreExpirationInterval is the interval at which expired objects are removed from disk.
+const blobstoreExpirationInterval = time.Hour
+
+// newBlobstoreFromConfig creates a new store backed by a directory on the local filesystem.
+func newBlobstoreFromConfig(ctx context.Context, config *Config, operations *operations) (Store, error) {
+	return newBlobstoreWithDir(filepath.Join(config.Blobstore.Dir, config.Bucket), config.TTL, config.ManageBucket, operations), nil
+}
+
+func newBlobstoreWithDir(dir string, ttl time.Duration, manageBucket bool, operations *operations) *blobstoreStore {
+	return &blobstoreStore{
+		dir:          dir,
+		ttl:          ttl,
+		manageBucket: manageBucket,
+		operations:   operations,
+	}
+}
+
+// Init creates the bucket directory if it does not exist and the bucket is managed by this
+// client. Files on disk do not have a lifecycle configuration, so Init also removes expired
+// objects and periodically does so in the background until the process exits, whether or not
+// the bucket is managed by this client.
+func (s *blobstoreStore) Init(ctx context.Context) error {
+	if s.manageBucket {
+		if err := os.MkdirAll(s.dir, os.ModePerm); err != nil {
+			return errors.Wrap(err, "failed to create bucket")
+		}
+	}
+
+	if err := s.expire(); err != nil {
+		return errors.Wrap(err, "failed to remove expired objects")
+	}
+
+	s.expireOnce.Do(func() {
+		// The given context may be scoped to a single request, so the background routine is
+		// detached from it
+		s.expirer = goroutine.NewPeriodicGoroutine(context.Background(), blobstoreExpirationInterval, goroutine.NewHandlerWithErrorMessage(
+			"blobstore expirer",
+			func(ctx context.Context) error { return s.expire() },
+		))
+		goroutine.Go(s.expirer.Start)
+	})
+
+	return nil
+}
+
+func (s *blobstoreStore) Get(ctx context.Context, key string) (_ io.ReadCloser, err error) {
+	ctx, endObservation := s.operations.get.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("key", key),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get object")
+	}
+
+	return f, nil
+}
+
+func (s *blobstoreStore) Upload(ctx context.Context, key string, r io.Reader) (_ int64, err error) {
+	ctx, endObservation := s.operations.upload.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("key", key),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	path, err := s.path(key)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := s.writeAtomically(path, func(w io.Writer) (int64, error) {
+		return io.Copy(w, r)
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to upload object")
+	}
+
+	return n, nil
+}
+
+func (s *blobstoreStore) Compose(ctx context.Context, destination string, sources ...string) (_ int64, err error) {
+	ctx, endObservation := s.operations.compose.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("destination", destination),
+		log.String("sources", strings.Join(sources, ", ")),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	destinationPath, err := s.path(destination)
+	if err != nil {
+		return 0, err
+	}
+
+	sourcePaths := make([]string, 0, len(sources))
+	for _, source := range sources {
+		sourcePath, err := s.path(source)
+		if err != nil {
+			return 0, err
+		}
+		sourcePaths = append(sourcePaths, sourcePath)
+	}
+
+	defer func() {
+		if err == nil {
+			// Delete sources on success
+			if err := s.deleteSources(sourcePaths); err != nil {
+				log15.Error("Failed to delete source objects", "error", err)
+			}
+		}
+	}()
+
+	n, err := s.writeAtomically(destinationPath, func(w io.Writer) (int64, error) {
+		var total int64
+		for _, sourcePath := range sourcePaths {
+			n, err := copyFile(w, sourcePath)
+			if err != nil {
+				return 0, err
+			}
+			total += n
+		}
+
+		return total, nil
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to compose objects")
+	}
+
+	return n, nil
+}
+
+func (s *blobstoreStore) Delete(ctx context.Context, key string) (err error) {
+	ctx, endObservation := s.operations.delete.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("key", key),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "failed to delete object")
+	}
+
+	return nil
+}
+
+// path returns the path on disk of the object with the given key. Keys must name a file
+// directly within the bucket directory.
+func (s *blobstoreStore) path(key string) (string, error) {
+	if key == "" || key == "." || key == ".." || strings.ContainsAny(key, `/\`) {
+		return "", errors.Errorf("invalid object key %q", key)
+	}
+
+	return filepath.Join(s.dir, key), nil
+}
+
+// writeAtomically invokes the given function with a writer to a temporary file in the bucket
+// directory, then moves the temporary file to the given path. Readers of the target path will
+// never observe a partially written object.
+func (s *blobstoreStore) writeAtomically(path string, fn func(w io.Writer) (int64, error)) (_ int64, err error) {
+	f, err := os.CreateTemp(s.dir, ".tmp-*")
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(f.Name())
+		}
+	}()
+
+	n, err := fn(f)
+	if closeErr := f.Close(); closeErr != nil {
+		err = multierror.Append(err, errors.Wrap(closeErr, "failed to close writer"))
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if err := os.Rename(f.Name(), path); err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
+
+// expire removes all objects from the bucket directory that were last written before the TTL.
+func (s *blobstoreStore) expire() error {
+	if s.ttl <= 0 {
+		return nil
+	}
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			// An unmanaged bucket may not have been created yet
+			return nil
+		}
+		return err
+	}
+
+	var paths []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return err
+		}
+
+		if time.Since(info.ModTime()) > s.ttl {
+			paths = append(paths, filepath.Join(s.dir, entry.Name()))
+		}
+	}
+
+	return s.deleteSources(paths)
+}
+
+func (s *blobstoreStore) deleteSources(paths []string) error {
+	return goroutine.RunWorkersOverStrings(paths, func(index int, path string) error {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return errors.Wrap(err, "failed to delete source object")
+		}
+
+		return nil
+	})
+}
+
+func copyFile(w io.Writer, path string) (int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	return io.Copy(w, f)
+}
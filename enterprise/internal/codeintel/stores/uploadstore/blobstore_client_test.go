@@ -0,0 +1,154 @@
+package uploadstore
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestBlobstoreInit(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "test-bucket")
+	client := newBlobstoreWithDir(dir, time.Hour*24*3, true, newOperations(&observation.TestContext))
+	if err := client.Init(context.Background()); err != nil {
+		t.Fatalf("unexpected error initializing client: %s", err)
+	}
+	client.expirer.Stop()
+
+	if info, err := os.Stat(dir); err != nil {
+		t.Fatalf("unexpected error reading bucket directory: %s", err)
+	} else if !info.IsDir() {
+		t.Errorf("expected bucket to be a directory")
+	}
+}
+
+func TestBlobstoreInitExpiresObjects(t *testing.T) {
+	dir := t.TempDir()
+	writeTestObject(t, dir, "expired", "old", time.Now().Add(-time.Hour*24*4))
+	writeTestObject(t, dir, "fresh", "new", time.Now())
+
+	for _, manageBucket := range []bool{true, false} {
+		client := newBlobstoreWithDir(dir, time.Hour*24*3, manageBucket, newOperations(&observation.TestContext))
+		if err := client.Init(context.Background()); err != nil {
+			t.Fatalf("unexpected error initializing client: %s", err)
+		}
+		client.expirer.Stop()
+
+		if _, err := os.Stat(filepath.Join(dir, "expired")); !os.IsNotExist(err) {
+			t.Errorf("expected expired object to be removed (manageBucket=%v)", manageBucket)
+		}
+		if _, err := os.Stat(filepath.Join(dir, "fresh")); err != nil {
+			t.Errorf("unexpected error reading fresh object: %s", err)
+		}
+
+		writeTestObject(t, dir, "expired", "old", time.Now().Add(-time.Hour*24*4))
+	}
+}
+
+func TestBlobstoreUploadGet(t *testing.T) {
+	client := testBlobstoreClient(t.TempDir(), true)
+
+	size, err := client.Upload(context.Background(), "test-key", strings.NewReader("TEST PAYLOAD"))
+	if err != nil {
+		t.Fatalf("unexpected error uploading object: %s", err)
+	}
+	if size != 12 {
+		t.Errorf("unexpected size. want=%d have=%d", 12, size)
+	}
+
+	if contents := readTestObject(t, client, "test-key"); contents != "TEST PAYLOAD" {
+		t.Errorf("unexpected contents. want=%s have=%s", "TEST PAYLOAD", contents)
+	}
+}
+
+func TestBlobstoreCompose(t *testing.T) {
+	dir := t.TempDir()
+	client := testBlobstoreClient(dir, true)
+
+	for _, key := range []string{"test-src1", "test-src2", "test-src3"} {
+		if _, err := client.Upload(context.Background(), key, strings.NewReader(key+";")); err != nil {
+			t.Fatalf("unexpected error uploading object: %s", err)
+		}
+	}
+
+	size, err := client.Compose(context.Background(), "test-key", "test-src1", "test-src2", "test-src3")
+	if err != nil {
+		t.Fatalf("unexpected error composing objects: %s", err)
+	}
+	if size != 30 {
+		t.Errorf("unexpected size. want=%d have=%d", 30, size)
+	}
+
+	if contents := readTestObject(t, client, "test-key"); contents != "test-src1;test-src2;test-src3;" {
+		t.Errorf("unexpected contents. want=%s have=%s", "test-src1;test-src2;test-src3;", contents)
+	}
+
+	for _, key := range []string{"test-src1", "test-src2", "test-src3"} {
+		if _, err := os.Stat(filepath.Join(dir, key)); !os.IsNotExist(err) {
+			t.Errorf("expected source object %s to be removed", key)
+		}
+	}
+}
+
+func TestBlobstoreDelete(t *testing.T) {
+	dir := t.TempDir()
+	client := testBlobstoreClient(dir, true)
+
+	if _, err := client.Upload(context.Background(), "test-key", strings.NewReader("TEST PAYLOAD")); err != nil {
+		t.Fatalf("unexpected error uploading object: %s", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := client.Delete(context.Background(), "test-key"); err != nil {
+			t.Fatalf("unexpected error deleting object: %s", err)
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "test-key")); !os.IsNotExist(err) {
+		t.Errorf("expected object to be removed")
+	}
+}
+
+func TestBlobstoreInvalidKey(t *testing.T) {
+	client := testBlobstoreClient(t.TempDir(), true)
+
+	for _, key := range []string{"", "..", "../test-key", "nested/test-key"} {
+		if _, err := client.Upload(context.Background(), key, strings.NewReader("TEST PAYLOAD")); err == nil {
+			t.Errorf("expected error uploading object with key %q", key)
+		}
+	}
+}
+
+func testBlobstoreClient(dir string, manageBucket bool) Store {
+	return newLazyStore(newBlobstoreWithDir(dir, time.Hour*24*3, manageBucket, newOperations(&observation.TestContext)))
+}
+
+func writeTestObject(t *testing.T, dir, key, contents string, modTime time.Time) {
+	path := filepath.Join(dir, key)
+	if err := os.WriteFile(path, []byte(contents), os.ModePerm); err != nil {
+		t.Fatalf("unexpected error writing object: %s", err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatalf("unexpected error setting object modification time: %s", err)
+	}
+}
+
+func readTestObject(t *testing.T, client Store, key string) string {
+	rc, err := client.Get(context.Background(), key)
+	if err != nil {
+		t.Fatalf("unexpected error getting object: %s", err)
+	}
+	defer rc.Close()
+
+	contents, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("unexpected error reading object: %s", err)
+	}
+
+	return string(contents)
+}
@@ -189,6 +189,33 @@ func TestConfigGCS(t *testing.T) {
 	}
 }
 
+func TestConfigBlobstore(t *testing.T) {
+	env := map[string]string{
+		"PRECISE_CODE_INTEL_UPLOAD_BACKEND":       "Blobstore",
+		"PRECISE_CODE_INTEL_UPLOAD_BUCKET":        "lsif-uploads",
+		"PRECISE_CODE_INTEL_UPLOAD_TTL":           "8h",
+		"PRECISE_CODE_INTEL_UPLOAD_BLOBSTORE_DIR": "/test/dir",
+	}
+
+	config := Config{}
+	config.SetMockGetter(mapGetter(env))
+	config.Load()
+
+	if err := config.Validate(); err != nil {
+		t.Fatalf("unexpected validation error: %s", err)
+	}
+
+	if !config.ManageBucket {
+		t.Errorf("expected Blobstore bucket to be managed")
+	}
+	if config.TTL != 8*time.Hour {
+		t.Errorf("unexpected value for Blobstore.TTL. want=%v have=%v", 8*time.Hour, config.TTL)
+	}
+	if config.Blobstore.Dir != "/test/dir" {
+		t.Errorf("unexpected value for Blobstore.Dir. want=%s have=%s", "/test/dir", config.Blobstore.Dir)
+	}
+}
+
 func mapGetter(env map[string]string) func(name, defaultValue, description string) string {
 	return func(name, defaultValue, description string) string {
 		if v, ok := env[name]; ok {
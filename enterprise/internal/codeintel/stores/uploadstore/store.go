@@ -31,9 +31,10 @@ type Store interface {
 }
 
 var storeConstructors = map[string]func(ctx context.Context, config *Config, operations *operations) (Store, error){
-	"s3":    newS3FromConfig,
-	"minio": newS3FromConfig,
-	"gcs":   newGCSFromConfig,
+	"s3":        newS3FromConfig,
+	"minio":     newS3FromConfig,
+	"gcs":       newGCSFromConfig,
+	"blobstore": newBlobstoreFromConfig,
 }
 
 // CreateLazy initialize a new store from the given configuration that is initialized
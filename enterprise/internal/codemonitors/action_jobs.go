@@ -3,6 +3,7 @@ package codemonitors
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/cockroachdb/errors"
@@ -15,9 +16,13 @@ import (
 
 type ActionJob struct {
 	Id           int
-	Email        int64
 	TriggerEvent int
 
+	// Exactly one of Email, Webhook and SlackWebhook is set.
+	Email        *int64
+	Webhook      *int64
+	SlackWebhook *int64
+
 	// Fields demanded by any dbworker.
 	State          string
 	FailureMessage *string
@@ -40,11 +45,17 @@ type ActionJobMetadata struct {
 
 	// The query with after: filter.
 	Query string
+
+	// The search results captured by the trigger job, capped at the limit
+	// configured when the trigger job ran.
+	Results []*SearchResult
 }
 
 var ActionJobsColumns = []*sqlf.Query{
 	sqlf.Sprintf("cm_action_jobs.id"),
 	sqlf.Sprintf("cm_action_jobs.email"),
+	sqlf.Sprintf("cm_action_jobs.webhook"),
+	sqlf.Sprintf("cm_action_jobs.slack_webhook"),
 	sqlf.Sprintf("cm_action_jobs.trigger_event"),
 	sqlf.Sprintf("cm_action_jobs.state"),
 	sqlf.Sprintf("cm_action_jobs.failure_message"),
@@ -56,8 +67,8 @@ var ActionJobsColumns = []*sqlf.Query{
 	sqlf.Sprintf("cm_action_jobs.log_contents"),
 }
 
-const readActionEmailEventsFmtStr = `
-SELECT id, email, trigger_event, state, failure_message, started_at, finished_at, process_after, num_resets, num_failures, log_contents
+const readActionEventsFmtStr = `
+SELECT id, email, webhook, slack_webhook, trigger_event, state, failure_message, started_at, finished_at, process_after, num_resets, num_failures, log_contents
 FROM cm_action_jobs
 WHERE %s
 AND id > %s
@@ -66,18 +77,24 @@ LIMIT %s;
 `
 
 func (s *Store) ReadActionEmailEvents(ctx context.Context, emailID int64, triggerEventID *int, args *graphqlbackend.ListEventsArgs) (js []*ActionJob, err error) {
-	var where *sqlf.Query
-	if triggerEventID == nil {
-		where = sqlf.Sprintf("email = %s", emailID)
-	} else {
-		where = sqlf.Sprintf("email = %s AND trigger_event = %s", emailID, *triggerEventID)
-	}
+	return s.readActionEvents(ctx, actionEventsWhere("email", emailID, triggerEventID), args)
+}
+
+func (s *Store) ReadActionWebhookEvents(ctx context.Context, webhookID int64, triggerEventID *int, args *graphqlbackend.ListEventsArgs) (js []*ActionJob, err error) {
+	return s.readActionEvents(ctx, actionEventsWhere("webhook", webhookID, triggerEventID), args)
+}
+
+func (s *Store) ReadActionSlackWebhookEvents(ctx context.Context, slackWebhookID int64, triggerEventID *int, args *graphqlbackend.ListEventsArgs) (js []*ActionJob, err error) {
+	return s.readActionEvents(ctx, actionEventsWhere("slack_webhook", slackWebhookID, triggerEventID), args)
+}
+
+func (s *Store) readActionEvents(ctx context.Context, where *sqlf.Query, args *graphqlbackend.ListEventsArgs) (js []*ActionJob, err error) {
 	var rows *sql.Rows
 	after, err := unmarshalAfter(args.After)
 	if err != nil {
 		return nil, err
 	}
-	rows, err = s.Query(ctx, sqlf.Sprintf(readActionEmailEventsFmtStr, where, after, args.First))
+	rows, err = s.Query(ctx, sqlf.Sprintf(readActionEventsFmtStr, where, after, args.First))
 	if err != nil {
 		return nil, err
 	}
@@ -85,26 +102,42 @@ func (s *Store) ReadActionEmailEvents(ctx context.Context, emailID int64, trigge
 	return scanActionJobs(rows, err)
 }
 
-const totalActionEmailEventsFmtStr = `
+const totalActionEventsFmtStr = `
 SELECT COUNT(*)
 FROM cm_action_jobs
 WHERE %s
 `
 
 func (s *Store) TotalActionEmailEvents(ctx context.Context, emailID int64, triggerEventID *int) (totalCount int32, err error) {
-	var where *sqlf.Query
-	if triggerEventID == nil {
-		where = sqlf.Sprintf("email = %s", emailID)
-	} else {
-		where = sqlf.Sprintf("email = %s AND trigger_event = %s", emailID, *triggerEventID)
-	}
-	err = s.QueryRow(ctx, sqlf.Sprintf(totalActionEmailEventsFmtStr, where)).Scan(&totalCount)
+	return s.totalActionEvents(ctx, actionEventsWhere("email", emailID, triggerEventID))
+}
+
+func (s *Store) TotalActionWebhookEvents(ctx context.Context, webhookID int64, triggerEventID *int) (totalCount int32, err error) {
+	return s.totalActionEvents(ctx, actionEventsWhere("webhook", webhookID, triggerEventID))
+}
+
+func (s *Store) TotalActionSlackWebhookEvents(ctx context.Context, slackWebhookID int64, triggerEventID *int) (totalCount int32, err error) {
+	return s.totalActionEvents(ctx, actionEventsWhere("slack_webhook", slackWebhookID, triggerEventID))
+}
+
+func (s *Store) totalActionEvents(ctx context.Context, where *sqlf.Query) (totalCount int32, err error) {
+	err = s.QueryRow(ctx, sqlf.Sprintf(totalActionEventsFmtStr, where)).Scan(&totalCount)
 	if err != nil {
 		return -1, err
 	}
 	return totalCount, nil
 }
 
+// actionEventsWhere returns the condition selecting the action jobs of the
+// action with the given ID. column must be one of the action columns of
+// cm_action_jobs.
+func actionEventsWhere(column string, actionID int64, triggerEventID *int) *sqlf.Query {
+	if triggerEventID == nil {
+		return sqlf.Sprintf(column+" = %s", actionID)
+	}
+	return sqlf.Sprintf(column+" = %s AND trigger_event = %s", actionID, *triggerEventID)
+}
+
 const enqueueActionEmailFmtStr = `
 WITH due AS (
 	SELECT e.id, e.monitor, e.enabled, e.priority, e.header, e.created_by, e.created_at, e.changed_by, e.changed_at
@@ -113,8 +146,8 @@ WITH due AS (
 ),
 busy AS (
     SELECT DISTINCT email as id FROM cm_action_jobs
-    WHERE state = 'queued'
-    OR state = 'processing'
+    WHERE email IS NOT NULL
+    AND (state = 'queued' OR state = 'processing')
 )
 INSERT INTO cm_action_jobs (email, trigger_event)
 SELECT id, %s::integer from due EXCEPT SELECT id, %s::integer from busy ORDER BY id
@@ -124,8 +157,72 @@ func (s *Store) EnqueueActionEmailsForQueryIDInt64(ctx context.Context, queryID
 	return s.Store.Exec(ctx, sqlf.Sprintf(enqueueActionEmailFmtStr, queryID, triggerEventID, triggerEventID))
 }
 
+const enqueueActionWebhookFmtStr = `
+WITH due AS (
+	SELECT w.id
+	FROM cm_webhooks w INNER JOIN cm_queries q ON w.monitor = q.monitor
+	WHERE q.id = %s AND w.enabled = true
+),
+busy AS (
+    SELECT DISTINCT webhook as id FROM cm_action_jobs
+    WHERE webhook IS NOT NULL
+    AND (state = 'queued' OR state = 'processing')
+)
+INSERT INTO cm_action_jobs (webhook, trigger_event)
+SELECT id, %s::integer from due EXCEPT SELECT id, %s::integer from busy ORDER BY id
+`
+
+func (s *Store) EnqueueActionWebhooksForQueryIDInt64(ctx context.Context, queryID int64, triggerEventID int) (err error) {
+	return s.Store.Exec(ctx, sqlf.Sprintf(enqueueActionWebhookFmtStr, queryID, triggerEventID, triggerEventID))
+}
+
+const enqueueActionSlackWebhookFmtStr = `
+WITH due AS (
+	SELECT w.id
+	FROM cm_slack_webhooks w INNER JOIN cm_queries q ON w.monitor = q.monitor
+	WHERE q.id = %s AND w.enabled = true
+),
+busy AS (
+    SELECT DISTINCT slack_webhook as id FROM cm_action_jobs
+    WHERE slack_webhook IS NOT NULL
+    AND (state = 'queued' OR state = 'processing')
+)
+INSERT INTO cm_action_jobs (slack_webhook, trigger_event)
+SELECT id, %s::integer from due EXCEPT SELECT id, %s::integer from busy ORDER BY id
+`
+
+func (s *Store) EnqueueActionSlackWebhooksForQueryIDInt64(ctx context.Context, queryID int64, triggerEventID int) (err error) {
+	return s.Store.Exec(ctx, sqlf.Sprintf(enqueueActionSlackWebhookFmtStr, queryID, triggerEventID, triggerEventID))
+}
+
+// EnqueueActionJobsForQueryIDInt64 enqueues an action job for every enabled
+// action (email, webhook and Slack webhook) of the monitor the query belongs to.
+func (s *Store) EnqueueActionJobsForQueryIDInt64(ctx context.Context, queryID int64, triggerEventID int) (err error) {
+	// Enqueue the jobs of all actions, or none of them.
+	var txStore *Store
+	txStore, err = s.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = txStore.Done(err) }()
+
+	err = txStore.EnqueueActionEmailsForQueryIDInt64(ctx, queryID, triggerEventID)
+	if err != nil {
+		return errors.Errorf("EnqueueActionEmailsForQueryIDInt64: %w", err)
+	}
+	err = txStore.EnqueueActionWebhooksForQueryIDInt64(ctx, queryID, triggerEventID)
+	if err != nil {
+		return errors.Errorf("EnqueueActionWebhooksForQueryIDInt64: %w", err)
+	}
+	err = txStore.EnqueueActionSlackWebhooksForQueryIDInt64(ctx, queryID, triggerEventID)
+	if err != nil {
+		return errors.Errorf("EnqueueActionSlackWebhooksForQueryIDInt64: %w", err)
+	}
+	return nil
+}
+
 const getActionJobMetadataFmtStr = `
-select cm.description, ctj.query_string, cm.id as monitorID, ctj.num_results from
+select cm.description, ctj.query_string, cm.id as monitorID, ctj.num_results, ctj.search_results from
 cm_action_jobs caj
 inner join cm_trigger_jobs ctj on caj.trigger_event = ctj.id
 inner join cm_queries cq on cq.id = ctj.query
@@ -136,15 +233,21 @@ where caj.id = %s
 func (s *Store) GetActionJobMetadata(ctx context.Context, recordID int) (m *ActionJobMetadata, err error) {
 	row := s.Store.QueryRow(ctx, sqlf.Sprintf(getActionJobMetadataFmtStr, recordID))
 	m = &ActionJobMetadata{}
-	err = row.Scan(&m.Description, &m.Query, &m.MonitorID, &m.NumResults)
+	var results []byte
+	err = row.Scan(&m.Description, &m.Query, &m.MonitorID, &m.NumResults, &results)
 	if err != nil {
 		return nil, err
 	}
+	if len(results) > 0 {
+		if err = json.Unmarshal(results, &m.Results); err != nil {
+			return nil, err
+		}
+	}
 	return m, nil
 }
 
 const actionJobForIDFmtStr = `
-SELECT id, email, trigger_event, state, failure_message, started_at, finished_at, process_after, num_resets, num_failures, log_contents
+SELECT id, email, webhook, slack_webhook, trigger_event, state, failure_message, started_at, finished_at, process_after, num_resets, num_failures, log_contents
 FROM cm_action_jobs
 WHERE id = %s
 `
@@ -190,6 +293,8 @@ func scanActionJobs(rows *sql.Rows, err error) ([]*ActionJob, error) {
 		if err := rows.Scan(
 			&aj.Id,
 			&aj.Email,
+			&aj.Webhook,
+			&aj.SlackWebhook,
 			&aj.TriggerEvent,
 			&aj.State,
 			&aj.FailureMessage,
@@ -7,6 +7,8 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 )
 
 func TestEnqueueActionEmailsForQueryIDInt64QueryByRecordID(t *testing.T) {
@@ -35,9 +37,10 @@ func TestEnqueueActionEmailsForQueryIDInt64QueryByRecordID(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	var wantEmail int64 = 1
 	want := &ActionJob{
 		Id:             1,
-		Email:          1,
+		Email:          &wantEmail,
 		TriggerEvent:   1,
 		State:          "queued",
 		FailureMessage: nil,
@@ -53,6 +56,54 @@ func TestEnqueueActionEmailsForQueryIDInt64QueryByRecordID(t *testing.T) {
 	}
 }
 
+func TestEnqueueActionJobsForQueryIDInt64(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx, s := newTestStore(t)
+	_, _, _, userCTX := newTestUser(ctx, t)
+	m, err := s.insertTestMonitor(userCTX, t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.CreateActions(userCTX, []*graphqlbackend.CreateActionArgs{
+		{Webhook: &graphqlbackend.CreateActionWebhookArgs{Enabled: true, URL: "https://example.com/hook"}},
+		{Webhook: &graphqlbackend.CreateActionWebhookArgs{Enabled: false, URL: "https://example.com/disabled"}},
+		{SlackWebhook: &graphqlbackend.CreateActionSlackWebhookArgs{Enabled: true, URL: "https://hooks.slack.com/services/test"}},
+	}, m.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.EnqueueTriggerQueries(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.EnqueueActionJobsForQueryIDInt64(ctx, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var emails, webhooks, slackWebhooks int
+	for id := 1; id <= 4; id++ {
+		j, err := s.ActionJobForIDInt(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		switch {
+		case j.Email != nil:
+			emails++
+		case j.Webhook != nil:
+			webhooks++
+		case j.SlackWebhook != nil:
+			slackWebhooks++
+		}
+	}
+	if emails != 2 || webhooks != 1 || slackWebhooks != 1 {
+		t.Fatalf("unexpected jobs: got %d emails, %d webhooks and %d slack webhooks", emails, webhooks, slackWebhooks)
+	}
+}
+
 func TestGetActionJobMetadata(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
@@ -78,6 +129,19 @@ func TestGetActionJobMetadata(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
+	authorDate := s.Now().UTC()
+	wantResults := []*SearchResult{{
+		Repository:  "github.com/sourcegraph/sourcegraph",
+		Commit:      "deadbeef",
+		Author:      "Jane Doe",
+		AuthorDate:  &authorDate,
+		Message:     "Add func",
+		DiffExcerpt: "+func main() {}",
+	}}
+	err = s.LogSearchResults(ctx, 1, wantResults)
+	if err != nil {
+		t.Fatal(err)
+	}
 	err = s.EnqueueActionEmailsForQueryIDInt64(ctx, 1, 1)
 	if err != nil {
 		t.Fatal(err)
@@ -92,6 +156,7 @@ func TestGetActionJobMetadata(t *testing.T) {
 		Query:       wantQuery,
 		NumResults:  &wantNumResults,
 		MonitorID:   wantMonitorID,
+		Results:     wantResults,
 	}
 	if diff := cmp.Diff(got, want); diff != "" {
 		t.Fatalf("diff: %s", diff)
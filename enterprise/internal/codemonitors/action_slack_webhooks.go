@@ -0,0 +1,212 @@
+package codemonitors
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+type MonitorSlackWebhook struct {
+	Id        int64
+	Monitor   int64
+	Enabled   bool
+	URL       string
+	CreatedBy int32
+	CreatedAt time.Time
+	ChangedBy int32
+	ChangedAt time.Time
+}
+
+func (s *Store) UpdateActionSlackWebhook(ctx context.Context, monitorID int64, action *graphqlbackend.EditActionArgs) (*MonitorSlackWebhook, error) {
+	q, err := s.updateActionSlackWebhookQuery(ctx, monitorID, action.SlackWebhook)
+	if err != nil {
+		return nil, err
+	}
+	return s.runSlackWebhookQuery(ctx, q)
+}
+
+func (s *Store) CreateActionSlackWebhook(ctx context.Context, monitorID int64, action *graphqlbackend.CreateActionArgs) (*MonitorSlackWebhook, error) {
+	return s.runSlackWebhookQuery(ctx, s.createActionSlackWebhookQuery(ctx, monitorID, action.SlackWebhook))
+}
+
+const deleteActionSlackWebhookFmtStr = `DELETE FROM cm_slack_webhooks WHERE id in (%s) AND MONITOR = %s`
+
+func (s *Store) DeleteActionSlackWebhooksInt64(ctx context.Context, actionIDs []int64, monitorID int64) error {
+	if len(actionIDs) == 0 {
+		return nil
+	}
+	return s.Exec(ctx, sqlf.Sprintf(deleteActionSlackWebhookFmtStr, idsToQuery(actionIDs), monitorID))
+}
+
+const totalCountActionSlackWebhooksFmtStr = `
+SELECT COUNT(*)
+FROM cm_slack_webhooks
+WHERE monitor = %s;
+`
+
+func (s *Store) TotalCountActionSlackWebhooks(ctx context.Context, monitorID int64) (count int32, err error) {
+	err = s.QueryRow(ctx, sqlf.Sprintf(totalCountActionSlackWebhooksFmtStr, monitorID)).Scan(&count)
+	return count, err
+}
+
+const actionSlackWebhookByIDFmtStr = `
+SELECT %s
+FROM cm_slack_webhooks
+WHERE id = %s
+`
+
+func (s *Store) ActionSlackWebhookByIDInt64(ctx context.Context, slackWebhookID int64) (*MonitorSlackWebhook, error) {
+	return s.runSlackWebhookQuery(ctx, sqlf.Sprintf(actionSlackWebhookByIDFmtStr, sqlf.Join(SlackWebhooksColumns, ", "), slackWebhookID))
+}
+
+const readActionSlackWebhookFmtStr = `
+SELECT %s
+FROM cm_slack_webhooks
+WHERE monitor = %s
+AND id > %s
+ORDER BY id ASC
+LIMIT %s;
+`
+
+// ListActionSlackWebhooks returns a page of the Slack webhook actions of the
+// monitor with the given ID.
+func (s *Store) ListActionSlackWebhooks(ctx context.Context, monitorID int64, args *graphqlbackend.ListActionArgs) ([]*MonitorSlackWebhook, error) {
+	after, err := unmarshalAfter(args.After)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.Query(ctx, sqlf.Sprintf(
+		readActionSlackWebhookFmtStr,
+		sqlf.Join(SlackWebhooksColumns, ", "),
+		monitorID,
+		after,
+		args.First,
+	))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return ScanSlackWebhooks(rows)
+}
+
+func (s *Store) runSlackWebhookQuery(ctx context.Context, q *sqlf.Query) (*MonitorSlackWebhook, error) {
+	rows, err := s.Query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	ws, err := ScanSlackWebhooks(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(ws) == 0 {
+		return nil, errors.Errorf("operation failed. Query should have returned 1 row")
+	}
+	return ws[0], nil
+}
+
+// updateActionSlackWebhookFmtStr keeps the stored URL if the URL of the
+// update is redacted, as it is when read through the API.
+const updateActionSlackWebhookFmtStr = `
+UPDATE cm_slack_webhooks
+SET enabled = %s,
+	url = COALESCE(NULLIF(%s, %s), url),
+	changed_by = %s,
+	changed_at = %s
+WHERE id = %s
+AND monitor = %s
+RETURNING %s;
+`
+
+func (s *Store) updateActionSlackWebhookQuery(ctx context.Context, monitorID int64, args *graphqlbackend.EditActionSlackWebhookArgs) (*sqlf.Query, error) {
+	if args.Id == nil {
+		return nil, errors.Errorf("nil is not a valid action ID")
+	}
+	var actionID int64
+	err := relay.UnmarshalSpec(*args.Id, &actionID)
+	if err != nil {
+		return nil, err
+	}
+	now := s.Now()
+	a := actor.FromContext(ctx)
+	return sqlf.Sprintf(
+		updateActionSlackWebhookFmtStr,
+		args.Update.Enabled,
+		args.Update.URL,
+		types.RedactedSecret,
+		a.UID,
+		now,
+		actionID,
+		monitorID,
+		sqlf.Join(SlackWebhooksColumns, ", "),
+	), nil
+}
+
+const createActionSlackWebhookFmtStr = `
+INSERT INTO cm_slack_webhooks
+(monitor, enabled, url, created_by, created_at, changed_by, changed_at)
+VALUES (%s,%s,%s,%s,%s,%s,%s)
+RETURNING %s;
+`
+
+func (s *Store) createActionSlackWebhookQuery(ctx context.Context, monitorID int64, args *graphqlbackend.CreateActionSlackWebhookArgs) *sqlf.Query {
+	now := s.Now()
+	a := actor.FromContext(ctx)
+	return sqlf.Sprintf(
+		createActionSlackWebhookFmtStr,
+		monitorID,
+		args.Enabled,
+		args.URL,
+		a.UID,
+		now,
+		a.UID,
+		now,
+		sqlf.Join(SlackWebhooksColumns, ", "),
+	)
+}
+
+var SlackWebhooksColumns = []*sqlf.Query{
+	sqlf.Sprintf("cm_slack_webhooks.id"),
+	sqlf.Sprintf("cm_slack_webhooks.monitor"),
+	sqlf.Sprintf("cm_slack_webhooks.enabled"),
+	sqlf.Sprintf("cm_slack_webhooks.url"),
+	sqlf.Sprintf("cm_slack_webhooks.created_by"),
+	sqlf.Sprintf("cm_slack_webhooks.created_at"),
+	sqlf.Sprintf("cm_slack_webhooks.changed_by"),
+	sqlf.Sprintf("cm_slack_webhooks.changed_at"),
+}
+
+func ScanSlackWebhooks(rows *sql.Rows) (ws []*MonitorSlackWebhook, err error) {
+	for rows.Next() {
+		w := &MonitorSlackWebhook{}
+		if err = rows.Scan(
+			&w.Id,
+			&w.Monitor,
+			&w.Enabled,
+			&w.URL,
+			&w.CreatedBy,
+			&w.CreatedAt,
+			&w.ChangedBy,
+			&w.ChangedAt,
+		); err != nil {
+			return nil, err
+		}
+		ws = append(ws, w)
+	}
+	err = rows.Close()
+	if err != nil {
+		return nil, err
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return ws, nil
+}
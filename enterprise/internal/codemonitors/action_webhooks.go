@@ -0,0 +1,226 @@
+package codemonitors
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+)
+
+type MonitorWebhook struct {
+	Id        int64
+	Monitor   int64
+	Enabled   bool
+	URL       string
+	Secret    string
+	CreatedBy int32
+	CreatedAt time.Time
+	ChangedBy int32
+	ChangedAt time.Time
+}
+
+func (s *Store) UpdateActionWebhook(ctx context.Context, monitorID int64, action *graphqlbackend.EditActionArgs) (*MonitorWebhook, error) {
+	q, err := s.updateActionWebhookQuery(ctx, monitorID, action.Webhook)
+	if err != nil {
+		return nil, err
+	}
+	return s.runWebhookQuery(ctx, q)
+}
+
+func (s *Store) CreateActionWebhook(ctx context.Context, monitorID int64, action *graphqlbackend.CreateActionArgs) (*MonitorWebhook, error) {
+	return s.runWebhookQuery(ctx, s.createActionWebhookQuery(ctx, monitorID, action.Webhook))
+}
+
+const deleteActionWebhookFmtStr = `DELETE FROM cm_webhooks WHERE id in (%s) AND MONITOR = %s`
+
+func (s *Store) DeleteActionWebhooksInt64(ctx context.Context, actionIDs []int64, monitorID int64) error {
+	if len(actionIDs) == 0 {
+		return nil
+	}
+	return s.Exec(ctx, sqlf.Sprintf(deleteActionWebhookFmtStr, idsToQuery(actionIDs), monitorID))
+}
+
+const totalCountActionWebhooksFmtStr = `
+SELECT COUNT(*)
+FROM cm_webhooks
+WHERE monitor = %s;
+`
+
+func (s *Store) TotalCountActionWebhooks(ctx context.Context, monitorID int64) (count int32, err error) {
+	err = s.QueryRow(ctx, sqlf.Sprintf(totalCountActionWebhooksFmtStr, monitorID)).Scan(&count)
+	return count, err
+}
+
+const actionWebhookByIDFmtStr = `
+SELECT %s
+FROM cm_webhooks
+WHERE id = %s
+`
+
+func (s *Store) ActionWebhookByIDInt64(ctx context.Context, webhookID int64) (*MonitorWebhook, error) {
+	return s.runWebhookQuery(ctx, sqlf.Sprintf(actionWebhookByIDFmtStr, sqlf.Join(WebhooksColumns, ", "), webhookID))
+}
+
+const readActionWebhookFmtStr = `
+SELECT %s
+FROM cm_webhooks
+WHERE monitor = %s
+AND id > %s
+ORDER BY id ASC
+LIMIT %s;
+`
+
+// ListActionWebhooks returns a page of the webhook actions of the monitor with
+// the given ID.
+func (s *Store) ListActionWebhooks(ctx context.Context, monitorID int64, args *graphqlbackend.ListActionArgs) ([]*MonitorWebhook, error) {
+	after, err := unmarshalAfter(args.After)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.Query(ctx, sqlf.Sprintf(
+		readActionWebhookFmtStr,
+		sqlf.Join(WebhooksColumns, ", "),
+		monitorID,
+		after,
+		args.First,
+	))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return ScanWebhooks(rows)
+}
+
+func (s *Store) runWebhookQuery(ctx context.Context, q *sqlf.Query) (*MonitorWebhook, error) {
+	rows, err := s.Query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	ws, err := ScanWebhooks(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(ws) == 0 {
+		return nil, errors.Errorf("operation failed. Query should have returned 1 row")
+	}
+	return ws[0], nil
+}
+
+const updateActionWebhookFmtStr = `
+UPDATE cm_webhooks
+SET enabled = %s,
+	url = %s,
+	secret = COALESCE(%s, secret),
+	changed_by = %s,
+	changed_at = %s
+WHERE id = %s
+AND monitor = %s
+RETURNING %s;
+`
+
+func (s *Store) updateActionWebhookQuery(ctx context.Context, monitorID int64, args *graphqlbackend.EditActionWebhookArgs) (*sqlf.Query, error) {
+	if args.Id == nil {
+		return nil, errors.Errorf("nil is not a valid action ID")
+	}
+	var actionID int64
+	err := relay.UnmarshalSpec(*args.Id, &actionID)
+	if err != nil {
+		return nil, err
+	}
+	now := s.Now()
+	a := actor.FromContext(ctx)
+	return sqlf.Sprintf(
+		updateActionWebhookFmtStr,
+		args.Update.Enabled,
+		args.Update.URL,
+		args.Update.Secret,
+		a.UID,
+		now,
+		actionID,
+		monitorID,
+		sqlf.Join(WebhooksColumns, ", "),
+	), nil
+}
+
+const createActionWebhookFmtStr = `
+INSERT INTO cm_webhooks
+(monitor, enabled, url, secret, created_by, created_at, changed_by, changed_at)
+VALUES (%s,%s,%s,%s,%s,%s,%s,%s)
+RETURNING %s;
+`
+
+func (s *Store) createActionWebhookQuery(ctx context.Context, monitorID int64, args *graphqlbackend.CreateActionWebhookArgs) *sqlf.Query {
+	now := s.Now()
+	a := actor.FromContext(ctx)
+	var secret string
+	if args.Secret != nil {
+		secret = *args.Secret
+	}
+	return sqlf.Sprintf(
+		createActionWebhookFmtStr,
+		monitorID,
+		args.Enabled,
+		args.URL,
+		secret,
+		a.UID,
+		now,
+		a.UID,
+		now,
+		sqlf.Join(WebhooksColumns, ", "),
+	)
+}
+
+var WebhooksColumns = []*sqlf.Query{
+	sqlf.Sprintf("cm_webhooks.id"),
+	sqlf.Sprintf("cm_webhooks.monitor"),
+	sqlf.Sprintf("cm_webhooks.enabled"),
+	sqlf.Sprintf("cm_webhooks.url"),
+	sqlf.Sprintf("cm_webhooks.secret"),
+	sqlf.Sprintf("cm_webhooks.created_by"),
+	sqlf.Sprintf("cm_webhooks.created_at"),
+	sqlf.Sprintf("cm_webhooks.changed_by"),
+	sqlf.Sprintf("cm_webhooks.changed_at"),
+}
+
+func ScanWebhooks(rows *sql.Rows) (ws []*MonitorWebhook, err error) {
+	for rows.Next() {
+		w := &MonitorWebhook{}
+		if err = rows.Scan(
+			&w.Id,
+			&w.Monitor,
+			&w.Enabled,
+			&w.URL,
+			&w.Secret,
+			&w.CreatedBy,
+			&w.CreatedAt,
+			&w.ChangedBy,
+			&w.ChangedAt,
+		); err != nil {
+			return nil, err
+		}
+		ws = append(ws, w)
+	}
+	err = rows.Close()
+	if err != nil {
+		return nil, err
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return ws, nil
+}
+
+func idsToQuery(ids []int64) *sqlf.Query {
+	qs := make([]*sqlf.Query, 0, len(ids))
+	for _, id := range ids {
+		qs = append(qs, sqlf.Sprintf("%d", id))
+	}
+	return sqlf.Join(qs, ", ")
+}
@@ -3,14 +3,20 @@ package background
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"log"
 	"net/http"
 	"net/url"
 	"runtime"
+	"sort"
+	"strings"
 	"time"
 
+	cm "github.com/sourcegraph/sourcegraph/enterprise/internal/codemonitors"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 
 	"github.com/cockroachdb/errors"
@@ -32,7 +38,16 @@ const gqlSearchQuery = `query Search(
 			timedout { name }
 			results {
 				__typename
+				... on Repository {
+					name
+				}
 				... on FileMatch {
+					file {
+						path
+					}
+					repository {
+						name
+					}
 					limitHit
 					lineMatches {
 						preview
@@ -79,6 +94,7 @@ const gqlSearchQuery = `query Search(
 						}
 						oid
 						abbreviatedOID
+						url
 						author {
 							person {
 								displayName
@@ -111,6 +127,7 @@ type gqlSearchResponse struct {
 		Search struct {
 			Results struct {
 				ApproximateResultCount string
+				LimitHit               bool
 				Cloning                []*api.Repo
 				Timedout               []*api.Repo
 				Results                []interface{}
@@ -201,3 +218,183 @@ func extractTime(result interface{}) (t *time.Time, err error) {
 		return nil, errors.Errorf("unexpected result __typename %q", typeName)
 	}
 }
+
+// maxDiffExcerptLines is the maximum number of lines of a diff included in the
+// excerpt of a search result.
+const maxDiffExcerptLines = 10
+
+// extractSearchResults summarizes up to limit commit and diff results of a
+// search. Results of other types are skipped.
+func extractSearchResults(v *gqlSearchResponse, limit int) []*cm.SearchResult {
+	if v == nil || limit <= 0 {
+		return nil
+	}
+	var results []*cm.SearchResult
+	for _, r := range v.Data.Search.Results.Results {
+		if len(results) >= limit {
+			break
+		}
+		sr, err := extractSearchResult(r)
+		if err != nil {
+			// Error already logged by extractSearchResult.
+			continue
+		}
+		if sr != nil {
+			results = append(results, sr)
+		}
+	}
+	return results
+}
+
+// extractSearchResult summarizes a single commit or diff search result. It
+// returns nil if the result is not a CommitSearchResult.
+func extractSearchResult(result interface{}) (sr *cm.SearchResult, err error) {
+	// Use recover because we assume the data structure here a lot, for less
+	// error checking.
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("failed to extract search result: %v", r)
+			err = errors.Errorf("failed to extract search result")
+		}
+	}()
+
+	m := result.(map[string]interface{})
+	if m["__typename"].(string) != "CommitSearchResult" {
+		return nil, nil
+	}
+	commit := m["commit"].(map[string]interface{})
+	author := commit["author"].(map[string]interface{})
+	person := author["person"].(map[string]interface{})
+	repo := commit["repository"].(map[string]interface{})
+
+	sr = &cm.SearchResult{
+		Repository: repo["name"].(string),
+		Commit:     commit["oid"].(string),
+		Author:     person["displayName"].(string),
+		Message:    firstLine(commit["message"].(string)),
+	}
+	if u, ok := commit["url"].(string); ok {
+		sr.URL = absoluteURL(u)
+	}
+	if date, ok := author["date"].(string); ok {
+		if t, err := time.Parse(time.RFC3339, date); err == nil {
+			sr.AuthorDate = &t
+		}
+	}
+	if diff, ok := m["diffPreview"].(map[string]interface{}); ok {
+		if value, ok := diff["value"].(string); ok {
+			sr.DiffExcerpt = excerpt(value, maxDiffExcerptLines)
+		}
+	}
+	return sr, nil
+}
+
+// absoluteURL resolves the URL of a search result, which is relative to the
+// root of this instance, against its external URL, so that it can be opened
+// from notifications.
+func absoluteURL(ref string) string {
+	base, err := url.Parse(conf.ExternalURL())
+	if err != nil {
+		return ref
+	}
+	u, err := base.Parse(ref)
+	if err != nil {
+		return ref
+	}
+	return u.String()
+}
+
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
+
+// excerpt returns the first n lines of s.
+func excerpt(s string, n int) string {
+	lines := strings.SplitN(strings.TrimSpace(s), "\n", n+1)
+	if len(lines) > n {
+		lines = append(lines[:n], "...")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// resultFingerprints returns the sorted, deduplicated fingerprints of the
+// results of a search. A fingerprint identifies the matched file, repository or
+// commit, but not the matched lines, so that a result set only changes if a
+// match appears or disappears.
+func resultFingerprints(v *gqlSearchResponse) []string {
+	if v == nil {
+		return []string{}
+	}
+	seen := make(map[string]struct{}, len(v.Data.Search.Results.Results))
+	fingerprints := make([]string, 0, len(v.Data.Search.Results.Results))
+	for _, r := range v.Data.Search.Results.Results {
+		fp, err := extractFingerprint(r)
+		if err != nil {
+			// Error already logged by extractFingerprint.
+			continue
+		}
+		if _, ok := seen[fp]; ok {
+			continue
+		}
+		seen[fp] = struct{}{}
+		fingerprints = append(fingerprints, fp)
+	}
+	sort.Strings(fingerprints)
+	return fingerprints
+}
+
+// extractFingerprint returns the fingerprint of a single search result.
+func extractFingerprint(result interface{}) (fp string, err error) {
+	// Use recover because we assume the data structure here a lot, for less
+	// error checking.
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("failed to extract fingerprint from search result: %v", r)
+			err = errors.Errorf("failed to extract fingerprint from search result")
+		}
+	}()
+
+	m := result.(map[string]interface{})
+	var key string
+	switch typeName := m["__typename"].(string); typeName {
+	case "FileMatch":
+		repo := m["repository"].(map[string]interface{})
+		file := m["file"].(map[string]interface{})
+		key = "file:" + repo["name"].(string) + ":" + file["path"].(string)
+	case "Repository":
+		key = "repo:" + m["name"].(string)
+	case "CommitSearchResult":
+		commit := m["commit"].(map[string]interface{})
+		repo := commit["repository"].(map[string]interface{})
+		key = "commit:" + repo["name"].(string) + ":" + commit["oid"].(string)
+	default:
+		return "", errors.Errorf("unexpected result __typename %q", typeName)
+	}
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// diffFingerprints returns the number of fingerprints in next which are not in
+// prev, and the number of fingerprints in prev which are not in next.
+func diffFingerprints(prev, next []string) (added, removed int) {
+	prevSet := make(map[string]struct{}, len(prev))
+	for _, fp := range prev {
+		prevSet[fp] = struct{}{}
+	}
+	nextSet := make(map[string]struct{}, len(next))
+	for _, fp := range next {
+		nextSet[fp] = struct{}{}
+		if _, ok := prevSet[fp]; !ok {
+			added++
+		}
+	}
+	for fp := range prevSet {
+		if _, ok := nextSet[fp]; !ok {
+			removed++
+		}
+	}
+	return added, removed
+}
@@ -0,0 +1,160 @@
+package background
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	cm "github.com/sourcegraph/sourcegraph/enterprise/internal/codemonitors"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestExtractSearchResults(t *testing.T) {
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{ExternalURL: "https://sourcegraph.example.com"}})
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	raw := `{"data":{"search":{"results":{"results":[
+		{
+			"__typename": "CommitSearchResult",
+			"diffPreview": {"value": "file.go file.go\n@@ -1,2 +1,3 @@\n+line1\n+line2"},
+			"commit": {
+				"repository": {"name": "github.com/sourcegraph/sourcegraph"},
+				"oid": "0123456789abcdef",
+				"url": "/github.com/sourcegraph/sourcegraph/-/commit/0123456789abcdef",
+				"author": {"person": {"displayName": "Jane Doe"}, "date": "2021-10-01T12:00:00Z"},
+				"message": "Add feature\n\nWith a long description."
+			}
+		},
+		{"__typename": "FileMatch"},
+		{
+			"__typename": "CommitSearchResult",
+			"commit": {
+				"repository": {"name": "github.com/sourcegraph/zoekt"},
+				"oid": "fedcba9876543210",
+				"author": {"person": {"displayName": "John Doe"}},
+				"message": "Fix bug"
+			}
+		}
+	]}}}}`
+	var resp gqlSearchResponse
+	if err := json.Unmarshal([]byte(raw), &resp); err != nil {
+		t.Fatal(err)
+	}
+
+	authorDate := time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)
+	want := []*cm.SearchResult{
+		{
+			Repository:  "github.com/sourcegraph/sourcegraph",
+			Commit:      "0123456789abcdef",
+			URL:         "https://sourcegraph.example.com/github.com/sourcegraph/sourcegraph/-/commit/0123456789abcdef",
+			Author:      "Jane Doe",
+			AuthorDate:  &authorDate,
+			Message:     "Add feature",
+			DiffExcerpt: "file.go file.go\n@@ -1,2 +1,3 @@\n+line1\n+line2",
+		},
+		{
+			Repository: "github.com/sourcegraph/zoekt",
+			Commit:     "fedcba9876543210",
+			Author:     "John Doe",
+			Message:    "Fix bug",
+		},
+	}
+	if diff := cmp.Diff(want, extractSearchResults(&resp, 10)); diff != "" {
+		t.Fatalf("unexpected results (-want +got):\n%s", diff)
+	}
+
+	if got := extractSearchResults(&resp, 1); len(got) != 1 {
+		t.Fatalf("expected results to be capped at 1, got %d", len(got))
+	}
+}
+
+func TestExcerpt(t *testing.T) {
+	if got, want := excerpt("a\nb\nc", 2), "a\nb\n..."; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	if got, want := excerpt("a\nb", 2), "a\nb"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestResultFingerprints(t *testing.T) {
+	raw := `{"data":{"search":{"results":{"results":[
+		{
+			"__typename": "FileMatch",
+			"repository": {"name": "github.com/sourcegraph/sourcegraph"},
+			"file": {"path": "cmd/main.go"}
+		},
+		{
+			"__typename": "FileMatch",
+			"repository": {"name": "github.com/sourcegraph/sourcegraph"},
+			"file": {"path": "cmd/main.go"}
+		},
+		{"__typename": "Repository", "name": "github.com/sourcegraph/zoekt"},
+		{"__typename": "FileMatch"}
+	]}}}}`
+	var resp gqlSearchResponse
+	if err := json.Unmarshal([]byte(raw), &resp); err != nil {
+		t.Fatal(err)
+	}
+
+	got := resultFingerprints(&resp)
+	if len(got) != 2 {
+		t.Fatalf("got %d fingerprints, want 2: %v", len(got), got)
+	}
+	if got[0] >= got[1] {
+		t.Errorf("fingerprints are not sorted: %v", got)
+	}
+	if fps := resultFingerprints(nil); fps == nil || len(fps) != 0 {
+		t.Errorf("got %v for nil response, want empty slice", fps)
+	}
+}
+
+func TestDiffFingerprints(t *testing.T) {
+	tests := []struct {
+		prev, next             []string
+		wantAdded, wantRemoved int
+	}{
+		{prev: nil, next: nil},
+		{prev: []string{"a", "b"}, next: []string{"a", "b"}},
+		{prev: []string{}, next: []string{"a", "b"}, wantAdded: 2},
+		{prev: []string{"a", "b"}, next: []string{"b", "c"}, wantAdded: 1, wantRemoved: 1},
+		{prev: []string{"a", "b", "c"}, next: []string{"a"}, wantRemoved: 2},
+	}
+	for _, tt := range tests {
+		added, removed := diffFingerprints(tt.prev, tt.next)
+		if added != tt.wantAdded || removed != tt.wantRemoved {
+			t.Errorf("diffFingerprints(%v, %v) = (%d, %d), want (%d, %d)", tt.prev, tt.next, added, removed, tt.wantAdded, tt.wantRemoved)
+		}
+	}
+}
+
+func TestNewQueryWithCount(t *testing.T) {
+	for queryString, want := range map[string]string{
+		"repo:foo type:file":         "repo:foo type:file count:10000",
+		"repo:foo type:file count:5": "repo:foo type:file count:5",
+	} {
+		if have := newQueryWithCount(queryString); have != want {
+			t.Errorf("want %q, have %q", want, have)
+		}
+	}
+}
+
+func TestIsComplete(t *testing.T) {
+	var resp gqlSearchResponse
+	if !isComplete(&resp) {
+		t.Error("expected results to be complete")
+	}
+	resp.Data.Search.Results.LimitHit = true
+	if isComplete(&resp) {
+		t.Error("expected results which hit a limit to be incomplete")
+	}
+	resp.Data.Search.Results.LimitHit = false
+	resp.Data.Search.Results.Timedout = []*api.Repo{{Name: "github.com/sourcegraph/sourcegraph"}}
+	if isComplete(&resp) {
+		t.Error("expected results with timed out repositories to be incomplete")
+	}
+}
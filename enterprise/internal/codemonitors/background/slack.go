@@ -0,0 +1,89 @@
+package background
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/errors"
+
+	cm "github.com/sourcegraph/sourcegraph/enterprise/internal/codemonitors"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codemonitors/email"
+	"github.com/sourcegraph/sourcegraph/internal/slack"
+)
+
+const utmSourceSlack = "code-monitoring-slack"
+
+func newSlackPayload(ctx context.Context, m *cm.ActionJobMetadata) (*slack.Payload, error) {
+	searchURL, err := email.GetSearchURL(ctx, m.Query, utmSourceSlack)
+	if err != nil {
+		return nil, errors.Errorf("GetSearchURL: %w", err)
+	}
+	monitorURL, err := email.GetCodeMonitorURL(ctx, m.MonitorID, utmSourceSlack)
+	if err != nil {
+		return nil, errors.Errorf("GetCodeMonitorURL: %w", err)
+	}
+
+	numResults := zeroOrVal(m.NumResults)
+	resultsText := fmt.Sprintf("There were %d new search results for your query", numResults)
+	if numResults == 1 {
+		resultsText = "There was 1 new search result for your query"
+	}
+
+	attachments := []*slack.Attachment{{
+		Color:      "#F96316",
+		Fallback:   resultsText,
+		Title:      resultsText,
+		TitleLink:  searchURL,
+		Text:       fmt.Sprintf("`%s`", m.Query),
+		MarkdownIn: []string{"text"},
+	}}
+	for _, r := range m.Results {
+		attachments = append(attachments, newSlackResultAttachment(r))
+	}
+
+	return &slack.Payload{
+		Username:    "Sourcegraph code monitor",
+		IconEmoji:   ":mag:",
+		UnfurlLinks: false,
+		UnfurlMedia: false,
+		Text:        fmt.Sprintf("Code monitor *<%s|%s>* was triggered", monitorURL, m.Description),
+		Attachments: attachments,
+	}, nil
+}
+
+func newSlackResultAttachment(r *cm.SearchResult) *slack.Attachment {
+	text := r.Message
+	if r.DiffExcerpt != "" {
+		text += "\n```" + r.DiffExcerpt + "```"
+	}
+	attachment := &slack.Attachment{
+		AuthorName: r.Author,
+		Color:      "#A6B6D9",
+		Fallback:   fmt.Sprintf("%s: %s", r.Repository, r.Message),
+		Title:      fmt.Sprintf("%s@%s", r.Repository, abbreviateOID(r.Commit)),
+		TitleLink:  r.URL,
+		Text:       text,
+		MarkdownIn: []string{"text"},
+	}
+	if r.AuthorDate != nil {
+		attachment.Timestamp = r.AuthorDate.Unix()
+	}
+	return attachment
+}
+
+func abbreviateOID(oid string) string {
+	if len(oid) > 7 {
+		return oid[:7]
+	}
+	return oid
+}
+
+// MockSendSlackMessage is used in tests to intercept messages sent to Slack.
+var MockSendSlackMessage func(ctx context.Context, url string, payload *slack.Payload) error
+
+func sendSlackMessage(ctx context.Context, url string, payload *slack.Payload) error {
+	if MockSendSlackMessage != nil {
+		return MockSendSlackMessage(ctx, url, payload)
+	}
+	return slack.New(url).Post(ctx, payload)
+}
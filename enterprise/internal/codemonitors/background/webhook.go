@@ -0,0 +1,94 @@
+package background
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	cm "github.com/sourcegraph/sourcegraph/enterprise/internal/codemonitors"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codemonitors/email"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+)
+
+const (
+	utmSourceWebhook       = "code-monitoring-webhook"
+	webhookSignatureHeader = "X-Sourcegraph-Signature"
+)
+
+// webhookPayload is the JSON body POSTed to webhook actions.
+type webhookPayload struct {
+	MonitorDescription string `json:"monitorDescription"`
+	MonitorURL         string `json:"monitorURL"`
+	Query              string `json:"query"`
+	SearchURL          string `json:"searchURL"`
+	NumResults         int    `json:"numResults"`
+
+	// Results are the matches captured when the monitor was triggered.
+	Results []*cm.SearchResult `json:"results,omitempty"`
+}
+
+func newWebhookPayload(ctx context.Context, m *cm.ActionJobMetadata, utmSource string) (*webhookPayload, error) {
+	searchURL, err := email.GetSearchURL(ctx, m.Query, utmSource)
+	if err != nil {
+		return nil, errors.Errorf("GetSearchURL: %w", err)
+	}
+	monitorURL, err := email.GetCodeMonitorURL(ctx, m.MonitorID, utmSource)
+	if err != nil {
+		return nil, errors.Errorf("GetCodeMonitorURL: %w", err)
+	}
+	return &webhookPayload{
+		MonitorDescription: m.Description,
+		MonitorURL:         monitorURL,
+		Query:              m.Query,
+		SearchURL:          searchURL,
+		NumResults:         zeroOrVal(m.NumResults),
+		Results:            m.Results,
+	}, nil
+}
+
+// sendWebhook POSTs payload as JSON to the webhook's URL. If the webhook has a
+// secret, the hex encoded HMAC-SHA256 of the body is sent in the
+// X-Sourcegraph-Signature header so that receivers can verify the request.
+func sendWebhook(ctx context.Context, doer httpcli.Doer, w *cm.MonitorWebhook, payload *webhookPayload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "marshal webhook payload")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "create webhook request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if w.Secret != "" {
+		req.Header.Set(webhookSignatureHeader, "sha256="+signPayload(w.Secret, body))
+	}
+
+	resp, err := doer.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "webhook request")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return errors.Errorf("webhook request failed with status %d: %s", resp.StatusCode, string(b))
+	}
+	return nil
+}
+
+func signPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
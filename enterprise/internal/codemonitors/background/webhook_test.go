@@ -0,0 +1,77 @@
+package background
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	cm "github.com/sourcegraph/sourcegraph/enterprise/internal/codemonitors"
+)
+
+func TestSendWebhook(t *testing.T) {
+	payload := &webhookPayload{
+		MonitorDescription: "test description",
+		MonitorURL:         "https://sourcegraph.com/code-monitoring/1",
+		Query:              "test type:diff",
+		SearchURL:          "https://sourcegraph.com/search?q=test+type%3Adiff",
+		NumResults:         3,
+	}
+
+	var (
+		gotPayload   webhookPayload
+		gotSignature string
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := json.Unmarshal(body, &gotPayload); err != nil {
+			t.Fatal(err)
+		}
+		gotSignature = r.Header.Get(webhookSignatureHeader)
+		if want := "sha256=" + signPayload("s3cret", body); gotSignature != want {
+			t.Errorf("wrong signature: got %q, want %q", gotSignature, want)
+		}
+	}))
+	defer ts.Close()
+
+	err := sendWebhook(context.Background(), http.DefaultClient, &cm.MonitorWebhook{URL: ts.URL, Secret: "s3cret"}, payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(*payload, gotPayload); diff != "" {
+		t.Fatalf("unexpected payload (-want +got):\n%s", diff)
+	}
+
+	t.Run("unsigned", func(t *testing.T) {
+		gotSignature = "unset"
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotSignature = r.Header.Get(webhookSignatureHeader)
+		}))
+		defer ts.Close()
+
+		if err := sendWebhook(context.Background(), http.DefaultClient, &cm.MonitorWebhook{URL: ts.URL}, payload); err != nil {
+			t.Fatal(err)
+		}
+		if gotSignature != "" {
+			t.Fatalf("expected no signature, got %q", gotSignature)
+		}
+	})
+
+	t.Run("error status", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer ts.Close()
+
+		if err := sendWebhook(context.Background(), http.DefaultClient, &cm.MonitorWebhook{URL: ts.URL}, payload); err == nil {
+			t.Fatal("expected error for non-2xx response")
+		}
+	})
+}
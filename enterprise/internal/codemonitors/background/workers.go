@@ -12,7 +12,10 @@ import (
 
 	cm "github.com/sourcegraph/sourcegraph/enterprise/internal/codemonitors"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codemonitors/email"
+	"github.com/sourcegraph/sourcegraph/internal/env"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
 	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
@@ -22,6 +25,8 @@ const (
 	eventRetentionInDays int = 7
 )
 
+var maxResultsInNotification = env.MustGetInt("CODE_MONITOR_MAX_RESULTS_IN_NOTIFICATION", 10, "The maximum number of matching commits and diffs included in code monitor notifications.")
+
 func newTriggerQueryRunner(ctx context.Context, s *cm.Store, metrics codeMonitorsMetrics) *workerutil.Worker {
 	options := workerutil.WorkerOptions{
 		Name:              "code_monitors_trigger_jobs_worker",
@@ -152,6 +157,9 @@ func (r *queryRunner) Handle(ctx context.Context, record workerutil.Record) (err
 	if err != nil {
 		return err
 	}
+	if !cm.IsCommitSearch(q.QueryString) {
+		return handleResultSetQuery(ctx, s, q, record.RecordID())
+	}
 	newQuery := newQueryWithAfterFilter(q)
 
 	// Search.
@@ -165,9 +173,9 @@ func (r *queryRunner) Handle(ctx context.Context, record workerutil.Record) (err
 		numResults = len(results.Data.Search.Results.Results)
 	}
 	if numResults > 0 {
-		err := s.EnqueueActionEmailsForQueryIDInt64(ctx, q.Id, record.RecordID())
+		err := s.EnqueueActionJobsForQueryIDInt64(ctx, q.Id, record.RecordID())
 		if err != nil {
-			return errors.Errorf("store.EnqueueActionEmailsForQueryIDInt64: %w", err)
+			return errors.Errorf("store.EnqueueActionJobsForQueryIDInt64: %w", err)
 		}
 	}
 	// Log next_run and latest_result to table cm_queries.
@@ -181,9 +189,99 @@ func (r *queryRunner) Handle(ctx context.Context, record workerutil.Record) (err
 	if err != nil {
 		return errors.Errorf("LogSearch: %w", err)
 	}
+	// Capture the results so that notifications show what matched now, even if
+	// the search returns different results later.
+	if numResults > 0 {
+		err = s.LogSearchResults(ctx, record.RecordID(), extractSearchResults(results, maxResultsInNotification))
+		if err != nil {
+			return errors.Errorf("LogSearchResults: %w", err)
+		}
+	}
+	return nil
+}
+
+// handleResultSetQuery runs a query which doesn't search commits or diffs.
+// Results of such queries have no timestamp we could filter on with after:, so
+// instead we compare the fingerprints of the results with those of the previous
+// run and trigger the monitor's actions if any result appeared or disappeared.
+func handleResultSetQuery(ctx context.Context, s *cm.Store, q *cm.MonitorQuery, recordID int) error {
+	newQuery := newQueryWithCount(q.QueryString)
+	results, err := search(ctx, newQuery)
+	if err != nil {
+		return err
+	}
+
+	// An incomplete result set would look like results disappeared, so we
+	// keep the fingerprints of the previous run until the search completes.
+	var numChanges int
+	if isComplete(results) {
+		fingerprints := resultFingerprints(results)
+
+		// Fingerprints are nil if the query hasn't run since it was created or
+		// changed. In that case the current results become the baseline and we
+		// don't trigger any actions.
+		if q.ResultFingerprints != nil {
+			added, removed := diffFingerprints(q.ResultFingerprints, fingerprints)
+			numChanges = added + removed
+		}
+		if numChanges > 0 {
+			err = s.EnqueueActionJobsForQueryIDInt64(ctx, q.Id, recordID)
+			if err != nil {
+				return errors.Errorf("store.EnqueueActionJobsForQueryIDInt64: %w", err)
+			}
+		}
+		err = s.SetTriggerQueryResultFingerprints(ctx, q.Id, fingerprints)
+		if err != nil {
+			return errors.Errorf("SetTriggerQueryResultFingerprints: %w", err)
+		}
+	} else {
+		log15.Warn("codemonitors: skipping incomplete search results", "queryID", q.Id, "query", newQuery)
+	}
+	now := s.Clock()()
+	newLatestResult := now
+	if numChanges == 0 && q.LatestResult != nil {
+		newLatestResult = *q.LatestResult
+	}
+	err = s.SetTriggerQueryNextRun(ctx, q.Id, now.Add(5*time.Minute), newLatestResult.UTC())
+	if err != nil {
+		return err
+	}
+	err = s.LogSearch(ctx, newQuery, numChanges, recordID)
+	if err != nil {
+		return errors.Errorf("LogSearch: %w", err)
+	}
 	return nil
 }
 
+// maxResultSetSize is the number of results requested by queries which don't
+// search commits or diffs and don't specify count: themselves. Result sets are
+// compared as a whole, so the default limit of a search would make them change
+// whenever the top results change.
+const maxResultSetSize = 10000
+
+// newQueryWithCount returns the query with a count: filter of
+// maxResultSetSize, unless it already has one.
+func newQueryWithCount(queryString string) string {
+	q, err := query.ParseLiteral(queryString)
+	if err != nil {
+		return queryString
+	}
+	if counts, _ := q.StringValues(query.FieldCount); len(counts) > 0 {
+		return queryString
+	}
+	return fmt.Sprintf("%s count:%d", queryString, maxResultSetSize)
+}
+
+// isComplete returns true if the search returned all of its results, which
+// is not the case if it hit a limit or repositories timed out or are cloning.
+func isComplete(v *gqlSearchResponse) bool {
+	if v == nil {
+		return false
+	}
+	results := v.Data.Search.Results
+	return !results.LimitHit && len(results.Timedout) == 0 && len(results.Cloning) == 0
+}
+
 type actionRunner struct {
 	*cm.Store
 }
@@ -202,36 +300,40 @@ func (r *actionRunner) Handle(ctx context.Context, record workerutil.Record) (er
 	}
 	defer func() { err = s.Done(err) }()
 
-	var (
-		j    *cm.ActionJob
-		m    *cm.ActionJobMetadata
-		e    *cm.MonitorEmail
-		recs []*cm.Recipient
-		data *email.TemplateDataNewSearchResults
-	)
-
-	var ok bool
-	j, ok = record.(*cm.ActionJob)
+	j, ok := record.(*cm.ActionJob)
 	if !ok {
 		return errors.Errorf("type assertion failed")
 	}
 
-	m, err = s.GetActionJobMetadata(ctx, record.RecordID())
+	m, err := s.GetActionJobMetadata(ctx, record.RecordID())
 	if err != nil {
 		return errors.Errorf("store.GetActionJobMetadata: %w", err)
 	}
 
-	e, err = s.ActionEmailByIDInt64(ctx, j.Email)
+	switch {
+	case j.Email != nil:
+		return handleEmail(ctx, s, j, m)
+	case j.Webhook != nil:
+		return handleWebhook(ctx, s, j, m)
+	case j.SlackWebhook != nil:
+		return handleSlackWebhook(ctx, s, j, m)
+	default:
+		return errors.Errorf("action job %d has no associated action", j.Id)
+	}
+}
+
+func handleEmail(ctx context.Context, s *cm.Store, j *cm.ActionJob, m *cm.ActionJobMetadata) error {
+	e, err := s.ActionEmailByIDInt64(ctx, *j.Email)
 	if err != nil {
 		return errors.Errorf("store.ActionEmailByIDInt64: %w", err)
 	}
 
-	recs, err = s.AllRecipientsForEmailIDInt64(ctx, j.Email)
+	recs, err := s.AllRecipientsForEmailIDInt64(ctx, *j.Email)
 	if err != nil {
 		return errors.Errorf("store.AllRecipientsForEmailIDInt64: %w", err)
 	}
 
-	data, err = email.NewTemplateDataForNewSearchResults(ctx, m.Description, m.Query, e, zeroOrVal(m.NumResults))
+	data, err := email.NewTemplateDataForNewSearchResults(ctx, m.Description, m.Query, e, zeroOrVal(m.NumResults), m.Results)
 	if err != nil {
 		return errors.Errorf("email.NewTemplateDataForNewSearchResults: %w", err)
 	}
@@ -251,6 +353,32 @@ func (r *actionRunner) Handle(ctx context.Context, record workerutil.Record) (er
 	return nil
 }
 
+func handleWebhook(ctx context.Context, s *cm.Store, j *cm.ActionJob, m *cm.ActionJobMetadata) error {
+	w, err := s.ActionWebhookByIDInt64(ctx, *j.Webhook)
+	if err != nil {
+		return errors.Errorf("store.ActionWebhookByIDInt64: %w", err)
+	}
+
+	payload, err := newWebhookPayload(ctx, m, utmSourceWebhook)
+	if err != nil {
+		return err
+	}
+	return sendWebhook(ctx, httpcli.ExternalDoer, w, payload)
+}
+
+func handleSlackWebhook(ctx context.Context, s *cm.Store, j *cm.ActionJob, m *cm.ActionJobMetadata) error {
+	w, err := s.ActionSlackWebhookByIDInt64(ctx, *j.SlackWebhook)
+	if err != nil {
+		return errors.Errorf("store.ActionSlackWebhookByIDInt64: %w", err)
+	}
+
+	payload, err := newSlackPayload(ctx, m)
+	if err != nil {
+		return err
+	}
+	return sendSlackMessage(ctx, w.URL, payload)
+}
+
 // newQueryWithAfterFilter constructs a new query which finds search results
 // introduced after the last time we queried.
 func newQueryWithAfterFilter(q *cm.MonitorQuery) string {
@@ -38,21 +38,26 @@ type TemplateDataNewSearchResults struct {
 	Description               string
 	NumberOfResultsWithDetail string
 	IsTest                    bool
+
+	// Results are the matches captured when the monitor was triggered. They
+	// are capped, so there may be fewer Results than the number of results
+	// mentioned in NumberOfResultsWithDetail.
+	Results []*codemonitors.SearchResult
 }
 
-func NewTemplateDataForNewSearchResults(ctx context.Context, monitorDescription, queryString string, email *codemonitors.MonitorEmail, numResults int) (d *TemplateDataNewSearchResults, err error) {
+func NewTemplateDataForNewSearchResults(ctx context.Context, monitorDescription, queryString string, email *codemonitors.MonitorEmail, numResults int, results []*codemonitors.SearchResult) (d *TemplateDataNewSearchResults, err error) {
 	var (
 		searchURL                 string
 		codeMonitorURL            string
 		priority                  string
 		numberOfResultsWithDetail string
 	)
-	searchURL, err = getSearchURL(ctx, queryString, utmSourceEmail)
+	searchURL, err = GetSearchURL(ctx, queryString, utmSourceEmail)
 	if err != nil {
 		return nil, err
 	}
 
-	codeMonitorURL, err = getCodeMonitorURL(ctx, email.Monitor, utmSourceEmail)
+	codeMonitorURL, err = GetCodeMonitorURL(ctx, email.Monitor, utmSourceEmail)
 	if err != nil {
 		return nil, err
 	}
@@ -75,6 +80,7 @@ func NewTemplateDataForNewSearchResults(ctx context.Context, monitorDescription,
 		SearchURL:                 searchURL,
 		Description:               monitorDescription,
 		NumberOfResultsWithDetail: numberOfResultsWithDetail,
+		Results:                   results,
 	}, nil
 }
 
@@ -105,11 +111,13 @@ func sendEmail(ctx context.Context, userID int32, template txtypes.Templates, da
 	return nil
 }
 
-func getSearchURL(ctx context.Context, query, utmSource string) (string, error) {
+// GetSearchURL returns the absolute URL of a search for query on this instance.
+func GetSearchURL(ctx context.Context, query, utmSource string) (string, error) {
 	return sourcegraphURL(ctx, "search", query, utmSource)
 }
 
-func getCodeMonitorURL(ctx context.Context, monitorID int64, utmSource string) (string, error) {
+// GetCodeMonitorURL returns the absolute URL of the code monitor page for monitorID.
+func GetCodeMonitorURL(ctx context.Context, monitorID int64, utmSource string) (string, error) {
 	return sourcegraphURL(ctx, fmt.Sprintf("code-monitoring/%s", relay.MarshalID(MonitorKind, monitorID)), "", utmSource)
 }
 
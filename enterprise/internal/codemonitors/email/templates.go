@@ -14,7 +14,12 @@ Code monitoring triggered a new event:
 
 {{.Description}}
 {{.NumberOfResultsWithDetail}}
-
+{{ range .Results }}
+{{.Repository}} {{.Commit}}
+{{.Author}}: {{.Message}}
+{{ if .DiffExcerpt }}
+{{.DiffExcerpt}}
+{{ end }}{{ end }}
 View search on Sourcegraph {{.SearchURL}}
 
 __
@@ -44,6 +49,16 @@ Sourcegraph limits what information is contained in this notification.
         >{{.NumberOfResultsWithDetail}}</span
       >
     </p>
+	{{ range .Results }}
+	<div style="font-size: 14px; line-height: 21px; margin-bottom: 16px">
+	  <a href="{{.URL}}" style="font-weight: 700">{{.Repository}}</a>
+	  <span style="color: #5E6E8C"> {{.Author}}</span><br />
+	  {{.Message}}
+	  {{ if .DiffExcerpt }}
+	  <pre style="font-size: 12px; line-height: 18px; padding: 8px; background-color: #F9FAFB; border-radius: 4px; overflow-x: auto">{{.DiffExcerpt}}</pre>
+	  {{ end }}
+	</div>
+	{{ end }}
 	<p style="font-size: 16px; line-height: 24px">
 	  <a href="{{.SearchURL}}" {{ if .IsTest }}style="color: #9C9FA6; font-weight: 400; text-decoration: underline; cursor: default"{{ end }}>
         View search on Sourcegraph
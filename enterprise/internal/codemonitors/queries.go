@@ -8,9 +8,11 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/graph-gophers/graphql-go/relay"
 	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
 )
 
 type MonitorQuery struct {
@@ -23,6 +25,11 @@ type MonitorQuery struct {
 	CreatedAt    time.Time
 	ChangedBy    int32
 	ChangedAt    time.Time
+
+	// ResultFingerprints are the fingerprints of the results of the last run
+	// of a query which doesn't search commits or diffs. It is nil if the query
+	// has not run since it was created or changed.
+	ResultFingerprints []string
 }
 
 var queryColumns = []*sqlf.Query{
@@ -55,7 +62,7 @@ func (s *Store) UpdateTriggerQuery(ctx context.Context, args *graphqlbackend.Upd
 }
 
 const triggerQueryByMonitorFmtStr = `
-SELECT id, monitor, query, next_run, latest_result, created_by, created_at, changed_by, changed_at
+SELECT id, monitor, query, next_run, latest_result, created_by, created_at, changed_by, changed_at, result_fingerprints
 FROM cm_queries
 WHERE monitor = %s;
 `
@@ -65,7 +72,7 @@ func (s *Store) TriggerQueryByMonitorIDInt64(ctx context.Context, monitorID int6
 }
 
 const triggerQueryByIDFmtStr = `
-SELECT id, monitor, query, next_run, latest_result, created_by, created_at, changed_by, changed_at
+SELECT id, monitor, query, next_run, latest_result, created_by, created_at, changed_by, changed_at, result_fingerprints
 FROM cm_queries
 WHERE id = %s;
 `
@@ -74,9 +81,12 @@ func (s *Store) triggerQueryByIDInt64(ctx context.Context, queryID int64) (*Moni
 	return s.runTriggerQuery(ctx, sqlf.Sprintf(triggerQueryByIDFmtStr, queryID))
 }
 
+// resetTriggerQueryTimestamps also resets the result fingerprints to an empty
+// set (instead of NULL), so that all results of the next run are considered new.
 const resetTriggerQueryTimestamps = `
 UPDATE cm_queries
 SET latest_result = null,
+    result_fingerprints = '{}',
     next_run = %s
 WHERE id = %s;
 `
@@ -114,7 +124,8 @@ UPDATE cm_queries
 SET query = %s,
 	changed_by = %s,
 	changed_at = %s,
-	latest_result = %s
+	latest_result = %s,
+	result_fingerprints = NULL
 WHERE id = %s
 AND monitor = %s
 RETURNING %s;
@@ -149,7 +160,7 @@ func (s *Store) updateTriggerQueryQuery(ctx context.Context, args *graphqlbacken
 }
 
 const getQueryByRecordIDFmtStr = `
-SELECT q.id, q.monitor, q.query, q.next_run, q.latest_result, q.created_by, q.created_at, q.changed_by, q.changed_at
+SELECT q.id, q.monitor, q.query, q.next_run, q.latest_result, q.created_by, q.created_at, q.changed_by, q.changed_at, q.result_fingerprints
 FROM cm_queries q INNER JOIN cm_trigger_jobs j ON q.id = j.query
 WHERE j.id = %s
 `
@@ -192,6 +203,21 @@ func (s *Store) SetTriggerQueryNextRun(ctx context.Context, triggerQueryID int64
 	return s.Exec(ctx, q)
 }
 
+const setTriggerQueryResultFingerprintsFmtStr = `
+UPDATE cm_queries
+SET result_fingerprints = %s
+WHERE id = %s
+`
+
+// SetTriggerQueryResultFingerprints stores the fingerprints of the results of
+// the latest run of the query with the given ID.
+func (s *Store) SetTriggerQueryResultFingerprints(ctx context.Context, triggerQueryID int64, fingerprints []string) error {
+	if fingerprints == nil {
+		fingerprints = []string{}
+	}
+	return s.Exec(ctx, sqlf.Sprintf(setTriggerQueryResultFingerprintsFmtStr, pq.Array(fingerprints), triggerQueryID))
+}
+
 func scanTriggerQueries(rows *sql.Rows) (ms []*MonitorQuery, err error) {
 	for rows.Next() {
 		m := &MonitorQuery{}
@@ -205,6 +231,7 @@ func scanTriggerQueries(rows *sql.Rows) (ms []*MonitorQuery, err error) {
 			&m.CreatedAt,
 			&m.ChangedBy,
 			&m.ChangedAt,
+			pq.Array(&m.ResultFingerprints),
 		); err != nil {
 			return nil, err
 		}
@@ -235,3 +262,52 @@ func (s *Store) runTriggerQuery(ctx context.Context, q *sqlf.Query) (*MonitorQue
 	}
 	return ms[0], nil
 }
+
+// monitoredTypes are the values of type: supported by code monitors. Queries
+// of type commit or diff are restricted to new commits with after:. The
+// results of other queries are compared with those of the previous run.
+var monitoredTypes = map[string]bool{
+	"commit": true,
+	"diff":   true,
+	"file":   false,
+	"path":   false,
+	"symbol": false,
+	"repo":   false,
+}
+
+// ValidateQuery returns an error if the query of a code monitor monitors a
+// type: of results which code monitors don't support. Queries without type:
+// monitor diffs, as they always have.
+func ValidateQuery(queryString string) error {
+	q, err := query.ParseLiteral(queryString)
+	if err != nil {
+		return err
+	}
+	types, _ := q.StringValues(query.FieldType)
+	for _, t := range types {
+		if _, ok := monitoredTypes[t]; !ok {
+			return errors.Errorf("code monitors don't support queries with type:%s", t)
+		}
+	}
+	return nil
+}
+
+// IsCommitSearch returns true if the query searches commits or diffs. Queries
+// without type: monitor diffs, and queries which can't be parsed are
+// considered commit searches too.
+func IsCommitSearch(queryString string) bool {
+	q, err := query.ParseLiteral(queryString)
+	if err != nil {
+		return true
+	}
+	types, _ := q.StringValues(query.FieldType)
+	if len(types) == 0 {
+		return true
+	}
+	for _, t := range types {
+		if monitoredTypes[t] {
+			return true
+		}
+	}
+	return false
+}
@@ -143,3 +143,25 @@ func TestResetTriggerQueryTimestamps(t *testing.T) {
 		t.Fatalf("diff: %s", diff)
 	}
 }
+
+func TestValidateQuery(t *testing.T) {
+	for queryString, wantCommitSearch := range map[string]bool{
+		"repo:foo bar":               true,
+		"repo:foo type:diff":         true,
+		"repo:foo type:commit":       true,
+		"repo:foo bar type:file":     false,
+		"repo:foo type:repo":         false,
+		"repo:foo type:path count:5": false,
+	} {
+		if err := ValidateQuery(queryString); err != nil {
+			t.Errorf("unexpected error for %q: %s", queryString, err)
+		}
+		if have := IsCommitSearch(queryString); have != wantCommitSearch {
+			t.Errorf("unexpected IsCommitSearch for %q. want=%t have=%t", queryString, wantCommitSearch, have)
+		}
+	}
+
+	if err := ValidateQuery("repo:foo type:code"); err == nil {
+		t.Error("expected error for unsupported type:code")
+	}
+}
@@ -3,6 +3,7 @@ package codemonitors
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/graph-gophers/graphql-go"
@@ -50,6 +51,40 @@ func (s *Store) LogSearch(ctx context.Context, queryString string, numResults in
 	return s.Store.Exec(ctx, sqlf.Sprintf(logSearchFmtStr, queryString, numResults > 0, numResults, recordID))
 }
 
+// SearchResult is a summary of a commit or diff matched by the query of a
+// code monitor. Search results are captured when the trigger job runs so that
+// notifications show what matched at that time, even if a later search would
+// return different results.
+type SearchResult struct {
+	Repository string `json:"repository"`
+	Commit     string `json:"commit"`
+
+	// URL is the absolute URL of the commit on this instance.
+	URL    string `json:"url,omitempty"`
+	Author string `json:"author"`
+
+	// AuthorDate is nil if the date of the commit is unknown.
+	AuthorDate  *time.Time `json:"authorDate,omitempty"`
+	Message     string     `json:"message"`
+	DiffExcerpt string     `json:"diffExcerpt,omitempty"`
+}
+
+const logSearchResultsFmtStr = `
+UPDATE cm_trigger_jobs
+SET search_results = %s
+WHERE id = %s
+`
+
+// LogSearchResults stores the search results captured by the trigger job with
+// the given ID.
+func (s *Store) LogSearchResults(ctx context.Context, recordID int, results []*SearchResult) error {
+	b, err := json.Marshal(results)
+	if err != nil {
+		return err
+	}
+	return s.Store.Exec(ctx, sqlf.Sprintf(logSearchResultsFmtStr, string(b), recordID))
+}
+
 const deleteObsoleteJobLogsFmtStr = `
 DELETE FROM cm_trigger_jobs
 WHERE results IS NOT TRUE
@@ -8,6 +8,10 @@ type Job struct {
 	// that different queues can share identifiers.
 	ID int `json:"id"`
 
+	// Queue is the name of the source queue. It is only set for jobs that
+	// were dequeued from one of multiple queues at once.
+	Queue string `json:"queue,omitempty"`
+
 	// RepositoryName is the name of the repository to be cloned into the
 	// workspace prior to job execution.
 	RepositoryName string `json:"repositoryName"`
@@ -29,6 +33,12 @@ type Job struct {
 	// may be done inside or outside of a Firecracker virtual machine.
 	CliSteps []CliStep `json:"cliSteps"`
 
+	// CacheVolumes describe named volumes that are mounted into the containers
+	// of the docker steps. Their content persists across jobs on the same executor
+	// host, so dependencies downloaded by one job can be reused by the next one.
+	// Executors without a cache volume directory ignore them.
+	CacheVolumes []CacheVolume `json:"cacheVolumes"`
+
 	// RedactedValues is a map from strings to replace to their replacement in the command
 	// output before sending it to the underlying job store. This should contain all worker
 	// environment variables, as well as secret values passed along with the dequeued job
@@ -54,6 +64,16 @@ type DockerStep struct {
 	Env []string `json:"env"`
 }
 
+type CacheVolume struct {
+	// Name identifies the volume on the executor host. Jobs that use the same
+	// name share the content of the volume.
+	Name string `json:"name"`
+
+	// Path is the absolute path at which the volume is mounted into the containers
+	// of the docker steps.
+	Path string `json:"path"`
+}
+
 type CliStep struct {
 	// Commands specifies the arguments supplied to the src command.
 	Commands []string `json:"command"`
@@ -68,6 +88,18 @@ type CliStep struct {
 type DequeueRequest struct {
 	ExecutorName     string `json:"executorName"`
 	ExecutorHostname string `json:"executorHostname"`
+
+	// Queues are the queues to dequeue a job from. They are only read by the
+	// dequeue route that isn't specific to a single queue.
+	Queues []WeightedQueue `json:"queues,omitempty"`
+}
+
+// WeightedQueue is one of multiple queues an executor dequeues jobs from. The
+// higher its weight relative to the other queues, the more often it is tried
+// first.
+type WeightedQueue struct {
+	Name   string `json:"name"`
+	Weight int    `json:"weight"`
 }
 
 type AddExecutionLogEntryRequest struct {
@@ -83,6 +115,13 @@ type UpdateExecutionLogEntryRequest struct {
 	workerutil.ExecutionLogEntry
 }
 
+type AppendExecutionLogEntryOutputRequest struct {
+	ExecutorName string `json:"executorName"`
+	JobID        int    `json:"jobId"`
+	EntryID      int    `json:"entryId"`
+	Out          string `json:"out"`
+}
+
 type MarkCompleteRequest struct {
 	ExecutorName string `json:"executorName"`
 	JobID        int    `json:"jobId"`
@@ -39,11 +39,16 @@ const gqlSearchQuery = `query Search(
 						id
 						name
 					}
+					file {
+						path
+					}
 					lineMatches {
+						preview
 						offsetAndLengths
 					}
 					symbols {
 						name
+						kind
 					}
 				}
 				... on CommitSearchResult {
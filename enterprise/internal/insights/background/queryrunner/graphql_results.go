@@ -113,11 +113,16 @@ type fileMatch struct {
 		ID   string
 		Name string
 	}
+	File struct {
+		Path string
+	}
 	LineMatches []struct {
+		Preview          string
 		OffsetAndLengths [][]int
 	}
 	Symbols []struct {
 		Name string
+		Kind string
 	}
 }
 
@@ -0,0 +1,189 @@
+package queryrunner
+
+import (
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+)
+
+// MaxGroups is the maximum number of distinct values a series which groups its results records
+// data points for per job, and shows as separate series.
+const MaxGroups = 50
+
+// grouper groups the results of a series which opted in with groupBySelect by the value its query
+// selects with select:. Each distinct value is recorded as a separate set of data points, so that
+// one insight can show a breakdown of its results with dynamically discovered series labels, e.g.
+// one series per Go version with `file:go\.mod$ ^go\s*(\d\.\d+) select:content patternType:regexp`.
+type grouper struct {
+	selectPath filter.SelectPath
+
+	// captureRe and captureIndex find the values results are grouped by with select:content,
+	// which are the values of the first capture group of the pattern, or the whole matches if the
+	// pattern has no capture group.
+	captureRe    *regexp.Regexp
+	captureIndex int
+}
+
+// newGrouper returns a grouper for the results of a series with the given query. Results are
+// grouped by repository with select:repo, by file with select:file or select:file.path, by
+// directory with select:file.directory, by symbol kind with select:symbol, and by the values
+// matched by the pattern with select:content. It returns an error if the query doesn't select one
+// of these values.
+func newGrouper(searchQuery string) (*grouper, error) {
+	q, err := parseQuery(searchQuery)
+	if err != nil {
+		return nil, err
+	}
+	selects, _ := q.StringValues(query.FieldSelect)
+	if len(selects) == 0 {
+		return nil, errors.Errorf("query %q has no select: value to group its results by", searchQuery)
+	}
+	selectPath, err := filter.SelectPathFromString(selects[len(selects)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	switch selectPath.String() {
+	case filter.Repository, filter.File, "file.path", "file.directory":
+		return &grouper{selectPath: selectPath}, nil
+	case filter.Content:
+		captureRe, err := captureRegexp(q)
+		if err != nil {
+			return nil, err
+		}
+		g := &grouper{selectPath: selectPath, captureRe: captureRe}
+		if captureRe.NumSubexp() > 0 {
+			g.captureIndex = 1
+		}
+		return g, nil
+	}
+	if selectPath.Root() == filter.Symbol {
+		return &grouper{selectPath: selectPath}, nil
+	}
+	return nil, errors.Errorf("the results of query %q can't be grouped by select:%s", searchQuery, selectPath)
+}
+
+// ValidateGroupBySelect returns an error if the results of a series with the given query can't be
+// grouped by the value its query selects with select:.
+func ValidateGroupBySelect(searchQuery string) error {
+	_, err := newGrouper(searchQuery)
+	return err
+}
+
+// parseQuery parses a search query with the pattern type set by its patternType: filter, or as a
+// literal query, which is the default of the searches of series.
+func parseQuery(searchQuery string) (query.Q, error) {
+	q, err := query.ParseLiteral(searchQuery)
+	if err != nil {
+		return nil, err
+	}
+	patternTypes, _ := q.StringValues(query.FieldPatternType)
+	for _, patternType := range patternTypes {
+		if patternType == "regexp" || patternType == "regex" {
+			return query.ParseRegexp(searchQuery)
+		}
+	}
+	return q, nil
+}
+
+// captureRegexp returns the regexp of the first non-negated pattern of q.
+func captureRegexp(q query.Q) (*regexp.Regexp, error) {
+	var pattern string
+	query.VisitPattern(q, func(value string, negated bool, annotation query.Annotation) {
+		if pattern != "" || negated {
+			return
+		}
+		if annotation.Labels.IsSet(query.Regexp) {
+			pattern = value
+		} else {
+			pattern = regexp.QuoteMeta(value)
+		}
+	})
+	if pattern == "" {
+		return nil, errors.New("query has no pattern to group its matches by")
+	}
+	if !q.IsCaseSensitive() {
+		pattern = "(?i:" + pattern + ")"
+	}
+	return regexp.Compile(pattern)
+}
+
+func (g *grouper) fileMatchCounts(r *fileMatch) map[string]int {
+	switch g.selectPath.String() {
+	case filter.Repository:
+		return map[string]int{r.repoName(): r.matchCount()}
+	case filter.File, "file.path":
+		return map[string]int{r.File.Path: r.matchCount()}
+	case "file.directory":
+		return map[string]int{path.Dir(r.File.Path): r.matchCount()}
+	case filter.Content:
+		counts := make(map[string]int)
+		for _, lineMatch := range r.LineMatches {
+			for _, submatch := range g.captureRe.FindAllStringSubmatch(lineMatch.Preview, -1) {
+				if value := submatch[g.captureIndex]; value != "" {
+					counts[value]++
+				}
+			}
+		}
+		return counts
+	}
+	if g.selectPath.Root() == filter.Symbol {
+		counts := make(map[string]int, len(r.Symbols))
+		for _, symbol := range r.Symbols {
+			counts[strings.ToLower(symbol.Kind)]++
+		}
+		return counts
+	}
+	return nil
+}
+
+// counts returns the number of matches of a result per value it is grouped by.
+func (g *grouper) counts(r result) map[string]int {
+	switch r := r.(type) {
+	case *fileMatch:
+		return g.fileMatchCounts(r)
+	case *repository:
+		if g.selectPath.String() == filter.Repository {
+			return map[string]int{r.repoName(): r.matchCount()}
+		}
+	}
+	// Commit results don't carry any file, symbol or preview information.
+	return nil
+}
+
+// limitGroups drops the counts of all but the max values with the most matches across all
+// repositories from countsPerRepo, so that the number of series shown for one series which groups
+// its results stays bounded.
+func limitGroups(countsPerRepo map[string]map[string]int, max int) {
+	totals := make(map[string]int)
+	for _, counts := range countsPerRepo {
+		for value, count := range counts {
+			totals[value] += count
+		}
+	}
+	if len(totals) <= max {
+		return
+	}
+
+	values := make([]string, 0, len(totals))
+	for value := range totals {
+		values = append(values, value)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		if totals[values[i]] != totals[values[j]] {
+			return totals[values[i]] > totals[values[j]]
+		}
+		return values[i] < values[j]
+	})
+	for _, value := range values[max:] {
+		for _, counts := range countsPerRepo {
+			delete(counts, value)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package queryrunner
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestValidateGroupBySelect(t *testing.T) {
+	for _, q := range []string{
+		`file:go\.mod$ ^go`,
+		`type:diff fix select:commit.diff.added`,
+		`file:go\.mod$ select:content`,
+	} {
+		if err := ValidateGroupBySelect(q); err == nil {
+			t.Errorf("expected error for query %q", q)
+		}
+	}
+
+	for _, q := range []string{
+		`go select:repo`,
+		`file:go\.mod$ ^go\s*(\d\.\d+) select:content patternType:regexp`,
+		`func select:symbol.function`,
+	} {
+		if err := ValidateGroupBySelect(q); err != nil {
+			t.Errorf("unexpected error for query %q: %s", q, err)
+		}
+	}
+}
+
+func TestGrouperCounts(t *testing.T) {
+	raw := `{
+		"__typename": "FileMatch",
+		"repository": {"id": "UmVwb3NpdG9yeTox", "name": "github.com/sourcegraph/sourcegraph"},
+		"file": {"path": "lib/go.mod"},
+		"lineMatches": [
+			{"preview": "go 1.17", "offsetAndLengths": [[0, 7]]},
+			{"preview": "go 1.16 // Go 1.17", "offsetAndLengths": [[0, 7], [11, 7]]}
+		],
+		"symbols": [
+			{"name": "main", "kind": "FUNCTION"},
+			{"name": "Foo", "kind": "STRUCT"},
+			{"name": "Bar", "kind": "FUNCTION"}
+		]
+	}`
+	decoded, err := decodeResult(json.RawMessage(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		query string
+		want  map[string]int
+	}{
+		{query: `go select:repo`, want: map[string]int{"github.com/sourcegraph/sourcegraph": 6}},
+		{query: `go select:file`, want: map[string]int{"lib/go.mod": 6}},
+		{query: `go select:file.directory`, want: map[string]int{"lib": 6}},
+		{query: `go select:symbol.function`, want: map[string]int{"function": 2, "struct": 1}},
+		{query: `go\s*(\d\.\d+) select:content patternType:regexp`, want: map[string]int{"1.17": 2, "1.16": 1}},
+		{query: `go\s*\d\.\d+ select:content patternType:regexp`, want: map[string]int{"go 1.17": 1, "Go 1.17": 1, "go 1.16": 1}},
+		{query: `Go 1.17 select:content case:yes`, want: map[string]int{"Go 1.17": 1}},
+	} {
+		t.Run(tc.query, func(t *testing.T) {
+			g, err := newGrouper(tc.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := g.counts(decoded)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected counts (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLimitGroups(t *testing.T) {
+	countsPerRepo := map[string]map[string]int{
+		"repo1": {"a": 3, "b": 1, "c": 1},
+		"repo2": {"b": 1, "d": 5},
+	}
+	limitGroups(countsPerRepo, 3)
+
+	want := map[string]map[string]int{
+		"repo1": {"a": 3, "b": 1},
+		"repo2": {"b": 1, "d": 5},
+	}
+	if diff := cmp.Diff(want, countsPerRepo); diff != "" {
+		t.Errorf("unexpected counts (-want +got):\n%s", diff)
+	}
+}
@@ -91,6 +91,15 @@ func (r *workHandler) Handle(ctx context.Context, record workerutil.Record) (err
 	if err != nil {
 		return err
 	}
+	var g *grouper
+	if series != nil && series.GroupBySelect {
+		// Use the query of the series instead of the query of the job, which may have been
+		// modified, e.g. with repo: and before: filters for historical data.
+		g, err = newGrouper(series.Query)
+		if err != nil {
+			return errors.Wrap(err, "newGrouper")
+		}
+	}
 
 	// Actually perform the search query.
 	//
@@ -162,6 +171,9 @@ func (r *workHandler) Handle(ctx context.Context, record workerutil.Record) (err
 	// results.
 	matchesPerRepo := make(map[string]int, len(results.Data.Search.Results.Results)*4)
 	repoNames := make(map[string]string, len(matchesPerRepo))
+	// If the series groups its results, we additionally figure out how many matches we got for
+	// every value of the capture within every repository.
+	capturesPerRepo := make(map[string]map[string]int)
 	for _, result := range results.Data.Search.Results.Results {
 		decoded, err := decodeResult(result)
 		if err != nil {
@@ -169,7 +181,11 @@ func (r *workHandler) Handle(ctx context.Context, record workerutil.Record) (err
 		}
 		repoNames[decoded.repoID()] = decoded.repoName()
 		matchesPerRepo[decoded.repoID()] = matchesPerRepo[decoded.repoID()] + decoded.matchCount()
+		if g != nil {
+			addGroupCounts(capturesPerRepo, decoded.repoID(), g.counts(decoded))
+		}
 	}
+	limitGroups(capturesPerRepo, MaxGroups)
 
 	tx, err := r.insightsStore.Transact(ctx)
 	if err != nil {
@@ -199,7 +215,19 @@ func (r *workHandler) Handle(ctx context.Context, record workerutil.Record) (err
 			continue
 		}
 
-		args := ToRecording(job, float64(matchCount), recordTime, repoName, dbRepoID)
+		if g != nil {
+			// Record one data point per value of the capture instead of the total.
+			for capture, count := range capturesPerRepo[graphQLRepoID] {
+				capture := capture
+				args := ToRecording(job, float64(count), recordTime, repoName, dbRepoID, &capture)
+				if recordErr := tx.RecordSeriesPoints(ctx, args); recordErr != nil {
+					err = multierror.Append(err, errors.Wrap(recordErr, "RecordSeriesPoints"))
+				}
+			}
+			continue
+		}
+
+		args := ToRecording(job, float64(matchCount), recordTime, repoName, dbRepoID, nil)
 		if recordErr := tx.RecordSeriesPoints(ctx, args); recordErr != nil {
 			err = multierror.Append(err, errors.Wrap(recordErr, "RecordSeriesPoints"))
 		}
@@ -207,7 +235,25 @@ func (r *workHandler) Handle(ctx context.Context, record workerutil.Record) (err
 	return err
 }
 
-func ToRecording(record *Job, value float64, recordTime time.Time, repoName string, repoID api.RepoID) []store.RecordSeriesPointArgs {
+// addGroupCounts adds the given counts per capture value to the counts of the given repository.
+func addGroupCounts(capturesPerRepo map[string]map[string]int, repoID string, counts map[string]int) {
+	if len(counts) == 0 {
+		return
+	}
+	repoCounts, ok := capturesPerRepo[repoID]
+	if !ok {
+		repoCounts = make(map[string]int, len(counts))
+		capturesPerRepo[repoID] = repoCounts
+	}
+	for capture, count := range counts {
+		repoCounts[capture] += count
+	}
+}
+
+// ToRecording returns the arguments to record a data point for the given job at the given time,
+// and at every time the job's results also apply to. capture is the value of the capture the
+// results were grouped by, if the series groups its results.
+func ToRecording(record *Job, value float64, recordTime time.Time, repoName string, repoID api.RepoID, capture *string) []store.RecordSeriesPointArgs {
 	args := make([]store.RecordSeriesPointArgs, 0, len(record.DependentFrames)+1)
 	base := store.RecordSeriesPointArgs{
 		SeriesID: record.SeriesID,
@@ -215,6 +261,7 @@ func ToRecording(record *Job, value float64, recordTime time.Time, repoName stri
 			SeriesID: record.SeriesID,
 			Time:     recordTime,
 			Value:    value,
+			Capture:  capture,
 		},
 		RepoName:    &repoName,
 		RepoID:      &repoID,
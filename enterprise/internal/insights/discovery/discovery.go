@@ -8,6 +8,7 @@ import (
 
 	"github.com/inconshreveable/log15"
 
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/background/queryrunner"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
@@ -195,12 +196,18 @@ func migrateSeries(ctx context.Context, insightStore *store.InsightStore, from i
 	metadata := make([]types.InsightViewSeriesMetadata, len(from.Series))
 
 	for i, timeSeries := range from.Series {
+		if timeSeries.GroupBySelect {
+			if err := queryrunner.ValidateGroupBySelect(timeSeries.Query); err != nil {
+				return errors.Wrapf(err, "unable to migrate insight unique_id: %s", from.ID)
+			}
+		}
 		temp := types.InsightSeries{
 			SeriesID:              Encode(timeSeries),
 			Query:                 timeSeries.Query,
 			RecordingIntervalDays: 1,
 			NextRecordingAfter:    insights.NextRecording(time.Now()),
 			NextSnapshotAfter:     insights.NextSnapshot(time.Now()),
+			GroupBySelect:         timeSeries.GroupBySelect,
 		}
 		var series types.InsightSeries
 		// first check if this data series already exists (somebody already created an insight of this query), in which case we just need to attach the view to this data series
@@ -30,6 +30,11 @@ func EncodeSeriesID(series *schema.InsightSeries) (string, error) {
 }
 
 func Encode(series insights.TimeSeries) string {
+	if series.GroupBySelect {
+		// Series which group their results record different data points than series with the same
+		// query which don't, so they must not share a series ID.
+		return fmt.Sprintf("s:%s", sha256String(series.Query+"\x00groupBySelect"))
+	}
 	return fmt.Sprintf("s:%s", sha256String(series.Query))
 }
 
@@ -6,6 +6,7 @@ import (
 
 	"github.com/hexops/autogold"
 
+	"github.com/sourcegraph/sourcegraph/internal/insights"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
 
@@ -40,3 +41,14 @@ func TestEncodeSeriesID(t *testing.T) {
 		})
 	}
 }
+
+func TestEncode_GroupBySelect(t *testing.T) {
+	ungrouped := Encode(insights.TimeSeries{Query: "go.mod select:repo"})
+	grouped := Encode(insights.TimeSeries{Query: "go.mod select:repo", GroupBySelect: true})
+	if ungrouped == grouped {
+		t.Errorf("expected grouped and ungrouped series to have different IDs, got %q", grouped)
+	}
+	if want := "s:" + sha256String("go.mod select:repo"); ungrouped != want {
+		t.Errorf("ID of ungrouped series changed: want %q, got %q", want, ungrouped)
+	}
+}
@@ -0,0 +1,185 @@
+// Package export implements exporting the data points recorded for code insights series as CSV or
+// JSON, and importing data points from the same formats.
+package export
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+// Format is a format data points can be exported to and imported from.
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatJSON Format = "json"
+)
+
+// ParseFormat returns the format with the given (case-insensitive) name.
+func ParseFormat(name string) (Format, error) {
+	switch f := Format(strings.ToLower(name)); f {
+	case FormatCSV, FormatJSON:
+		return f, nil
+	default:
+		return "", errors.Errorf("unsupported insights export format %q", name)
+	}
+}
+
+// ContentType returns the MIME type of the format.
+func (f Format) ContentType() string {
+	if f == FormatCSV {
+		return "text/csv"
+	}
+	return "application/json"
+}
+
+// Point is a single data point of a series for a single repository.
+type Point struct {
+	SeriesID     string     `json:"seriesId"`
+	Time         time.Time  `json:"time"`
+	Repository   string     `json:"repository"`
+	RepositoryID api.RepoID `json:"repositoryId"`
+	Capture      *string    `json:"capture,omitempty"`
+	Value        float64    `json:"value"`
+}
+
+// csvHeader is the header row of exported CSV data. The columns are in the order of the fields of
+// Point.
+var csvHeader = []string{"series_id", "time", "repository", "repository_id", "capture", "value"}
+
+// SeriesPoints returns the per-repository data points recorded for the given series, in the
+// order of their time.
+func SeriesPoints(ctx context.Context, insightsStore store.Interface, seriesID string) ([]Point, error) {
+	repoPoints, err := insightsStore.RepoSeriesPoints(ctx, store.SeriesPointsOpts{SeriesID: &seriesID})
+	if err != nil {
+		return nil, errors.Wrap(err, "RepoSeriesPoints")
+	}
+
+	points := make([]Point, 0, len(repoPoints))
+	for _, p := range repoPoints {
+		points = append(points, Point{
+			SeriesID:     p.SeriesID,
+			Time:         p.Time.UTC(),
+			Repository:   p.RepoName,
+			RepositoryID: p.RepoID,
+			Capture:      p.Capture,
+			Value:        p.Value,
+		})
+	}
+	return points, nil
+}
+
+// Write writes the given data points to w in the given format.
+func Write(w io.Writer, format Format, points []Point) error {
+	switch format {
+	case FormatJSON:
+		if points == nil {
+			points = []Point{}
+		}
+		return json.NewEncoder(w).Encode(points)
+
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(csvHeader); err != nil {
+			return err
+		}
+		for _, p := range points {
+			capture := ""
+			if p.Capture != nil {
+				capture = *p.Capture
+			}
+			record := []string{
+				p.SeriesID,
+				p.Time.UTC().Format(time.RFC3339),
+				p.Repository,
+				strconv.Itoa(int(p.RepositoryID)),
+				capture,
+				strconv.FormatFloat(p.Value, 'f', -1, 64),
+			}
+			if err := cw.Write(record); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	}
+
+	return errors.Errorf("unsupported insights export format %q", format)
+}
+
+// Read reads data points in the given format from r. It is the inverse of Write.
+func Read(r io.Reader, format Format) ([]Point, error) {
+	switch format {
+	case FormatJSON:
+		var points []Point
+		if err := json.NewDecoder(r).Decode(&points); err != nil {
+			return nil, errors.Wrap(err, "decoding JSON")
+		}
+		return points, nil
+
+	case FormatCSV:
+		cr := csv.NewReader(r)
+		cr.FieldsPerRecord = len(csvHeader)
+		records, err := cr.ReadAll()
+		if err != nil {
+			return nil, errors.Wrap(err, "decoding CSV")
+		}
+		if len(records) == 0 {
+			return nil, nil
+		}
+		if strings.Join(records[0], ",") != strings.Join(csvHeader, ",") {
+			return nil, errors.Errorf("unexpected CSV header %q, want %q", strings.Join(records[0], ","), strings.Join(csvHeader, ","))
+		}
+
+		points := make([]Point, 0, len(records)-1)
+		for i, record := range records[1:] {
+			point, err := parseCSVRecord(record)
+			if err != nil {
+				// Line numbers are 1-based and the header takes the first line.
+				return nil, errors.Wrapf(err, "line %d", i+2)
+			}
+			points = append(points, point)
+		}
+		return points, nil
+	}
+
+	return nil, errors.Errorf("unsupported insights export format %q", format)
+}
+
+func parseCSVRecord(record []string) (Point, error) {
+	t, err := time.Parse(time.RFC3339, record[1])
+	if err != nil {
+		return Point{}, errors.Wrap(err, "parsing time")
+	}
+	repoID, err := strconv.Atoi(record[3])
+	if err != nil {
+		return Point{}, errors.Wrap(err, "parsing repository ID")
+	}
+	value, err := strconv.ParseFloat(record[5], 64)
+	if err != nil {
+		return Point{}, errors.Wrap(err, "parsing value")
+	}
+
+	var capture *string
+	if record[4] != "" {
+		capture = &record[4]
+	}
+	return Point{
+		SeriesID:     record[0],
+		Time:         t.UTC(),
+		Repository:   record[2],
+		RepositoryID: api.RepoID(repoID),
+		Capture:      capture,
+		Value:        value,
+	}, nil
+}
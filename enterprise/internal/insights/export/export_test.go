@@ -0,0 +1,131 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+)
+
+func TestWriteRead(t *testing.T) {
+	capture := "v1.2.3"
+	points := []Point{
+		{
+			SeriesID:     "s1",
+			Time:         time.Date(2021, time.September, 10, 10, 0, 0, 0, time.UTC),
+			Repository:   "github.com/sourcegraph/sourcegraph",
+			RepositoryID: 1,
+			Value:        42,
+		},
+		{
+			SeriesID:     "s1",
+			Time:         time.Date(2021, time.September, 10, 10, 0, 0, 0, time.UTC),
+			Repository:   "github.com/sourcegraph/src-cli",
+			RepositoryID: 2,
+			Capture:      &capture,
+			Value:        1.5,
+		},
+	}
+
+	for _, format := range []Format{FormatCSV, FormatJSON} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Write(&buf, format, points); err != nil {
+				t.Fatal(err)
+			}
+			got, err := Read(&buf, format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(points, got); diff != "" {
+				t.Errorf("unexpected points (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	err := Write(&buf, FormatCSV, []Point{{
+		SeriesID:     "s1",
+		Time:         time.Date(2021, time.September, 10, 10, 0, 0, 0, time.UTC),
+		Repository:   "github.com/sourcegraph/sourcegraph",
+		RepositoryID: 1,
+		Value:        42,
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "series_id,time,repository,repository_id,capture,value\n" +
+		"s1,2021-09-10T10:00:00Z,github.com/sourcegraph/sourcegraph,1,,42\n"
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("unexpected CSV (-want +got):\n%s", diff)
+	}
+}
+
+func TestReadCSVErrors(t *testing.T) {
+	for name, input := range map[string]string{
+		"header": "a,b,c,d,e,f\n",
+		"time":   "series_id,time,repository,repository_id,capture,value\ns1,yesterday,r,1,,42\n",
+		"value":  "series_id,time,repository,repository_id,capture,value\ns1,2021-09-10T10:00:00Z,r,1,,many\n",
+		"fields": "series_id,time,repository,repository_id,capture,value\ns1,2021-09-10T10:00:00Z\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Read(strings.NewReader(input), FormatCSV); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestImport(t *testing.T) {
+	ctx := context.Background()
+	point := Point{
+		SeriesID:     "s1",
+		Time:         time.Date(2021, time.September, 10, 10, 0, 0, 0, time.UTC),
+		Repository:   "github.com/sourcegraph/sourcegraph",
+		RepositoryID: 1,
+		Value:        42,
+	}
+
+	t.Run("unknown series", func(t *testing.T) {
+		insightsStore := store.NewMockInterface()
+		dataSeriesStore := store.NewMockDataSeriesStore()
+
+		if err := Import(ctx, insightsStore, dataSeriesStore, []Point{point}); err == nil {
+			t.Fatal("expected error")
+		}
+		if len(insightsStore.RecordSeriesPointsFunc.History()) != 0 {
+			t.Error("expected no points to be recorded")
+		}
+	})
+
+	t.Run("records points", func(t *testing.T) {
+		insightsStore := store.NewMockInterface()
+		dataSeriesStore := store.NewMockDataSeriesStore()
+		dataSeriesStore.GetDataSeriesFunc.SetDefaultReturn([]types.InsightSeries{{SeriesID: "s1"}}, nil)
+
+		if err := Import(ctx, insightsStore, dataSeriesStore, []Point{point}); err != nil {
+			t.Fatal(err)
+		}
+
+		history := insightsStore.RecordSeriesPointsFunc.History()
+		if len(history) != 1 {
+			t.Fatalf("expected one call to RecordSeriesPoints, got %d", len(history))
+		}
+		args := history[0].Arg1
+		if len(args) != 1 {
+			t.Fatalf("expected one point, got %d", len(args))
+		}
+		if args[0].SeriesID != "s1" || *args[0].RepoName != point.Repository || *args[0].RepoID != point.RepositoryID || args[0].Point.Value != 42 || args[0].PersistMode != store.RecordMode {
+			t.Errorf("unexpected recorded point %+v", args[0])
+		}
+	})
+}
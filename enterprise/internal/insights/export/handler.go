@@ -0,0 +1,147 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+)
+
+type handler struct {
+	insightsStore   store.Interface
+	metadataStore   store.InsightMetadataStore
+	dataSeriesStore store.DataSeriesStore
+	postgres        dbutil.DB
+}
+
+// NewHandler returns an HTTP handler which exports the data points of an insight on GET requests,
+// and imports data points on POST requests.
+func NewHandler(insightsStore store.Interface, insightStore *store.InsightStore, postgres dbutil.DB) http.Handler {
+	h := &handler{
+		insightsStore:   insightsStore,
+		metadataStore:   insightStore,
+		dataSeriesStore: insightStore,
+		postgres:        postgres,
+	}
+	return http.HandlerFunc(h.serve)
+}
+
+func (h *handler) serve(w http.ResponseWriter, r *http.Request) {
+	format := FormatJSON
+	if name := r.URL.Query().Get("format"); name != "" {
+		var err error
+		if format, err = ParseFormat(name); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.serveExport(w, r, format)
+	case http.MethodPost:
+		h.serveImport(w, r, format)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// GET /insights/data?id={insight unique ID}&format={csv,json}
+func (h *handler) serveExport(w http.ResponseWriter, r *http.Request, format Format) {
+	ctx := r.Context()
+
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		http.Error(w, "id must be specified", http.StatusBadRequest)
+		return
+	}
+
+	points, err := InsightPoints(ctx, h.insightsStore, h.metadataStore, database.Orgs(h.postgres), id)
+	if err != nil {
+		if errors.Is(err, ErrInsightNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		log15.Error("insights: failed to export data points", "insight", id, "error", err)
+		http.Error(w, fmt.Sprintf("failed to export data points: %s", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", id+"."+string(format)))
+	if err := Write(w, format, points); err != nil {
+		log15.Error("insights: failed to write exported data points", "insight", id, "error", err)
+	}
+}
+
+// POST /insights/data?format={csv,json}
+func (h *handler) serveImport(w http.ResponseWriter, r *http.Request, format Format) {
+	ctx := r.Context()
+
+	// 🚨 SECURITY: Imported data points are visible to everyone who can see the series, so only
+	// site admins may import them.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, h.postgres); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	points, err := Read(r.Body, format)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := Import(ctx, h.insightsStore, h.dataSeriesStore, points); err != nil {
+		http.Error(w, fmt.Sprintf("failed to import data points: %s", err), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ErrInsightNotFound is returned by InsightPoints when the insight does not exist or is not
+// visible to the current user.
+var ErrInsightNotFound = errors.New("insight not found")
+
+// InsightPoints returns the per-repository data points of all series of the insight with the
+// given unique ID.
+func InsightPoints(ctx context.Context, insightsStore store.Interface, metadataStore store.InsightMetadataStore, orgStore *database.OrgStore, uniqueID string) ([]Point, error) {
+	args := store.InsightQueryArgs{UniqueID: uniqueID}
+	if uid := actor.FromContext(ctx).UID; uid != 0 {
+		// 🚨 SECURITY
+		// only add users / orgs if the user is non-anonymous. This will restrict anonymous users to only see
+		// insights with a global grant.
+		args.UserID = []int{int(uid)}
+		orgs, err := orgStore.GetByUserID(ctx, uid)
+		if err != nil {
+			return nil, err
+		}
+		for _, org := range orgs {
+			args.OrgID = append(args.OrgID, int(org.ID))
+		}
+	}
+
+	insights, err := metadataStore.GetMapped(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	if len(insights) == 0 {
+		return nil, ErrInsightNotFound
+	}
+
+	var points []Point
+	for _, series := range insights[0].Series {
+		seriesPoints, err := SeriesPoints(ctx, insightsStore, series.SeriesID)
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, seriesPoints...)
+	}
+	return points, nil
+}
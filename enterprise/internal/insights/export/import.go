@@ -0,0 +1,66 @@
+package export
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+)
+
+// Import records the given data points. Every point must belong to an existing series and name
+// the repository it was recorded for. Points are recorded atomically, so either all or none of
+// them are stored.
+func Import(ctx context.Context, insightsStore store.Interface, dataSeriesStore store.DataSeriesStore, points []Point) error {
+	if err := validateSeries(ctx, dataSeriesStore, points); err != nil {
+		return err
+	}
+
+	args := make([]store.RecordSeriesPointArgs, 0, len(points))
+	for i, p := range points {
+		if p.Repository == "" || p.RepositoryID == 0 {
+			return errors.Errorf("point %d: repository and repository ID must be specified", i)
+		}
+		if p.Time.IsZero() {
+			return errors.Errorf("point %d: time must be specified", i)
+		}
+
+		repoName, repoID := p.Repository, p.RepositoryID
+		args = append(args, store.RecordSeriesPointArgs{
+			SeriesID: p.SeriesID,
+			Point: store.SeriesPoint{
+				SeriesID: p.SeriesID,
+				Time:     p.Time.UTC(),
+				Value:    p.Value,
+				Capture:  p.Capture,
+			},
+			RepoName:    &repoName,
+			RepoID:      &repoID,
+			PersistMode: store.RecordMode,
+		})
+	}
+	return insightsStore.RecordSeriesPoints(ctx, args)
+}
+
+// validateSeries returns an error if any of the points belongs to a series which does not exist.
+func validateSeries(ctx context.Context, dataSeriesStore store.DataSeriesStore, points []Point) error {
+	checked := make(map[string]struct{})
+	for _, p := range points {
+		if _, ok := checked[p.SeriesID]; ok {
+			continue
+		}
+		checked[p.SeriesID] = struct{}{}
+
+		if p.SeriesID == "" {
+			return errors.New("series ID must be specified")
+		}
+		series, err := dataSeriesStore.GetDataSeries(ctx, store.GetDataSeriesArgs{SeriesID: p.SeriesID})
+		if err != nil {
+			return errors.Wrap(err, "GetDataSeries")
+		}
+		if len(series) == 0 {
+			return errors.Errorf("insight series %q does not exist", p.SeriesID)
+		}
+	}
+	return nil
+}
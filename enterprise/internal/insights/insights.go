@@ -10,7 +10,9 @@ import (
 	"github.com/cockroachdb/errors"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/enterprise"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/export"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/resolvers"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbconn"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
@@ -50,6 +52,11 @@ func Init(ctx context.Context, postgres dbutil.DB, outOfBandMigrationRunner *oob
 		return err
 	}
 	enterpriseServices.InsightsResolver = resolvers.New(timescale, postgres)
+	enterpriseServices.InsightsDataHandler = export.NewHandler(
+		store.New(timescale, store.NewInsightPermissionStore(postgres)),
+		store.NewInsightStore(timescale),
+		postgres,
+	)
 	return nil
 }
 
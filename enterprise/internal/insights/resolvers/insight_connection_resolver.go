@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/sourcegraph/sourcegraph/internal/database"
 
@@ -15,6 +16,8 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/background/queryrunner"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/export"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 )
@@ -129,16 +132,81 @@ func (r *insightResolver) Title() string { return r.insight.Title }
 
 func (r *insightResolver) Description() string { return r.insight.Description }
 
-func (r *insightResolver) Series() []graphqlbackend.InsightSeriesResolver {
+func (r *insightResolver) Series(ctx context.Context, args *graphqlbackend.InsightSeriesArgs) ([]graphqlbackend.InsightSeriesResolver, error) {
 	series := r.insight.Series
 	resolvers := make([]graphqlbackend.InsightSeriesResolver, 0, len(series))
 	for _, series := range series {
+		if series.GroupBySelect {
+			grouped, err := r.groupedSeries(ctx, series, args)
+			if err != nil {
+				return nil, err
+			}
+			resolvers = append(resolvers, grouped...)
+			continue
+		}
+		resolvers = append(resolvers, &insightSeriesResolver{
+			insightsStore:   r.insightsStore,
+			workerBaseStore: r.workerBaseStore,
+			series:          series,
+			metadataStore:   r.metadataStore,
+		})
+	}
+	return resolvers, nil
+}
+
+func (r *insightResolver) Export(ctx context.Context, args *graphqlbackend.InsightExportArgs) (string, error) {
+	format, err := export.ParseFormat(args.Format)
+	if err != nil {
+		return "", err
+	}
+
+	var points []export.Point
+	for _, series := range r.insight.Series {
+		seriesPoints, err := export.SeriesPoints(ctx, r.insightsStore, series.SeriesID)
+		if err != nil {
+			return "", err
+		}
+		points = append(points, seriesPoints...)
+	}
+
+	var b strings.Builder
+	if err := export.Write(&b, format, points); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// groupedSeries returns one series for each of the queryrunner.MaxGroups distinct values with the
+// most matches recorded in the requested time range for a series which groups its results by the
+// value its query selects with select:. Each series is labelled with its value.
+func (r *insightResolver) groupedSeries(ctx context.Context, series types.InsightViewSeries, args *graphqlbackend.InsightSeriesArgs) ([]graphqlbackend.InsightSeriesResolver, error) {
+	seriesID := series.SeriesID
+	opts := store.SeriesPointsOpts{SeriesID: &seriesID, Limit: queryrunner.MaxGroups}
+	if args != nil && args.From != nil {
+		opts.From = &args.From.Time
+	} else {
+		// Default to last 12mo of data, like the points of series.
+		from := time.Now().AddDate(-1, 0, 0)
+		opts.From = &from
+	}
+	if args != nil && args.To != nil {
+		opts.To = &args.To.Time
+	}
+	captures, err := r.insightsStore.SeriesCaptures(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.InsightSeriesResolver, 0, len(captures))
+	for _, capture := range captures {
+		capture := capture
 		resolvers = append(resolvers, &insightSeriesResolver{
 			insightsStore:   r.insightsStore,
 			workerBaseStore: r.workerBaseStore,
 			series:          series,
 			metadataStore:   r.metadataStore,
+			capture:         &capture,
 		})
 	}
-	return resolvers
+	return resolvers, nil
 }
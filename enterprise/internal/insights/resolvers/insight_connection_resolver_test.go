@@ -115,8 +115,12 @@ func TestResolver_InsightConnection(t *testing.T) {
 			"title":       nodes[0].Title(),
 			"description": nodes[0].Description(),
 		})
+		series, err := nodes[0].Series(ctx, &graphqlbackend.InsightSeriesArgs{})
+		if err != nil {
+			t.Fatal(err)
+		}
 		// TODO(slimsag): put series length into map (autogold bug, omits the field for some reason?)
-		autogold.Want("first insight: series length", int(1)).Equal(t, len(nodes[0].Series()))
+		autogold.Want("first insight: series length", int(1)).Equal(t, len(series))
 	})
 }
 
@@ -301,7 +305,10 @@ func resolvePoints(ctx context.Context, conn graphqlbackend.InsightConnectionRes
 	}
 
 	expected := nodes[0]
-	seriesResolvers := expected.Series()
+	seriesResolvers, err := expected.Series(ctx, &graphqlbackend.InsightSeriesArgs{})
+	if err != nil {
+		t.Fatal(err)
+	}
 	if len(seriesResolvers) != 1 {
 		t.Errorf("unexpected length of series resolvers: want: %v got: %v", 1, len(seriesResolvers))
 	}
@@ -78,7 +78,12 @@ func TestResolver_InsightSeries(t *testing.T) {
 		}
 		var series [][]graphqlbackend.InsightSeriesResolver
 		for _, node := range nodes {
-			series = append(series, node.Series())
+			nodeSeries, err := node.Series(ctx, &graphqlbackend.InsightSeriesArgs{})
+			if err != nil {
+				cleanup()
+				t.Fatal(err)
+			}
+			series = append(series, nodeSeries)
 		}
 		return ctx, series, mockStore, cleanup
 	}
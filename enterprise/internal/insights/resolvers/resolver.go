@@ -3,13 +3,16 @@ package resolvers
 import (
 	"context"
 	"database/sql"
+	"strings"
 	"time"
 
 	"github.com/sourcegraph/sourcegraph/internal/database"
 
 	"github.com/cockroachdb/errors"
 
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/export"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
@@ -69,6 +72,27 @@ func (r *Resolver) InsightDashboards(ctx context.Context, args *graphqlbackend.I
 	}, nil
 }
 
+func (r *Resolver) ImportInsightsData(ctx context.Context, args *graphqlbackend.ImportInsightsDataArgs) (*graphqlbackend.EmptyResponse, error) {
+	// 🚨 SECURITY: Imported data points are visible to everyone who can see the series, so only
+	// site admins may import them.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.postgresDatabase); err != nil {
+		return nil, err
+	}
+
+	format, err := export.ParseFormat(args.Format)
+	if err != nil {
+		return nil, err
+	}
+	points, err := export.Read(strings.NewReader(args.Data), format)
+	if err != nil {
+		return nil, err
+	}
+	if err := export.Import(ctx, r.insightsStore, store.NewInsightStore(r.insightsDatabase), points); err != nil {
+		return nil, err
+	}
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
 type disabledResolver struct {
 	reason string
 }
@@ -84,3 +108,7 @@ func (r *disabledResolver) Insights(ctx context.Context, args *graphqlbackend.In
 func (r *disabledResolver) InsightDashboards(ctx context.Context, args *graphqlbackend.InsightDashboardsArgs) (graphqlbackend.InsightsDashboardConnectionResolver, error) {
 	return nil, errors.New(r.reason)
 }
+
+func (r *disabledResolver) ImportInsightsData(ctx context.Context, args *graphqlbackend.ImportInsightsDataArgs) (*graphqlbackend.EmptyResponse, error) {
+	return nil, errors.New(r.reason)
+}
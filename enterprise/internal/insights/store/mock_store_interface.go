@@ -21,6 +21,12 @@ type MockInterface struct {
 	// RecordSeriesPointsFunc is an instance of a mock function object
 	// controlling the behavior of the method RecordSeriesPoints.
 	RecordSeriesPointsFunc *InterfaceRecordSeriesPointsFunc
+	// RepoSeriesPointsFunc is an instance of a mock function object
+	// controlling the behavior of the method RepoSeriesPoints.
+	RepoSeriesPointsFunc *InterfaceRepoSeriesPointsFunc
+	// SeriesCapturesFunc is an instance of a mock function object
+	// controlling the behavior of the method SeriesCaptures.
+	SeriesCapturesFunc *InterfaceSeriesCapturesFunc
 	// SeriesPointsFunc is an instance of a mock function object controlling
 	// the behavior of the method SeriesPoints.
 	SeriesPointsFunc *InterfaceSeriesPointsFunc
@@ -45,6 +51,16 @@ func NewMockInterface() *MockInterface {
 				return nil
 			},
 		},
+		RepoSeriesPointsFunc: &InterfaceRepoSeriesPointsFunc{
+			defaultHook: func(context.Context, SeriesPointsOpts) ([]RepoSeriesPoint, error) {
+				return nil, nil
+			},
+		},
+		SeriesCapturesFunc: &InterfaceSeriesCapturesFunc{
+			defaultHook: func(context.Context, SeriesPointsOpts) ([]string, error) {
+				return nil, nil
+			},
+		},
 		SeriesPointsFunc: &InterfaceSeriesPointsFunc{
 			defaultHook: func(context.Context, SeriesPointsOpts) ([]SeriesPoint, error) {
 				return nil, nil
@@ -66,6 +82,12 @@ func NewMockInterfaceFrom(i Interface) *MockInterface {
 		RecordSeriesPointsFunc: &InterfaceRecordSeriesPointsFunc{
 			defaultHook: i.RecordSeriesPoints,
 		},
+		RepoSeriesPointsFunc: &InterfaceRepoSeriesPointsFunc{
+			defaultHook: i.RepoSeriesPoints,
+		},
+		SeriesCapturesFunc: &InterfaceSeriesCapturesFunc{
+			defaultHook: i.SeriesCaptures,
+		},
 		SeriesPointsFunc: &InterfaceSeriesPointsFunc{
 			defaultHook: i.SeriesPoints,
 		},
@@ -393,6 +415,224 @@ func (c InterfaceRecordSeriesPointsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// InterfaceRepoSeriesPointsFunc describes the behavior when the
+// RepoSeriesPoints method of the parent MockInterface instance is invoked.
+type InterfaceRepoSeriesPointsFunc struct {
+	defaultHook func(context.Context, SeriesPointsOpts) ([]RepoSeriesPoint, error)
+	hooks       []func(context.Context, SeriesPointsOpts) ([]RepoSeriesPoint, error)
+	history     []InterfaceRepoSeriesPointsFuncCall
+	mutex       sync.Mutex
+}
+
+// RepoSeriesPoints delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockInterface) RepoSeriesPoints(v0 context.Context, v1 SeriesPointsOpts) ([]RepoSeriesPoint, error) {
+	r0, r1 := m.RepoSeriesPointsFunc.nextHook()(v0, v1)
+	m.RepoSeriesPointsFunc.appendCall(InterfaceRepoSeriesPointsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the RepoSeriesPoints
+// method of the parent MockInterface instance is invoked and the hook queue
+// is empty.
+func (f *InterfaceRepoSeriesPointsFunc) SetDefaultHook(hook func(context.Context, SeriesPointsOpts) ([]RepoSeriesPoint, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RepoSeriesPoints method of the parent MockInterface instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *InterfaceRepoSeriesPointsFunc) PushHook(hook func(context.Context, SeriesPointsOpts) ([]RepoSeriesPoint, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *InterfaceRepoSeriesPointsFunc) SetDefaultReturn(r0 []RepoSeriesPoint, r1 error) {
+	f.SetDefaultHook(func(context.Context, SeriesPointsOpts) ([]RepoSeriesPoint, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *InterfaceRepoSeriesPointsFunc) PushReturn(r0 []RepoSeriesPoint, r1 error) {
+	f.PushHook(func(context.Context, SeriesPointsOpts) ([]RepoSeriesPoint, error) {
+		return r0, r1
+	})
+}
+
+func (f *InterfaceRepoSeriesPointsFunc) nextHook() func(context.Context, SeriesPointsOpts) ([]RepoSeriesPoint, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *InterfaceRepoSeriesPointsFunc) appendCall(r0 InterfaceRepoSeriesPointsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of InterfaceRepoSeriesPointsFuncCall objects
+// describing the invocations of this function.
+func (f *InterfaceRepoSeriesPointsFunc) History() []InterfaceRepoSeriesPointsFuncCall {
+	f.mutex.Lock()
+	history := make([]InterfaceRepoSeriesPointsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// InterfaceRepoSeriesPointsFuncCall is an object that describes an
+// invocation of method RepoSeriesPoints on an instance of MockInterface.
+type InterfaceRepoSeriesPointsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 SeriesPointsOpts
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []RepoSeriesPoint
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c InterfaceRepoSeriesPointsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c InterfaceRepoSeriesPointsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// InterfaceSeriesCapturesFunc describes the behavior when the
+// SeriesCaptures method of the parent MockInterface instance is invoked.
+type InterfaceSeriesCapturesFunc struct {
+	defaultHook func(context.Context, SeriesPointsOpts) ([]string, error)
+	hooks       []func(context.Context, SeriesPointsOpts) ([]string, error)
+	history     []InterfaceSeriesCapturesFuncCall
+	mutex       sync.Mutex
+}
+
+// SeriesCaptures delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockInterface) SeriesCaptures(v0 context.Context, v1 SeriesPointsOpts) ([]string, error) {
+	r0, r1 := m.SeriesCapturesFunc.nextHook()(v0, v1)
+	m.SeriesCapturesFunc.appendCall(InterfaceSeriesCapturesFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the SeriesCaptures
+// method of the parent MockInterface instance is invoked and the hook queue
+// is empty.
+func (f *InterfaceSeriesCapturesFunc) SetDefaultHook(hook func(context.Context, SeriesPointsOpts) ([]string, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SeriesCaptures method of the parent MockInterface instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *InterfaceSeriesCapturesFunc) PushHook(hook func(context.Context, SeriesPointsOpts) ([]string, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *InterfaceSeriesCapturesFunc) SetDefaultReturn(r0 []string, r1 error) {
+	f.SetDefaultHook(func(context.Context, SeriesPointsOpts) ([]string, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *InterfaceSeriesCapturesFunc) PushReturn(r0 []string, r1 error) {
+	f.PushHook(func(context.Context, SeriesPointsOpts) ([]string, error) {
+		return r0, r1
+	})
+}
+
+func (f *InterfaceSeriesCapturesFunc) nextHook() func(context.Context, SeriesPointsOpts) ([]string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *InterfaceSeriesCapturesFunc) appendCall(r0 InterfaceSeriesCapturesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of InterfaceSeriesCapturesFuncCall objects
+// describing the invocations of this function.
+func (f *InterfaceSeriesCapturesFunc) History() []InterfaceSeriesCapturesFuncCall {
+	f.mutex.Lock()
+	history := make([]InterfaceSeriesCapturesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// InterfaceSeriesCapturesFuncCall is an object that describes an invocation
+// of method SeriesCaptures on an instance of MockInterface.
+type InterfaceSeriesCapturesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 SeriesPointsOpts
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c InterfaceSeriesCapturesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c InterfaceSeriesCapturesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // InterfaceSeriesPointsFunc describes the behavior when the SeriesPoints
 // method of the parent MockInterface instance is invoked.
 type InterfaceSeriesPointsFunc struct {
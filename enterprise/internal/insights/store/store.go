@@ -23,6 +23,8 @@ import (
 // for actual API usage.
 type Interface interface {
 	SeriesPoints(ctx context.Context, opts SeriesPointsOpts) ([]SeriesPoint, error)
+	SeriesCaptures(ctx context.Context, opts SeriesPointsOpts) ([]string, error)
+	RepoSeriesPoints(ctx context.Context, opts SeriesPointsOpts) ([]RepoSeriesPoint, error)
 	RecordSeriesPoint(ctx context.Context, v RecordSeriesPointArgs) error
 	RecordSeriesPoints(ctx context.Context, pts []RecordSeriesPointArgs) error
 	CountData(ctx context.Context, opts CountDataOpts) (int, error)
@@ -87,9 +89,16 @@ type SeriesPoint struct {
 	Time     time.Time
 	Value    float64
 	Metadata []byte
+
+	// Capture is the value of the capture the results were grouped by, if the
+	// series groups its results.
+	Capture *string
 }
 
 func (s *SeriesPoint) String() string {
+	if s.Capture != nil {
+		return fmt.Sprintf("SeriesPoint{Time: %q, Value: %v, Metadata: %s, Capture: %q}", s.Time, s.Value, s.Metadata, *s.Capture)
+	}
 	return fmt.Sprintf("SeriesPoint{Time: %q, Value: %v, Metadata: %s}", s.Time, s.Value, s.Metadata)
 }
 
@@ -110,6 +119,9 @@ type SeriesPointsOpts struct {
 	IncludeRepoRegex string
 	ExcludeRepoRegex string
 
+	// Capture, if non-nil, indicates to filter results to only points recorded with this capture.
+	Capture *string
+
 	// Time ranges to query from/to, if non-nil, in UTC.
 	From, To *time.Time
 
@@ -146,6 +158,7 @@ func (s *Store) SeriesPoints(ctx context.Context, opts SeriesPointsOpts) ([]Seri
 			&point.Time,
 			&point.Value,
 			&point.Metadata,
+			&point.Capture,
 		)
 		if err != nil {
 			return err
@@ -156,25 +169,69 @@ func (s *Store) SeriesPoints(ctx context.Context, opts SeriesPointsOpts) ([]Seri
 	return points, err
 }
 
+// SeriesCaptures returns the distinct values the data points of a series which groups its results
+// were recorded with, ordered by their number of matches. Only the series ID, time range, repository
+// and limit options of opts are used.
+func (s *Store) SeriesCaptures(ctx context.Context, opts SeriesPointsOpts) ([]string, error) {
+	// 🚨 SECURITY: Captures can be repository names or file contents, so we exclude those of
+	// repositories the current user cannot see, like in SeriesPoints. 🚨
+	denylist, err := s.permStore.GetUnauthorizedRepoIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	opts.Excluded = append(opts.Excluded, denylist...)
+	opts.Capture = nil
+
+	limitClause := ""
+	if opts.Limit > 0 {
+		limitClause = fmt.Sprintf("LIMIT %d", opts.Limit)
+	}
+	q := sqlf.Sprintf(seriesCapturesSql+limitClause, sqlf.Join(seriesPointsPredicates(opts), "\n AND "))
+	var captures []string
+	err = s.query(ctx, q, func(sc scanner) error {
+		var capture string
+		if err := sc.Scan(&capture); err != nil {
+			return err
+		}
+		captures = append(captures, capture)
+		return nil
+	})
+	return captures, err
+}
+
+const seriesCapturesSql = `
+-- source: enterprise/internal/insights/store/store.go:SeriesCaptures
+SELECT sp.capture
+FROM (  select * from series_points
+		union
+		select * from series_points_snapshots
+) AS sp
+JOIN repo_names rn ON sp.repo_name_id = rn.id
+WHERE sp.capture IS NOT NULL AND %s
+GROUP BY sp.capture
+ORDER BY SUM(sp.value) DESC, sp.capture
+`
+
 // Note: the inner query could return duplicate points on its own if we merely did a SUM(value) over
 // all desired repositories. By using the sub-query, we select the per-repository maximum (thus
 // eliminating duplicate points that might have been recorded in a given interval for a given repository)
-// and then SUM the result for each repository, giving us our final total number.
+// and then SUM the result for each repository, giving us our final total number. Points of series which
+// group their results are aggregated per capture.
 const fullVectorSeriesAggregation = `
 -- source: enterprise/internal/insights/store/store.go:SeriesPoints
-SELECT sub.series_id, sub.interval_time, SUM(sub.value) as value, sub.metadata FROM (
-	SELECT sp.repo_name_id, sp.series_id, sp.time AS interval_time, MAX(value) as value, null as metadata
+SELECT sub.series_id, sub.interval_time, SUM(sub.value) as value, sub.metadata, sub.capture FROM (
+	SELECT sp.repo_name_id, sp.series_id, sp.time AS interval_time, MAX(value) as value, null as metadata, sp.capture
 	FROM (  select * from series_points
 			union
 			select * from series_points_snapshots
 	) AS sp
 	JOIN repo_names rn ON sp.repo_name_id = rn.id
 	WHERE %s
-	GROUP BY sp.series_id, interval_time, sp.repo_name_id
+	GROUP BY sp.series_id, interval_time, sp.repo_name_id, sp.capture
 	ORDER BY sp.series_id, interval_time, sp.repo_name_id DESC
 ) sub
-GROUP BY sub.series_id, sub.interval_time, sub.metadata
-ORDER BY sub.series_id, sub.interval_time DESC
+GROUP BY sub.series_id, sub.interval_time, sub.metadata, sub.capture
+ORDER BY sub.series_id, sub.interval_time DESC, sub.capture
 `
 
 // Note that the series_points table may contain duplicate points, or points recorded at irregular
@@ -188,6 +245,18 @@ ORDER BY sub.series_id, sub.interval_time DESC
 // 3. Searches may not complete at the same exact time, so even in a perfect world if the interval
 //    should be 12h it may be off by a minute or so.
 func seriesPointsQuery(opts SeriesPointsOpts) *sqlf.Query {
+	limitClause := ""
+	if opts.Limit > 0 {
+		limitClause = fmt.Sprintf("LIMIT %d", opts.Limit)
+	}
+	return sqlf.Sprintf(
+		fullVectorSeriesAggregation+limitClause,
+		sqlf.Join(seriesPointsPredicates(opts), "\n AND "),
+	)
+}
+
+// seriesPointsPredicates returns the WHERE clause predicates shared by the series points queries.
+func seriesPointsPredicates(opts SeriesPointsOpts) []*sqlf.Query {
 	preds := []*sqlf.Query{}
 
 	if opts.SeriesID != nil {
@@ -202,9 +271,8 @@ func seriesPointsQuery(opts SeriesPointsOpts) *sqlf.Query {
 	if opts.To != nil {
 		preds = append(preds, sqlf.Sprintf("time <= %s", *opts.To))
 	}
-	limitClause := ""
-	if opts.Limit > 0 {
-		limitClause = fmt.Sprintf("LIMIT %d", opts.Limit)
+	if opts.Capture != nil {
+		preds = append(preds, sqlf.Sprintf("capture = %s", *opts.Capture))
 	}
 	if len(opts.Included) > 0 {
 		s := fmt.Sprintf("repo_id = any(%v)", values(opts.Included))
@@ -224,9 +292,78 @@ func seriesPointsQuery(opts SeriesPointsOpts) *sqlf.Query {
 	if len(preds) == 0 {
 		preds = append(preds, sqlf.Sprintf("TRUE"))
 	}
+	return preds
+}
+
+// RepoSeriesPoint describes a single insights' series data point for a single repository.
+type RepoSeriesPoint struct {
+	SeriesID string
+	// Time (always UTC).
+	Time     time.Time
+	RepoName string
+	RepoID   api.RepoID
+	Value    float64
+
+	// Capture is the value of the capture the results were grouped by, if the
+	// series groups its results.
+	Capture *string
+}
+
+// RepoSeriesPoints queries data points over time for a specific insights' series, broken down per
+// repository rather than aggregated over all repositories. Repository permissions are enforced the
+// same way as in SeriesPoints.
+func (s *Store) RepoSeriesPoints(ctx context.Context, opts SeriesPointsOpts) ([]RepoSeriesPoint, error) {
+	points := make([]RepoSeriesPoint, 0, opts.Limit)
+
+	// 🚨 SECURITY: See SeriesPoints for why repo permissions are enforced using a denylist. 🚨
+	denylist, err := s.permStore.GetUnauthorizedRepoIDs(ctx)
+	if err != nil {
+		return []RepoSeriesPoint{}, err
+	}
+	opts.Excluded = append(opts.Excluded, denylist...)
+
+	err = s.query(ctx, repoSeriesPointsQuery(opts), func(sc scanner) error {
+		var point RepoSeriesPoint
+		err := sc.Scan(
+			&point.SeriesID,
+			&point.Time,
+			&point.RepoName,
+			&point.RepoID,
+			&point.Capture,
+			&point.Value,
+		)
+		if err != nil {
+			return err
+		}
+		points = append(points, point)
+		return nil
+	})
+	return points, err
+}
+
+// Note: duplicate points recorded in a given interval for a given repository are eliminated by
+// selecting the per-repository maximum, the same way fullVectorSeriesAggregation does.
+const repoSeriesPointsFmtstr = `
+-- source: enterprise/internal/insights/store/store.go:RepoSeriesPoints
+SELECT sp.series_id, sp.time, rn.name, sp.repo_id, sp.capture, MAX(sp.value) AS value
+FROM (  select * from series_points
+		union
+		select * from series_points_snapshots
+) AS sp
+JOIN repo_names rn ON sp.repo_name_id = rn.id
+WHERE %s
+GROUP BY sp.series_id, sp.time, rn.name, sp.repo_id, sp.capture
+ORDER BY sp.series_id, sp.time, rn.name, sp.capture
+`
+
+func repoSeriesPointsQuery(opts SeriesPointsOpts) *sqlf.Query {
+	limitClause := ""
+	if opts.Limit > 0 {
+		limitClause = fmt.Sprintf("LIMIT %d", opts.Limit)
+	}
 	return sqlf.Sprintf(
-		fullVectorSeriesAggregation+limitClause,
-		sqlf.Join(preds, "\n AND "),
+		repoSeriesPointsFmtstr+limitClause,
+		sqlf.Join(seriesPointsPredicates(opts), "\n AND "),
 	)
 }
 
@@ -412,6 +549,7 @@ func (s *Store) RecordSeriesPoint(ctx context.Context, v RecordSeriesPointArgs)
 		v.RepoID,           // repo_id
 		repoNameID,         // repo_name_id
 		repoNameID,         // original_repo_name_id
+		v.Point.Capture,    // capture
 	)
 	// Insert the actual data point.
 	return txStore.Exec(ctx, q)
@@ -427,7 +565,7 @@ func (s *Store) RecordSeriesPoints(ctx context.Context, pts []RecordSeriesPointA
 
 	for _, pt := range pts {
 		// this is a pretty naive implementation, this can be refactored to reduce db calls
-		if err := s.RecordSeriesPoint(ctx, pt); err != nil {
+		if err := tx.RecordSeriesPoint(ctx, pt); err != nil {
 			return err
 		}
 	}
@@ -469,8 +607,9 @@ INSERT INTO %s (
 	metadata_id,
 	repo_id,
 	repo_name_id,
-	original_repo_name_id)
-VALUES (%s, %s, %s, %s, %s, %s, %s);
+	original_repo_name_id,
+	capture)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s);
 `
 
 func (s *Store) query(ctx context.Context, q *sqlf.Query, sc scanFunc) error {
@@ -464,6 +464,74 @@ func TestRecordSeriesPointsRecordingOnly(t *testing.T) {
 	}
 }
 
+func TestRepoSeriesPoints(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	clock := timeutil.Now
+	timescale, cleanup := insightsdbtesting.TimescaleDB(t)
+	defer cleanup()
+	postgres := dbtest.NewDB(t, "")
+	permStore := NewInsightPermissionStore(postgres)
+	store := NewWithClock(timescale, permStore, clock)
+
+	optionalString := func(v string) *string { return &v }
+	optionalRepoID := func(v api.RepoID) *api.RepoID { return &v }
+
+	current := time.Date(2021, time.September, 10, 10, 0, 0, 0, time.UTC)
+
+	for _, record := range []RecordSeriesPointArgs{
+		{
+			SeriesID:    "one",
+			Point:       SeriesPoint{Time: current, Value: 1},
+			RepoName:    optionalString("repo1"),
+			RepoID:      optionalRepoID(3),
+			PersistMode: RecordMode,
+		},
+		{
+			// Duplicate point for the same repository, only the maximum is returned.
+			SeriesID:    "one",
+			Point:       SeriesPoint{Time: current, Value: 2},
+			RepoName:    optionalString("repo1"),
+			RepoID:      optionalRepoID(3),
+			PersistMode: RecordMode,
+		},
+		{
+			SeriesID:    "one",
+			Point:       SeriesPoint{Time: current, Value: 5},
+			RepoName:    optionalString("repo2"),
+			RepoID:      optionalRepoID(4),
+			PersistMode: SnapshotMode,
+		},
+		{
+			SeriesID:    "two",
+			Point:       SeriesPoint{Time: current, Value: 7},
+			RepoName:    optionalString("repo1"),
+			RepoID:      optionalRepoID(3),
+			PersistMode: RecordMode,
+		},
+	} {
+		if err := store.RecordSeriesPoint(ctx, record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	seriesID := "one"
+	points, err := store.RepoSeriesPoints(ctx, SeriesPointsOpts{SeriesID: &seriesID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []RepoSeriesPoint{
+		{SeriesID: "one", Time: current, RepoName: "repo1", RepoID: 3, Value: 2},
+		{SeriesID: "one", Time: current, RepoName: "repo2", RepoID: 4, Value: 5},
+	}
+	if diff := cmp.Diff(want, points); diff != "" {
+		t.Errorf("unexpected repo series points (-want +got): %v", diff)
+	}
+}
+
 func TestDeleteSnapshots(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
@@ -21,6 +21,7 @@ type InsightViewSeries struct {
 	RecordingIntervalDays int
 	Label                 string
 	Stroke                string
+	GroupBySelect         bool
 }
 
 type Insight struct {
@@ -58,6 +59,7 @@ type InsightSeries struct {
 	NextSnapshotAfter     time.Time
 	BackfillQueuedAt      time.Time
 	RecordingIntervalDays int
+	GroupBySelect         bool // If true, results are grouped by the value the query selects with select:.
 }
 
 type DirtyQuery struct {
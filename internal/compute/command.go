@@ -0,0 +1,69 @@
+package compute
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Command is a compute operation that is evaluated over the results of a
+// search.
+type Command interface {
+	command()
+	String() string
+}
+
+var (
+	_ Command = (*MatchOnly)(nil)
+	_ Command = (*Replace)(nil)
+	_ Command = (*Output)(nil)
+	_ Command = (*Count)(nil)
+)
+
+// MatchOnly returns the matches of MatchPattern along with the environment of
+// submatches of each match.
+type MatchOnly struct {
+	MatchPattern *regexp.Regexp
+}
+
+// Replace replaces all matches of MatchPattern in the content of a file with
+// the evaluated ReplacePattern template.
+type Replace struct {
+	MatchPattern   *regexp.Regexp
+	ReplacePattern string
+}
+
+// Output returns the evaluated OutputPattern template for every match of
+// MatchPattern.
+type Output struct {
+	MatchPattern  *regexp.Regexp
+	OutputPattern string
+}
+
+// Count groups all matches of MatchPattern by the evaluated GroupPattern
+// template and counts the matches in each group. An empty GroupPattern groups
+// matches by their value.
+type Count struct {
+	MatchPattern *regexp.Regexp
+	GroupPattern string
+}
+
+func (MatchOnly) command() {}
+func (Replace) command()   {}
+func (Output) command()    {}
+func (Count) command()     {}
+
+func (c *MatchOnly) String() string {
+	return fmt.Sprintf("Match only search pattern: %s", c.MatchPattern.String())
+}
+
+func (c *Replace) String() string {
+	return fmt.Sprintf("Replace in place: (%s) -> (%s)", c.MatchPattern.String(), c.ReplacePattern)
+}
+
+func (c *Output) String() string {
+	return fmt.Sprintf("Output: (%s) -> (%s)", c.MatchPattern.String(), c.OutputPattern)
+}
+
+func (c *Count) String() string {
+	return fmt.Sprintf("Count: (%s) -> (%s)", c.MatchPattern.String(), c.GroupPattern)
+}
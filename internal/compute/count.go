@@ -0,0 +1,59 @@
+package compute
+
+import (
+	"sort"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+// Run groups the matches of the match pattern in the matched lines of all
+// given file matches by the value of the group template and returns the groups
+// ordered by descending count.
+func (c *Count) Run(matches []result.Match) []*Group {
+	counter := NewCounter(c)
+	counter.Add(matches)
+	return counter.Groups()
+}
+
+// Counter accumulates the groups of a count command over successive batches of
+// search results, such as the events of a streaming search.
+type Counter struct {
+	command *Count
+	counts  map[string]int
+}
+
+// NewCounter returns a counter without any groups.
+func NewCounter(command *Count) *Counter {
+	return &Counter{command: command, counts: map[string]int{}}
+}
+
+// Add counts the matches of the match pattern in the given search results.
+func (c *Counter) Add(matches []result.Match) {
+	template := c.command.GroupPattern
+	if template == "" {
+		template = "$0"
+	}
+
+	for _, m := range matches {
+		if fm, ok := m.(*result.FileMatch); ok {
+			for _, value := range evaluateTemplate(fm, c.command.MatchPattern, template) {
+				c.counts[value]++
+			}
+		}
+	}
+}
+
+// Groups returns the groups counted so far ordered by descending count.
+func (c *Counter) Groups() []*Group {
+	groups := make([]*Group, 0, len(c.counts))
+	for value, count := range c.counts {
+		groups = append(groups, &Group{Value: value, Count: count})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Count != groups[j].Count {
+			return groups[i].Count > groups[j].Count
+		}
+		return groups[i].Value < groups[j].Value
+	})
+	return groups
+}
@@ -0,0 +1,8 @@
+package compute
+
+// Group is a value computed from search results along with the number of
+// matches that computed it.
+type Group struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
@@ -41,8 +41,10 @@ type Match struct {
 }
 
 type MatchContext struct {
-	Matches []Match `json:"matches"`
-	Path    string  `json:"path"`
+	Repository string  `json:"repository,omitempty"`
+	Commit     string  `json:"commit,omitempty"`
+	Matches    []Match `json:"matches"`
+	Path       string  `json:"path"`
 }
 
 func newLocation(line, column, offset int) Location {
@@ -106,5 +108,10 @@ func FromFileMatch(fm *result.FileMatch, r *regexp.Regexp) *MatchContext {
 			matches = append(matches, fromRegexpMatches(regexpMatches, r.SubexpNames(), l.Preview, int(l.LineNumber)))
 		}
 	}
-	return &MatchContext{Matches: matches, Path: fm.Path}
+	return &MatchContext{
+		Repository: string(fm.Repo.Name),
+		Commit:     string(fm.CommitID),
+		Matches:    matches,
+		Path:       fm.Path,
+	}
 }
@@ -0,0 +1,38 @@
+package compute
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+// Run returns the output template evaluated for every match of the match
+// pattern in the matched lines of the given file match, one per line. It
+// returns nil if no line matches.
+func (c *Output) Run(fm *result.FileMatch) *Text {
+	values := evaluateTemplate(fm, c.MatchPattern, c.OutputPattern)
+	if len(values) == 0 {
+		return nil
+	}
+
+	return &Text{
+		Repository: string(fm.Repo.Name),
+		Commit:     string(fm.CommitID),
+		Path:       fm.Path,
+		Value:      strings.Join(values, "\n"),
+		Kind:       "output",
+	}
+}
+
+// evaluateTemplate returns the template evaluated in the environment of every
+// match of r in the matched lines of the given file match.
+func evaluateTemplate(fm *result.FileMatch, r *regexp.Regexp, template string) []string {
+	var values []string
+	for _, l := range fm.LineMatches {
+		for _, submatch := range r.FindAllStringSubmatchIndex(l.Preview, -1) {
+			values = append(values, substituteTemplate(template, templateEnvironment(fm, r, l.Preview, submatch)))
+		}
+	}
+	return values
+}
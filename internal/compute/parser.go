@@ -0,0 +1,138 @@
+package compute
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+)
+
+// Query is a parsed compute query.
+type Query struct {
+	// Command is evaluated over the results of the search.
+	Command Command
+
+	// SearchQuery is the search query that finds the data the command is
+	// evaluated over.
+	SearchQuery string
+}
+
+// CommandResultCount is the number of search results that a command is
+// evaluated over if the query does not specify a count. Commands aggregate over
+// many results, so the default count of a search is raised explicitly. Queries
+// may raise it further with count:all.
+const CommandResultCount = 10000
+
+var commandPattern = lazyregexp.New(`(?s)^(replace|output|count)\((.*)\)$`)
+
+// Parse parses a compute query. The pattern of the query is either a
+// regular expression, which returns the matches of the expression, or one of
+// the commands
+//
+//     content:replace(pattern -> template)
+//     content:output(pattern -> template)
+//     content:count(pattern -> template)
+//
+// where pattern is a regular expression and template may refer to its capture
+// groups as $1 or $name, and to the $repo, $path, and $commit of the result.
+// The template of count is optional. Commands are evaluated over up to
+// CommandResultCount search results unless the query specifies a count.
+func Parse(q string) (*Query, error) {
+	plan, err := query.Pipeline(query.Init(q, query.SearchTypeRegex))
+	if err != nil {
+		return nil, err
+	}
+	if len(plan) != 1 {
+		return nil, errors.New("compute endpoint only supports one search pattern currently ('and' or 'or' operators are not supported yet)")
+	}
+
+	pattern, err := extractPattern(plan[0])
+	if err != nil {
+		return nil, err
+	}
+
+	submatches := commandPattern.FindStringSubmatch(pattern.Value)
+	if submatches == nil {
+		rp, err := compileMatchPattern(pattern.Value)
+		if err != nil {
+			return nil, err
+		}
+		return &Query{Command: &MatchOnly{MatchPattern: rp}, SearchQuery: q}, nil
+	}
+
+	name, args := submatches[1], submatches[2]
+	matchPattern, template, hasTemplate := splitTemplate(args)
+	if !hasTemplate && name != "count" {
+		return nil, errors.Errorf("compute command %s expects an argument of the form %s(pattern -> template)", name, name)
+	}
+	rp, err := compileMatchPattern(matchPattern)
+	if err != nil {
+		return nil, err
+	}
+
+	var command Command
+	switch name {
+	case "replace":
+		command = &Replace{MatchPattern: rp, ReplacePattern: template}
+	case "output":
+		command = &Output{MatchPattern: rp, OutputPattern: template}
+	case "count":
+		command = &Count{MatchPattern: rp, GroupPattern: template}
+	}
+
+	basic := plan[0]
+	if basic.GetCount() == "" {
+		basic = basic.AddCount(CommandResultCount)
+	}
+
+	return &Query{Command: command, SearchQuery: toSearchQuery(basic, matchPattern)}, nil
+}
+
+// extractPattern returns the single, non-negated pattern of the given basic
+// query.
+func extractPattern(basic query.Basic) (query.Pattern, error) {
+	switch node := basic.Pattern.(type) {
+	case query.Operator:
+		if len(node.Operands) == 1 {
+			if pattern, ok := node.Operands[0].(query.Pattern); ok && !pattern.Negated {
+				return pattern, nil
+			}
+		}
+	case query.Pattern:
+		if !node.Negated {
+			return node, nil
+		}
+	}
+	return query.Pattern{}, errors.New("compute endpoint only supports one search pattern currently ('and' or 'or' operators are not supported yet)")
+}
+
+// splitTemplate splits the arguments of a command at the first arrow into
+// the match pattern and the template. Whitespace around either is ignored.
+func splitTemplate(args string) (matchPattern, template string, ok bool) {
+	i := strings.Index(args, "->")
+	if i < 0 {
+		return strings.TrimSpace(args), "", false
+	}
+	return strings.TrimSpace(args[:i]), strings.TrimSpace(args[i+len("->"):]), true
+}
+
+func compileMatchPattern(pattern string) (*regexp.Regexp, error) {
+	rp, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, errors.Wrap(err, "regular expression is not valid for compute endpoint")
+	}
+	return rp, nil
+}
+
+// toSearchQuery returns the search query that finds the given match pattern,
+// subject to the parameters of the given query.
+func toSearchQuery(basic query.Basic, matchPattern string) string {
+	nodes := query.MapPattern(basic.ToParseTree(), func(_ string, negated bool, annotation query.Annotation) query.Node {
+		annotation.Labels |= query.Quoted
+		return query.Parameter{Field: query.FieldContent, Value: matchPattern, Negated: negated, Annotation: annotation}
+	})
+	return query.StringHuman(nodes)
+}
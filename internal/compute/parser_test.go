@@ -0,0 +1,75 @@
+package compute
+
+import (
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	for _, tc := range []struct {
+		query       string
+		command     string
+		searchQuery string
+	}{
+		{
+			query:       `a(b)c`,
+			command:     `Match only search pattern: a(b)c`,
+			searchQuery: `a(b)c`,
+		},
+		{
+			query:       `content:replace(foo -> bar) repo:baz`,
+			command:     `Replace in place: (foo) -> (bar)`,
+			searchQuery: `repo:baz count:10000 content:"foo"`,
+		},
+		{
+			query:       `lang:go content:output((\w+) v(\d+) -> $1 in $repo)`,
+			command:     `Output: ((\w+) v(\d+)) -> ($1 in $repo)`,
+			searchQuery: `lang:go count:10000 content:"(\\w+) v(\\d+)"`,
+		},
+		{
+			query:       `content:count(v(\d+))`,
+			command:     `Count: (v(\d+)) -> ()`,
+			searchQuery: `count:10000 content:"v(\\d+)"`,
+		},
+		{
+			query:       `content:count(v(\d+) -> $1)`,
+			command:     `Count: (v(\d+)) -> ($1)`,
+			searchQuery: `count:10000 content:"v(\\d+)"`,
+		},
+		{
+			query:       `content:count(v(\d+)) count:5`,
+			command:     `Count: (v(\d+)) -> ()`,
+			searchQuery: `count:5 content:"v(\\d+)"`,
+		},
+		{
+			query:       `content:count(v(\d+)) count:all`,
+			command:     `Count: (v(\d+)) -> ()`,
+			searchQuery: `count:99999999 content:"v(\\d+)"`,
+		},
+	} {
+		t.Run(tc.query, func(t *testing.T) {
+			q, err := Parse(tc.query)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if have := q.Command.String(); have != tc.command {
+				t.Errorf("unexpected command. want=%q have=%q", tc.command, have)
+			}
+			if q.SearchQuery != tc.searchQuery {
+				t.Errorf("unexpected search query. want=%q have=%q", tc.searchQuery, q.SearchQuery)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, query := range []string{
+		`content:output(foo)`,
+		`content:replace(foo)`,
+		`content:output(( -> $1)`,
+		`a or b`,
+	} {
+		if _, err := Parse(query); err == nil {
+			t.Errorf("expected error parsing %q", query)
+		}
+	}
+}
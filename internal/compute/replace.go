@@ -0,0 +1,41 @@
+package compute
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+// Run reads the content of the file of the given file match and returns the
+// content after replacing all matches of the match pattern.
+func (c *Replace) Run(ctx context.Context, fm *result.FileMatch) (*Text, error) {
+	content, err := git.ReadFile(ctx, fm.Repo.Name, fm.CommitID, fm.Path, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Text{
+		Repository: string(fm.Repo.Name),
+		Commit:     string(fm.CommitID),
+		Path:       fm.Path,
+		Value:      replaceAll(fm, c.MatchPattern, string(content), c.ReplacePattern),
+		Kind:       "replace-in-place",
+	}, nil
+}
+
+// replaceAll replaces every match of r in content with the template evaluated
+// in the environment of the match.
+func replaceAll(fm *result.FileMatch, r *regexp.Regexp, content, template string) string {
+	var b strings.Builder
+	last := 0
+	for _, submatch := range r.FindAllStringSubmatchIndex(content, -1) {
+		b.WriteString(content[last:submatch[0]])
+		b.WriteString(substituteTemplate(template, templateEnvironment(fm, r, content, submatch)))
+		last = submatch[1]
+	}
+	b.WriteString(content[last:])
+	return b.String()
+}
@@ -0,0 +1,103 @@
+package compute
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+// templateEnvironment returns the values of the variables a template may refer
+// to for the match of r at the given submatch offsets in content: the capture
+// groups of the match by index and by name, and the repository, path, and commit
+// of the file match.
+func templateEnvironment(fm *result.FileMatch, r *regexp.Regexp, content string, submatch []int) map[string]string {
+	env := map[string]string{
+		"repo":   string(fm.Repo.Name),
+		"path":   fm.Path,
+		"commit": string(fm.CommitID),
+	}
+
+	names := r.SubexpNames()
+	for j := 0; j < len(submatch); j += 2 {
+		start, end := submatch[j], submatch[j+1]
+		if start == -1 || end == -1 {
+			// The capture group did not participate in the match.
+			continue
+		}
+		env[strconv.Itoa(j/2)] = content[start:end]
+		if names[j/2] != "" {
+			env[names[j/2]] = content[start:end]
+		}
+	}
+	return env
+}
+
+// substituteTemplate replaces the variables $name and ${name} in template with
+// their values in env. Variables without a value are replaced by the empty
+// string, and $$ is replaced by $.
+func substituteTemplate(template string, env map[string]string) string {
+	var b strings.Builder
+	for {
+		i := strings.IndexByte(template, '$')
+		if i < 0 {
+			break
+		}
+		b.WriteString(template[:i])
+		template = template[i+1:]
+
+		if strings.HasPrefix(template, "$") {
+			b.WriteByte('$')
+			template = template[1:]
+			continue
+		}
+
+		name, rest, ok := scanVariable(template)
+		if !ok {
+			b.WriteByte('$')
+			continue
+		}
+		b.WriteString(env[name])
+		template = rest
+	}
+	b.WriteString(template)
+	return b.String()
+}
+
+// scanVariable scans the name of a variable at the start of s, which is either
+// a sequence of letters, digits, and underscores, or such a sequence in braces.
+func scanVariable(s string) (name, rest string, ok bool) {
+	if strings.HasPrefix(s, "{") {
+		end := strings.IndexByte(s, '}')
+		if end < 0 || !isVariableName(s[1:end]) {
+			return "", s, false
+		}
+		return s[1:end], s[end+1:], true
+	}
+
+	end := 0
+	for end < len(s) && isVariableChar(s[end]) {
+		end++
+	}
+	if end == 0 {
+		return "", s, false
+	}
+	return s[:end], s[end:], true
+}
+
+func isVariableName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if !isVariableChar(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isVariableChar(c byte) bool {
+	return c == '_' || '0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
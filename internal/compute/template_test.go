@@ -0,0 +1,101 @@
+package compute
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestSubstituteTemplate(t *testing.T) {
+	env := map[string]string{"1": "one", "name": "value", "repo": "github.com/foo/bar"}
+	for template, want := range map[string]string{
+		"$1":             "one",
+		"${1}st":         "onest",
+		"$1st":           "",
+		"$name in $repo": "value in github.com/foo/bar",
+		"$$1":            "$1",
+		"$missing.":      ".",
+		"cost: $":        "cost: $",
+		"${bad":          "${bad",
+	} {
+		if have := substituteTemplate(template, env); have != want {
+			t.Errorf("unexpected value for %q. want=%q have=%q", template, want, have)
+		}
+	}
+}
+
+func testFileMatch(lines ...string) *result.FileMatch {
+	fm := &result.FileMatch{
+		File: result.File{
+			Repo:     types.RepoName{Name: "github.com/foo/bar"},
+			CommitID: "deadbeef",
+			Path:     "go.mod",
+		},
+	}
+	for i, line := range lines {
+		fm.LineMatches = append(fm.LineMatches, &result.LineMatch{Preview: line, LineNumber: int32(i)})
+	}
+	return fm
+}
+
+func TestOutput(t *testing.T) {
+	fm := testFileMatch("require (", "\tgithub.com/a v1.2.0", "\tgithub.com/b v2.0.1 // indirect")
+	command := &Output{
+		MatchPattern:  regexp.MustCompile(`(?P<module>\S+) (v\d+)\.\d+\.\d+`),
+		OutputPattern: "$module@$2 in $repo",
+	}
+
+	want := &Text{
+		Repository: "github.com/foo/bar",
+		Commit:     "deadbeef",
+		Path:       "go.mod",
+		Value:      "github.com/a@v1 in github.com/foo/bar\ngithub.com/b@v2 in github.com/foo/bar",
+		Kind:       "output",
+	}
+	if diff := cmp.Diff(want, command.Run(fm)); diff != "" {
+		t.Errorf("unexpected output (-want +got):\n%s", diff)
+	}
+
+	if text := command.Run(testFileMatch("nothing")); text != nil {
+		t.Errorf("unexpected output for file without matches: %v", text)
+	}
+}
+
+func TestReplaceAll(t *testing.T) {
+	fm := testFileMatch()
+	have := replaceAll(fm, regexp.MustCompile(`v(\d+)`), "a v1 b v22 c", "version ${1}0")
+	if want := "a version 10 b version 220 c"; have != want {
+		t.Errorf("unexpected content. want=%q have=%q", want, have)
+	}
+}
+
+func TestCount(t *testing.T) {
+	matches := []result.Match{
+		testFileMatch("a v1", "b v2 c v1"),
+		testFileMatch("d v1"),
+		&result.RepoMatch{Name: "github.com/foo/bar"},
+	}
+
+	for _, tc := range []struct {
+		groupPattern string
+		want         []*Group
+	}{
+		{
+			groupPattern: "",
+			want:         []*Group{{Value: "v1", Count: 3}, {Value: "v2", Count: 1}},
+		},
+		{
+			groupPattern: "$1 in $repo",
+			want:         []*Group{{Value: "1 in github.com/foo/bar", Count: 3}, {Value: "2 in github.com/foo/bar", Count: 1}},
+		},
+	} {
+		command := &Count{MatchPattern: regexp.MustCompile(`v(\d+)`), GroupPattern: tc.groupPattern}
+		if diff := cmp.Diff(tc.want, command.Run(matches)); diff != "" {
+			t.Errorf("unexpected groups for %q (-want +got):\n%s", tc.groupPattern, diff)
+		}
+	}
+}
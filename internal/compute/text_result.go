@@ -1,6 +1,11 @@
 package compute
 
+// Text is an arbitrary textual value computed from a search result. Kind
+// communicates what the value represents.
 type Text struct {
-	Value string `json:"value"`
-	Kind  string `json:"kind"`
+	Repository string `json:"repository,omitempty"`
+	Commit     string `json:"commit,omitempty"`
+	Path       string `json:"path,omitempty"`
+	Value      string `json:"value"`
+	Kind       string `json:"kind"`
 }
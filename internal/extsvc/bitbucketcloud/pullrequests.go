@@ -0,0 +1,376 @@
+package bitbucketcloud
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+)
+
+// PullRequestState is the state of a Bitbucket Cloud pull request.
+type PullRequestState string
+
+const (
+	PullRequestStateOpen       PullRequestState = "OPEN"
+	PullRequestStateMerged     PullRequestState = "MERGED"
+	PullRequestStateDeclined   PullRequestState = "DECLINED"
+	PullRequestStateSuperseded PullRequestState = "SUPERSEDED"
+)
+
+// ParticipantState is the review state of a participant of a pull request.
+// Participants that have neither approved nor requested changes have an
+// empty state.
+type ParticipantState string
+
+const (
+	ParticipantStateApproved         ParticipantState = "approved"
+	ParticipantStateChangesRequested ParticipantState = "changes_requested"
+)
+
+// PullRequestStatusState is the state of a build status reported on a commit.
+type PullRequestStatusState string
+
+const (
+	PullRequestStatusStateSuccessful PullRequestStatusState = "SUCCESSFUL"
+	PullRequestStatusStateFailed     PullRequestStatusState = "FAILED"
+	PullRequestStatusStateInProgress PullRequestStatusState = "INPROGRESS"
+	PullRequestStatusStateStopped    PullRequestStatusState = "STOPPED"
+)
+
+// MergeStrategy is the strategy used to merge a pull request.
+type MergeStrategy string
+
+const (
+	MergeStrategyMergeCommit MergeStrategy = "merge_commit"
+	MergeStrategySquash      MergeStrategy = "squash"
+	MergeStrategyFastForward MergeStrategy = "fast_forward"
+)
+
+type PullRequest struct {
+	ID                int64               `json:"id"`
+	Title             string              `json:"title"`
+	Description       string              `json:"description"`
+	State             PullRequestState    `json:"state"`
+	Author            Account             `json:"author"`
+	Source            PullRequestEndpoint `json:"source"`
+	Destination       PullRequestEndpoint `json:"destination"`
+	Reviewers         []Account           `json:"reviewers"`
+	Participants      []Participant       `json:"participants"`
+	CloseSourceBranch bool                `json:"close_source_branch"`
+	CreatedOn         time.Time           `json:"created_on"`
+	UpdatedOn         time.Time           `json:"updated_on"`
+	Links             PullRequestLinks    `json:"links"`
+
+	// Statuses are not part of the pull request API response and are loaded
+	// separately through LoadPullRequestStatuses.
+	Statuses []*PullRequestStatus `json:"statuses,omitempty"`
+}
+
+type PullRequestEndpoint struct {
+	Branch     PullRequestBranch `json:"branch"`
+	Commit     PullRequestCommit `json:"commit"`
+	Repository Repo              `json:"repository"`
+}
+
+type PullRequestBranch struct {
+	Name string `json:"name"`
+}
+
+// PullRequestCommit is the commit a pull request endpoint points at. Note that
+// Bitbucket Cloud returns abbreviated commit hashes for pull requests.
+type PullRequestCommit struct {
+	Hash string `json:"hash"`
+}
+
+type PullRequestLinks struct {
+	HTML Link `json:"html"`
+}
+
+// Account is a Bitbucket Cloud user or team.
+type Account struct {
+	UUID        string `json:"uuid"`
+	AccountID   string `json:"account_id"`
+	DisplayName string `json:"display_name"`
+	Nickname    string `json:"nickname"`
+}
+
+// Participant is a user that has interacted with a pull request.
+type Participant struct {
+	User           Account          `json:"user"`
+	Role           string           `json:"role"`
+	Approved       bool             `json:"approved"`
+	State          ParticipantState `json:"state"`
+	ParticipatedOn time.Time        `json:"participated_on"`
+}
+
+// Key is a unique key identifying this participant in the context of its
+// pull request.
+func (p *Participant) Key() string {
+	return p.User.UUID
+}
+
+// PullRequestStatus is a build status reported on a commit of a pull request.
+type PullRequestStatus struct {
+	UUID        string                 `json:"uuid"`
+	StatusKey   string                 `json:"key"`
+	Name        string                 `json:"name"`
+	URL         string                 `json:"url"`
+	State       PullRequestStatusState `json:"state"`
+	Description string                 `json:"description"`
+	RefName     string                 `json:"refname"`
+	Commit      PullRequestCommit      `json:"commit"`
+	CreatedOn   time.Time              `json:"created_on"`
+	UpdatedOn   time.Time              `json:"updated_on"`
+}
+
+// Key is a unique key identifying this status in the context of its pull
+// request. Bitbucket Cloud only allows a single status per key and commit.
+func (s *PullRequestStatus) Key() string {
+	return s.Commit.Hash + ":" + s.StatusKey
+}
+
+// PullRequestInput is the payload used to create or update a pull request.
+type PullRequestInput struct {
+	Title             string
+	Description       string
+	SourceBranch      string
+	DestinationBranch string
+	CloseSourceBranch bool
+}
+
+func (input *PullRequestInput) MarshalJSON() ([]byte, error) {
+	type branch struct {
+		Name string `json:"name"`
+	}
+	type endpoint struct {
+		Branch branch `json:"branch"`
+	}
+
+	payload := struct {
+		Title             string    `json:"title"`
+		Description       string    `json:"description"`
+		Source            *endpoint `json:"source,omitempty"`
+		Destination       *endpoint `json:"destination,omitempty"`
+		CloseSourceBranch bool      `json:"close_source_branch"`
+	}{
+		Title:             input.Title,
+		Description:       input.Description,
+		CloseSourceBranch: input.CloseSourceBranch,
+	}
+	if input.SourceBranch != "" {
+		payload.Source = &endpoint{Branch: branch{Name: input.SourceBranch}}
+	}
+	if input.DestinationBranch != "" {
+		payload.Destination = &endpoint{Branch: branch{Name: input.DestinationBranch}}
+	}
+
+	return json.Marshal(payload)
+}
+
+// WithAuthenticator returns a copy of the client that authenticates with the
+// given authenticator. Bitbucket Cloud only supports username and app password
+// authentication, so any other authenticator type results in an error.
+func (c *Client) WithAuthenticator(a auth.Authenticator) (*Client, error) {
+	var basic *auth.BasicAuth
+	switch a := a.(type) {
+	case *auth.BasicAuth:
+		basic = a
+	case *auth.BasicAuthWithSSH:
+		basic = &a.BasicAuth
+	default:
+		return nil, errors.Errorf("authenticator type unsupported for Bitbucket Cloud clients: %T", a)
+	}
+
+	cc := *c
+	cc.Username = basic.Username
+	cc.AppPassword = basic.Password
+	return &cc, nil
+}
+
+// CurrentUser returns the account that the client is authenticated as.
+func (c *Client) CurrentUser(ctx context.Context) (*Account, error) {
+	var user Account
+	if err := c.send(ctx, "GET", "/2.0/user", nil, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// ErrPullRequestNotFound is returned by GetPullRequest and FindOpenPullRequest
+// when the pull request has been deleted on upstream, or never existed.
+var ErrPullRequestNotFound = errors.New("pull request not found")
+
+// ErrNotMergeable is returned by MergePullRequest when the pull request failed
+// to merge, because a precondition such as a merge check is not met or the pull
+// request has conflicts.
+var ErrNotMergeable = errors.New("pull request cannot be merged")
+
+// CreatePullRequest opens a new pull request in the given repository.
+func (c *Client) CreatePullRequest(ctx context.Context, repo *Repo, input *PullRequestInput) (*PullRequest, error) {
+	var pr PullRequest
+	if err := c.send(ctx, "POST", pullRequestsPath(repo), input, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// FindOpenPullRequest returns the open pull request from the given source
+// branch into the given destination branch. If no such pull request exists,
+// ErrPullRequestNotFound is returned.
+func (c *Client) FindOpenPullRequest(ctx context.Context, repo *Repo, sourceBranch, destinationBranch string) (*PullRequest, error) {
+	qry := url.Values{"q": {fmt.Sprintf(
+		"source.branch.name = %s AND destination.branch.name = %s AND state = %q",
+		quoteQueryString(sourceBranch),
+		quoteQueryString(destinationBranch),
+		PullRequestStateOpen,
+	)}}
+
+	var prs []*PullRequest
+	if _, err := c.page(ctx, pullRequestsPath(repo), qry, nil, &prs); err != nil {
+		return nil, err
+	}
+	if len(prs) == 0 {
+		return nil, ErrPullRequestNotFound
+	}
+	return prs[0], nil
+}
+
+// GetPullRequest returns the pull request with the given ID.
+func (c *Client) GetPullRequest(ctx context.Context, repo *Repo, id int64) (*PullRequest, error) {
+	var pr PullRequest
+	if err := c.send(ctx, "GET", pullRequestPath(repo, id, ""), nil, &pr); err != nil {
+		if errcode.IsNotFound(err) {
+			return nil, ErrPullRequestNotFound
+		}
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// UpdatePullRequest updates the title, description and destination branch of
+// the pull request with the given ID. The source branch cannot be changed.
+func (c *Client) UpdatePullRequest(ctx context.Context, repo *Repo, id int64, input *PullRequestInput) (*PullRequest, error) {
+	update := *input
+	update.SourceBranch = ""
+
+	var pr PullRequest
+	if err := c.send(ctx, "PUT", pullRequestPath(repo, id, ""), &update, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// DeclinePullRequest declines the pull request with the given ID. Declined pull
+// requests cannot be reopened on Bitbucket Cloud.
+func (c *Client) DeclinePullRequest(ctx context.Context, repo *Repo, id int64) (*PullRequest, error) {
+	var pr PullRequest
+	if err := c.send(ctx, "POST", pullRequestPath(repo, id, "decline"), nil, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// MergePullRequest merges the pull request with the given ID using the given
+// strategy. If strategy is empty, the default merge strategy of the repository
+// is used.
+func (c *Client) MergePullRequest(ctx context.Context, repo *Repo, id int64, strategy MergeStrategy) (*PullRequest, error) {
+	var payload interface{}
+	if strategy != "" {
+		payload = map[string]MergeStrategy{"merge_strategy": strategy}
+	}
+
+	var pr PullRequest
+	if err := c.send(ctx, "POST", pullRequestPath(repo, id, "merge"), payload, &pr); err != nil {
+		var e *httpError
+		if errors.As(err, &e) && e.StatusCode == http.StatusBadRequest {
+			return nil, errors.Wrap(ErrNotMergeable, err.Error())
+		}
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// CreatePullRequestComment posts a comment on the pull request with the given ID.
+func (c *Client) CreatePullRequestComment(ctx context.Context, repo *Repo, id int64, text string) error {
+	payload := struct {
+		Content struct {
+			Raw string `json:"raw"`
+		} `json:"content"`
+	}{}
+	payload.Content.Raw = text
+
+	return c.send(ctx, "POST", pullRequestPath(repo, id, "comments"), &payload, nil)
+}
+
+// LoadPullRequestStatuses loads the build statuses reported on the commits of
+// the given pull request into its Statuses field.
+func (c *Client) LoadPullRequestStatuses(ctx context.Context, repo *Repo, pr *PullRequest) error {
+	var statuses []*PullRequestStatus
+
+	token := &PageToken{Pagelen: 100}
+	for {
+		var page []*PullRequestStatus
+		var err error
+		if token.HasMore() {
+			token, err = c.reqPage(ctx, token.Next, &page)
+		} else {
+			token, err = c.page(ctx, pullRequestPath(repo, pr.ID, "statuses"), nil, token, &page)
+		}
+		if err != nil {
+			return err
+		}
+
+		statuses = append(statuses, page...)
+		if !token.HasMore() {
+			break
+		}
+	}
+
+	pr.Statuses = statuses
+	return nil
+}
+
+// send marshals the given payload as JSON, sends it to the given path and
+// unmarshals the response into result.
+func (c *Client) send(ctx context.Context, method, path string, payload, result interface{}) error {
+	var body bytes.Buffer
+	if payload != nil {
+		if err := json.NewEncoder(&body).Encode(payload); err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequest(method, path, &body)
+	if err != nil {
+		return err
+	}
+
+	return c.do(ctx, req, result)
+}
+
+func pullRequestsPath(repo *Repo) string {
+	return "/2.0/repositories/" + repo.FullName + "/pullrequests"
+}
+
+func pullRequestPath(repo *Repo, id int64, action string) string {
+	path := fmt.Sprintf("%s/%d", pullRequestsPath(repo), id)
+	if action != "" {
+		path += "/" + action
+	}
+	return path
+}
+
+// quoteQueryString quotes s for use as a string literal in a Bitbucket Cloud
+// filter query.
+func quoteQueryString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
@@ -0,0 +1,207 @@
+package bitbucketcloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+)
+
+// newFakeClient returns a client that sends all requests to the given handler.
+func newFakeClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cli := NewClient(u, nil)
+	cli.Username = "user"
+	cli.AppPassword = "password"
+	return cli
+}
+
+var testRepo = &Repo{Slug: "repo", FullName: "workspace/repo"}
+
+func TestClient_CreatePullRequest(t *testing.T) {
+	cli := newFakeClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if have, want := r.Method+" "+r.URL.Path, "POST /2.0/repositories/workspace/repo/pullrequests"; have != want {
+			t.Errorf("unexpected request: have %q, want %q", have, want)
+		}
+		if username, password, _ := r.BasicAuth(); username != "user" || password != "password" {
+			t.Errorf("unexpected credentials: %q:%q", username, password)
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		var payload map[string]interface{}
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Fatal(err)
+		}
+		want := map[string]interface{}{
+			"title":               "title",
+			"description":         "body",
+			"source":              map[string]interface{}{"branch": map[string]interface{}{"name": "feature"}},
+			"destination":         map[string]interface{}{"branch": map[string]interface{}{"name": "main"}},
+			"close_source_branch": false,
+		}
+		if diff := cmp.Diff(want, payload); diff != "" {
+			t.Errorf("unexpected payload (-want +got):\n%s", diff)
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id": 7, "title": "title", "state": "OPEN", "source": {"branch": {"name": "feature"}}}`)
+	})
+
+	pr, err := cli.CreatePullRequest(context.Background(), testRepo, &PullRequestInput{
+		Title:             "title",
+		Description:       "body",
+		SourceBranch:      "feature",
+		DestinationBranch: "main",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pr.ID != 7 || pr.State != PullRequestStateOpen || pr.Source.Branch.Name != "feature" {
+		t.Errorf("unexpected pull request: %+v", pr)
+	}
+}
+
+func TestClient_FindOpenPullRequest(t *testing.T) {
+	cli := newFakeClient(t, func(w http.ResponseWriter, r *http.Request) {
+		want := `source.branch.name = "feature" AND destination.branch.name = "main" AND state = "OPEN"`
+		if have := r.URL.Query().Get("q"); have != want {
+			t.Errorf("unexpected query: have %q, want %q", have, want)
+		}
+		fmt.Fprint(w, `{"values": [{"id": 3}]}`)
+	})
+
+	pr, err := cli.FindOpenPullRequest(context.Background(), testRepo, "feature", "main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pr.ID != 3 {
+		t.Errorf("unexpected pull request ID: %d", pr.ID)
+	}
+
+	cli = newFakeClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"values": []}`)
+	})
+	if _, err := cli.FindOpenPullRequest(context.Background(), testRepo, "feature", "main"); err != ErrPullRequestNotFound {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestClient_GetPullRequest_NotFound(t *testing.T) {
+	cli := newFakeClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if have, want := r.URL.Path, "/2.0/repositories/workspace/repo/pullrequests/42"; have != want {
+			t.Errorf("unexpected path: have %q, want %q", have, want)
+		}
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	if _, err := cli.GetPullRequest(context.Background(), testRepo, 42); err != ErrPullRequestNotFound {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestClient_MergePullRequest(t *testing.T) {
+	t.Run("strategy", func(t *testing.T) {
+		cli := newFakeClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if have, want := r.URL.Path, "/2.0/repositories/workspace/repo/pullrequests/42/merge"; have != want {
+				t.Errorf("unexpected path: have %q, want %q", have, want)
+			}
+			body, _ := io.ReadAll(r.Body)
+			if have, want := string(body), `{"merge_strategy":"squash"}`+"\n"; have != want {
+				t.Errorf("unexpected payload: have %q, want %q", have, want)
+			}
+			fmt.Fprint(w, `{"id": 42, "state": "MERGED"}`)
+		})
+
+		pr, err := cli.MergePullRequest(context.Background(), testRepo, 42, MergeStrategySquash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pr.State != PullRequestStateMerged {
+			t.Errorf("unexpected state: %q", pr.State)
+		}
+	})
+
+	t.Run("not mergeable", func(t *testing.T) {
+		cli := newFakeClient(t, func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			if len(body) != 0 {
+				t.Errorf("unexpected payload: %q", body)
+			}
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"type": "error", "error": {"message": "You can't merge until you resolve all merge conflicts."}}`)
+		})
+
+		_, err := cli.MergePullRequest(context.Background(), testRepo, 42, "")
+		if !errors.Is(err, ErrNotMergeable) {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestClient_LoadPullRequestStatuses(t *testing.T) {
+	var cli *Client
+	cli = newFakeClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `{"values": [{"key": "b", "state": "FAILED", "commit": {"hash": "abc"}}]}`)
+			return
+		}
+
+		next := cli.URL.ResolveReference(&url.URL{Path: r.URL.Path, RawQuery: "page=2"})
+		fmt.Fprintf(w, `{"next": %q, "values": [{"key": "a", "state": "SUCCESSFUL", "commit": {"hash": "abc"}}]}`, next)
+	})
+
+	pr := &PullRequest{ID: 42}
+	if err := cli.LoadPullRequestStatuses(context.Background(), testRepo, pr); err != nil {
+		t.Fatal(err)
+	}
+
+	var keys []string
+	for _, status := range pr.Statuses {
+		keys = append(keys, status.Key()+"="+string(status.State))
+	}
+	if diff := cmp.Diff([]string{"abc:a=SUCCESSFUL", "abc:b=FAILED"}, keys); diff != "" {
+		t.Errorf("unexpected statuses (-want +got):\n%s", diff)
+	}
+}
+
+func TestClient_WithAuthenticator(t *testing.T) {
+	cli := NewClient(&url.URL{Scheme: "https", Host: "api.bitbucket.org"}, nil)
+
+	for _, a := range []auth.Authenticator{
+		&auth.BasicAuth{Username: "alice", Password: "secret"},
+		&auth.BasicAuthWithSSH{BasicAuth: auth.BasicAuth{Username: "alice", Password: "secret"}},
+	} {
+		authenticated, err := cli.WithAuthenticator(a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if authenticated.Username != "alice" || authenticated.AppPassword != "secret" {
+			t.Errorf("unexpected credentials for %T: %q:%q", a, authenticated.Username, authenticated.AppPassword)
+		}
+		if cli.Username != "" {
+			t.Error("original client was modified")
+		}
+	}
+
+	if _, err := cli.WithAuthenticator(&auth.OAuthBearerToken{Token: "token"}); err == nil {
+		t.Error("expected error for unsupported authenticator")
+	}
+}
@@ -0,0 +1,106 @@
+package bitbucketcloud
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// WebhookEventKey returns the event key of the webhook request. Bitbucket Cloud
+// sends it in the X-Event-Key header, e.g. "pullrequest:approved".
+func WebhookEventKey(r *http.Request) string {
+	return r.Header.Get("X-Event-Key")
+}
+
+// ParseWebhookEvent parses the payload of a webhook request with the given
+// event key. Events that are not relevant to pull requests result in an error.
+func ParseWebhookEvent(eventKey string, payload []byte) (e interface{}, err error) {
+	switch eventKey {
+	case "pullrequest:approved":
+		e = &PullRequestApprovedEvent{}
+	case "pullrequest:unapproved":
+		e = &PullRequestUnapprovedEvent{}
+	case "pullrequest:changes_request_created":
+		e = &PullRequestChangesRequestCreatedEvent{}
+	case "pullrequest:changes_request_removed":
+		e = &PullRequestChangesRequestRemovedEvent{}
+	case "pullrequest:fulfilled":
+		e = &PullRequestFulfilledEvent{}
+	case "pullrequest:rejected":
+		e = &PullRequestRejectedEvent{}
+	case "repo:commit_status_created", "repo:commit_status_updated":
+		e = &RepoCommitStatusEvent{}
+	default:
+		return nil, errors.Errorf("unknown webhook event key %q", eventKey)
+	}
+
+	return e, json.Unmarshal(payload, e)
+}
+
+// PullRequestEvent contains the fields common to all pull request webhook
+// events.
+type PullRequestEvent struct {
+	Actor       Account     `json:"actor"`
+	Repository  Repo        `json:"repository"`
+	PullRequest PullRequest `json:"pullrequest"`
+}
+
+// Approval is the approval or change request of a user in a pull request
+// webhook event.
+type Approval struct {
+	Date time.Time `json:"date"`
+	User Account   `json:"user"`
+}
+
+type PullRequestApprovedEvent struct {
+	PullRequestEvent
+	Approval Approval `json:"approval"`
+}
+
+type PullRequestUnapprovedEvent struct {
+	PullRequestEvent
+	Approval Approval `json:"approval"`
+}
+
+type PullRequestChangesRequestCreatedEvent struct {
+	PullRequestEvent
+	ChangesRequest Approval `json:"changes_request"`
+}
+
+type PullRequestChangesRequestRemovedEvent struct {
+	PullRequestEvent
+	ChangesRequest Approval `json:"changes_request"`
+}
+
+// PullRequestFulfilledEvent is sent when a pull request is merged.
+type PullRequestFulfilledEvent struct {
+	PullRequestEvent
+}
+
+// Key is a unique key identifying this event in the context of its pull
+// request.
+func (e *PullRequestFulfilledEvent) Key() string {
+	return strconv.FormatInt(e.PullRequest.ID, 10)
+}
+
+// PullRequestRejectedEvent is sent when a pull request is declined.
+type PullRequestRejectedEvent struct {
+	PullRequestEvent
+}
+
+// Key is a unique key identifying this event in the context of its pull
+// request.
+func (e *PullRequestRejectedEvent) Key() string {
+	return strconv.FormatInt(e.PullRequest.ID, 10)
+}
+
+// RepoCommitStatusEvent is sent when a build status is created or updated on a
+// commit.
+type RepoCommitStatusEvent struct {
+	Actor        Account           `json:"actor"`
+	Repository   Repo              `json:"repository"`
+	CommitStatus PullRequestStatus `json:"commit_status"`
+}
@@ -0,0 +1,58 @@
+package bitbucketcloud
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseWebhookEvent(t *testing.T) {
+	t.Run("approved", func(t *testing.T) {
+		payload := `{
+			"repository": {"uuid": "{repo}"},
+			"pullrequest": {"id": 7, "state": "OPEN"},
+			"approval": {"date": "2021-09-01T10:00:00Z", "user": {"uuid": "{alice}"}}
+		}`
+
+		e, err := ParseWebhookEvent("pullrequest:approved", []byte(payload))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		approved, ok := e.(*PullRequestApprovedEvent)
+		if !ok {
+			t.Fatalf("unexpected event type %T", e)
+		}
+		if approved.Repository.UUID != "{repo}" || approved.PullRequest.ID != 7 || approved.Approval.User.UUID != "{alice}" {
+			t.Errorf("unexpected event: %+v", approved)
+		}
+		if want := time.Date(2021, 9, 1, 10, 0, 0, 0, time.UTC); !approved.Approval.Date.Equal(want) {
+			t.Errorf("unexpected date: %s", approved.Approval.Date)
+		}
+	})
+
+	t.Run("commit status", func(t *testing.T) {
+		payload := `{
+			"repository": {"uuid": "{repo}"},
+			"commit_status": {"key": "ci", "state": "INPROGRESS", "refname": "feature", "commit": {"hash": "abcdef"}}
+		}`
+
+		e, err := ParseWebhookEvent("repo:commit_status_updated", []byte(payload))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		status, ok := e.(*RepoCommitStatusEvent)
+		if !ok {
+			t.Fatalf("unexpected event type %T", e)
+		}
+		if status.CommitStatus.Key() != "abcdef:ci" || status.CommitStatus.RefName != "feature" || status.CommitStatus.State != PullRequestStatusStateInProgress {
+			t.Errorf("unexpected event: %+v", status)
+		}
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		if _, err := ParseWebhookEvent("repo:push", []byte(`{}`)); err == nil {
+			t.Error("expected error for unknown event key")
+		}
+	})
+}
@@ -505,6 +505,10 @@ type UpdatePullRequestInput struct {
 	Title       string `json:"title"`
 	Description string `json:"description"`
 	ToRef       Ref    `json:"toRef"`
+
+	// Reviewers are the usernames of the reviewers of the pull request. If
+	// empty, the reviewers are left unchanged, otherwise they are replaced.
+	Reviewers []string `json:"-"`
 }
 
 func (c *Client) UpdatePullRequest(ctx context.Context, in *UpdatePullRequestInput) (*PullRequest, error) {
@@ -515,11 +519,44 @@ func (c *Client) UpdatePullRequest(ctx context.Context, in *UpdatePullRequestInp
 		in.PullRequestID,
 	)
 
+	payload := struct {
+		*UpdatePullRequestInput
+		Reviewers []reviewer `json:"reviewers,omitempty"`
+	}{
+		UpdatePullRequestInput: in,
+		Reviewers:              newReviewers(in.Reviewers),
+	}
+
 	pr := &PullRequest{}
-	_, err := c.send(ctx, "PUT", path, nil, in, pr)
+	_, err := c.send(ctx, "PUT", path, nil, payload, pr)
 	return pr, err
 }
 
+// reviewer is a minimal version of Reviewer, to reduce payload size sent.
+type reviewer struct {
+	User struct {
+		Name string `json:"name"`
+	} `json:"user"`
+}
+
+// newReviewers returns the reviewers with the given usernames, skipping
+// duplicates.
+func newReviewers(names []string) []reviewer {
+	seen := make(map[string]struct{}, len(names))
+	reviewers := make([]reviewer, 0, len(names))
+	for _, name := range names {
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+
+		var r reviewer
+		r.User.Name = name
+		reviewers = append(reviewers, r)
+	}
+	return reviewers
+}
+
 // ErrAlreadyExists is returned by Client.CreatePullRequest when a Pull Request
 // for the given FromRef and ToRef already exists.
 type ErrAlreadyExists struct {
@@ -550,13 +587,6 @@ func (c *Client) CreatePullRequest(ctx context.Context, pr *PullRequest) error {
 		}
 	}
 
-	// Minimal version of Reviewer, to reduce payload size sent.
-	type reviewer struct {
-		User struct {
-			Name string `json:"name"`
-		} `json:"user"`
-	}
-
 	type requestBody struct {
 		Title       string     `json:"title"`
 		Description string     `json:"description"`
@@ -577,12 +607,14 @@ func (c *Client) CreatePullRequest(ctx context.Context, pr *PullRequest) error {
 		// return errors.Wrap(err, "fetching default reviewers")
 	}
 
-	reviewers := make([]reviewer, 0, len(defaultReviewers))
-	for _, r := range defaultReviewers {
-		reviewers = append(reviewers, reviewer{User: struct {
-			Name string `json:"name"`
-		}{Name: r}})
+	// The reviewers explicitly set on the given PR are requested in addition
+	// to the default reviewers.
+	for _, r := range pr.Reviewers {
+		if r.User != nil {
+			defaultReviewers = append(defaultReviewers, r.User.Name)
+		}
 	}
+	reviewers := newReviewers(defaultReviewers)
 
 	// Bitbucket Server doesn't support GFM taskitems. But since we might add
 	// those to a PR description for certain batch changes, we have to
@@ -1177,8 +1209,10 @@ type Project struct {
 }
 
 type Ref struct {
-	ID         string `json:"id"`
-	Repository struct {
+	ID string `json:"id"`
+	// LatestCommit is the latest commit of the ref known to Bitbucket Server.
+	LatestCommit string `json:"latestCommit,omitempty"`
+	Repository   struct {
 		ID      int    `json:"id"`
 		Slug    string `json:"slug"`
 		Project struct {
@@ -1203,7 +1237,10 @@ type PullRequest struct {
 	Author       PullRequestAuthor `json:"author"`
 	Reviewers    []Reviewer        `json:"reviewers"`
 	Participants []Participant     `json:"participants"`
-	Links        struct {
+	Properties   struct {
+		MergeResult PullRequestMergeResult `json:"mergeResult"`
+	} `json:"properties"`
+	Links struct {
 		Self []struct {
 			Href string `json:"href"`
 		} `json:"self"`
@@ -1217,6 +1254,17 @@ type PullRequest struct {
 	BuildStatuses []*BuildStatus `json:"buildstatuses,omitempty"`
 }
 
+// PullRequestMergeResult is the result of the last attempt of Bitbucket
+// Server to merge a pull request into its target branch.
+type PullRequestMergeResult struct {
+	Outcome string `json:"outcome"`
+	Current bool   `json:"current"`
+}
+
+// PullRequestMergeResultConflicted is the merge result outcome of a pull
+// request that cannot be merged without conflicts.
+const PullRequestMergeResultConflicted = "CONFLICTED"
+
 // PullRequestAuthor is the author of a pull request.
 type PullRequestAuthor struct {
 	User     *User  `json:"user"`
@@ -172,8 +172,13 @@ type PullRequest struct {
 	TimelineItems []TimelineItem
 	Commits       struct{ Nodes []CommitWithChecks }
 	IsDraft       bool
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
+	// Mergeable is one of MERGEABLE, CONFLICTING or UNKNOWN.
+	Mergeable string
+	// MergeStateStatus is BEHIND if the head branch is not up to date with
+	// the base branch. It is only requested from GitHub Enterprise 3.0 on.
+	MergeStateStatus string
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
 }
 
 // AssignedEvent represents an 'assigned' event on a PullRequest.
@@ -607,6 +612,12 @@ type UpdatePullRequestInput struct {
 	Title string `json:"title"`
 	// The body of the pull request (optional).
 	Body string `json:"body"`
+	// The Node IDs of the users to assign to the pull request. The assignees
+	// are left unchanged when empty.
+	AssigneeIDs []string `json:"assigneeIds,omitempty"`
+	// The Node IDs of the labels to set on the pull request. The labels are
+	// left unchanged when empty.
+	LabelIDs []string `json:"labelIds,omitempty"`
 }
 
 // UpdatePullRequest creates a PullRequest on Github.
@@ -935,6 +946,114 @@ mutation CreatePullRequestComment($input: AddCommentInput!) {
 }
 `
 
+const requestReviewsMutation = `
+mutation RequestReviews($input: RequestReviewsInput!) {
+  requestReviews(input: $input) {
+    pullRequest { id }
+  }
+}
+`
+
+// RequestReviews requests reviews from the users with the given Node IDs on
+// the PullRequest on Github. Existing review requests are kept.
+func (c *V4Client) RequestReviews(ctx context.Context, pr *PullRequest, userIDs []string) error {
+	var result struct {
+		RequestReviews struct {
+			PullRequest struct {
+				ID string
+			} `json:"pullRequest"`
+		} `json:"requestReviews"`
+	}
+
+	input := map[string]interface{}{"input": struct {
+		PullRequestID string   `json:"pullRequestId"`
+		UserIDs       []string `json:"userIds"`
+		Union         bool     `json:"union"`
+	}{PullRequestID: pr.ID, UserIDs: userIDs, Union: true}}
+	return c.requestGraphQL(ctx, requestReviewsMutation, input, &result)
+}
+
+// GetUserIDs returns the Node IDs of the users with the given logins, in the
+// same order. An error is returned if any of the users doesn't exist.
+func (c *V4Client) GetUserIDs(ctx context.Context, logins []string) ([]string, error) {
+	if len(logins) == 0 {
+		return []string{}, nil
+	}
+
+	var q strings.Builder
+	vars := make(map[string]interface{}, len(logins))
+	q.WriteString("query GetUserIDs(")
+	for i := range logins {
+		if i > 0 {
+			q.WriteString(", ")
+		}
+		fmt.Fprintf(&q, "$login%d: String!", i)
+		vars[fmt.Sprintf("login%d", i)] = logins[i]
+	}
+	q.WriteString(") {\n")
+	for i := range logins {
+		fmt.Fprintf(&q, "  user%d: user(login: $login%d) { id }\n", i, i)
+	}
+	q.WriteString("}")
+
+	var result map[string]*struct{ ID string }
+	if err := c.requestGraphQL(ctx, q.String(), vars, &result); err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(logins))
+	for i, login := range logins {
+		u := result[fmt.Sprintf("user%d", i)]
+		if u == nil {
+			return nil, errors.Errorf("user %q not found", login)
+		}
+		ids = append(ids, u.ID)
+	}
+	return ids, nil
+}
+
+// GetLabelIDs returns the Node IDs of the labels with the given names in the
+// given repository, in the same order. An error is returned if any of the
+// labels doesn't exist.
+func (c *V4Client) GetLabelIDs(ctx context.Context, owner, name string, labels []string) ([]string, error) {
+	if len(labels) == 0 {
+		return []string{}, nil
+	}
+
+	var q strings.Builder
+	vars := map[string]interface{}{"owner": owner, "name": name}
+	q.WriteString("query GetLabelIDs($owner: String!, $name: String!")
+	for i := range labels {
+		fmt.Fprintf(&q, ", $label%d: String!", i)
+		vars[fmt.Sprintf("label%d", i)] = labels[i]
+	}
+	q.WriteString(") {\n  repository(owner: $owner, name: $name) {\n")
+	for i := range labels {
+		fmt.Fprintf(&q, "    label%d: label(name: $label%d) { id }\n", i, i)
+	}
+	q.WriteString("  }\n}")
+
+	var result struct {
+		Repository map[string]*struct{ ID string }
+	}
+	if err := c.requestGraphQL(ctx, q.String(), vars, &result); err != nil {
+		return nil, err
+	}
+	if result.Repository == nil {
+		return nil, errors.Errorf("repository %s/%s not found", owner, name)
+	}
+
+	ids := make([]string, 0, len(labels))
+	for i, label := range labels {
+		l := result.Repository[fmt.Sprintf("label%d", i)]
+		if l == nil {
+			return nil, errors.Errorf("label %q not found in repository %s/%s", label, owner, name)
+		}
+		ids = append(ids, l.ID)
+	}
+	return ids, nil
+}
+
 // CreatePullRequestComment creates a comment on the PullRequest on Github.
 func (c *V4Client) CreatePullRequestComment(ctx context.Context, pr *PullRequest, body string) error {
 	var result struct {
@@ -1386,6 +1505,7 @@ fragment pr on PullRequest {
   baseRefOid
   headRefName
   baseRefName
+  mergeable
   %s
   author {
     ...actor
@@ -1431,6 +1551,9 @@ func pullRequestFragments(version *semver.Version) (string, error) {
 		// Don't ask for isDraft for ghe 2.20.
 		return fmt.Sprintf(timelineItemsFragment+pullRequestFragmentsFmtstr, "", timelineItemTypes), nil
 	}
+	if ghe300PlusOrDotComSemver.Check(version) {
+		return fmt.Sprintf(timelineItemsFragment+pullRequestFragmentsFmtstr, "isDraft\n  mergeStateStatus", timelineItemTypes), nil
+	}
 	if ghe221PlusOrDotComSemver.Check(version) {
 		return fmt.Sprintf(timelineItemsFragment+pullRequestFragmentsFmtstr, "isDraft", timelineItemTypes), nil
 	}
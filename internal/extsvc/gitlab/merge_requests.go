@@ -42,6 +42,13 @@ type MergeRequest struct {
 	WebURL         string            `json:"web_url"`
 	WorkInProgress bool              `json:"work_in_progress"`
 	Author         User              `json:"author"`
+	Reviewers      []User            `json:"reviewers,omitempty"`
+	HasConflicts   bool              `json:"has_conflicts"`
+
+	// DivergedCommitsCount is the number of commits the target branch is
+	// ahead of the source branch. It is only set when the merge request is
+	// loaded with GetMergeRequest.
+	DivergedCommitsCount int `json:"diverged_commits_count"`
 
 	DiffRefs DiffRefs `json:"diff_refs"`
 
@@ -89,6 +96,10 @@ type CreateMergeRequestOpts struct {
 	TargetBranch string `json:"target_branch"`
 	Title        string `json:"title"`
 	Description  string `json:"description,omitempty"`
+	// Labels is a comma-separated list of label names.
+	Labels      string  `json:"labels,omitempty"`
+	AssigneeIDs []int32 `json:"assignee_ids,omitempty"`
+	ReviewerIDs []int32 `json:"reviewer_ids,omitempty"`
 	// TODO: other fields at
 	// https://docs.gitlab.com/ee/api/merge_requests.html#create-mr as needed.
 }
@@ -129,7 +140,7 @@ func (c *Client) GetMergeRequest(ctx context.Context, project *Project, iid ID)
 
 	time.Sleep(c.rateLimitMonitor.RecommendedWaitForBackgroundOp(1))
 
-	req, err := http.NewRequest("GET", fmt.Sprintf("projects/%d/merge_requests/%d", project.ID, iid), nil)
+	req, err := http.NewRequest("GET", fmt.Sprintf("projects/%d/merge_requests/%d?include_diverged_commits_count=true", project.ID, iid), nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "creating request to get a merge request")
 	}
@@ -199,6 +210,12 @@ type UpdateMergeRequestOpts struct {
 	Title        string                       `json:"title"`
 	Description  string                       `json:"description,omitempty"`
 	StateEvent   UpdateMergeRequestStateEvent `json:"state_event,omitempty"`
+	// Labels is a comma-separated list of label names. The labels of the
+	// merge request are left unchanged when empty, as are its assignees and
+	// reviewers.
+	Labels      string  `json:"labels,omitempty"`
+	AssigneeIDs []int32 `json:"assignee_ids,omitempty"`
+	ReviewerIDs []int32 `json:"reviewer_ids,omitempty"`
 }
 
 type UpdateMergeRequestStateEvent string
@@ -240,6 +240,10 @@ type TimeSeries struct {
 	Name   string
 	Stroke string
 	Query  string
+
+	// GroupBySelect, if true, groups the results of the query by the value it selects with
+	// select: (e.g. select:repo) and shows one series per distinct value.
+	GroupBySelect bool
 }
 
 type Interval struct {
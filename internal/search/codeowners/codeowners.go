@@ -0,0 +1,245 @@
+// Package codeowners parses CODEOWNERS files in the GitHub and GitLab formats
+// and resolves the owners of files in repositories from them.
+package codeowners
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+)
+
+// Rule is a line of a CODEOWNERS file which assigns owners to the files
+// matching its pattern.
+type Rule struct {
+	// Pattern is the gitignore-style pattern of the files the rule applies to.
+	Pattern string
+
+	// Owners are the users, teams, or email addresses owning the files. A
+	// rule without owners makes the files unowned.
+	Owners []string
+
+	// Section is the name of the GitLab section the rule is in, or empty if
+	// it is not in a section.
+	Section string
+
+	// LineNumber is the 0-based line number of the rule in the file.
+	LineNumber int
+
+	// Line is the line of the rule as written in the file.
+	Line string
+
+	pattern *regexp.Regexp
+}
+
+// Ruleset is a parsed CODEOWNERS file.
+type Ruleset struct {
+	// Path is the path of the CODEOWNERS file in its repository. It is only
+	// set for rulesets returned by a Resolver.
+	Path string
+
+	rules []*Rule
+}
+
+// sectionPattern matches the header of a GitLab section, such as
+// "[Section]", "^[Optional section]", or "[Section][2] @default-owner".
+var sectionPattern = lazyregexp.New(`^\^?\[([^\]]+)\](?:\[\d+\])?(.*)$`)
+
+// Parse parses a CODEOWNERS file. Lines which are blank or comments are
+// ignored. Rules in GitLab sections without owners are assigned the default
+// owners of their section.
+func Parse(r io.Reader) (*Ruleset, error) {
+	var (
+		rs            Ruleset
+		section       string
+		sectionOwners []string
+	)
+
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 0; scanner.Scan(); lineNumber++ {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		if submatches := sectionPattern.FindStringSubmatch(trimmed); submatches != nil {
+			section = submatches[1]
+			sectionOwners = fields(submatches[2])
+			continue
+		}
+
+		tokens := fields(trimmed)
+		if len(tokens) == 0 {
+			continue
+		}
+		owners := tokens[1:]
+		if len(owners) == 0 {
+			owners = sectionOwners
+		}
+		rs.rules = append(rs.rules, &Rule{
+			Pattern:    tokens[0],
+			Owners:     owners,
+			Section:    section,
+			LineNumber: lineNumber,
+			Line:       line,
+			pattern:    compilePattern(tokens[0]),
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return &rs, nil
+}
+
+// fields splits a line into whitespace separated fields, like strings.Fields,
+// but keeps whitespace escaped with a backslash and removes the backslashes
+// of escaped characters. Fields starting with an unescaped "#" start a
+// comment and are dropped with all following fields.
+func fields(line string) []string {
+	var (
+		tokens  []string
+		current strings.Builder
+		escaped bool
+	)
+	flush := func() {
+		if current.Len() > 0 {
+			tokens = append(tokens, current.String())
+			current.Reset()
+		}
+	}
+
+	for _, r := range line {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == ' ' || r == '\t':
+			flush()
+		case r == '#' && current.Len() == 0:
+			return tokens
+		default:
+			current.WriteRune(r)
+		}
+	}
+	flush()
+	return tokens
+}
+
+// compilePattern compiles a gitignore-style pattern of a CODEOWNERS rule to
+// a regular expression matching the paths of the files it applies to:
+//
+//   - A pattern starting with or containing a "/" is relative to the root of
+//     the repository, otherwise it matches at any depth.
+//   - A pattern ending with a "/" only matches directories.
+//   - A pattern matching a directory applies to all files below it, except
+//     for patterns ending with "/*", which only apply to the files directly
+//     in the directory.
+//   - "*" matches anything but a "/", "?" matches any character but a "/",
+//     and "**" matches anything, including "/".
+func compilePattern(pattern string) *regexp.Regexp {
+	anchored := strings.HasPrefix(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+	directory := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+	if strings.Contains(pattern, "/") {
+		anchored = true
+	}
+
+	var b strings.Builder
+	if anchored {
+		b.WriteString("^")
+	} else {
+		b.WriteString("^(?:.*/)?")
+	}
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += len("**/") - 1
+		case strings.HasPrefix(pattern[i:], "**"):
+			b.WriteString(".*")
+			i += len("**") - 1
+		case pattern[i] == '*':
+			b.WriteString("[^/]*")
+		case pattern[i] == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	switch {
+	case directory:
+		b.WriteString("/.*$")
+	case strings.HasSuffix(pattern, "/*"):
+		// Like on GitHub, docs/* only applies to the files directly in docs/.
+		b.WriteString("$")
+	default:
+		b.WriteString("(?:/.*)?$")
+	}
+	return regexp.MustCompile(b.String())
+}
+
+// Match returns the rules which apply to the file at path. Like on GitHub,
+// the last matching rule of the file applies. Like on GitLab, the last
+// matching rule of each section applies. The rules are returned in the order
+// they appear in the file.
+func (rs *Ruleset) Match(path string) []*Rule {
+	path = strings.TrimPrefix(path, "/")
+
+	var matched []*Rule
+	seenSections := map[string]struct{}{}
+	for i := len(rs.rules) - 1; i >= 0; i-- {
+		rule := rs.rules[i]
+		if _, ok := seenSections[rule.Section]; ok {
+			continue
+		}
+		if rule.pattern.MatchString(path) {
+			seenSections[rule.Section] = struct{}{}
+			matched = append(matched, rule)
+		}
+	}
+
+	// Restore the order of the file.
+	for i, j := 0, len(matched)-1; i < j; i, j = i+1, j-1 {
+		matched[i], matched[j] = matched[j], matched[i]
+	}
+	return matched
+}
+
+// Owners returns the distinct owners of the file at path.
+func (rs *Ruleset) Owners(path string) []string {
+	var owners []string
+	seen := map[string]struct{}{}
+	for _, rule := range rs.Match(path) {
+		for _, owner := range rule.Owners {
+			key := normalizeOwner(owner)
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			owners = append(owners, owner)
+		}
+	}
+	return owners
+}
+
+// IsOwnedBy returns true if the file at path is owned by owner. Owners are
+// compared case insensitively, and the "@" of users and teams is optional.
+func (rs *Ruleset) IsOwnedBy(path, owner string) bool {
+	owner = normalizeOwner(owner)
+	for _, o := range rs.Owners(path) {
+		if normalizeOwner(o) == owner {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeOwner(owner string) string {
+	return strings.ToLower(strings.TrimPrefix(owner, "@"))
+}
@@ -0,0 +1,80 @@
+package codeowners
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRulesetOwners(t *testing.T) {
+	rs, err := Parse(strings.NewReader(`# Default owners
+*       @global-owner
+
+*.js    @js-owner # inline comment
+**/logs @logs-owner
+/build/logs/ @doctocat
+docs/*  docs@example.com
+apps/   @octocat
+/scripts/\ with\ space.sh @spacey
+/vendor/
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for path, want := range map[string][]string{
+		"README.md":                 {"@global-owner"},
+		"src/index.js":              {"@js-owner"},
+		"build/logs/out.txt":        {"@doctocat"},
+		"build/logs/deep/out.txt":   {"@doctocat"},
+		"docs/getting-started.md":   {"docs@example.com"},
+		"docs/build-app/trouble.md": {"@global-owner"},
+		"apps/web/main.go":          {"@octocat"},
+		"nested/apps/main.go":       {"@octocat"},
+		"deep/down/logs/x.log":      {"@logs-owner"},
+		"scripts/ with space.sh":    {"@spacey"},
+		"vendor/lib/lib.go":         nil,
+	} {
+		if have := rs.Owners(path); !reflect.DeepEqual(have, want) {
+			t.Errorf("unexpected owners of %q. want=%q have=%q", path, want, have)
+		}
+	}
+}
+
+func TestRulesetSections(t *testing.T) {
+	rs, err := Parse(strings.NewReader(`*.go @go-owner
+
+[Documentation] @docs-team
+docs/
+README.md @readme-owner
+
+^[Security][2] @security
+/internal/auth/ @auth-owner @Security
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for path, want := range map[string][]string{
+		"main.go":               {"@go-owner"},
+		"docs/intro.md":         {"@docs-team"},
+		"README.md":             {"@readme-owner"},
+		"internal/auth/auth.go": {"@go-owner", "@auth-owner", "@Security"},
+	} {
+		if have := rs.Owners(path); !reflect.DeepEqual(have, want) {
+			t.Errorf("unexpected owners of %q. want=%q have=%q", path, want, have)
+		}
+	}
+
+	if !rs.IsOwnedBy("internal/auth/auth.go", "security") {
+		t.Error("expected owners to be compared case insensitively and without @")
+	}
+	if rs.IsOwnedBy("main.go", "@docs-team") {
+		t.Error("expected main.go not to be owned by @docs-team")
+	}
+
+	rules := rs.Match("internal/auth/auth.go")
+	if len(rules) != 2 || rules[0].LineNumber != 0 || rules[1].LineNumber != 7 || rules[1].Section != "Security" {
+		t.Errorf("unexpected rules %+v", rules)
+	}
+}
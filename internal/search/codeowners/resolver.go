@@ -0,0 +1,106 @@
+package codeowners
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	"github.com/golang/groupcache/lru"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+// Paths are the paths at which CODEOWNERS files are looked up in a
+// repository, in order of precedence. GitHub looks in .github/, the root, and
+// docs/, and GitLab additionally looks in .gitlab/.
+var Paths = []string{
+	".github/CODEOWNERS",
+	"CODEOWNERS",
+	"docs/CODEOWNERS",
+	".gitlab/CODEOWNERS",
+}
+
+// maxFileSize is the maximum size of CODEOWNERS files which are read. Like on
+// GitHub, larger files are ignored.
+const maxFileSize = 3 * 1024 * 1024
+
+// DefaultResolver is the Resolver used by search.
+var DefaultResolver = NewResolver(1000)
+
+// Resolver resolves the owners of files in repositories from their
+// CODEOWNERS files, which are read from gitserver. Rulesets are cached by
+// commit, since the CODEOWNERS file of a commit never changes.
+type Resolver struct {
+	mu    sync.Mutex
+	cache *lru.Cache
+}
+
+// NewResolver returns a Resolver caching the rulesets of up to size commits.
+func NewResolver(size int) *Resolver {
+	return &Resolver{cache: lru.New(size)}
+}
+
+type cacheKey struct {
+	repo   api.RepoName
+	commit api.CommitID
+}
+
+// Ruleset returns the ruleset of the CODEOWNERS file of repo at commit, or
+// nil if there is none.
+func (r *Resolver) Ruleset(ctx context.Context, repo api.RepoName, commit api.CommitID) (*Ruleset, error) {
+	key := cacheKey{repo: repo, commit: commit}
+
+	r.mu.Lock()
+	cached, ok := r.cache.Get(key)
+	r.mu.Unlock()
+	if ok {
+		return cached.(*Ruleset), nil
+	}
+
+	rs, err := readRuleset(ctx, repo, commit)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	r.cache.Add(key, rs)
+	r.mu.Unlock()
+	return rs, nil
+}
+
+// Owners returns the owners of the file at path in repo at commit.
+func (r *Resolver) Owners(ctx context.Context, repo api.RepoName, commit api.CommitID, path string) ([]string, error) {
+	rs, err := r.Ruleset(ctx, repo, commit)
+	if err != nil || rs == nil {
+		return nil, err
+	}
+	return rs.Owners(path), nil
+}
+
+// readRuleset reads and parses the first CODEOWNERS file of Paths which
+// exists in repo at commit. It returns nil if there is none.
+func readRuleset(ctx context.Context, repo api.RepoName, commit api.CommitID) (*Ruleset, error) {
+	for _, path := range Paths {
+		content, err := git.ReadFile(ctx, repo, commit, path, maxFileSize+1)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, errors.Wrapf(err, "reading %s of %s@%s", path, repo, commit)
+		}
+		if len(content) > maxFileSize {
+			return nil, nil
+		}
+
+		rs, err := Parse(bytes.NewReader(content))
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing %s of %s@%s", path, repo, commit)
+		}
+		rs.Path = path
+		return rs, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,151 @@
+package codeowners
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+)
+
+// FilterByOwner returns the file matches of matches for files owned by owner,
+// as evaluated by the file:has.owner(...) predicate. The files of
+// repositories whose owners cannot be resolved are dropped.
+func FilterByOwner(ctx context.Context, r *Resolver, matches []result.Match, owner string) ([]result.Match, error) {
+	filtered := make([]result.Match, 0, len(matches))
+	failed := make(map[api.RepoName]struct{})
+	for _, match := range matches {
+		fm, ok := match.(*result.FileMatch)
+		if !ok {
+			continue
+		}
+		if _, ok := failed[fm.Repo.Name]; ok {
+			continue
+		}
+		rs, err := r.Ruleset(ctx, fm.Repo.Name, fm.CommitID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			log15.Warn("codeowners: failed to resolve owners", "repo", fm.Repo.Name, "error", err)
+			failed[fm.Repo.Name] = struct{}{}
+			continue
+		}
+		if rs != nil && rs.IsOwnedBy(fm.Path, owner) {
+			filtered = append(filtered, fm)
+		}
+	}
+	return filtered, nil
+}
+
+// OwnerSelector selects the owners of file matches for select:file.owners.
+// Each owner is represented by a match of the line of the CODEOWNERS file
+// that assigns ownership to the owner, and is only selected once per
+// repository.
+type OwnerSelector struct {
+	resolver *Resolver
+
+	mu   sync.Mutex
+	seen map[ownerKey]struct{}
+}
+
+type ownerKey struct {
+	repo  api.RepoName
+	owner string
+}
+
+func NewOwnerSelector(r *Resolver) *OwnerSelector {
+	return &OwnerSelector{
+		resolver: r,
+		seen:     make(map[ownerKey]struct{}),
+	}
+}
+
+// Select returns the matches of the owners of the file matches of matches
+// which have not been selected yet. Files without owners are dropped.
+func (s *OwnerSelector) Select(ctx context.Context, matches []result.Match) ([]result.Match, error) {
+	var selected []result.Match
+	for _, match := range matches {
+		fm, ok := match.(*result.FileMatch)
+		if !ok {
+			continue
+		}
+		rs, err := s.resolver.Ruleset(ctx, fm.Repo.Name, fm.CommitID)
+		if err != nil {
+			return nil, err
+		}
+		if rs == nil {
+			continue
+		}
+
+		for _, rule := range rs.Match(fm.Path) {
+			for _, owner := range rule.Owners {
+				if !s.add(ownerKey{repo: fm.Repo.Name, owner: normalizeOwner(owner)}) {
+					continue
+				}
+				selected = append(selected, ownerMatch(fm, rs, rule, owner))
+			}
+		}
+	}
+	return selected, nil
+}
+
+// add returns true if key has not been seen yet, and marks it as seen.
+func (s *OwnerSelector) add(key ownerKey) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.seen[key]; ok {
+		return false
+	}
+	s.seen[key] = struct{}{}
+	return true
+}
+
+// ownerMatch returns a match of the line of rule in the CODEOWNERS file of
+// the repository of fm, highlighting owner if it is on the line.
+func ownerMatch(fm *result.FileMatch, rs *Ruleset, rule *Rule, owner string) *result.FileMatch {
+	lm := &result.LineMatch{
+		Preview:    rule.Line,
+		LineNumber: int32(rule.LineNumber),
+	}
+	// The owners of rules without owners in GitLab sections are on the line
+	// of the section, so they may not be on the line of the rule.
+	if i := strings.Index(rule.Line, owner); i >= 0 {
+		lm.OffsetAndLengths = [][2]int32{{
+			int32(utf8.RuneCountInString(rule.Line[:i])),
+			int32(utf8.RuneCountInString(owner)),
+		}}
+	}
+
+	return &result.FileMatch{
+		File: result.File{
+			InputRev: fm.InputRev,
+			Repo:     fm.Repo,
+			CommitID: fm.CommitID,
+			Path:     rs.Path,
+		},
+		LineMatches: []*result.LineMatch{lm},
+	}
+}
+
+// WithSelectOwners returns a child Stream of parent that replaces file
+// matches by the matches of their owners selected by s. Files whose owners
+// cannot be resolved are dropped.
+func WithSelectOwners(ctx context.Context, parent streaming.Sender, s *OwnerSelector) streaming.Sender {
+	return streaming.StreamFunc(func(e streaming.SearchEvent) {
+		selected, err := s.Select(ctx, e.Results)
+		if err != nil {
+			if ctx.Err() == nil {
+				log15.Warn("codeowners: failed to select owners", "error", err)
+			}
+			selected = nil
+		}
+		e.Results = selected
+		parent.Send(e)
+	})
+}
@@ -0,0 +1,126 @@
+package codeowners
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+func TestSearch(t *testing.T) {
+	var reads int
+	git.Mocks.ReadFile = func(commit api.CommitID, name string) ([]byte, error) {
+		reads++
+		if commit == "withowners" && name == ".github/CODEOWNERS" {
+			return []byte("*.go @go-owner\n/web/ @web-owner @go-owner\n"), nil
+		}
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	}
+	t.Cleanup(func() { git.Mocks.ReadFile = nil })
+
+	fileMatch := func(repo string, commit api.CommitID, path string) *result.FileMatch {
+		return &result.FileMatch{
+			File: result.File{
+				Repo:     types.RepoName{Name: api.RepoName(repo)},
+				CommitID: commit,
+				Path:     path,
+			},
+		}
+	}
+	matches := func() []result.Match {
+		return []result.Match{
+			fileMatch("a", "withowners", "main.go"),
+			fileMatch("a", "withowners", "web/index.ts"),
+			fileMatch("a", "withowners", "README.md"),
+			fileMatch("b", "withoutowners", "main.go"),
+			&result.RepoMatch{Name: "c"},
+		}
+	}
+	paths := func(matches []result.Match) []string {
+		var paths []string
+		for _, m := range matches {
+			fm := m.(*result.FileMatch)
+			paths = append(paths, string(fm.Repo.Name)+"/"+fm.Path)
+		}
+		return paths
+	}
+
+	ctx := context.Background()
+	r := NewResolver(10)
+
+	t.Run("FilterByOwner", func(t *testing.T) {
+		filtered, err := FilterByOwner(ctx, r, matches(), "@GO-owner")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := paths(filtered), []string{"a/main.go", "a/web/index.ts"}; !reflect.DeepEqual(have, want) {
+			t.Fatalf("want %q, have %q", want, have)
+		}
+	})
+
+	t.Run("OwnerSelector", func(t *testing.T) {
+		s := NewOwnerSelector(r)
+		selected, err := s.Select(ctx, matches())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var have []string
+		for _, m := range selected {
+			fm := m.(*result.FileMatch)
+			if fm.Path != ".github/CODEOWNERS" {
+				t.Fatalf("unexpected path %q", fm.Path)
+			}
+			have = append(have, fm.LineMatches[0].MatchedValues()...)
+		}
+		if want := []string{"@go-owner", "@web-owner"}; !reflect.DeepEqual(have, want) {
+			t.Fatalf("want %q, have %q", want, have)
+		}
+
+		// Owners are only selected once.
+		selected, err = s.Select(ctx, matches())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(selected) != 0 {
+			t.Fatalf("expected no matches, have %d", len(selected))
+		}
+	})
+
+	// Each of the CODEOWNERS paths is only read once per commit.
+	if want := 1 + len(Paths); reads != want {
+		t.Fatalf("expected %d reads, have %d", want, reads)
+	}
+}
+
+func TestFilterByOwnerSkipsFailingRepos(t *testing.T) {
+	git.Mocks.ReadFile = func(commit api.CommitID, name string) ([]byte, error) {
+		if commit == "broken" {
+			return nil, errors.New("gitserver unavailable")
+		}
+		if name == "CODEOWNERS" {
+			return []byte("* @owner\n"), nil
+		}
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	}
+	t.Cleanup(func() { git.Mocks.ReadFile = nil })
+
+	matches := []result.Match{
+		&result.FileMatch{File: result.File{Repo: types.RepoName{Name: "a"}, CommitID: "broken", Path: "main.go"}},
+		&result.FileMatch{File: result.File{Repo: types.RepoName{Name: "b"}, CommitID: "ok", Path: "main.go"}},
+	}
+	filtered, err := FilterByOwner(context.Background(), NewResolver(10), matches, "@owner")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(filtered) != 1 || filtered[0].(*result.FileMatch).Repo.Name != "b" {
+		t.Fatalf("expected only the file of repo b, have %v", filtered)
+	}
+}
@@ -1,9 +1,13 @@
 package filter
 
 import (
+	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
 )
 
 const (
@@ -30,6 +34,35 @@ func (sp SelectPath) Root() string {
 	return ""
 }
 
+// Group returns the capture group selected by a content.group(...) select
+// path, which is either the index or the name of the group. ok is false if
+// the select path does not select a capture group.
+func (sp SelectPath) Group() (group string, ok bool) {
+	if len(sp) != 2 || sp[0] != Content {
+		return "", false
+	}
+	submatches := groupSelector.FindStringSubmatch(sp[1])
+	if submatches == nil {
+		return "", false
+	}
+	return submatches[1], true
+}
+
+// groupSelector matches the field of content.group(...) select paths.
+var groupSelector = lazyregexp.New(`^group\((\w+)\)$`)
+
+// GroupIndex returns the index of the capture group of rp with the given
+// index or name, or -1 if rp has no such group.
+func GroupIndex(rp *regexp.Regexp, group string) int {
+	if index, err := strconv.Atoi(group); err == nil {
+		if index > rp.NumSubexp() {
+			return -1
+		}
+		return index
+	}
+	return rp.SubexpIndex(group)
+}
+
 type object map[string]object
 
 var validSelectors = object{
@@ -43,6 +76,7 @@ var validSelectors = object{
 	File: {
 		"directory": nil,
 		"path":      nil,
+		"owners":    nil,
 	},
 	Repository: nil,
 	Symbol: object{
@@ -78,6 +112,11 @@ var validSelectors = object{
 
 func SelectPathFromString(s string) (SelectPath, error) {
 	fields := strings.Split(s, ".")
+	if len(fields) == 2 && fields[0] == Content && groupSelector.MatchString(fields[1]) {
+		// The capture groups that can be selected depend on the pattern, so
+		// they are not part of validSelectors.
+		return SelectPath(fields), nil
+	}
 	cur := validSelectors
 	for _, field := range fields {
 		child, ok := cur[field]
@@ -35,6 +35,7 @@ var DefaultPredicateRegistry = predicateRegistry{
 	FieldFile: {
 		"contains.content": func() Predicate { return &FileContainsContentPredicate{} },
 		"contains":         func() Predicate { return &FileContainsContentPredicate{} },
+		"has.owner":        func() Predicate { return &FileHasOwnerPredicate{} },
 	},
 }
 
@@ -284,6 +285,77 @@ func (f *FileContainsContentPredicate) Plan(parent Basic) (Plan, error) {
 	return ToPlan(Dnf(nodes))
 }
 
+/* file:has.owner(owner) */
+
+// FileHasOwnerPredicate represents the `file:has.owner()` predicate, which
+// filters to files owned by a user, team, or email address according to the
+// CODEOWNERS file of their repository.
+type FileHasOwnerPredicate struct {
+	Owner string
+}
+
+func (f *FileHasOwnerPredicate) ParseParams(params string) error {
+	owner := strings.TrimSpace(params)
+	if owner == "" {
+		return errors.Errorf("file:has.owner argument should not be empty")
+	}
+	if strings.ContainsAny(owner, " \t") {
+		return errors.Errorf("file:has.owner argument should be a single owner, like @user or @org/team")
+	}
+	f.Owner = owner
+	return nil
+}
+
+func (f FileHasOwnerPredicate) Field() string { return FieldFile }
+func (f FileHasOwnerPredicate) Name() string  { return "has.owner" }
+
+// Plan returns a plan that finds the files the parent query applies to,
+// which are then filtered by their owners. It does not filter by owners
+// itself, since ownership is not known to the search backends, so the parent
+// query must be scoped by a repo: or file: filter to not resolve the owners
+// of every file on the instance.
+func (f *FileHasOwnerPredicate) Plan(parent Basic) (Plan, error) {
+	nodes := make([]Node, 0, len(parent.Parameters)+3)
+	nodes = append(nodes, Parameter{
+		Field: FieldSelect,
+		Value: "file",
+	}, Parameter{
+		Field: FieldCount,
+		Value: "99999",
+	})
+
+	var seenRepo, seenFile bool
+	for _, p := range parent.Parameters {
+		if p.Annotation.Labels.IsSet(IsPredicate) || p.Field == FieldSelect || p.Field == FieldCount {
+			continue
+		}
+		if p.Field == FieldRepo && !p.Negated {
+			seenRepo = true
+		}
+		if p.Field == FieldFile {
+			seenFile = true
+		}
+		nodes = append(nodes, p)
+	}
+
+	if !seenRepo && !seenFile {
+		return nil, errors.Errorf("file:has.owner requires a repo: or file: filter to scope the files to search")
+	}
+
+	if parent.Pattern != nil {
+		nodes = append(nodes, parent.Pattern)
+	} else if !seenFile {
+		// Without a pattern or file filter the query would only find
+		// repositories, so find all of their files instead.
+		nodes = append(nodes, Parameter{
+			Field: FieldFile,
+			Value: ".",
+		})
+	}
+
+	return ToPlan(Dnf(nodes))
+}
+
 // nonPredicateRepos returns the repo nodes in a query that aren't predicates,
 // respecting parameters that determine repo results.
 func nonPredicateRepos(q Basic) []Node {
@@ -56,6 +56,66 @@ func TestRepoContainsPredicate(t *testing.T) {
 	})
 }
 
+func TestFileHasOwnerPredicate(t *testing.T) {
+	t.Run("ParseParams", func(t *testing.T) {
+		p := &FileHasOwnerPredicate{}
+		if err := p.ParseParams(" @org/team "); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if want := (&FileHasOwnerPredicate{Owner: "@org/team"}); !reflect.DeepEqual(want, p) {
+			t.Fatalf("expected %#v, got %#v", want, p)
+		}
+
+		for _, params := range []string{``, `@a @b`} {
+			if err := (&FileHasOwnerPredicate{}).ParseParams(params); err == nil {
+				t.Fatalf("expected error for %q but got none", params)
+			}
+		}
+	})
+
+	t.Run("Plan", func(t *testing.T) {
+		for _, tc := range []struct {
+			query string
+			want  string
+		}{
+			{
+				query: `repo:foo file:has.owner(@team) select:file.owners bar`,
+				want:  `(and "select:file" "count:99999" "repo:foo" "bar")`,
+			},
+			{
+				query: `repo:foo file:has.owner(@team)`,
+				want:  `(and "select:file" "count:99999" "repo:foo" "file:.")`,
+			},
+			{
+				query: `file:\.go$ file:has.owner(@team) bar`,
+				want:  `(and "select:file" "count:99999" "file:\\.go$" "bar")`,
+			},
+		} {
+			plan, err := Pipeline(Init(tc.query, SearchTypeRegex))
+			if err != nil {
+				t.Fatal(err)
+			}
+			predicatePlan, err := (&FileHasOwnerPredicate{Owner: "@team"}).Plan(plan[0])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have := predicatePlan.ToParseTree().String(); have != tc.want {
+				t.Fatalf("unexpected plan for %q.\nwant: %s\nhave: %s", tc.query, tc.want, have)
+			}
+		}
+
+		for _, query := range []string{`file:has.owner(@team) bar`, `-repo:foo file:has.owner(@team)`} {
+			plan, err := Pipeline(Init(query, SearchTypeRegex))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := (&FileHasOwnerPredicate{Owner: "@team"}).Plan(plan[0]); err == nil {
+				t.Fatalf("expected error for unscoped query %q but got none", query)
+			}
+		}
+	})
+}
+
 func TestParseAsPredicate(t *testing.T) {
 	tests := []struct {
 		input  string
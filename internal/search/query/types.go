@@ -367,7 +367,8 @@ func (q Q) valueToTypedValue(field, value string, label labels) []*Value {
 		return []*Value{{String: &value}}
 
 	case
-		FieldCase:
+		FieldCase,
+		FieldRank:
 		b, _ := parseBool(value)
 		return []*Value{{Bool: &b}}
 
@@ -294,7 +294,8 @@ func validateField(field, value string, negated bool, seen map[string]struct{})
 		FieldDefault:
 		// Search patterns are not validated here, as it depends on the search type.
 	case
-		FieldCase:
+		FieldCase,
+		FieldRank:
 		return satisfies(isSingular, isBoolean, isNotNegated)
 	case
 		FieldRepo:
@@ -482,6 +483,39 @@ func validateRepoHasFile(nodes []Node) error {
 	return nil
 }
 
+// validateSelectGroup validates that a query which selects a capture group
+// with select:content.group(...) contains a regexp pattern with that group.
+func validateSelectGroup(nodes []Node) error {
+	var group string
+	var selectsGroup bool
+	VisitField(nodes, FieldSelect, func(value string, _ bool, _ Annotation) {
+		if sp, err := filter.SelectPathFromString(value); err == nil {
+			group, selectsGroup = sp.Group()
+		}
+	})
+	if !selectsGroup {
+		return nil
+	}
+
+	var seenRegexp, seenGroup bool
+	VisitPattern(nodes, func(value string, negated bool, annotation Annotation) {
+		if negated || !annotation.Labels.IsSet(Regexp) {
+			return
+		}
+		seenRegexp = true
+		if rp, err := regexp.Compile(value); err == nil && filter.GroupIndex(rp, group) >= 0 {
+			seenGroup = true
+		}
+	})
+	if !seenRegexp {
+		return errors.New("select:content.group(...) requires a regular expression search pattern. Add patterntype:regexp to your query and try again")
+	}
+	if !seenGroup {
+		return errors.Errorf("the search pattern does not contain the capture group %s selected by select:content.group(%s)", group, group)
+	}
+	return nil
+}
+
 // validatePureLiteralPattern checks that no pattern expression contains and/or
 // operators nested inside concat. It may happen that we interpret a query this
 // way due to ambiguity. If this happens, return an error message.
@@ -557,6 +591,7 @@ func validate(nodes []Node) error {
 		validateCommitParameters,
 		validateTypeStructural,
 		validateRefGlobs,
+		validateSelectGroup,
 	)
 }
 
@@ -99,6 +99,16 @@ func TestAndOrQuery_Validation(t *testing.T) {
 			input: "type:symbol select:symbol.timelime",
 			want:  `invalid field "timelime" on select path "symbol.timelime"`,
 		},
+		{
+			input:      `select:content.group(1) foo(\d+)`,
+			want:       "select:content.group(...) requires a regular expression search pattern. Add patterntype:regexp to your query and try again",
+			searchType: SearchTypeLiteral,
+		},
+		{
+			input:      `select:content.group(version) foo(\d+)`,
+			want:       "the search pattern does not contain the capture group version selected by select:content.group(version)",
+			searchType: SearchTypeRegex,
+		},
 		{
 			input:      "nice try type:repo",
 			want:       "this structural search query specifies `type:` and is not supported. Structural search syntax only applies to searching file contents",
@@ -0,0 +1,148 @@
+package result
+
+import (
+	"regexp"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+)
+
+// GroupSelector narrows file matches to the values of a capture group of the
+// search patterns, as selected by select:content.group(...).
+type GroupSelector struct {
+	patterns []groupPattern
+}
+
+type groupPattern struct {
+	regexp *regexp.Regexp
+	index  int
+}
+
+// NewGroupSelector returns a GroupSelector for the capture group with the
+// given index or name. Only the non-negated regexp patterns of q that
+// contain the capture group are used to find its values.
+func NewGroupSelector(q query.Q, group string) *GroupSelector {
+	var flags string
+	if !q.IsCaseSensitive() {
+		flags = "(?i)"
+	}
+
+	s := &GroupSelector{}
+	query.VisitPattern(q, func(value string, negated bool, annotation query.Annotation) {
+		if negated || !annotation.Labels.IsSet(query.Regexp) {
+			return
+		}
+		rp, err := regexp.Compile(flags + value)
+		if err != nil {
+			return // Invariant: patterns are validated.
+		}
+		if index := filter.GroupIndex(rp, group); index >= 0 {
+			s.patterns = append(s.patterns, groupPattern{regexp: rp, index: index})
+		}
+	})
+	return s
+}
+
+// Select narrows the line matches of fm to the non-empty values of the
+// capture group, and drops its symbols. It returns nil if fm contains no
+// values of the capture group.
+func (s *GroupSelector) Select(fm *FileMatch) *FileMatch {
+	lineMatches := fm.LineMatches[:0]
+	for _, lm := range fm.LineMatches {
+		var offsetAndLengths [][2]int32
+		seen := map[[2]int32]struct{}{}
+		for _, p := range s.patterns {
+			for _, submatch := range p.regexp.FindAllStringSubmatchIndex(lm.Preview, -1) {
+				start, end := submatch[2*p.index], submatch[2*p.index+1]
+				if start < 0 || start == end {
+					// The group did not participate in the match or is empty.
+					continue
+				}
+				// Offsets and lengths are in runes, like those of the search backends.
+				offset := len([]rune(lm.Preview[:start]))
+				length := len([]rune(lm.Preview[start:end]))
+				offsetAndLength := [2]int32{int32(offset), int32(length)}
+				if _, ok := seen[offsetAndLength]; ok {
+					continue
+				}
+				seen[offsetAndLength] = struct{}{}
+				offsetAndLengths = append(offsetAndLengths, offsetAndLength)
+			}
+		}
+		if len(offsetAndLengths) > 0 {
+			lm.OffsetAndLengths = offsetAndLengths
+			lineMatches = append(lineMatches, lm)
+		}
+	}
+	if len(lineMatches) == 0 {
+		return nil
+	}
+	fm.LineMatches = lineMatches
+	fm.Symbols = nil
+	return fm
+}
+
+// MatchedValues returns the text of each match of lm.
+func (lm *LineMatch) MatchedValues() []string {
+	preview := []rune(lm.Preview)
+	values := make([]string, 0, len(lm.OffsetAndLengths))
+	for _, offsetAndLength := range lm.OffsetAndLengths {
+		if value, ok := matchedValue(preview, offsetAndLength); ok {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// matchedValue returns the text of preview at the given offset and length,
+// and false if they are out of bounds.
+func matchedValue(preview []rune, offsetAndLength [2]int32) (string, bool) {
+	start, end := int(offsetAndLength[0]), int(offsetAndLength[0]+offsetAndLength[1])
+	if start < 0 || start > end || end > len(preview) {
+		return "", false
+	}
+	return string(preview[start:end]), true
+}
+
+// GroupDeduper deduplicates the values of capture groups selected by a
+// GroupSelector across file matches, so that each value is only returned
+// once.
+type GroupDeduper struct {
+	seen map[string]struct{}
+}
+
+func NewGroupDeduper() *GroupDeduper {
+	return &GroupDeduper{
+		seen: make(map[string]struct{}),
+	}
+}
+
+// Dedup removes the matches of fm with values that have been seen in fm or
+// in previous file matches. It returns nil if no matches remain.
+func (d *GroupDeduper) Dedup(fm *FileMatch) *FileMatch {
+	lineMatches := fm.LineMatches[:0]
+	for _, lm := range fm.LineMatches {
+		preview := []rune(lm.Preview)
+		offsetAndLengths := lm.OffsetAndLengths[:0]
+		for _, offsetAndLength := range lm.OffsetAndLengths {
+			value, ok := matchedValue(preview, offsetAndLength)
+			if !ok {
+				continue
+			}
+			if _, ok := d.seen[value]; ok {
+				continue
+			}
+			d.seen[value] = struct{}{}
+			offsetAndLengths = append(offsetAndLengths, offsetAndLength)
+		}
+		if len(offsetAndLengths) > 0 {
+			lm.OffsetAndLengths = offsetAndLengths
+			lineMatches = append(lineMatches, lm)
+		}
+	}
+	if len(lineMatches) == 0 {
+		return nil
+	}
+	fm.LineMatches = lineMatches
+	return fm
+}
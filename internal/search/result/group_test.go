@@ -0,0 +1,105 @@
+package result
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+)
+
+func TestGroupSelector(t *testing.T) {
+	fileMatch := func(lines ...string) *FileMatch {
+		fm := &FileMatch{Symbols: []*SymbolMatch{{}}}
+		for _, line := range lines {
+			fm.LineMatches = append(fm.LineMatches, &LineMatch{Preview: line})
+		}
+		return fm
+	}
+
+	values := func(fm *FileMatch) []string {
+		if fm == nil {
+			return nil
+		}
+		var values []string
+		for _, lm := range fm.LineMatches {
+			values = append(values, lm.MatchedValues()...)
+		}
+		return values
+	}
+
+	for _, tc := range []struct {
+		query string
+		group string
+		fm    *FileMatch
+		want  []string
+	}{
+		{
+			query: `(\w+)@v(\d+)`,
+			group: "2",
+			fm:    fileMatch("foo@v1 bar@v2", "nothing here", "é x@v3"),
+			want:  []string{"1", "2", "3"},
+		},
+		{
+			query: `import (?P<pkg>\w+)`,
+			group: "pkg",
+			fm:    fileMatch("IMPORT Fmt"),
+			want:  []string{"Fmt"},
+		},
+		{
+			query: `case:yes import (?P<pkg>\w+)`,
+			group: "pkg",
+			fm:    fileMatch("IMPORT Fmt"),
+			want:  nil,
+		},
+		{
+			query: `a(x)?b`,
+			group: "1",
+			fm:    fileMatch("ab"),
+			want:  nil,
+		},
+		{
+			query: `a(\d) or b(\d)`,
+			group: "1",
+			fm:    fileMatch("a1 b2"),
+			want:  []string{"1", "2"},
+		},
+	} {
+		t.Run(tc.query, func(t *testing.T) {
+			plan, err := query.Pipeline(query.Init(tc.query, query.SearchTypeRegex))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := NewGroupSelector(plan.ToParseTree(), tc.group).Select(tc.fm)
+			if have := values(got); !reflect.DeepEqual(have, tc.want) {
+				t.Fatalf("want %q, have %q", tc.want, have)
+			}
+			if got != nil && len(got.Symbols) > 0 {
+				t.Fatal("expected symbols to be dropped")
+			}
+		})
+	}
+}
+
+func TestGroupDeduper(t *testing.T) {
+	fileMatch := func(preview string, offsetAndLengths ...[2]int32) *FileMatch {
+		return &FileMatch{
+			LineMatches: []*LineMatch{{
+				Preview:          preview,
+				OffsetAndLengths: offsetAndLengths,
+			}},
+		}
+	}
+
+	d := NewGroupDeduper()
+	first := d.Dedup(fileMatch("a b a", [2]int32{0, 1}, [2]int32{2, 1}, [2]int32{4, 1}))
+	if have, want := first.LineMatches[0].MatchedValues(), []string{"a", "b"}; !reflect.DeepEqual(have, want) {
+		t.Fatalf("want %q, have %q", want, have)
+	}
+	if second := d.Dedup(fileMatch("b a", [2]int32{0, 1}, [2]int32{2, 1})); second != nil {
+		t.Fatalf("expected seen values to be removed, have %q", second.LineMatches[0].MatchedValues())
+	}
+	third := d.Dedup(fileMatch("c", [2]int32{0, 1}))
+	if have, want := third.LineMatches[0].MatchedValues(), []string{"c"}; !reflect.DeepEqual(have, want) {
+		t.Fatalf("want %q, have %q", want, have)
+	}
+}
@@ -12,13 +12,44 @@ func Select(results []Match, q query.Basic) []Match {
 	}
 	sp, _ := filter.SelectPathFromString(v) // Invariant: select already validated
 
+	var (
+		groupSelector *GroupSelector
+		groupDedup    *GroupDeduper
+	)
+	if group, ok := sp.Group(); ok {
+		groupSelector = NewGroupSelector(q.ToParseTree(), group)
+		groupDedup = NewGroupDeduper()
+	}
+
 	dedup := NewDeduper()
 	for _, result := range results {
 		current := result.Select(sp)
 		if current == nil {
 			continue
 		}
+		if groupSelector != nil {
+			current = selectGroup(current, groupSelector, groupDedup)
+			if current == nil {
+				continue
+			}
+		}
 		dedup.Add(current)
 	}
 	return dedup.Results()
 }
+
+// selectGroup narrows a match selected by select:content.group(...) to the
+// values of the capture group that have not been seen yet.
+func selectGroup(m Match, s *GroupSelector, d *GroupDeduper) Match {
+	fm, ok := m.(*FileMatch)
+	if !ok {
+		return nil
+	}
+	if fm = s.Select(fm); fm == nil {
+		return nil
+	}
+	if fm = d.Dedup(fm); fm == nil {
+		return nil
+	}
+	return fm
+}
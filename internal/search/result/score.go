@@ -0,0 +1,120 @@
+package result
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// Weights of the signals that make up the score of a match.
+const (
+	scorePathDepth     = -0.5 // per directory the file is nested in, up to maxScoredPathDepth
+	scoreTestFile      = -3
+	scoreVendoredFile  = -5
+	scoreGeneratedFile = -5
+	scoreDefinition    = 4
+	scoreMatchCount    = 1 // per doubling of the number of matches
+	scoreMatchDensity  = 2 // for matches covering entire lines
+	scoreStars         = 1 // per order of magnitude of stars
+	scoreRecentRepo    = 1
+
+	maxScoredPathDepth = 10
+	recentRepoAge      = 30 * 24 * time.Hour
+)
+
+var (
+	testPathPattern      = lazyregexp.New(`(^|/)(tests?|__tests__|spec|testdata)/|_test\.\w+$|\.(test|spec)\.\w+$|(^|/)test_[^/]*\.py$`)
+	vendoredPathPattern  = lazyregexp.New(`(^|/)(vendor|node_modules|third_party|bower_components)/`)
+	generatedPathPattern = lazyregexp.New(`(^|/)(generated|gen)/|[._]generated\.\w+$|\.gen\.\w+$|\.pb(\.gw)?\.go$|_pb2\.py$|\.min\.(js|css)$|(^|/)(package-lock\.json|yarn\.lock|go\.sum)$`)
+	definitionPattern    = lazyregexp.New(`^\s*(export\s+)?(pub\s+)?(func|type|class|def|interface|struct|enum|trait|fn|module|const|var|let)\s`)
+)
+
+// Score returns the relevance of a match for ranking search results, where
+// more relevant matches have a higher score. The score of a file match
+// combines signals of the importance of the file, such as its path depth and
+// whether it is a test, vendored, or generated file, with signals of the
+// quality of its matches, such as symbol definitions and how much of the
+// matched lines the matches cover. If repo is not nil, the score also takes
+// the stars and the last fetch of the repository of the match into account.
+func Score(m Match, repo *types.SearchedRepo, now time.Time) float64 {
+	var score float64
+	if fm, ok := m.(*FileMatch); ok {
+		score += scoreFile(fm)
+	}
+	if repo != nil {
+		score += scoreRepo(repo, now)
+	}
+	return score
+}
+
+func scoreFile(fm *FileMatch) float64 {
+	var score float64
+
+	depth := strings.Count(fm.Path, "/")
+	if depth > maxScoredPathDepth {
+		depth = maxScoredPathDepth
+	}
+	score += scorePathDepth * float64(depth)
+
+	if testPathPattern.MatchString(fm.Path) {
+		score += scoreTestFile
+	}
+	if vendoredPathPattern.MatchString(fm.Path) {
+		score += scoreVendoredFile
+	}
+	if generatedPathPattern.MatchString(fm.Path) {
+		score += scoreGeneratedFile
+	}
+
+	if isDefinition(fm) {
+		score += scoreDefinition
+	}
+
+	score += scoreMatchCount * math.Log2(1+float64(fm.ResultCount()))
+	score += scoreMatchDensity * matchDensity(fm)
+	return score
+}
+
+// isDefinition returns true if the file match contains a symbol or if one of
+// its matched lines looks like a definition.
+func isDefinition(fm *FileMatch) bool {
+	if len(fm.Symbols) > 0 {
+		return true
+	}
+	for _, lm := range fm.LineMatches {
+		if definitionPattern.MatchString(lm.Preview) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchDensity returns the fraction of the matched lines of the file match
+// that is covered by matches, ignoring surrounding whitespace.
+func matchDensity(fm *FileMatch) float64 {
+	var matched, total int
+	for _, lm := range fm.LineMatches {
+		total += len(strings.TrimSpace(lm.Preview))
+		for _, offsetAndLength := range lm.OffsetAndLengths {
+			matched += int(offsetAndLength[1])
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	if matched > total {
+		return 1
+	}
+	return float64(matched) / float64(total)
+}
+
+func scoreRepo(repo *types.SearchedRepo, now time.Time) float64 {
+	score := scoreStars * math.Log10(1+float64(repo.Stars))
+	if repo.LastFetched != nil && now.Sub(*repo.LastFetched) < recentRepoAge {
+		score += scoreRecentRepo
+	}
+	return score
+}
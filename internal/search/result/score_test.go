@@ -0,0 +1,83 @@
+package result
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestScore(t *testing.T) {
+	now := time.Now()
+	fileMatch := func(path string, lines ...string) *FileMatch {
+		fm := &FileMatch{File: File{Path: path}}
+		for _, line := range lines {
+			fm.LineMatches = append(fm.LineMatches, &LineMatch{
+				Preview:          line,
+				OffsetAndLengths: [][2]int32{{0, 3}},
+			})
+		}
+		return fm
+	}
+
+	// Each pair lists a match that should score higher than the other.
+	for name, tc := range map[string]struct {
+		better, worse         Match
+		betterRepo, worseRepo *types.SearchedRepo
+	}{
+		"shallow path": {
+			better: fileMatch("foo.go", "foo := 1"),
+			worse:  fileMatch("a/b/c/foo.go", "foo := 1"),
+		},
+		"test file": {
+			better: fileMatch("foo.go", "foo := 1"),
+			worse:  fileMatch("foo_test.go", "foo := 1"),
+		},
+		"vendored file": {
+			better: fileMatch("lib/foo.js", "foo = 1"),
+			worse:  fileMatch("node_modules/foo.js", "foo = 1"),
+		},
+		"generated file": {
+			better: fileMatch("api/foo.go", "foo := 1"),
+			worse:  fileMatch("api/foo.pb.go", "foo := 1"),
+		},
+		"definition": {
+			better: fileMatch("bar.go", "func foo() {"),
+			worse:  fileMatch("baz.go", "x := foo() + 1"),
+		},
+		"symbol": {
+			better: &FileMatch{File: File{Path: "bar.go"}, Symbols: []*SymbolMatch{{}}},
+			worse:  &FileMatch{File: File{Path: "baz.go"}},
+		},
+		"match density": {
+			better: fileMatch("bar.go", "foo"),
+			worse:  fileMatch("baz.go", "foo and a lot of other things"),
+		},
+		"more matches": {
+			better: fileMatch("bar.go", "foo", "foo"),
+			worse:  fileMatch("baz.go", "foo"),
+		},
+		"stars": {
+			better:     fileMatch("foo.go", "foo"),
+			worse:      fileMatch("foo.go", "foo"),
+			betterRepo: &types.SearchedRepo{Stars: 1000},
+			worseRepo:  &types.SearchedRepo{Stars: 2},
+		},
+		"recently fetched": {
+			better:     &RepoMatch{},
+			worse:      &RepoMatch{},
+			betterRepo: &types.SearchedRepo{LastFetched: timePtr(now.Add(-time.Hour))},
+			worseRepo:  &types.SearchedRepo{LastFetched: timePtr(now.Add(-365 * 24 * time.Hour))},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			better := Score(tc.better, tc.betterRepo, now)
+			worse := Score(tc.worse, tc.worseRepo, now)
+			if better <= worse {
+				t.Errorf("expected score %f to be greater than %f", better, worse)
+			}
+		})
+	}
+}
+
+func timePtr(t time.Time) *time.Time { return &t }
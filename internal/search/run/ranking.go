@@ -0,0 +1,206 @@
+package run
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+const (
+	// rankingWindow is the number of results that are held back to be
+	// ranked before they are sent.
+	rankingWindow = 100
+
+	// rankingDelay is the maximum time a result is held back to be ranked.
+	rankingDelay = 250 * time.Millisecond
+)
+
+// WithRanking returns a child Stream of parent that orders results by
+// descending result.Score before passing them on to parent. Results are
+// ranked within a bounded window: they are held back until rankingWindow
+// results have been received or rankingDelay has passed since the first
+// held back result, so a result is never delayed for long but is only
+// ranked against the results received around the same time.
+//
+// The returned function sends all results that are still held back, after
+// which results sent on the child stream are passed on immediately. It must
+// be called once the search is done.
+func WithRanking(ctx context.Context, db dbutil.DB, parent streaming.Sender) (streaming.Sender, func()) {
+	s := &rankingStream{
+		ctx:    ctx,
+		db:     db,
+		parent: parent,
+		window: rankingWindow,
+		delay:  rankingDelay,
+	}
+	return s, s.close
+}
+
+type rankingStream struct {
+	ctx    context.Context
+	db     dbutil.DB
+	parent streaming.Sender
+	window int
+	delay  time.Duration
+
+	mu     sync.Mutex
+	event  streaming.SearchEvent
+	timer  *time.Timer
+	closed bool
+	// sent is closed once the last batch taken out of the stream has been
+	// sent to parent.
+	sent chan struct{}
+}
+
+// rankingBatch is an event taken out of the stream under the lock. It is
+// ranked and sent to parent without holding the lock, but only after the
+// batch taken out before it, so that batches are sent in order.
+type rankingBatch struct {
+	event streaming.SearchEvent
+	rank  bool
+	prev  <-chan struct{}
+	sent  chan struct{}
+}
+
+func (s *rankingStream) Send(event streaming.SearchEvent) {
+	s.mu.Lock()
+	if s.closed {
+		b := s.nextBatchLocked(event, false)
+		s.mu.Unlock()
+		s.send(b)
+		return
+	}
+
+	s.event.Results = append(s.event.Results, event.Results...)
+	s.event.Stats.Update(&event.Stats)
+
+	var b *rankingBatch
+	if len(s.event.Results) >= s.window {
+		b = s.flushLocked()
+	} else if s.timer == nil && len(s.event.Results) > 0 {
+		s.timer = time.AfterFunc(s.delay, s.flush)
+	}
+	s.mu.Unlock()
+
+	s.send(b)
+}
+
+func (s *rankingStream) flush() {
+	s.mu.Lock()
+	b := s.flushLocked()
+	s.mu.Unlock()
+
+	s.send(b)
+}
+
+func (s *rankingStream) close() {
+	s.mu.Lock()
+	b := s.flushLocked()
+	s.closed = true
+	last := s.sent
+	s.mu.Unlock()
+
+	if b != nil {
+		s.send(b)
+	} else if last != nil {
+		<-last
+	}
+}
+
+// flushLocked takes the held back results out of the stream as a batch to be
+// ranked. It returns nil if there is nothing to send.
+func (s *rankingStream) flushLocked() *rankingBatch {
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+	if len(s.event.Results) == 0 && s.event.Stats.Zero() {
+		return nil
+	}
+
+	event := s.event
+	s.event = streaming.SearchEvent{}
+	return s.nextBatchLocked(event, true)
+}
+
+func (s *rankingStream) nextBatchLocked(event streaming.SearchEvent, rank bool) *rankingBatch {
+	b := &rankingBatch{event: event, rank: rank, prev: s.sent, sent: make(chan struct{})}
+	s.sent = b.sent
+	return b
+}
+
+// send ranks the given batch, which may be nil, and sends it to parent once
+// the previous batch has been sent. It must be called without holding the
+// lock, since ranking fetches repository metadata from the database.
+func (s *rankingStream) send(b *rankingBatch) {
+	if b == nil {
+		return
+	}
+	defer close(b.sent)
+
+	if b.rank {
+		RankMatches(s.ctx, s.db, b.event.Results)
+	}
+	if b.prev != nil {
+		<-b.prev
+	}
+	s.parent.Send(b.event)
+}
+
+// RankMatches sorts matches by descending result.Score in place. The order of
+// matches with the same score is preserved.
+func RankMatches(ctx context.Context, db dbutil.DB, matches []result.Match) {
+	if len(matches) < 2 {
+		return
+	}
+
+	repos := rankingRepoMetadata(ctx, db, matches)
+	now := time.Now()
+	scores := make(map[result.Match]float64, len(matches))
+	for _, m := range matches {
+		scores[m] = result.Score(m, repos[m.RepoName().ID], now)
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return scores[matches[i]] > scores[matches[j]]
+	})
+}
+
+// rankingRepoMetadata returns the metadata of the repositories of the given
+// matches. Ranking is best effort, so if the metadata cannot be fetched, the
+// matches are ranked without it.
+func rankingRepoMetadata(ctx context.Context, db dbutil.DB, matches []result.Match) map[api.RepoID]*types.SearchedRepo {
+	seen := make(map[api.RepoID]struct{}, len(matches))
+	ids := make([]api.RepoID, 0, len(matches))
+	for _, m := range matches {
+		id := m.RepoName().ID
+		if _, ok := seen[id]; !ok {
+			seen[id] = struct{}{}
+			ids = append(ids, id)
+		}
+	}
+
+	metadata, err := database.Repos(db).Metadata(ctx, ids...)
+	if err != nil {
+		if ctx.Err() == nil {
+			log15.Warn("ranking: failed to fetch repository metadata", "error", err)
+		}
+		return nil
+	}
+
+	repos := make(map[api.RepoID]*types.SearchedRepo, len(metadata))
+	for _, repo := range metadata {
+		repos[repo.ID] = repo
+	}
+	return repos
+}
@@ -0,0 +1,198 @@
+package run
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtesting"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestRankMatches(t *testing.T) {
+	database.Mocks.Repos.Metadata = func(ctx context.Context, ids ...api.RepoID) ([]*types.SearchedRepo, error) {
+		return []*types.SearchedRepo{{ID: 2, Name: "popular", Stars: 100000}}, nil
+	}
+	t.Cleanup(func() { database.Mocks.Repos.Metadata = nil })
+
+	matches := []result.Match{
+		rankingFileMatch(1, "vendor/lib/foo.go"),
+		rankingFileMatch(1, "foo_test.go"),
+		rankingFileMatch(1, "foo.go"),
+		rankingFileMatch(2, "foo.go"),
+		&result.CommitMatch{},
+	}
+	RankMatches(context.Background(), new(dbtesting.MockDB), matches)
+
+	if diff := cmp.Diff([]string{"2:foo.go", "1:foo.go", ":", "1:foo_test.go", "1:vendor/lib/foo.go"}, rankingKeys(matches)); diff != "" {
+		t.Errorf("unexpected order (-want +got):\n%s", diff)
+	}
+}
+
+func TestWithRanking(t *testing.T) {
+	database.Mocks.Repos.Metadata = func(ctx context.Context, ids ...api.RepoID) ([]*types.SearchedRepo, error) {
+		return nil, nil
+	}
+	t.Cleanup(func() { database.Mocks.Repos.Metadata = nil })
+
+	var (
+		mu     sync.Mutex
+		events [][]string
+	)
+	parent := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, rankingKeys(event.Results))
+	})
+	sent := func() [][]string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([][]string(nil), events...)
+	}
+
+	stream := &rankingStream{
+		ctx:    context.Background(),
+		db:     new(dbtesting.MockDB),
+		parent: parent,
+		window: 3,
+		delay:  time.Hour,
+	}
+
+	// Results are held back until the window is full.
+	stream.Send(streaming.SearchEvent{Results: []result.Match{rankingFileMatch(1, "a/b/c.go"), rankingFileMatch(1, "a.go")}})
+	if len(sent()) != 0 {
+		t.Fatalf("unexpected events before the window is full: %v", sent())
+	}
+	stream.Send(streaming.SearchEvent{Results: []result.Match{rankingFileMatch(1, "a/b.go")}})
+
+	// Remaining results are sent on close, and later results immediately.
+	stream.Send(streaming.SearchEvent{Results: []result.Match{rankingFileMatch(1, "a/d/e.go"), rankingFileMatch(1, "d.go")}})
+	stream.close()
+	stream.Send(streaming.SearchEvent{Results: []result.Match{rankingFileMatch(1, "a/b/c/d.go"), rankingFileMatch(1, "f.go")}})
+
+	want := [][]string{
+		{"1:a.go", "1:a/b.go", "1:a/b/c.go"},
+		{"1:d.go", "1:a/d/e.go"},
+		{"1:a/b/c/d.go", "1:f.go"},
+	}
+	if diff := cmp.Diff(want, sent()); diff != "" {
+		t.Errorf("unexpected events (-want +got):\n%s", diff)
+	}
+}
+
+func TestWithRankingUnlocked(t *testing.T) {
+	ranking := make(chan struct{})
+	unblock := make(chan struct{})
+	database.Mocks.Repos.Metadata = func(ctx context.Context, ids ...api.RepoID) ([]*types.SearchedRepo, error) {
+		close(ranking)
+		<-unblock
+		return nil, nil
+	}
+	t.Cleanup(func() { database.Mocks.Repos.Metadata = nil })
+
+	var (
+		mu     sync.Mutex
+		events [][]string
+	)
+	stream := &rankingStream{
+		ctx: context.Background(),
+		db:  new(dbtesting.MockDB),
+		parent: streaming.StreamFunc(func(event streaming.SearchEvent) {
+			mu.Lock()
+			defer mu.Unlock()
+			events = append(events, rankingKeys(event.Results))
+		}),
+		window: 2,
+		delay:  time.Hour,
+	}
+
+	firstSent := make(chan struct{})
+	go func() {
+		defer close(firstSent)
+		stream.Send(streaming.SearchEvent{Results: []result.Match{rankingFileMatch(1, "a.go"), rankingFileMatch(1, "b.go")}})
+	}()
+	<-ranking
+
+	// The first window is being ranked, which must not keep further results
+	// from being held back.
+	held := make(chan struct{})
+	go func() {
+		defer close(held)
+		stream.Send(streaming.SearchEvent{Results: []result.Match{rankingFileMatch(1, "c.go")}})
+	}()
+	select {
+	case <-held:
+	case <-time.After(10 * time.Second):
+		t.Fatal("sending blocked while ranking")
+	}
+
+	close(unblock)
+	<-firstSent
+	stream.close()
+
+	want := [][]string{
+		{"1:a.go", "1:b.go"},
+		{"1:c.go"},
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if diff := cmp.Diff(want, events); diff != "" {
+		t.Errorf("unexpected events (-want +got):\n%s", diff)
+	}
+}
+
+func TestWithRankingDelay(t *testing.T) {
+	database.Mocks.Repos.Metadata = func(ctx context.Context, ids ...api.RepoID) ([]*types.SearchedRepo, error) {
+		return nil, nil
+	}
+	t.Cleanup(func() { database.Mocks.Repos.Metadata = nil })
+
+	sent := make(chan streaming.SearchEvent, 1)
+	stream := &rankingStream{
+		ctx:    context.Background(),
+		db:     new(dbtesting.MockDB),
+		parent: streaming.StreamFunc(func(event streaming.SearchEvent) { sent <- event }),
+		window: 100,
+		delay:  time.Millisecond,
+	}
+	stream.Send(streaming.SearchEvent{Results: []result.Match{rankingFileMatch(1, "a.go")}})
+
+	select {
+	case event := <-sent:
+		if diff := cmp.Diff([]string{"1:a.go"}, rankingKeys(event.Results)); diff != "" {
+			t.Errorf("unexpected results (-want +got):\n%s", diff)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("results were not sent after the delay")
+	}
+}
+
+func rankingFileMatch(repoID api.RepoID, path string) *result.FileMatch {
+	return &result.FileMatch{
+		File: result.File{
+			Repo: types.RepoName{ID: repoID},
+			Path: path,
+		},
+	}
+}
+
+func rankingKeys(matches []result.Match) []string {
+	keys := make([]string, 0, len(matches))
+	for _, m := range matches {
+		switch v := m.(type) {
+		case *result.FileMatch:
+			keys = append(keys, fmt.Sprintf("%d:%s", v.Repo.ID, v.Path))
+		default:
+			keys = append(keys, ":")
+		}
+	}
+	return keys
+}
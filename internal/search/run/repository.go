@@ -59,6 +59,7 @@ func SearchRepositories(ctx context.Context, args *search.TextParameters, limit
 		query.FieldRepoHasCommitAfter: {},
 		query.FieldPatternType:        {},
 		query.FieldSelect:             {},
+		query.FieldRank:               {},
 	}
 	// Don't return repo results if the search contains fields that aren't on the allowlist.
 	// Matching repositories based whether they contain files at a certain path (etc.) is not yet implemented.
@@ -20,7 +20,7 @@ type Filter struct {
 	// incomplete.
 	IsLimitHit bool
 
-	// Kind of filter. Should be "repo", "file", or "lang".
+	// Kind of filter. Should be "repo", "file", "lang", or "group".
 	Kind string
 
 	// important is used to prioritize the order that filters appear in.
@@ -73,23 +73,32 @@ type computeOpts struct {
 	// MaxRepos is the maximum number of filters to return with kind repo.
 	MaxRepos int
 
-	// MaxOther is the maximum number of filters to return which are not repo.
+	// MaxGroups is the maximum number of filters to return with kind group.
+	MaxGroups int
+
+	// MaxOther is the maximum number of filters to return which are not repo
+	// or group.
 	MaxOther int
 }
 
 // Compute returns an ordered slice of Filter to present to the user.
 func (m filters) Compute(opts computeOpts) []*Filter {
 	repos := filterHeap{max: opts.MaxRepos}
+	groups := filterHeap{max: opts.MaxGroups}
 	other := filterHeap{max: opts.MaxOther}
 	for _, f := range m {
-		if f.Kind == "repo" {
+		switch f.Kind {
+		case "repo":
 			repos.Add(f)
-		} else {
+		case "group":
+			groups.Add(f)
+		default:
 			other.Add(f)
 		}
 	}
 
-	all := append(repos.filterSlice, other.filterSlice...)
+	all := append(repos.filterSlice, groups.filterSlice...)
+	all = append(all, other.filterSlice...)
 	sort.Sort(all)
 
 	return all
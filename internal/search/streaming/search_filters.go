@@ -24,6 +24,11 @@ type SearchFilters struct {
 	// Globbing is true if the user has enabled globbing support.
 	Globbing bool
 
+	// SelectGroup is true if the query selects the values of a capture group
+	// with select:content.group(...). Each value is proposed as a filter of
+	// kind "group", counting how often it occurs.
+	SelectGroup bool
+
 	filters filters
 }
 
@@ -121,6 +126,15 @@ func (s *SearchFilters) Update(event SearchEvent) {
 		}
 	}
 
+	addGroupFilters := func(fm *result.FileMatch) {
+		for _, lm := range fm.LineMatches {
+			for _, value := range lm.MatchedValues() {
+				filter := fmt.Sprintf(`content:%s`, strconv.Quote(regexp.QuoteMeta(value)))
+				s.filters.Add(filter, value, 1, fm.LimitHit, "group")
+			}
+		}
+	}
+
 	if event.Stats.ExcludedForks > 0 {
 		s.filters.Add("fork:yes", "fork:yes", int32(event.Stats.ExcludedForks), event.Stats.IsLimitHit, "dynamic")
 		s.filters.MarkImportant("fork:yes")
@@ -145,6 +159,9 @@ func (s *SearchFilters) Update(event SearchEvent) {
 			if len(v.Symbols) > 0 {
 				s.filters.Add("type:symbol", "type:symbol", 1, v.LimitHit, "symbol")
 			}
+			if s.SelectGroup {
+				addGroupFilters(v)
+			}
 		case *result.RepoMatch:
 			// It should be fine to leave this blank since revision specifiers
 			// can only be used with the 'repo:' scope. In that case,
@@ -162,7 +179,8 @@ func (s *SearchFilters) Update(event SearchEvent) {
 // events passed to Next.
 func (s *SearchFilters) Compute() []*Filter {
 	return s.filters.Compute(computeOpts{
-		MaxRepos: 40,
-		MaxOther: 40,
+		MaxRepos:  40,
+		MaxGroups: 40,
+		MaxOther:  40,
 	})
 }
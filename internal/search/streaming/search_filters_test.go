@@ -96,3 +96,39 @@ func TestSearchFiltersUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestSearchFiltersUpdateSelectGroup(t *testing.T) {
+	fileMatch := func(preview string, offsetAndLengths ...[2]int32) *result.FileMatch {
+		return &result.FileMatch{
+			File: result.File{Repo: types.RepoName{Name: "foo"}},
+			LineMatches: []*result.LineMatch{{
+				Preview:          preview,
+				OffsetAndLengths: offsetAndLengths,
+			}},
+		}
+	}
+
+	s := &SearchFilters{SelectGroup: true}
+	s.Update(SearchEvent{
+		Results: []result.Match{
+			fileMatch("v1.2 v1.2", [2]int32{1, 3}, [2]int32{6, 3}),
+			fileMatch("v3", [2]int32{1, 1}),
+		},
+	})
+
+	for value, wantCount := range map[string]int{
+		`content:"1\\.2"`: 2,
+		`content:"3"`:     1,
+	} {
+		f, ok := s.filters[value]
+		if !ok {
+			t.Fatalf("expected %s", value)
+		}
+		if f.Kind != "group" {
+			t.Fatalf("want group, got %s", f.Kind)
+		}
+		if f.Count != wantCount {
+			t.Fatalf("want %d, got %d", wantCount, f.Count)
+		}
+	}
+}
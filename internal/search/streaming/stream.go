@@ -107,6 +107,31 @@ func WithSelect(parent Sender, s filter.SelectPath) Sender {
 	})
 }
 
+// WithSelectGroup returns a child Stream of parent that narrows file matches
+// to the values of the capture group selected by s, dropping all other
+// matches. Values are not deduplicated, so that consumers can count them.
+func WithSelectGroup(parent Sender, s *result.GroupSelector) Sender {
+	return StreamFunc(func(e SearchEvent) {
+		if parent == nil {
+			return
+		}
+
+		selected := e.Results[:0]
+		for _, match := range e.Results {
+			fm, ok := match.(*result.FileMatch)
+			if !ok {
+				continue
+			}
+			if fm = s.Select(fm); fm != nil {
+				selected = append(selected, fm)
+			}
+		}
+		e.Results = selected
+
+		parent.Send(e)
+	})
+}
+
 type StreamFunc func(SearchEvent)
 
 func (f StreamFunc) Send(se SearchEvent) {
@@ -47,7 +47,7 @@ type Attachment struct {
 	MarkdownIn []string `json:"mrkdwn_in"`
 	ThumbURL   string   `json:"thumb_url"`
 	Text       string   `json:"text,omitempty"`
-	Timestamp  int64    `json:"ts"`
+	Timestamp  int64    `json:"ts,omitempty"`
 	Title      string   `json:"title"`
 	TitleLink  string   `json:"title_link,omitempty"`
 }
@@ -21,6 +21,10 @@ type MockStore struct {
 	// AddExecutionLogEntryFunc is an instance of a mock function object
 	// controlling the behavior of the method AddExecutionLogEntry.
 	AddExecutionLogEntryFunc *StoreAddExecutionLogEntryFunc
+	// AppendExecutionLogEntryOutputFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// AppendExecutionLogEntryOutput.
+	AppendExecutionLogEntryOutputFunc *StoreAppendExecutionLogEntryOutputFunc
 	// DequeueFunc is an instance of a mock function object controlling the
 	// behavior of the method Dequeue.
 	DequeueFunc *StoreDequeueFunc
@@ -65,6 +69,11 @@ func NewMockStore() *MockStore {
 				return 0, nil
 			},
 		},
+		AppendExecutionLogEntryOutputFunc: &StoreAppendExecutionLogEntryOutputFunc{
+			defaultHook: func(context.Context, int, int, string, store.ExecutionLogEntryOptions) error {
+				return nil
+			},
+		},
 		DequeueFunc: &StoreDequeueFunc{
 			defaultHook: func(context.Context, string, []*sqlf.Query) (workerutil.Record, bool, error) {
 				return nil, false, nil
@@ -130,6 +139,9 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		AddExecutionLogEntryFunc: &StoreAddExecutionLogEntryFunc{
 			defaultHook: i.AddExecutionLogEntry,
 		},
+		AppendExecutionLogEntryOutputFunc: &StoreAppendExecutionLogEntryOutputFunc{
+			defaultHook: i.AppendExecutionLogEntryOutput,
+		},
 		DequeueFunc: &StoreDequeueFunc{
 			defaultHook: i.Dequeue,
 		},
@@ -281,6 +293,125 @@ func (c StoreAddExecutionLogEntryFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreAppendExecutionLogEntryOutputFunc describes the behavior when the
+// AppendExecutionLogEntryOutput method of the parent MockStore instance is
+// invoked.
+type StoreAppendExecutionLogEntryOutputFunc struct {
+	defaultHook func(context.Context, int, int, string, store.ExecutionLogEntryOptions) error
+	hooks       []func(context.Context, int, int, string, store.ExecutionLogEntryOptions) error
+	history     []StoreAppendExecutionLogEntryOutputFuncCall
+	mutex       sync.Mutex
+}
+
+// AppendExecutionLogEntryOutput delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockStore) AppendExecutionLogEntryOutput(v0 context.Context, v1 int, v2 int, v3 string, v4 store.ExecutionLogEntryOptions) error {
+	r0 := m.AppendExecutionLogEntryOutputFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.AppendExecutionLogEntryOutputFunc.appendCall(StoreAppendExecutionLogEntryOutputFuncCall{v0, v1, v2, v3, v4, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// AppendExecutionLogEntryOutput method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreAppendExecutionLogEntryOutputFunc) SetDefaultHook(hook func(context.Context, int, int, string, store.ExecutionLogEntryOptions) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// AppendExecutionLogEntryOutput method of the parent MockStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *StoreAppendExecutionLogEntryOutputFunc) PushHook(hook func(context.Context, int, int, string, store.ExecutionLogEntryOptions) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *StoreAppendExecutionLogEntryOutputFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, int, string, store.ExecutionLogEntryOptions) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *StoreAppendExecutionLogEntryOutputFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, int, string, store.ExecutionLogEntryOptions) error {
+		return r0
+	})
+}
+
+func (f *StoreAppendExecutionLogEntryOutputFunc) nextHook() func(context.Context, int, int, string, store.ExecutionLogEntryOptions) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreAppendExecutionLogEntryOutputFunc) appendCall(r0 StoreAppendExecutionLogEntryOutputFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// StoreAppendExecutionLogEntryOutputFuncCall objects describing the
+// invocations of this function.
+func (f *StoreAppendExecutionLogEntryOutputFunc) History() []StoreAppendExecutionLogEntryOutputFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreAppendExecutionLogEntryOutputFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreAppendExecutionLogEntryOutputFuncCall is an object that describes
+// an invocation of method AppendExecutionLogEntryOutput on an instance of
+// MockStore.
+type StoreAppendExecutionLogEntryOutputFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 store.ExecutionLogEntryOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreAppendExecutionLogEntryOutputFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreAppendExecutionLogEntryOutputFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // StoreDequeueFunc describes the behavior when the Dequeue method of the
 // parent MockStore instance is invoked.
 type StoreDequeueFunc struct {
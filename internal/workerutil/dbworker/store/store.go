@@ -104,6 +104,11 @@ type Store interface {
 	// found (due to options not matching or the record being deleted), ErrExecutionLogEntryNotUpdated is returned.
 	UpdateExecutionLogEntry(ctx context.Context, recordID, entryID int, entry workerutil.ExecutionLogEntry, options ExecutionLogEntryOptions) error
 
+	// AppendExecutionLogEntryOutput appends the given output to the executor log entry with the given ID on the given
+	// record. When the record is not found (due to options not matching or the record being deleted),
+	// ErrExecutionLogEntryNotUpdated is returned.
+	AppendExecutionLogEntryOutput(ctx context.Context, recordID, entryID int, out string, options ExecutionLogEntryOptions) error
+
 	// MarkComplete attempts to update the state of the record to complete. If this record has already been moved from
 	// the processing state to a terminal state, this method will have no effect. This method returns a boolean flag
 	// indicating if the record was updated.
@@ -694,6 +699,56 @@ RETURNING
 	array_length({execution_logs}, 1)
 `
 
+// AppendExecutionLogEntryOutput appends the given output to the executor log entry with the given ID on the given
+// record. When the record is not found (due to options not matching or the record being deleted),
+// ErrExecutionLogEntryNotUpdated is returned.
+func (s *store) AppendExecutionLogEntryOutput(ctx context.Context, recordID, entryID int, out string, options ExecutionLogEntryOptions) (err error) {
+	ctx, endObservation := s.operations.appendExecutionLogEntryOutput.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("recordID", recordID),
+		log.Int("entryID", entryID),
+		log.Int("outLen", len(out)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	conds := []*sqlf.Query{
+		s.formatQuery("{id} = %s", recordID),
+		s.formatQuery("array_length({execution_logs}, 1) >= %s", entryID),
+	}
+	conds = append(conds, options.ToSQLConds(s.formatQuery)...)
+
+	_, ok, err := basestore.ScanFirstInt(s.Query(ctx, s.formatQuery(
+		appendExecutionLogEntryOutputQuery,
+		quote(s.options.TableName),
+		entryID,
+		entryID,
+		entryID,
+		out,
+		sqlf.Join(conds, "AND"),
+	)))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrExecutionLogEntryNotUpdated
+	}
+	return nil
+}
+
+const appendExecutionLogEntryOutputQuery = `
+-- source: internal/workerutil/store.go:AppendExecutionLogEntryOutput
+UPDATE
+	%s
+SET {execution_logs}[%s] = jsonb_set(
+	{execution_logs}[%s]::jsonb,
+	'{out}',
+	to_jsonb(COALESCE({execution_logs}[%s]::jsonb->>'out', '') || %s::text)
+)::json
+WHERE
+	%s
+RETURNING
+	array_length({execution_logs}, 1)
+`
+
 // MarkComplete attempts to update the state of the record to complete. If this record has already been moved from
 // the processing state to a terminal state, this method will have no effect. This method returns a boolean flag
 // indicating if the record was updated.
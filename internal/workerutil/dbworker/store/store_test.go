@@ -557,6 +557,57 @@ func TestStoreUpdateExecutionLogEntryUnknownEntry(t *testing.T) {
 	}
 }
 
+func TestStoreAppendExecutionLogEntryOutput(t *testing.T) {
+	db := setupStoreTest(t)
+
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO workerutil_test (id, state)
+		VALUES
+			(1, 'processing')
+	`); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+
+	entry := workerutil.ExecutionLogEntry{
+		Command: []string{"ls", "-a"},
+	}
+	entryID, err := testStore(db, defaultTestStoreOptions(nil)).AddExecutionLogEntry(context.Background(), 1, entry, ExecutionLogEntryOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error adding executor log entry: %s", err)
+	}
+
+	for _, out := range []string{"stdout: a\n", "stdout: b\n", "stderr: 'c' \"d\"\n"} {
+		if err := testStore(db, defaultTestStoreOptions(nil)).AppendExecutionLogEntryOutput(context.Background(), 1, entryID, out, ExecutionLogEntryOptions{}); err != nil {
+			t.Fatalf("unexpected error appending executor log entry output: %s", err)
+		}
+	}
+
+	contents, err := basestore.ScanStrings(db.QueryContext(context.Background(), `SELECT unnest(execution_logs)::text FROM workerutil_test WHERE id = 1`))
+	if err != nil {
+		t.Fatalf("unexpected error scanning record: %s", err)
+	}
+	if len(contents) != 1 {
+		t.Fatalf("unexpected number of payloads. want=%d have=%d", 1, len(contents))
+	}
+
+	var actual workerutil.ExecutionLogEntry
+	if err := json.Unmarshal([]byte(contents[0]), &actual); err != nil {
+		t.Fatalf("unexpected error decoding entry: %s", err)
+	}
+
+	expected := workerutil.ExecutionLogEntry{
+		Command: []string{"ls", "-a"},
+		Out:     "stdout: a\nstdout: b\nstderr: 'c' \"d\"\n",
+	}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("unexpected entry (-want +got):\n%s", diff)
+	}
+
+	if err := testStore(db, defaultTestStoreOptions(nil)).AppendExecutionLogEntryOutput(context.Background(), 1, entryID+1, "oops", ExecutionLogEntryOptions{}); err != ErrExecutionLogEntryNotUpdated {
+		t.Fatalf("unexpected error. want=%q have=%q", ErrExecutionLogEntryNotUpdated, err)
+	}
+}
+
 func TestStoreMarkComplete(t *testing.T) {
 	db := setupStoreTest(t)
 
@@ -0,0 +1,70 @@
+package logstream
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+)
+
+// EventWriter writes named events to a client, such as a *streamhttp.Writer.
+type EventWriter interface {
+	Event(event string, data interface{}) error
+}
+
+// Job is a snapshot of a job whose execution logs are followed.
+type Job struct {
+	ExecutionLogs []workerutil.ExecutionLogEntry
+
+	// Finished is true once the job is in a terminal state, after which its
+	// execution logs no longer change.
+	Finished bool
+
+	// State and FailureMessage are sent with the "done" event.
+	State          string
+	FailureMessage *string
+}
+
+// DoneEvent is the payload of the "done" event.
+type DoneEvent struct {
+	State          string  `json:"state"`
+	FailureMessage *string `json:"failureMessage,omitempty"`
+}
+
+// Follow writes each change to the execution logs of the given job as a "log" event
+// holding an Event, reading the job again with next every interval. Once the job
+// has finished, a "done" event holding a DoneEvent is written and Follow returns.
+//
+// If reading the job fails, an "error" event is written and the error is returned.
+// Follow returns nil early if the client went away or ctx is canceled.
+func Follow(ctx context.Context, w EventWriter, interval time.Duration, job Job, next func(ctx context.Context) (Job, error)) error {
+	var tracker Tracker
+	for {
+		for _, event := range tracker.Update(job.ExecutionLogs) {
+			if err := w.Event("log", event); err != nil {
+				// The client went away.
+				return nil
+			}
+		}
+
+		if job.Finished {
+			_ = w.Event("done", DoneEvent{State: job.State, FailureMessage: job.FailureMessage})
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+
+		var err error
+		if job, err = next(ctx); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			_ = w.Event("error", map[string]string{"message": err.Error()})
+			return err
+		}
+	}
+}
@@ -0,0 +1,87 @@
+// Package logstream turns successive snapshots of the execution logs of a record into
+// incremental updates that can be streamed to clients following a running job.
+package logstream
+
+import (
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+)
+
+// Event describes a change to an execution log entry since the previous snapshot.
+type Event struct {
+	// Entry is the zero-based index of the execution log entry.
+	Entry int `json:"entry"`
+
+	// Key, Command, and StartTime are only set for the first event of an entry.
+	Key       string     `json:"key,omitempty"`
+	Command   []string   `json:"command,omitempty"`
+	StartTime *time.Time `json:"startTime,omitempty"`
+
+	// Out is the output written since the previous event of the entry, which belongs
+	// at byte offset Offset of the output of the entry. An offset of zero replaces any
+	// output received for the entry so far.
+	Offset int    `json:"offset"`
+	Out    string `json:"out,omitempty"`
+
+	// ExitCode and DurationMs are set once the command of the entry has finished.
+	ExitCode   *int `json:"exitCode,omitempty"`
+	DurationMs *int `json:"durationMs,omitempty"`
+}
+
+// Tracker remembers the execution log entries it has seen last.
+type Tracker struct {
+	entries []workerutil.ExecutionLogEntry
+}
+
+// Update returns the events that describe the changes from the previously seen
+// execution log entries to the given ones.
+func (t *Tracker) Update(entries []workerutil.ExecutionLogEntry) []Event {
+	var events []Event
+	for i, entry := range entries {
+		if i >= len(t.entries) {
+			startTime := entry.StartTime
+			events = append(events, Event{
+				Entry:      i,
+				Key:        entry.Key,
+				Command:    entry.Command,
+				StartTime:  &startTime,
+				Out:        entry.Out,
+				ExitCode:   entry.ExitCode,
+				DurationMs: entry.DurationMs,
+			})
+			continue
+		}
+
+		event, ok := diff(i, t.entries[i], entry)
+		if ok {
+			events = append(events, event)
+		}
+	}
+
+	t.entries = append(t.entries[:0], entries...)
+	return events
+}
+
+func diff(i int, old, new workerutil.ExecutionLogEntry) (Event, bool) {
+	event := Event{Entry: i}
+	if strings.HasPrefix(new.Out, old.Out) {
+		event.Offset = len(old.Out)
+		event.Out = new.Out[len(old.Out):]
+	} else {
+		// The output was rewritten, e.g. when the final write of the executor
+		// repaired output that failed to be appended.
+		event.Out = new.Out
+	}
+
+	if new.ExitCode != nil && old.ExitCode == nil {
+		event.ExitCode = new.ExitCode
+	}
+	if new.DurationMs != nil && old.DurationMs == nil {
+		event.DurationMs = new.DurationMs
+	}
+
+	changed := event.Out != "" || event.Offset == 0 && old.Out != "" || event.ExitCode != nil || event.DurationMs != nil
+	return event, changed
+}
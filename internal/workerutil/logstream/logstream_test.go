@@ -0,0 +1,118 @@
+package logstream
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+)
+
+func TestTracker(t *testing.T) {
+	startTime := time.Unix(1587396557, 0).UTC()
+	exitCode := 0
+	durationMs := 1234
+
+	entry := func(out string, finished bool) workerutil.ExecutionLogEntry {
+		e := workerutil.ExecutionLogEntry{Key: "step.docker.0", Command: []string{"docker", "run"}, StartTime: startTime, Out: out}
+		if finished {
+			e.ExitCode = &exitCode
+			e.DurationMs = &durationMs
+		}
+		return e
+	}
+
+	var tracker Tracker
+	for _, tc := range []struct {
+		name    string
+		entries []workerutil.ExecutionLogEntry
+		want    []Event
+	}{
+		{
+			name: "no entries",
+			want: nil,
+		},
+		{
+			name:    "new entry",
+			entries: []workerutil.ExecutionLogEntry{entry("stdout: a\n", false)},
+			want: []Event{
+				{Entry: 0, Key: "step.docker.0", Command: []string{"docker", "run"}, StartTime: &startTime, Out: "stdout: a\n"},
+			},
+		},
+		{
+			name:    "unchanged",
+			entries: []workerutil.ExecutionLogEntry{entry("stdout: a\n", false)},
+			want:    nil,
+		},
+		{
+			name:    "appended output",
+			entries: []workerutil.ExecutionLogEntry{entry("stdout: a\nstdout: b\n", false)},
+			want:    []Event{{Entry: 0, Offset: 10, Out: "stdout: b\n"}},
+		},
+		{
+			name:    "finished",
+			entries: []workerutil.ExecutionLogEntry{entry("stdout: a\nstdout: b\n", true)},
+			want:    []Event{{Entry: 0, Offset: 20, ExitCode: &exitCode, DurationMs: &durationMs}},
+		},
+		{
+			name: "rewritten output and second entry",
+			entries: []workerutil.ExecutionLogEntry{
+				entry("stdout: a\nstdout: c\n", true),
+				entry("", false),
+			},
+			want: []Event{
+				{Entry: 0, Offset: 0, Out: "stdout: a\nstdout: c\n"},
+				{Entry: 1, Key: "step.docker.0", Command: []string{"docker", "run"}, StartTime: &startTime},
+			},
+		},
+	} {
+		if diff := cmp.Diff(tc.want, tracker.Update(tc.entries)); diff != "" {
+			t.Errorf("%s: unexpected events (-want +got):\n%s", tc.name, diff)
+		}
+	}
+}
+
+type recordedEvent struct {
+	Name string
+	Data interface{}
+}
+
+type eventRecorder struct {
+	events []recordedEvent
+}
+
+func (r *eventRecorder) Event(event string, data interface{}) error {
+	r.events = append(r.events, recordedEvent{Name: event, Data: data})
+	return nil
+}
+
+func TestFollow(t *testing.T) {
+	startTime := time.Unix(1587396557, 0).UTC()
+	entry := workerutil.ExecutionLogEntry{Key: "step.docker.0", StartTime: startTime, Out: "stdout: a\n"}
+	failureMessage := "exit status 1"
+
+	jobs := []Job{
+		{ExecutionLogs: []workerutil.ExecutionLogEntry{entry}},
+		{ExecutionLogs: []workerutil.ExecutionLogEntry{entry}, Finished: true, State: "ERRORED", FailureMessage: &failureMessage},
+	}
+
+	var w eventRecorder
+	err := Follow(context.Background(), &w, time.Millisecond, Job{}, func(ctx context.Context) (Job, error) {
+		job := jobs[0]
+		jobs = jobs[1:]
+		return job, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := []recordedEvent{
+		{Name: "log", Data: Event{Entry: 0, Key: "step.docker.0", StartTime: &startTime, Out: "stdout: a\n"}},
+		{Name: "done", Data: DoneEvent{State: "ERRORED", FailureMessage: &failureMessage}},
+	}
+	if diff := cmp.Diff(want, w.events); diff != "" {
+		t.Errorf("unexpected events (-want +got):\n%s", diff)
+	}
+}
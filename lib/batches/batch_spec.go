@@ -2,6 +2,7 @@ package batches
 
 import (
 	"fmt"
+	"path"
 	"strings"
 
 	"github.com/cockroachdb/errors"
@@ -46,6 +47,10 @@ type ChangesetTemplate struct {
 	Branch    string                       `json:"branch,omitempty" yaml:"branch"`
 	Commit    ExpandedGitCommitDescription `json:"commit,omitempty" yaml:"commit"`
 	Published *overridable.BoolOrString    `json:"published" yaml:"published"`
+	Rebase    bool                         `json:"rebase,omitempty" yaml:"rebase"`
+	Reviewers *overridable.StringList      `json:"reviewers,omitempty" yaml:"reviewers"`
+	Assignees *overridable.StringList      `json:"assignees,omitempty" yaml:"assignees"`
+	Labels    *overridable.StringList      `json:"labels,omitempty" yaml:"labels"`
 }
 
 type GitCommitAuthor struct {
@@ -81,6 +86,7 @@ type Step struct {
 	Env       env.Environment   `json:"env,omitempty" yaml:"env"`
 	Files     map[string]string `json:"files,omitempty" yaml:"files,omitempty"`
 	Outputs   Outputs           `json:"outputs,omitempty" yaml:"outputs,omitempty"`
+	Artifacts []StepArtifact    `json:"artifacts,omitempty" yaml:"artifacts,omitempty"`
 
 	If interface{} `json:"if,omitempty" yaml:"if,omitempty"`
 }
@@ -106,6 +112,28 @@ type Output struct {
 	Format string `json:"format,omitempty" yaml:"format,omitempty"`
 }
 
+// StepArtifact is a file produced by a step that is uploaded after the step
+// finished.
+type StepArtifact struct {
+	Path string `json:"path,omitempty" yaml:"path"`
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+}
+
+// DisplayName returns the name of the artifact, which defaults to its path.
+func (a StepArtifact) DisplayName() string {
+	if a.Name != "" {
+		return a.Name
+	}
+	return a.Path
+}
+
+// RelativePath returns the path of the artifact relative to the directory the
+// step ran in. Leading slashes and ".." elements are removed, so that the
+// artifact can't refer to a file outside of that directory.
+func (a StepArtifact) RelativePath() string {
+	return path.Clean("/" + a.Path)[1:]
+}
+
 type TransformChanges struct {
 	Group []Group `json:"group,omitempty" yaml:"group"`
 }
@@ -77,6 +77,14 @@ type ChangesetSpec struct {
 	Commits []GitCommitDescription `json:"commits,omitempty"`
 
 	Published PublishedValue `json:"published,omitempty"`
+
+	// Reviewers, Assignees and Labels are the usernames of the users to
+	// request reviews from and to assign, and the labels to set on the
+	// changeset on the code host. They are left unchanged on the code host
+	// when empty.
+	Reviewers []string `json:"reviewers,omitempty"`
+	Assignees []string `json:"assignees,omitempty"`
+	Labels    []string `json:"labels,omitempty"`
 }
 
 type GitCommitDescription struct {
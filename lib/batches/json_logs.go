@@ -158,12 +158,12 @@ type CheckingCacheMetadata struct {
 }
 
 type JSONLinesTask struct {
-	ID                     string `json:"id"`
-	Repository             string `json:"repository"`
-	Workspace              string `json:"workspace"`
-	Steps                  []Step `json:"steps"`
-	CachedStepResultsFound bool   `json:"cachedStepResultFound"`
-	StartStep              int    `json:"startStep"`
+	ID                    string `json:"id"`
+	Repository            string `json:"repository"`
+	Workspace             string `json:"workspace"`
+	Steps                 []Step `json:"steps"`
+	CachedStepResultFound bool   `json:"cachedStepResultFound"`
+	StartStep             int    `json:"startStep"`
 }
 
 type ExecutingTasksMetadata struct {
@@ -0,0 +1,100 @@
+package overridable
+
+import (
+	"encoding/json"
+
+	"github.com/cockroachdb/errors"
+)
+
+// StringList is a set of rules that evaluate to a list of strings.
+type StringList struct {
+	rules rules
+}
+
+// FromStringList creates a StringList representing a static, scalar value.
+func FromStringList(l []string) StringList {
+	return StringList{
+		rules: rules{simpleRule(l)},
+	}
+}
+
+// Value returns the list of strings for the given repository.
+func (sl *StringList) Value(name string) []string {
+	v := sl.rules.Match(name)
+	if v == nil {
+		return nil
+	}
+	return v.([]string)
+}
+
+// MarshalJSON encodes the StringList overridable to a json representation.
+func (sl StringList) MarshalJSON() ([]byte, error) {
+	if len(sl.rules) == 0 {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(sl.rules)
+}
+
+// UnmarshalJSON unmarshalls a JSON value into a StringList.
+func (sl *StringList) UnmarshalJSON(data []byte) error {
+	var all []string
+	if err := json.Unmarshal(data, &all); err == nil {
+		*sl = StringList{rules: rules{simpleRule(all)}}
+		return nil
+	}
+
+	var c complex
+	if err := json.Unmarshal(data, &c); err != nil {
+		return err
+	}
+
+	return sl.hydrateFromComplex(c)
+}
+
+// UnmarshalYAML unmarshalls a YAML value into a StringList.
+func (sl *StringList) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var all []string
+	if err := unmarshal(&all); err == nil {
+		*sl = StringList{rules: rules{simpleRule(all)}}
+		return nil
+	}
+
+	var c complex
+	if err := unmarshal(&c); err != nil {
+		return err
+	}
+
+	return sl.hydrateFromComplex(c)
+}
+
+// hydrateFromComplex builds the rules out of a complex value, ensuring that
+// every rule evaluates to a list of strings.
+func (sl *StringList) hydrateFromComplex(c complex) error {
+	if err := sl.rules.hydrateFromComplex(c); err != nil {
+		return err
+	}
+
+	for i, rule := range sl.rules {
+		values, ok := rule.value.([]interface{})
+		if !ok {
+			return errors.Errorf("unexpected value in the array at entry %d: %v (must be a list of strings)", i, rule.value)
+		}
+
+		l := make([]string, len(values))
+		for j, v := range values {
+			s, ok := v.(string)
+			if !ok {
+				return errors.Errorf("unexpected value in the list at entry %d: %v (must be a string)", i, v)
+			}
+			l[j] = s
+		}
+		rule.value = l
+	}
+
+	return nil
+}
+
+// Equal tests two StringLists for equality, used in cmp.
+func (sl StringList) Equal(other StringList) bool {
+	return sl.rules.Equal(other.rules)
+}
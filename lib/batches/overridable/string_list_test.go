@@ -0,0 +1,212 @@
+package overridable
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v2"
+)
+
+func TestStringListValue(t *testing.T) {
+	for name, tc := range map[string]struct {
+		in   StringList
+		name string
+		want []string
+	}{
+		"wildcard": {
+			in: StringList{
+				rules: rules{{pattern: allPattern, value: []string{"a", "b"}}},
+			},
+			name: "foo",
+			want: []string{"a", "b"},
+		},
+		"list exhausted": {
+			in: StringList{
+				rules: rules{{pattern: "bar*", value: []string{"a"}}},
+			},
+			name: "foo",
+			want: nil,
+		},
+		"multiple matches": {
+			in: StringList{
+				rules: rules{
+					{pattern: allPattern, value: []string{"a"}},
+					{pattern: "bar*", value: []string{"b"}},
+				},
+			},
+			name: "bar",
+			want: []string{"b"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if err := initStringList(&tc.in); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.want, tc.in.Value(tc.name)); diff != "" {
+				t.Errorf("unexpected value (-want +have):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestStringListMarshalJSON(t *testing.T) {
+	for name, tc := range map[string]struct {
+		in   StringList
+		want string
+	}{
+		"scalar": {
+			in:   FromStringList([]string{"a", "b"}),
+			want: `["a","b"]`,
+		},
+		"rules": {
+			in: StringList{
+				rules{
+					{pattern: allPattern, value: []string{"a"}},
+					{pattern: "bar*", value: []string{}},
+				},
+			},
+			want: `[{"*":["a"]},{"bar*":[]}]`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			data, err := json.Marshal(&tc.in)
+			if err != nil {
+				t.Errorf("unexpected non-nil error: %v", err)
+			}
+			if have := string(data); have != tc.want {
+				t.Errorf("unexpected JSON: have=%q want=%q", have, tc.want)
+			}
+		})
+	}
+}
+
+func TestStringListUnmarshalJSON(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		for name, tc := range map[string]struct {
+			in   string
+			want StringList
+		}{
+			"single list": {
+				in: `["a","b"]`,
+				want: StringList{
+					rules: rules{
+						{pattern: allPattern, value: []string{"a", "b"}},
+					},
+				},
+			},
+			"empty list": {
+				in: `[]`,
+				want: StringList{
+					rules: rules{
+						{pattern: allPattern, value: []string{}},
+					},
+				},
+			},
+			"multiple rule list": {
+				in: `[{"*":["a"]},{"github.com/sourcegraph/*":["b","c"]}]`,
+				want: StringList{
+					rules: rules{
+						{pattern: allPattern, value: []string{"a"}},
+						{pattern: "github.com/sourcegraph/*", value: []string{"b", "c"}},
+					},
+				},
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				var have StringList
+				if err := json.Unmarshal([]byte(tc.in), &have); err != nil {
+					t.Errorf("unexpected non-nil error: %v", err)
+				}
+				if diff := cmp.Diff(&have, &tc.want); diff != "" {
+					t.Errorf("unexpected StringList: %s", diff)
+				}
+			})
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for name, in := range map[string]string{
+			"string":          `"foo"`,
+			"empty object":    `[{}]`,
+			"too many fields": `[{"foo":["a"],"bar":["b"]}]`,
+			"invalid glob":    `[{"[":["a"]}]`,
+			"scalar value":    `[{"*":"a"}]`,
+			"non-string item": `[{"*":[true]}]`,
+		} {
+			t.Run(name, func(t *testing.T) {
+				var have StringList
+				if err := json.Unmarshal([]byte(in), &have); err == nil {
+					t.Error("unexpected nil error")
+				}
+			})
+		}
+	})
+}
+
+func TestStringListYAML(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		for name, tc := range map[string]struct {
+			in   string
+			want StringList
+		}{
+			"single list": {
+				in: `[a, b]`,
+				want: StringList{
+					rules: rules{
+						{pattern: allPattern, value: []string{"a", "b"}},
+					},
+				},
+			},
+			"multiple rule list": {
+				in: "- \"*\": [a]\n- github.com/sourcegraph/*: [b, c]",
+				want: StringList{
+					rules: rules{
+						{pattern: allPattern, value: []string{"a"}},
+						{pattern: "github.com/sourcegraph/*", value: []string{"b", "c"}},
+					},
+				},
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				var have StringList
+				if err := yaml.Unmarshal([]byte(tc.in), &have); err != nil {
+					t.Errorf("unexpected non-nil error: %v", err)
+				}
+				if diff := cmp.Diff(&have, &tc.want); diff != "" {
+					t.Errorf("unexpected StringList: %s", diff)
+				}
+			})
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for name, in := range map[string]string{
+			"string":       `foo`,
+			"empty object": `- {}`,
+			"scalar value": `- "*": a`,
+		} {
+			t.Run(name, func(t *testing.T) {
+				var have StringList
+				if err := yaml.Unmarshal([]byte(in), &have); err == nil {
+					t.Error("unexpected nil error")
+				}
+			})
+		}
+	})
+}
+
+// initStringList ensures all rules are compiled.
+func initStringList(sl *StringList) (err error) {
+	for i, rule := range sl.rules {
+		if rule.compiled == nil {
+			sl.rules[i], err = newRule(rule.pattern, rule.value)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
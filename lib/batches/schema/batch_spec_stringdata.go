@@ -129,6 +129,27 @@ const BatchSpecJSON = `{
               }
             }
           },
+          "artifacts": {
+            "type": "array",
+            "description": "Files produced by this step that should be kept after the step ran, such as test reports or logs. The files are uploaded after the step finished and can be downloaded from the workspace of the step. Only supported when executing batch specs on Sourcegraph.",
+            "items": {
+              "title": "StepArtifact",
+              "type": "object",
+              "additionalProperties": false,
+              "required": ["path"],
+              "properties": {
+                "path": {
+                  "type": "string",
+                  "description": "The path of the file, relative to the root directory of the repository checkout.",
+                  "examples": ["reports/junit.xml"]
+                },
+                "name": {
+                  "type": "string",
+                  "description": "The name of the artifact. Defaults to the path of the file."
+                }
+              }
+            }
+          },
           "env": {
             "description": "Environment variables to set in the step environment.",
             "oneOf": [
@@ -328,6 +349,94 @@ const BatchSpecJSON = `{
               }
             }
           ]
+        },
+        "rebase": {
+          "type": "boolean",
+          "description": "Whether to keep published changesets up to date with their base branch. If true, the diff of a changeset that the code host reports as behind its base branch or as having merge conflicts is re-applied on the latest commit of the base branch and force-pushed."
+        },
+        "reviewers": {
+          "description": "The usernames of the users to request reviews from on published changesets. Reviews requested on the code host are never withdrawn. Not supported on Bitbucket Cloud.",
+          "anyOf": [
+            {
+              "type": "array",
+              "items": {
+                "type": "string"
+              },
+              "description": "A single list of reviewers for all changesets."
+            },
+            {
+              "type": "array",
+              "description": "A list of glob patterns to match repository names. In the event multiple patterns match, the last matching pattern in the list will be used.",
+              "items": {
+                "type": "object",
+                "description": "An object with one field: the key is the glob pattern to match against repository names; the value will be used as the reviewers for matching repositories.",
+                "additionalProperties": {
+                  "type": "array",
+                  "items": {
+                    "type": "string"
+                  }
+                },
+                "minProperties": 1,
+                "maxProperties": 1
+              }
+            }
+          ]
+        },
+        "assignees": {
+          "description": "The usernames of the users to assign to published changesets. If not empty, the assignees on the code host are replaced by these users. Not supported on Bitbucket Server and Bitbucket Cloud.",
+          "anyOf": [
+            {
+              "type": "array",
+              "items": {
+                "type": "string"
+              },
+              "description": "A single list of assignees for all changesets."
+            },
+            {
+              "type": "array",
+              "description": "A list of glob patterns to match repository names. In the event multiple patterns match, the last matching pattern in the list will be used.",
+              "items": {
+                "type": "object",
+                "description": "An object with one field: the key is the glob pattern to match against repository names; the value will be used as the assignees for matching repositories.",
+                "additionalProperties": {
+                  "type": "array",
+                  "items": {
+                    "type": "string"
+                  }
+                },
+                "minProperties": 1,
+                "maxProperties": 1
+              }
+            }
+          ]
+        },
+        "labels": {
+          "description": "The labels to set on published changesets. If not empty, the labels on the code host are replaced by these labels. Not supported on Bitbucket Server and Bitbucket Cloud.",
+          "anyOf": [
+            {
+              "type": "array",
+              "items": {
+                "type": "string"
+              },
+              "description": "A single list of labels for all changesets."
+            },
+            {
+              "type": "array",
+              "description": "A list of glob patterns to match repository names. In the event multiple patterns match, the last matching pattern in the list will be used.",
+              "items": {
+                "type": "object",
+                "description": "An object with one field: the key is the glob pattern to match against repository names; the value will be used as the labels for matching repositories.",
+                "additionalProperties": {
+                  "type": "array",
+                  "items": {
+                    "type": "string"
+                  }
+                },
+                "minProperties": 1,
+                "maxProperties": 1
+              }
+            }
+          ]
         }
       }
     }
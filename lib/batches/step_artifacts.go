@@ -0,0 +1,85 @@
+package batches
+
+import (
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+// StepArtifactsDir is the directory, relative to the directory `src batch exec`
+// runs in, that the artifacts of executed steps are copied to. When the
+// executor finished running src-cli, it uploads every file in this directory
+// to StepArtifactsPath and removes it.
+//
+// The artifact with a given path of a step is copied to
+// StepArtifactsDir/<Workspace.ID>/<one-based step number>/<path>.
+const StepArtifactsDir = ".src-batch-artifacts"
+
+// StepArtifactFile returns the path of the file that the artifact with the
+// given path of the given one-based step of the workspace with the given ID is
+// copied to, relative to StepArtifactsDir.
+func StepArtifactFile(workspaceID string, step int, artifactPath string) string {
+	return path.Join(workspaceID, strconv.Itoa(step), StepArtifact{Path: artifactPath}.RelativePath())
+}
+
+// ParseStepArtifactFile is the inverse of StepArtifactFile.
+func ParseStepArtifactFile(file string) (workspaceID string, step int, artifactPath string, err error) {
+	parts := strings.SplitN(file, "/", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[2] == "" {
+		return "", 0, "", errors.Errorf("invalid step artifact file %q", file)
+	}
+	step, err = strconv.Atoi(parts[1])
+	if err != nil || step < 1 {
+		return "", 0, "", errors.Errorf("invalid step in step artifact file %q", file)
+	}
+	return parts[0], step, parts[2], nil
+}
+
+// CopyStepArtifacts copies the artifacts declared by the given one-based step
+// of the workspace with the given ID from the directory the step ran in to
+// artifactsDir, which is StepArtifactsDir when running on an executor.
+// Declared artifacts that the step didn't produce are skipped.
+//
+// CopyStepArtifacts is called by src-cli after each step it executes, so
+// artifacts are only uploaded by executors that run a src-cli release which
+// does so. Until then, StepArtifactsDir stays empty and no artifacts are shown.
+func CopyStepArtifacts(workspaceDir, artifactsDir, workspaceID string, step int, artifacts []StepArtifact) error {
+	for _, artifact := range artifacts {
+		src := filepath.Join(workspaceDir, filepath.FromSlash(artifact.RelativePath()))
+		dst := filepath.Join(artifactsDir, filepath.FromSlash(StepArtifactFile(workspaceID, step, artifact.Path)))
+
+		if err := copyStepArtifact(src, dst); err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return errors.Wrapf(err, "copying artifact %q of step %d", artifact.Path, step)
+		}
+	}
+	return nil
+}
+
+func copyStepArtifact(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	if err := os.MkdirAll(filepath.Dir(dst), os.ModePerm); err != nil {
+		return err
+	}
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,63 @@
+package batches
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStepArtifactFile(t *testing.T) {
+	for artifactPath, want := range map[string]string{
+		"report.json":          "d29ya3NwYWNl/2/report.json",
+		"./reports/test.json":  "d29ya3NwYWNl/2/reports/test.json",
+		"../../etc/passwd":     "d29ya3NwYWNl/2/etc/passwd",
+		"/reports/../out.json": "d29ya3NwYWNl/2/out.json",
+	} {
+		file := StepArtifactFile("d29ya3NwYWNl", 2, artifactPath)
+		if file != want {
+			t.Errorf("unexpected file for %q: have=%q want=%q", artifactPath, file, want)
+		}
+
+		workspaceID, step, parsedPath, err := ParseStepArtifactFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if workspaceID != "d29ya3NwYWNl" || step != 2 || parsedPath != (StepArtifact{Path: artifactPath}).RelativePath() {
+			t.Errorf("unexpected parsed file %q: %q %d %q", file, workspaceID, step, parsedPath)
+		}
+	}
+
+	for _, file := range []string{"d29ya3NwYWNl/2", "d29ya3NwYWNl/0/report.json", "d29ya3NwYWNl/two/report.json"} {
+		if _, _, _, err := ParseStepArtifactFile(file); err == nil {
+			t.Errorf("expected error for %q", file)
+		}
+	}
+}
+
+func TestCopyStepArtifacts(t *testing.T) {
+	workspaceDir := t.TempDir()
+	artifactsDir := t.TempDir()
+
+	if err := os.MkdirAll(filepath.Join(workspaceDir, "reports"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(workspaceDir, "reports", "test.json"), []byte("{}"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	artifacts := []StepArtifact{{Path: "reports/test.json"}, {Path: "missing.txt"}}
+	if err := CopyStepArtifacts(workspaceDir, artifactsDir, "d29ya3NwYWNl", 1, artifacts); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(artifactsDir, "d29ya3NwYWNl", "1", "reports", "test.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "{}" {
+		t.Errorf("unexpected content: %q", content)
+	}
+	if _, err := os.Stat(filepath.Join(artifactsDir, "d29ya3NwYWNl", "1", "missing.txt")); !os.IsNotExist(err) {
+		t.Errorf("expected missing artifact to be skipped, got %v", err)
+	}
+}
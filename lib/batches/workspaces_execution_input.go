@@ -5,13 +5,40 @@ type WorkspacesExecutionInput struct {
 	Workspaces []*Workspace `json:"workspaces"`
 }
 
+// StepArtifactsPath is the path of the Sourcegraph API endpoint that step
+// artifacts are uploaded to and downloaded from. Artifacts are uploaded with a
+// POST request whose body is the content of the file, and the Workspace.ID,
+// the one-based number of the step, and the declared path of the artifact in
+// the "workspace", "step" and "path" query parameters.
+const StepArtifactsPath = "/.api/batches/artifacts"
+
 type Workspace struct {
+	// ID is the GraphQL ID of the batch spec workspace, if the workspace is
+	// executed on Sourcegraph.
+	ID string `json:"id,omitempty"`
+
 	Repository         WorkspaceRepo   `json:"repository"`
 	Branch             WorkspaceBranch `json:"branch"`
 	Path               string          `json:"path"`
 	OnlyFetchWorkspace bool            `json:"onlyFetchWorkspace"`
 	Steps              []Step          `json:"steps"`
 	SearchResultPaths  []string        `json:"searchResultPaths"`
+
+	// CachedStepResult, if set, is the result of a previous execution of the
+	// leading steps of the workspace. In that case, only the steps after
+	// CachedStepResult.StepIndex need to be executed.
+	CachedStepResult *AfterStepResult `json:"cachedStepResult,omitempty"`
+}
+
+// AfterStepResult is the result of executing the steps of a workspace up to
+// and including the step at StepIndex.
+type AfterStepResult struct {
+	// StepIndex is the zero-based index of the last step that was executed.
+	StepIndex int `json:"stepIndex"`
+	// Diff is the diff of all changes made to the workspace by the steps.
+	Diff string `json:"diff"`
+	// Outputs are the outputs set by the steps.
+	Outputs map[string]interface{} `json:"outputs"`
 }
 
 type WorkspaceRepo struct {
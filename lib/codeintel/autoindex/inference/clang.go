@@ -0,0 +1,108 @@
+package inference
+
+import (
+	"path/filepath"
+	"regexp"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func ClangPatterns() []*regexp.Regexp {
+	return []*regexp.Regexp{
+		pathPattern(rawPattern("compile_commands.json")),
+		pathPattern(rawPattern("CMakeLists.txt")),
+	}
+}
+
+func CanIndexClangRepo(gitclient GitClient, paths []string) bool {
+	for _, path := range paths {
+		if isCompilationDatabasePath(path) || isCMakeListsPath(path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+const lsifClangImage = "sourcegraph/lsif-clang:autoindex"
+
+// cmakeBuildDir is the directory, relative to the project root, in which CMake generates the
+// compilation database for projects that do not check one in.
+const cmakeBuildDir = "lsif-build"
+
+func InferClangIndexJobs(gitclient GitClient, paths []string) (indexes []config.IndexJob) {
+	// Prefer checked-in compilation databases, which reflect the project's real build
+	var databaseRoots []string
+	for _, path := range paths {
+		if !isCompilationDatabasePath(path) {
+			continue
+		}
+
+		root := dirWithoutDot(path)
+		databaseRoots = append(databaseRoots, root)
+
+		indexes = append(indexes, config.IndexJob{
+			Steps:       nil,
+			Root:        root,
+			Indexer:     lsifClangImage,
+			IndexerArgs: []string{"lsif-clang", "compile_commands.json"},
+			Outfile:     "",
+		})
+	}
+
+	for _, path := range paths {
+		if !isCMakeListsPath(path) {
+			continue
+		}
+
+		root := dirWithoutDot(path)
+		if withinAnyDir(root, databaseRoots) || hasAncestorCMakeLists(path, paths) {
+			// Already indexed, or a subdirectory added by a parent project
+			continue
+		}
+
+		indexes = append(indexes, config.IndexJob{
+			Steps: []config.DockerStep{
+				{
+					Root:     root,
+					Image:    lsifClangImage,
+					Commands: []string{"cmake -B " + cmakeBuildDir + " -DCMAKE_EXPORT_COMPILE_COMMANDS=ON"},
+				},
+			},
+			Root:        root,
+			Indexer:     lsifClangImage,
+			IndexerArgs: []string{"lsif-clang", filepath.Join(cmakeBuildDir, "compile_commands.json")},
+			Outfile:     "",
+		})
+	}
+
+	return indexes
+}
+
+// hasAncestorCMakeLists returns true if a CMakeLists.txt file exists in a proper ancestor
+// directory of the given CMakeLists.txt path.
+func hasAncestorCMakeLists(path string, paths []string) bool {
+	dir := dirWithoutDot(path)
+	if dir == "" {
+		// The repository root has no proper ancestors
+		return false
+	}
+
+	for _, ancestor := range ancestorDirs(dir) {
+		if contains(paths, filepath.Join(ancestor, "CMakeLists.txt")) {
+			return true
+		}
+	}
+
+	return false
+}
+
+var clangSegmentBlockList = append([]string{"third_party", "third-party", "vendor", "build"}, segmentBlockList...)
+
+func isCompilationDatabasePath(path string) bool {
+	return filepath.Base(path) == "compile_commands.json" && containsNoSegments(path, clangSegmentBlockList...)
+}
+
+func isCMakeListsPath(path string) bool {
+	return filepath.Base(path) == "CMakeLists.txt" && containsNoSegments(path, clangSegmentBlockList...)
+}
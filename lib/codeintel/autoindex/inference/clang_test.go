@@ -0,0 +1,101 @@
+package inference
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestClangPatterns(t *testing.T) {
+	testCases := []struct {
+		path     string
+		expected bool
+	}{
+		{"compile_commands.json", true},
+		{"subdir/compile_commands.json", true},
+		{"CMakeLists.txt", true},
+		{"subdir/CMakeLists.txt", true},
+		{"CMakeLists.txt/subdir", false},
+		{"foo.cpp", false},
+	}
+
+	for _, testCase := range testCases {
+		match := false
+		for _, pattern := range ClangPatterns() {
+			if pattern.MatchString(testCase.path) {
+				match = true
+				break
+			}
+		}
+
+		if match {
+			if !testCase.expected {
+				t.Error(fmt.Sprintf("did not expect match: %s", testCase.path))
+			}
+
+		} else if testCase.expected {
+			t.Error(fmt.Sprintf("expected match: %s", testCase.path))
+		}
+	}
+}
+
+func TestCanIndexClangRepo(t *testing.T) {
+	testCases := []struct {
+		paths    []string
+		expected bool
+	}{
+		{paths: []string{"compile_commands.json"}, expected: true},
+		{paths: []string{"a/CMakeLists.txt"}, expected: true},
+		{paths: []string{"third_party/foo/CMakeLists.txt"}, expected: false},
+		{paths: []string{"build/compile_commands.json"}, expected: false},
+	}
+
+	for _, testCase := range testCases {
+		name := strings.Join(testCase.paths, ", ")
+
+		t.Run(name, func(t *testing.T) {
+			if value := CanIndexClangRepo(NewMockGitClient(), testCase.paths); value != testCase.expected {
+				t.Errorf("unexpected result from CanIndex. want=%v have=%v", testCase.expected, value)
+			}
+		})
+	}
+}
+
+func TestInferClangIndexJobs(t *testing.T) {
+	paths := []string{
+		"a/compile_commands.json",
+		"a/CMakeLists.txt",
+		"b/CMakeLists.txt",
+		"b/src/CMakeLists.txt",
+	}
+
+	expectedIndexJobs := []config.IndexJob{
+		{
+			Steps:       nil,
+			Root:        "a",
+			Indexer:     lsifClangImage,
+			IndexerArgs: []string{"lsif-clang", "compile_commands.json"},
+			Outfile:     "",
+		},
+		{
+			Steps: []config.DockerStep{
+				{
+					Root:     "b",
+					Image:    lsifClangImage,
+					Commands: []string{"cmake -B lsif-build -DCMAKE_EXPORT_COMPILE_COMMANDS=ON"},
+				},
+			},
+			Root:        "b",
+			Indexer:     lsifClangImage,
+			IndexerArgs: []string{"lsif-clang", "lsif-build/compile_commands.json"},
+			Outfile:     "",
+		},
+	}
+	if diff := cmp.Diff(expectedIndexJobs, InferClangIndexJobs(NewMockGitClient(), paths)); diff != "" {
+		t.Errorf("unexpected index jobs (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,81 @@
+package inference
+
+import (
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func PythonPatterns() []*regexp.Regexp {
+	return []*regexp.Regexp{
+		pathPattern(rawPattern("setup.py")),
+		pathPattern(rawPattern("pyproject.toml")),
+		pathPattern(rawPattern("requirements.txt")),
+	}
+}
+
+func CanIndexPythonRepo(gitclient GitClient, paths []string) bool {
+	for _, path := range paths {
+		if isPythonProjectPath(path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+const lsifPyImage = "sourcegraph/lsif-py:autoindex"
+
+func InferPythonIndexJobs(gitclient GitClient, paths []string) (indexes []config.IndexJob) {
+	for _, root := range pythonProjectRoots(paths) {
+		var commands []string
+		if contains(paths, filepath.Join(root, "requirements.txt")) {
+			commands = append(commands, "pip install -r requirements.txt")
+		}
+		commands = append(commands, "pip install .")
+
+		indexes = append(indexes, config.IndexJob{
+			Steps: []config.DockerStep{
+				{
+					Root:     root,
+					Image:    lsifPyImage,
+					Commands: commands,
+				},
+			},
+			Root:        root,
+			Indexer:     lsifPyImage,
+			IndexerArgs: []string{"lsif-py", "."},
+			Outfile:     "",
+		})
+	}
+
+	return indexes
+}
+
+// pythonProjectRoots returns the sorted, distinct directories containing a setup.py or
+// pyproject.toml file. A project that declares both files is indexed once.
+func pythonProjectRoots(paths []string) []string {
+	roots := map[string]struct{}{}
+	for _, path := range paths {
+		if isPythonProjectPath(path) {
+			roots[dirWithoutDot(path)] = struct{}{}
+		}
+	}
+
+	sorted := make([]string, 0, len(roots))
+	for root := range roots {
+		sorted = append(sorted, root)
+	}
+	sort.Strings(sorted)
+
+	return sorted
+}
+
+var pythonSegmentBlockList = append([]string{"venv", ".venv", "site-packages"}, segmentBlockList...)
+
+func isPythonProjectPath(path string) bool {
+	base := filepath.Base(path)
+	return (base == "setup.py" || base == "pyproject.toml") && containsNoSegments(path, pythonSegmentBlockList...)
+}
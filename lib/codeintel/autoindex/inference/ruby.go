@@ -0,0 +1,65 @@
+package inference
+
+import (
+	"path/filepath"
+	"regexp"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func RubyPatterns() []*regexp.Regexp {
+	return []*regexp.Regexp{
+		pathPattern(rawPattern("Gemfile")),
+		pathPattern(rawPattern("Gemfile.lock")),
+	}
+}
+
+func CanIndexRubyRepo(gitclient GitClient, paths []string) bool {
+	for _, path := range paths {
+		if isGemfilePath(path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+const lsifRubyImage = "sourcegraph/lsif-ruby:autoindex"
+
+func InferRubyIndexJobs(gitclient GitClient, paths []string) (indexes []config.IndexJob) {
+	for _, path := range paths {
+		if !isGemfilePath(path) {
+			continue
+		}
+
+		root := dirWithoutDot(path)
+
+		commands := []string{"bundle install"}
+		if contains(paths, filepath.Join(root, "Gemfile.lock")) {
+			// Respect the locked dependency versions
+			commands = []string{"bundle install --frozen"}
+		}
+
+		indexes = append(indexes, config.IndexJob{
+			Steps: []config.DockerStep{
+				{
+					Root:     root,
+					Image:    lsifRubyImage,
+					Commands: commands,
+				},
+			},
+			Root:        root,
+			Indexer:     lsifRubyImage,
+			IndexerArgs: []string{"lsif-ruby", "."},
+			Outfile:     "",
+		})
+	}
+
+	return indexes
+}
+
+var rubySegmentBlockList = append([]string{"vendor"}, segmentBlockList...)
+
+func isGemfilePath(path string) bool {
+	return filepath.Base(path) == "Gemfile" && containsNoSegments(path, rubySegmentBlockList...)
+}
@@ -0,0 +1,105 @@
+package inference
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestRubyPatterns(t *testing.T) {
+	testCases := []struct {
+		path     string
+		expected bool
+	}{
+		{"Gemfile", true},
+		{"subdir/Gemfile", true},
+		{"subdir/Gemfile.lock", true},
+		{"Gemfile/subdir", false},
+		{"foo.gemspec", false},
+	}
+
+	for _, testCase := range testCases {
+		match := false
+		for _, pattern := range RubyPatterns() {
+			if pattern.MatchString(testCase.path) {
+				match = true
+				break
+			}
+		}
+
+		if match {
+			if !testCase.expected {
+				t.Error(fmt.Sprintf("did not expect match: %s", testCase.path))
+			}
+
+		} else if testCase.expected {
+			t.Error(fmt.Sprintf("expected match: %s", testCase.path))
+		}
+	}
+}
+
+func TestCanIndexRubyRepo(t *testing.T) {
+	testCases := []struct {
+		paths    []string
+		expected bool
+	}{
+		{paths: []string{"Gemfile"}, expected: true},
+		{paths: []string{"a/Gemfile"}, expected: true},
+		{paths: []string{"Gemfile.lock"}, expected: false},
+		{paths: []string{"vendor/bundle/foo/Gemfile"}, expected: false},
+	}
+
+	for _, testCase := range testCases {
+		name := strings.Join(testCase.paths, ", ")
+
+		t.Run(name, func(t *testing.T) {
+			if value := CanIndexRubyRepo(NewMockGitClient(), testCase.paths); value != testCase.expected {
+				t.Errorf("unexpected result from CanIndex. want=%v have=%v", testCase.expected, value)
+			}
+		})
+	}
+}
+
+func TestInferRubyIndexJobs(t *testing.T) {
+	paths := []string{
+		"Gemfile",
+		"Gemfile.lock",
+		"b/Gemfile",
+	}
+
+	expectedIndexJobs := []config.IndexJob{
+		{
+			Steps: []config.DockerStep{
+				{
+					Root:     "",
+					Image:    lsifRubyImage,
+					Commands: []string{"bundle install --frozen"},
+				},
+			},
+			Root:        "",
+			Indexer:     lsifRubyImage,
+			IndexerArgs: []string{"lsif-ruby", "."},
+			Outfile:     "",
+		},
+		{
+			Steps: []config.DockerStep{
+				{
+					Root:     "b",
+					Image:    lsifRubyImage,
+					Commands: []string{"bundle install"},
+				},
+			},
+			Root:        "b",
+			Indexer:     lsifRubyImage,
+			IndexerArgs: []string{"lsif-ruby", "."},
+			Outfile:     "",
+		},
+	}
+	if diff := cmp.Diff(expectedIndexJobs, InferRubyIndexJobs(NewMockGitClient(), paths)); diff != "" {
+		t.Errorf("unexpected index jobs (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,106 @@
+package inference
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func RustPatterns() []*regexp.Regexp {
+	return []*regexp.Regexp{
+		pathPattern(rawPattern("Cargo.toml")),
+	}
+}
+
+func CanIndexRustRepo(gitclient GitClient, paths []string) bool {
+	for _, path := range paths {
+		if isCargoManifestPath(path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+const lsifRustImage = "sourcegraph/lsif-rust:autoindex"
+
+func InferRustIndexJobs(gitclient GitClient, paths []string) (indexes []config.IndexJob) {
+	var manifests []string
+	for _, path := range paths {
+		if isCargoManifestPath(path) {
+			manifests = append(manifests, path)
+		}
+	}
+
+	// Members of a workspace are indexed together from the workspace root
+	var workspaceRoots []string
+	for _, path := range manifests {
+		if isCargoWorkspaceManifest(gitclient, path) {
+			workspaceRoots = append(workspaceRoots, dirWithoutDot(path))
+		}
+	}
+
+	for _, path := range manifests {
+		root := dirWithoutDot(path)
+		if !contains(workspaceRoots, root) && withinAnyDir(root, workspaceRoots) {
+			continue
+		}
+
+		indexes = append(indexes, config.IndexJob{
+			Steps: []config.DockerStep{
+				{
+					Root:     root,
+					Image:    lsifRustImage,
+					Commands: []string{"cargo fetch"},
+				},
+			},
+			Root:        root,
+			Indexer:     lsifRustImage,
+			IndexerArgs: []string{"lsif-rust", "index"},
+			Outfile:     "dump.lsif",
+		})
+	}
+
+	return indexes
+}
+
+// isCargoWorkspaceManifest returns true if the Cargo.toml file at the given path declares a
+// workspace table. Manifests that cannot be read are assumed to describe a single crate.
+func isCargoWorkspaceManifest(gitclient GitClient, path string) bool {
+	contents, err := gitclient.RawContents(context.TODO(), path)
+	if err != nil {
+		return false
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	for scanner.Scan() {
+		if strings.TrimSpace(scanner.Text()) == "[workspace]" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// withinAnyDir returns true if the given directory is equal to or nested within any of the given
+// candidate directories. The empty string denotes the repository root.
+func withinAnyDir(dir string, candidates []string) bool {
+	for _, ancestor := range ancestorDirs(filepath.Join(dir, "_")) {
+		if contains(candidates, ancestor) {
+			return true
+		}
+	}
+
+	return false
+}
+
+var rustSegmentBlockList = append([]string{"target", "vendor"}, segmentBlockList...)
+
+func isCargoManifestPath(path string) bool {
+	return filepath.Base(path) == "Cargo.toml" && containsNoSegments(path, rustSegmentBlockList...)
+}
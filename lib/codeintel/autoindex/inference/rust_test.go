@@ -0,0 +1,136 @@
+package inference
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestRustPatterns(t *testing.T) {
+	testCases := []struct {
+		path     string
+		expected bool
+	}{
+		{"Cargo.toml", true},
+		{"subdir/Cargo.toml", true},
+		{"Cargo.toml/subdir", false},
+		{"Cargo.lock", false},
+	}
+
+	for _, testCase := range testCases {
+		match := false
+		for _, pattern := range RustPatterns() {
+			if pattern.MatchString(testCase.path) {
+				match = true
+				break
+			}
+		}
+
+		if match {
+			if !testCase.expected {
+				t.Error(fmt.Sprintf("did not expect match: %s", testCase.path))
+			}
+
+		} else if testCase.expected {
+			t.Error(fmt.Sprintf("expected match: %s", testCase.path))
+		}
+	}
+}
+
+func TestCanIndexRustRepo(t *testing.T) {
+	testCases := []struct {
+		paths    []string
+		expected bool
+	}{
+		{paths: []string{"Cargo.toml"}, expected: true},
+		{paths: []string{"a/Cargo.toml"}, expected: true},
+		{paths: []string{"target/debug/Cargo.toml"}, expected: false},
+		{paths: []string{"vendor/foo/Cargo.toml"}, expected: false},
+	}
+
+	for _, testCase := range testCases {
+		name := strings.Join(testCase.paths, ", ")
+
+		t.Run(name, func(t *testing.T) {
+			if value := CanIndexRustRepo(NewMockGitClient(), testCase.paths); value != testCase.expected {
+				t.Errorf("unexpected result from CanIndex. want=%v have=%v", testCase.expected, value)
+			}
+		})
+	}
+}
+
+func TestInferRustIndexJobsWorkspace(t *testing.T) {
+	paths := []string{
+		"Cargo.toml",
+		"crates/a/Cargo.toml",
+		"crates/b/Cargo.toml",
+		"tools/c/Cargo.toml",
+		"tools/c/d/Cargo.toml",
+	}
+
+	gitclient := NewMockGitClient()
+	gitclient.RawContentsFunc.SetDefaultHook(func(ctx context.Context, file string) ([]byte, error) {
+		switch file {
+		case "Cargo.toml":
+			return []byte("[workspace]\nmembers = [\"crates/*\"]\n"), nil
+		case "tools/c/Cargo.toml":
+			return []byte("[package]\nname = \"c\"\n\n[workspace]\n"), nil
+		}
+		return []byte("[package]\n"), nil
+	})
+
+	expectedIndexJobs := []config.IndexJob{
+		{
+			Steps: []config.DockerStep{
+				{
+					Root:     "",
+					Image:    lsifRustImage,
+					Commands: []string{"cargo fetch"},
+				},
+			},
+			Root:        "",
+			Indexer:     lsifRustImage,
+			IndexerArgs: []string{"lsif-rust", "index"},
+			Outfile:     "dump.lsif",
+		},
+		{
+			Steps: []config.DockerStep{
+				{
+					Root:     "tools/c",
+					Image:    lsifRustImage,
+					Commands: []string{"cargo fetch"},
+				},
+			},
+			Root:        "tools/c",
+			Indexer:     lsifRustImage,
+			IndexerArgs: []string{"lsif-rust", "index"},
+			Outfile:     "dump.lsif",
+		},
+	}
+	if diff := cmp.Diff(expectedIndexJobs, InferRustIndexJobs(gitclient, paths)); diff != "" {
+		t.Errorf("unexpected index jobs (-want +got):\n%s", diff)
+	}
+}
+
+func TestInferRustIndexJobsCrates(t *testing.T) {
+	paths := []string{
+		"a/Cargo.toml",
+		"b/Cargo.toml",
+	}
+
+	gitclient := NewMockGitClient()
+	gitclient.RawContentsFunc.SetDefaultReturn([]byte("[package]\n"), nil)
+
+	var roots []string
+	for _, indexJob := range InferRustIndexJobs(gitclient, paths) {
+		roots = append(roots, indexJob.Root)
+	}
+	if diff := cmp.Diff([]string{"a", "b"}, roots); diff != "" {
+		t.Errorf("unexpected index job roots (-want +got):\n%s", diff)
+	}
+}
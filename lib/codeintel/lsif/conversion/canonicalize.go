@@ -45,6 +45,8 @@ func canonicalizeDocuments(state *State) {
 
 			canonicalizeDocumentsInDefinitionReferences(state, state.DefinitionData, documentID, canonicalID)
 			canonicalizeDocumentsInDefinitionReferences(state, state.ReferenceData, documentID, canonicalID)
+			canonicalizeDocumentsInDefinitionReferences(state, state.ImplementationData, documentID, canonicalID)
+			canonicalizeDocumentsInDefinitionReferences(state, state.TypeDefinitionData, documentID, canonicalID)
 
 			// Remove non-canonical document
 			delete(state.DocumentData, documentID)
@@ -152,7 +154,7 @@ func canonicalizeResultSetData(state *State, id int, item ResultSet) ResultSet {
 	return item
 }
 
-// mergeNextResultSetData merges the definition, reference, and hover result identifiers from
+// mergeNextResultSetData merges the definition, reference, hover, implementation, and type definition result identifiers from
 // nextItem into item when not already defined. The moniker identifiers of nextItem are unioned
 // into the moniker identifiers of item.
 func mergeNextResultSetData(state *State, itemID int, item ResultSet, nextID int, nextItem ResultSet) ResultSet {
@@ -168,6 +170,12 @@ func mergeNextResultSetData(state *State, itemID int, item ResultSet, nextID int
 	if item.DocumentationResultID == 0 {
 		item = item.SetDocumentationResultID(nextItem.DocumentationResultID)
 	}
+	if item.ImplementationResultID == 0 {
+		item = item.SetImplementationResultID(nextItem.ImplementationResultID)
+	}
+	if item.TypeDefinitionResultID == 0 {
+		item = item.SetTypeDefinitionResultID(nextItem.TypeDefinitionResultID)
+	}
 
 	state.Monikers.SetUnion(itemID, state.Monikers.Get(nextID))
 	return item
@@ -189,6 +197,12 @@ func mergeNextRangeData(state *State, itemID int, item Range, nextID int, nextIt
 	if item.DocumentationResultID == 0 {
 		item = item.SetDocumentationResultID(nextItem.DocumentationResultID)
 	}
+	if item.ImplementationResultID == 0 {
+		item = item.SetImplementationResultID(nextItem.ImplementationResultID)
+	}
+	if item.TypeDefinitionResultID == 0 {
+		item = item.SetTypeDefinitionResultID(nextItem.TypeDefinitionResultID)
+	}
 
 	state.Monikers.SetUnion(itemID, state.Monikers.Get(nextID))
 	return item
@@ -104,6 +104,8 @@ func TestCorrelate(t *testing.T) {
 			14: datastructures.DefaultIDSetMapWith(map[int]*datastructures.IDSet{2: datastructures.IDSetWith(4, 5)}),
 			15: datastructures.DefaultIDSetMapWith(map[int]*datastructures.IDSet{}),
 		},
+		ImplementationData: map[int]*datastructures.DefaultIDSetMap{},
+		TypeDefinitionData: map[int]*datastructures.DefaultIDSetMap{},
 		HoverData: map[int]string{
 			16: "```go\ntext A\n```",
 			17: "```go\ntext B\n```",
@@ -217,6 +219,8 @@ func TestCorrelateMetaDataRoot(t *testing.T) {
 		ResultSetData:          map[int]ResultSet{},
 		DefinitionData:         map[int]*datastructures.DefaultIDSetMap{},
 		ReferenceData:          map[int]*datastructures.DefaultIDSetMap{},
+		ImplementationData:     map[int]*datastructures.DefaultIDSetMap{},
+		TypeDefinitionData:     map[int]*datastructures.DefaultIDSetMap{},
 		HoverData:              map[int]string{},
 		MonikerData:            map[int]Moniker{},
 		PackageInformationData: map[int]PackageInformation{},
@@ -265,6 +269,8 @@ func TestCorrelateMetaDataRootX(t *testing.T) {
 		ResultSetData:          map[int]ResultSet{},
 		DefinitionData:         map[int]*datastructures.DefaultIDSetMap{},
 		ReferenceData:          map[int]*datastructures.DefaultIDSetMap{},
+		ImplementationData:     map[int]*datastructures.DefaultIDSetMap{},
+		TypeDefinitionData:     map[int]*datastructures.DefaultIDSetMap{},
 		HoverData:              map[int]string{},
 		MonikerData:            map[int]Moniker{},
 		PackageInformationData: map[int]PackageInformation{},
@@ -291,3 +297,74 @@ func TestCorrelateMetaDataRootX(t *testing.T) {
 		t.Errorf("unexpected state (-want +got):\n%s", diff)
 	}
 }
+
+func TestCorrelateImplementations(t *testing.T) {
+	input := `{"id": "01", "type": "vertex", "label": "metaData", "version": "0.4.3", "projectRoot": "file:///test/"}
+{"id": "02", "type": "vertex", "label": "document", "uri": "file:///test/root/foo.go"}
+{"id": "03", "type": "vertex", "label": "range", "start": {"line": 1, "character": 2}, "end": {"line": 1, "character": 5}}
+{"id": "04", "type": "vertex", "label": "range", "start": {"line": 5, "character": 2}, "end": {"line": 5, "character": 5}}
+{"id": "05", "type": "vertex", "label": "range", "start": {"line": 9, "character": 2}, "end": {"line": 9, "character": 5}}
+{"id": "06", "type": "vertex", "label": "resultSet"}
+{"id": "07", "type": "vertex", "label": "implementationResult"}
+{"id": "08", "type": "edge", "label": "contains", "outV": "02", "inVs": ["03", "04", "05"]}
+{"id": "09", "type": "edge", "label": "next", "outV": "03", "inV": "06"}
+{"id": "10", "type": "edge", "label": "textDocument/implementation", "outV": "06", "inV": "07"}
+{"id": "11", "type": "edge", "label": "item", "outV": "07", "inVs": ["04", "05"], "document": "02"}
+`
+
+	state, err := correlateFromReader(context.Background(), bytes.NewReader([]byte(input)), "root")
+	if err != nil {
+		t.Fatalf("unexpected error correlating input: %s", err)
+	}
+
+	expectedImplementationData := map[int]*datastructures.DefaultIDSetMap{
+		7: datastructures.DefaultIDSetMapWith(map[int]*datastructures.IDSet{2: datastructures.IDSetWith(4, 5)}),
+	}
+	if diff := cmp.Diff(expectedImplementationData, state.ImplementationData, datastructures.Comparers...); diff != "" {
+		t.Errorf("unexpected implementation data (-want +got):\n%s", diff)
+	}
+	if resultID := state.ResultSetData[6].ImplementationResultID; resultID != 7 {
+		t.Errorf("unexpected implementation result of result set. want=%d have=%d", 7, resultID)
+	}
+
+	canonicalize(state)
+
+	if resultID := state.RangeData[3].ImplementationResultID; resultID != 7 {
+		t.Errorf("unexpected implementation result of range. want=%d have=%d", 7, resultID)
+	}
+}
+
+func TestCorrelateTypeDefinitions(t *testing.T) {
+	input := `{"id": "01", "type": "vertex", "label": "metaData", "version": "0.4.3", "projectRoot": "file:///test/"}
+{"id": "02", "type": "vertex", "label": "document", "uri": "file:///test/root/foo.go"}
+{"id": "03", "type": "vertex", "label": "range", "start": {"line": 1, "character": 2}, "end": {"line": 1, "character": 5}}
+{"id": "04", "type": "vertex", "label": "range", "start": {"line": 5, "character": 5}, "end": {"line": 5, "character": 8}}
+{"id": "05", "type": "vertex", "label": "resultSet"}
+{"id": "06", "type": "vertex", "label": "typeDefinitionResult"}
+{"id": "07", "type": "edge", "label": "contains", "outV": "02", "inVs": ["03", "04"]}
+{"id": "08", "type": "edge", "label": "next", "outV": "03", "inV": "05"}
+{"id": "09", "type": "edge", "label": "textDocument/typeDefinition", "outV": "05", "inV": "06"}
+{"id": "10", "type": "edge", "label": "item", "outV": "06", "inVs": ["04"], "document": "02"}
+`
+
+	state, err := correlateFromReader(context.Background(), bytes.NewReader([]byte(input)), "root")
+	if err != nil {
+		t.Fatalf("unexpected error correlating input: %s", err)
+	}
+
+	expectedTypeDefinitionData := map[int]*datastructures.DefaultIDSetMap{
+		6: datastructures.DefaultIDSetMapWith(map[int]*datastructures.IDSet{2: datastructures.IDSetWith(4)}),
+	}
+	if diff := cmp.Diff(expectedTypeDefinitionData, state.TypeDefinitionData, datastructures.Comparers...); diff != "" {
+		t.Errorf("unexpected type definition data (-want +got):\n%s", diff)
+	}
+	if resultID := state.ResultSetData[5].TypeDefinitionResultID; resultID != 6 {
+		t.Errorf("unexpected type definition result of result set. want=%d have=%d", 6, resultID)
+	}
+
+	canonicalize(state)
+
+	if resultID := state.RangeData[3].TypeDefinitionResultID; resultID != 6 {
+		t.Errorf("unexpected type definition result of range. want=%d have=%d", 6, resultID)
+	}
+}
@@ -0,0 +1,705 @@
+package conversion
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/bloomfilter"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/protocol/reader"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/pathexistence"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/scip"
+)
+
+// CorrelateSCIP reads a SCIP index from the given reader and converts it into the format written
+// to the code intelligence database. SCIP indexes are document-oriented, so each document is
+// converted into document data as soon as it is read. Across documents, only the occurrences and
+// relationships of each symbol are retained, from which the definition, reference, implementation
+// and type definition results are linked once the entire index has been read.
+//
+// If getChildren == nil, no pruning of irrelevant data is performed.
+func CorrelateSCIP(ctx context.Context, r io.Reader, root string, getChildren pathexistence.GetChildrenFunc) (*precise.GroupedBundleDataChans, error) {
+	converter, err := convertSCIPFromReader(ctx, r, root)
+	if err != nil {
+		return nil, err
+	}
+
+	if getChildren != nil {
+		// Remove documents we don't need to store
+		if err := converter.prune(ctx, root, getChildren); err != nil {
+			return nil, err
+		}
+	}
+
+	converter.link()
+	return converter.groupedBundleData(ctx)
+}
+
+// convertSCIPFromReader reads the given SCIP index and converts each of its documents. The
+// converted data is neither pruned nor linked.
+func convertSCIPFromReader(ctx context.Context, r io.Reader, root string) (*scipConverter, error) {
+	converter := newSCIPConverter(root)
+
+	hasMetadata := false
+	visitor := scip.Visitor{
+		Metadata: func(metadata scip.Metadata) error {
+			hasMetadata = true
+			return nil
+		},
+		Document: func(document *scip.Document) error {
+			if !hasMetadata {
+				return ErrMissingMetaData
+			}
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			return converter.convertDocument(document)
+		},
+		ExternalSymbol: func(symbol *scip.SymbolInformation) error {
+			converter.convertSymbolInformation(symbol)
+			return nil
+		},
+	}
+
+	if err := scip.ReadIndex(r, visitor); err != nil {
+		return nil, errors.Wrap(err, "dump malformed")
+	}
+	if !hasMetadata {
+		return nil, ErrMissingMetaData
+	}
+
+	return converter, nil
+}
+
+// scipConverter converts the documents of a SCIP index into document data, and tracks the
+// occurrences of each symbol to link results across documents.
+type scipConverter struct {
+	root      string
+	id        int
+	documents []*scipDocument
+	symbols   map[string]*scipSymbol // keyed by symbol; local symbols are qualified by document
+	ordered   []*scipSymbol          // symbols in the order they were first seen
+	packages  map[string]int         // package key -> package information id
+
+	packageInformation map[int]precise.PackageInformationData
+}
+
+// scipDocument is a converted document along with the ranges of its symbol occurrences, whose
+// result identifiers, hover text and monikers are filled in once the index has been linked.
+type scipDocument struct {
+	id          int
+	path        string
+	pruned      bool
+	data        precise.DocumentData
+	occurrences []scipOccurrence
+}
+
+// stored returns true if the document is written to the code intelligence database.
+func (d *scipDocument) stored() bool {
+	return !d.pruned && !strings.HasPrefix(d.path, "..")
+}
+
+// scipOccurrence pairs a range of a document with the symbol occurring there.
+type scipOccurrence struct {
+	rangeID int
+	symbol  *scipSymbol
+}
+
+// scipLocation is a range of a document along with its position, so that results can be
+// assembled without looking into the document data.
+type scipLocation struct {
+	document       *scipDocument
+	rangeID        int
+	startLine      int
+	startCharacter int
+	endLine        int
+	endCharacter   int
+}
+
+// scipSymbol tracks the occurrences and relationships of a single symbol across all documents.
+type scipSymbol struct {
+	name            string
+	local           bool
+	hover           string
+	definitions     []scipLocation
+	references      []scipLocation
+	implementedBy   []*scipSymbol
+	typeDefinitions []*scipSymbol
+	referencedBy    []*scipSymbol
+
+	// The following fields are set when the index is linked.
+	definitionResultID     int
+	referenceResultID      int
+	hoverResultID          int
+	implementationResultID int
+	typeDefinitionResultID int
+	monikerID              int
+	moniker                precise.MonikerData
+	packageInformationID   int
+}
+
+func newSCIPConverter(root string) *scipConverter {
+	if root != "" && !strings.HasSuffix(root, "/") {
+		root += "/"
+	}
+
+	return &scipConverter{
+		root:               root,
+		symbols:            map[string]*scipSymbol{},
+		packages:           map[string]int{},
+		packageInformation: map[int]precise.PackageInformationData{},
+	}
+}
+
+func (c *scipConverter) nextID() int {
+	c.id++
+	return c.id
+}
+
+// convertDocument converts the given document into document data with one range per occurrence,
+// and records the occurrences of each symbol.
+func (c *scipConverter) convertDocument(document *scip.Document) error {
+	d := &scipDocument{
+		id: c.nextID(),
+		// Paths are relative to the project root; make them relative to the upload root
+		path: strings.TrimPrefix(document.RelativePath, c.root),
+		data: precise.DocumentData{
+			Ranges:             make(map[precise.ID]precise.RangeData, len(document.Occurrences)),
+			HoverResults:       map[precise.ID]string{},
+			Monikers:           map[precise.ID]precise.MonikerData{},
+			PackageInformation: map[precise.ID]precise.PackageInformationData{},
+			Diagnostics:        []precise.DiagnosticData{},
+		},
+	}
+
+	for _, occurrence := range document.Occurrences {
+		startLine, startCharacter, endLine, endCharacter, ok := occurrence.Span()
+		if !ok {
+			return errors.Errorf("malformed range %v in document %q", occurrence.Range, document.RelativePath)
+		}
+
+		rangeID := c.nextID()
+		rangeData := precise.RangeData{
+			StartLine:      startLine,
+			StartCharacter: startCharacter,
+			EndLine:        endLine,
+			EndCharacter:   endCharacter,
+		}
+
+		if len(occurrence.OverrideDocumentation) > 0 {
+			rangeData.HoverResultID = toID(c.nextID())
+			d.data.HoverResults[rangeData.HoverResultID] = strings.Join(occurrence.OverrideDocumentation, reader.HoverPartSeparator)
+		}
+
+		d.data.Ranges[toID(rangeID)] = rangeData
+
+		if occurrence.Symbol == "" {
+			continue
+		}
+
+		symbol := c.symbol(d.id, occurrence.Symbol)
+		location := scipLocation{
+			document:       d,
+			rangeID:        rangeID,
+			startLine:      startLine,
+			startCharacter: startCharacter,
+			endLine:        endLine,
+			endCharacter:   endCharacter,
+		}
+		symbol.references = append(symbol.references, location)
+		if occurrence.HasRole(scip.SymbolRoleDefinition) {
+			symbol.definitions = append(symbol.definitions, location)
+		}
+		d.occurrences = append(d.occurrences, scipOccurrence{rangeID: rangeID, symbol: symbol})
+	}
+
+	for _, symbolInformation := range document.Symbols {
+		c.convertSymbolInformation(symbolInformation)
+	}
+
+	c.documents = append(c.documents, d)
+	return nil
+}
+
+// convertSymbolInformation records the hover text and relationships of the given symbol.
+// Document symbols are never local, as local symbols are not described by SymbolInformation.
+func (c *scipConverter) convertSymbolInformation(symbolInformation *scip.SymbolInformation) {
+	if symbolInformation.Symbol == "" || scip.IsLocalSymbol(symbolInformation.Symbol) {
+		return
+	}
+	symbol := c.symbol(0, symbolInformation.Symbol)
+
+	if len(symbolInformation.Documentation) > 0 && symbol.hover == "" {
+		symbol.hover = strings.Join(symbolInformation.Documentation, reader.HoverPartSeparator)
+	}
+
+	for _, relationship := range symbolInformation.Relationships {
+		if relationship.Symbol == "" || scip.IsLocalSymbol(relationship.Symbol) {
+			continue
+		}
+		target := c.symbol(0, relationship.Symbol)
+
+		if relationship.IsImplementation {
+			target.implementedBy = append(target.implementedBy, symbol)
+		}
+		if relationship.IsTypeDefinition {
+			symbol.typeDefinitions = append(symbol.typeDefinitions, target)
+		}
+		if relationship.IsReference {
+			target.referencedBy = append(target.referencedBy, symbol)
+		}
+	}
+}
+
+// symbol returns the tracked symbol with the given name, creating it if this is its first
+// occurrence. Local symbols are scoped to the given document.
+func (c *scipConverter) symbol(documentID int, name string) *scipSymbol {
+	local := scip.IsLocalSymbol(name)
+
+	key := name
+	if local {
+		key = fmt.Sprintf("%d:%s", documentID, name)
+	}
+
+	if symbol, ok := c.symbols[key]; ok {
+		return symbol
+	}
+
+	symbol := &scipSymbol{name: name, local: local}
+	c.symbols[key] = symbol
+	c.ordered = append(c.ordered, symbol)
+	return symbol
+}
+
+// prune marks the documents that do not exist in the git clone at the target commit. Pruned
+// documents are not stored, and their ranges are removed from all results.
+func (c *scipConverter) prune(ctx context.Context, root string, getChildren pathexistence.GetChildrenFunc) error {
+	paths := make([]string, 0, len(c.documents))
+	for _, d := range c.documents {
+		paths = append(paths, d.path)
+	}
+
+	checker, err := pathexistence.NewExistenceChecker(ctx, root, paths, getChildren)
+	if err != nil {
+		return err
+	}
+
+	for _, d := range c.documents {
+		d.pruned = !checker.Exists(d.path)
+	}
+	return nil
+}
+
+// link assigns result identifiers and monikers to every symbol that occurs in the index, once
+// all documents have been read and pruned. No result identifiers are assigned to results without
+// any locations in stored documents.
+func (c *scipConverter) link() {
+	for _, symbol := range c.ordered {
+		if len(symbol.references) == 0 {
+			// Only mentioned by symbol information; nothing can query these results
+			continue
+		}
+
+		if len(symbol.definitionLocations()) > 0 {
+			symbol.definitionResultID = c.nextID()
+		}
+		symbol.referenceResultID = c.nextID()
+		if symbol.hover != "" {
+			symbol.hoverResultID = c.nextID()
+		}
+		for _, implementer := range symbol.implementedBy {
+			if len(implementer.definitionLocations()) > 0 {
+				symbol.implementationResultID = c.nextID()
+				break
+			}
+		}
+		for _, typeDefinition := range symbol.typeDefinitions {
+			if len(typeDefinition.definitionLocations()) > 0 {
+				symbol.typeDefinitionResultID = c.nextID()
+				break
+			}
+		}
+
+		c.linkMoniker(symbol)
+	}
+}
+
+// linkMoniker creates a moniker for the given global symbol. Symbols defined within the index are
+// exported and all other symbols are imported. No moniker is created for local symbols and symbols
+// that cannot be attributed to a package.
+func (c *scipConverter) linkMoniker(symbol *scipSymbol) {
+	if symbol.local {
+		return
+	}
+
+	parsed, err := scip.ParseSymbol(symbol.name)
+	if err != nil || parsed.Name == "" {
+		return
+	}
+
+	key := makeKey(parsed.Manager, parsed.Name, parsed.Version)
+	packageInformationID, ok := c.packages[key]
+	if !ok {
+		packageInformationID = c.nextID()
+		c.packages[key] = packageInformationID
+		c.packageInformation[packageInformationID] = precise.PackageInformationData{
+			Name:    parsed.Name,
+			Version: parsed.Version,
+		}
+	}
+
+	kind := "import"
+	if len(symbol.definitions) > 0 {
+		kind = "export"
+	}
+
+	symbol.packageInformationID = packageInformationID
+	symbol.monikerID = c.nextID()
+	symbol.moniker = precise.MonikerData{
+		Kind:                 kind,
+		Scheme:               parsed.Scheme,
+		Identifier:           parsed.Descriptors,
+		PackageInformationID: toID(packageInformationID),
+	}
+}
+
+// groupedBundleData returns channels which send the converted data of the linked index.
+func (c *scipConverter) groupedBundleData(ctx context.Context) (*precise.GroupedBundleDataChans, error) {
+	numResults := 0
+	for _, symbol := range c.ordered {
+		for _, id := range []int{symbol.definitionResultID, symbol.referenceResultID, symbol.implementationResultID, symbol.typeDefinitionResultID} {
+			if id != 0 {
+				numResults++
+			}
+		}
+	}
+	numResultChunks := int(math.Max(1, math.Floor(float64(numResults)/resultsPerResultChunk)))
+
+	packages := c.gatherPackages()
+	packageReferences, err := c.gatherPackageReferences(packages)
+	if err != nil {
+		return nil, err
+	}
+
+	// SCIP indexes carry no documentation pages
+	documentation := newDocumentationChannels()
+	documentation.close()
+
+	return &precise.GroupedBundleDataChans{
+		Meta:                  precise.MetaData{NumResultChunks: numResultChunks},
+		Documents:             c.serializeDocuments(ctx),
+		ResultChunks:          c.serializeResultChunks(ctx, numResultChunks),
+		Definitions:           c.gatherMonikersLocations(ctx, (*scipSymbol).definitionLocations),
+		References:            c.gatherMonikersLocations(ctx, (*scipSymbol).referenceLocations),
+		Implementations:       c.gatherMonikersLocations(ctx, (*scipSymbol).implementationLocations),
+		DocumentationPages:    documentation.pages,
+		DocumentationPathInfo: documentation.pathInfo,
+		DocumentationMappings: documentation.mappings,
+		Packages:              packages,
+		PackageReferences:     packageReferences,
+	}, nil
+}
+
+// serializeDocuments fills in the result identifiers, hover text and monikers of the symbol
+// occurrences of each stored document and sends it. The data of each document is released once
+// it has been sent.
+func (c *scipConverter) serializeDocuments(ctx context.Context) chan precise.KeyedDocumentData {
+	ch := make(chan precise.KeyedDocumentData)
+
+	go func() {
+		defer close(ch)
+
+		for _, d := range c.documents {
+			if !d.stored() {
+				continue
+			}
+
+			for _, occurrence := range d.occurrences {
+				symbol := occurrence.symbol
+				rangeData := d.data.Ranges[toID(occurrence.rangeID)]
+				rangeData.DefinitionResultID = toID(symbol.definitionResultID)
+				rangeData.ReferenceResultID = toID(symbol.referenceResultID)
+				rangeData.ImplementationResultID = toID(symbol.implementationResultID)
+				rangeData.TypeDefinitionResultID = toID(symbol.typeDefinitionResultID)
+
+				if rangeData.HoverResultID == "" && symbol.hoverResultID != 0 {
+					rangeData.HoverResultID = toID(symbol.hoverResultID)
+					d.data.HoverResults[rangeData.HoverResultID] = symbol.hover
+				}
+
+				if symbol.monikerID != 0 {
+					rangeData.MonikerIDs = []precise.ID{toID(symbol.monikerID)}
+					d.data.Monikers[toID(symbol.monikerID)] = symbol.moniker
+					d.data.PackageInformation[symbol.moniker.PackageInformationID] = c.packageInformation[symbol.packageInformationID]
+				}
+
+				d.data.Ranges[toID(occurrence.rangeID)] = rangeData
+			}
+
+			data := precise.KeyedDocumentData{
+				Path:     d.path,
+				Document: d.data,
+			}
+
+			select {
+			case ch <- data:
+			case <-ctx.Done():
+				return
+			}
+
+			d.data, d.occurrences = precise.DocumentData{}, nil
+		}
+	}()
+
+	return ch
+}
+
+// serializeResultChunks sends the locations of the definition, reference, implementation and type
+// definition results of all symbols, hashed into numResultChunks result chunks.
+func (c *scipConverter) serializeResultChunks(ctx context.Context, numResultChunks int) chan precise.IndexedResultChunkData {
+	ch := make(chan precise.IndexedResultChunkData)
+
+	go func() {
+		defer close(ch)
+
+		resultChunks := make(map[int]precise.ResultChunkData, numResultChunks)
+		addResult := func(resultID int, locations []scipLocation) {
+			if resultID == 0 {
+				return
+			}
+
+			index := precise.HashKey(toID(resultID), numResultChunks)
+			resultChunk, ok := resultChunks[index]
+			if !ok {
+				resultChunk = precise.ResultChunkData{
+					DocumentPaths:      map[precise.ID]string{},
+					DocumentIDRangeIDs: map[precise.ID][]precise.DocumentIDRangeID{},
+				}
+				resultChunks[index] = resultChunk
+			}
+
+			documentIDRangeIDs := make([]precise.DocumentIDRangeID, 0, len(locations))
+			for _, location := range locations {
+				resultChunk.DocumentPaths[toID(location.document.id)] = location.document.path
+				documentIDRangeIDs = append(documentIDRangeIDs, precise.DocumentIDRangeID{
+					DocumentID: toID(location.document.id),
+					RangeID:    toID(location.rangeID),
+				})
+			}
+			resultChunk.DocumentIDRangeIDs[toID(resultID)] = documentIDRangeIDs
+		}
+
+		for _, symbol := range c.ordered {
+			addResult(symbol.definitionResultID, symbol.definitionLocations())
+			addResult(symbol.referenceResultID, symbol.referenceLocations())
+			addResult(symbol.implementationResultID, symbol.implementationLocations())
+			addResult(symbol.typeDefinitionResultID, symbol.typeDefinitionLocations())
+		}
+
+		for index, resultChunk := range resultChunks {
+			data := precise.IndexedResultChunkData{
+				Index:       index,
+				ResultChunk: resultChunk,
+			}
+
+			select {
+			case ch <- data:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch
+}
+
+// gatherMonikersLocations sends the locations of the results returned by getLocations for each
+// moniker of the index.
+func (c *scipConverter) gatherMonikersLocations(ctx context.Context, getLocations func(symbol *scipSymbol) []scipLocation) chan precise.MonikerLocations {
+	ch := make(chan precise.MonikerLocations)
+
+	go func() {
+		defer close(ch)
+
+		locationsBySchemeByIdentifier := map[string]map[string][]precise.LocationData{}
+		for _, symbol := range c.ordered {
+			if symbol.monikerID == 0 {
+				continue
+			}
+
+			locationsByIdentifier, ok := locationsBySchemeByIdentifier[symbol.moniker.Scheme]
+			if !ok {
+				locationsByIdentifier = map[string][]precise.LocationData{}
+				locationsBySchemeByIdentifier[symbol.moniker.Scheme] = locationsByIdentifier
+			}
+			for _, location := range getLocations(symbol) {
+				locationsByIdentifier[symbol.moniker.Identifier] = append(locationsByIdentifier[symbol.moniker.Identifier], precise.LocationData{
+					URI:            location.document.path,
+					StartLine:      location.startLine,
+					StartCharacter: location.startCharacter,
+					EndLine:        location.endLine,
+					EndCharacter:   location.endCharacter,
+				})
+			}
+		}
+
+		for scheme, locationsByIdentifier := range locationsBySchemeByIdentifier {
+			for identifier, locations := range locationsByIdentifier {
+				// Sort locations by containing document path then by offset within the text
+				// document (in reading order). This provides us with an obvious and deterministic
+				// ordering of a result set over multiple API requests.
+
+				sort.Sort(sortableLocations(locations))
+
+				data := precise.MonikerLocations{
+					Scheme:     scheme,
+					Identifier: identifier,
+					Locations:  locations,
+				}
+
+				select {
+				case ch <- data:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return ch
+}
+
+// definitionLocations returns the stored definitions of the symbol.
+func (s *scipSymbol) definitionLocations() []scipLocation {
+	return storedLocations(s.definitions)
+}
+
+// referenceLocations returns the stored references of the symbol, including the references of
+// the symbols that declare a reference relationship with it.
+func (s *scipSymbol) referenceLocations() []scipLocation {
+	locations := s.references
+	for _, referencer := range s.referencedBy {
+		locations = append(locations[:len(locations):len(locations)], referencer.references...)
+	}
+	return storedLocations(locations)
+}
+
+// implementationLocations returns the stored definitions of the symbols implementing the symbol.
+func (s *scipSymbol) implementationLocations() []scipLocation {
+	var locations []scipLocation
+	for _, implementer := range s.implementedBy {
+		locations = append(locations, implementer.definitions...)
+	}
+	return storedLocations(locations)
+}
+
+// typeDefinitionLocations returns the stored definitions of the type definitions of the symbol.
+func (s *scipSymbol) typeDefinitionLocations() []scipLocation {
+	var locations []scipLocation
+	for _, typeDefinition := range s.typeDefinitions {
+		locations = append(locations, typeDefinition.definitions...)
+	}
+	return storedLocations(locations)
+}
+
+// storedLocations returns the distinct locations within stored documents sorted by containing
+// document path, then by offset within the document.
+func storedLocations(locations []scipLocation) []scipLocation {
+	stored := make([]scipLocation, 0, len(locations))
+	seen := make(map[int]struct{}, len(locations))
+	for _, location := range locations {
+		if _, ok := seen[location.rangeID]; ok || !location.document.stored() {
+			continue
+		}
+		seen[location.rangeID] = struct{}{}
+		stored = append(stored, location)
+	}
+
+	sort.Slice(stored, func(i, j int) bool {
+		if stored[i].document.path != stored[j].document.path {
+			return stored[i].document.path < stored[j].document.path
+		}
+		if stored[i].startLine != stored[j].startLine {
+			return stored[i].startLine < stored[j].startLine
+		}
+		return stored[i].startCharacter < stored[j].startCharacter
+	})
+	return stored
+}
+
+// gatherPackages returns the packages of the exported monikers of the index.
+func (c *scipConverter) gatherPackages() []precise.Package {
+	uniques := map[string]precise.Package{}
+	for _, symbol := range c.ordered {
+		if symbol.monikerID == 0 || symbol.moniker.Kind != "export" {
+			continue
+		}
+
+		packageInfo := c.packageInformation[symbol.packageInformationID]
+		uniques[makeKey(symbol.moniker.Scheme, packageInfo.Name, packageInfo.Version)] = precise.Package{
+			Scheme:  symbol.moniker.Scheme,
+			Name:    packageInfo.Name,
+			Version: packageInfo.Version,
+		}
+	}
+
+	packages := make([]precise.Package, 0, len(uniques))
+	for _, v := range uniques {
+		packages = append(packages, v)
+	}
+
+	return packages
+}
+
+// gatherPackageReferences returns the packages of the imported monikers of the index that are not
+// also defined by the index, along with a filter of the imported identifiers of each package.
+func (c *scipConverter) gatherPackageReferences(packageDefinitions []precise.Package) ([]precise.PackageReference, error) {
+	packageDefinitionKeySet := make(map[string]struct{}, len(packageDefinitions))
+	for _, pkg := range packageDefinitions {
+		packageDefinitionKeySet[makeKey(pkg.Scheme, pkg.Name, pkg.Version)] = struct{}{}
+	}
+
+	packages := map[string]precise.Package{}
+	identifiers := map[string][]string{}
+	for _, symbol := range c.ordered {
+		if symbol.monikerID == 0 || symbol.moniker.Kind != "import" {
+			continue
+		}
+
+		packageInfo := c.packageInformation[symbol.packageInformationID]
+		key := makeKey(symbol.moniker.Scheme, packageInfo.Name, packageInfo.Version)
+		if _, ok := packageDefinitionKeySet[key]; ok {
+			// Self-references are not needed to link this index to its dependencies
+			continue
+		}
+
+		packages[key] = precise.Package{
+			Scheme:  symbol.moniker.Scheme,
+			Name:    packageInfo.Name,
+			Version: packageInfo.Version,
+		}
+		identifiers[key] = append(identifiers[key], symbol.moniker.Identifier)
+	}
+
+	packageReferences := make([]precise.PackageReference, 0, len(packages))
+	for key, pkg := range packages {
+		filter, err := bloomfilter.CreateFilter(identifiers[key])
+		if err != nil {
+			return nil, errors.Wrap(err, "bloomfilter.CreateFilter")
+		}
+
+		packageReferences = append(packageReferences, precise.PackageReference{
+			Package: pkg,
+			Filter:  filter,
+		})
+	}
+
+	return packageReferences, nil
+}
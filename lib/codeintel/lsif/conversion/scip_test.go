@@ -0,0 +1,224 @@
+package conversion
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/scip"
+)
+
+func TestCorrelateSCIP(t *testing.T) {
+	const (
+		fooSymbol     = "scip-go gomod example v1.0.0 main/Foo#"
+		barSymbol     = "scip-go gomod example v1.0.0 main/Bar#"
+		printlnSymbol = "scip-go gomod fmt . fmt/Println()."
+	)
+
+	index := &scip.Index{
+		Metadata: scip.Metadata{ToolInfo: scip.ToolInfo{Name: "scip-go"}, ProjectRoot: "file:///test"},
+		Documents: []*scip.Document{
+			{
+				RelativePath: "root/main.go",
+				Occurrences: []*scip.Occurrence{
+					{Range: []int32{1, 5, 8}, Symbol: fooSymbol, SymbolRoles: int32(scip.SymbolRoleDefinition)},
+					{Range: []int32{2, 1, 4}, Symbol: "local 0", SymbolRoles: int32(scip.SymbolRoleDefinition)},
+					{Range: []int32{3, 2, 3, 5}, Symbol: "local 0"},
+					{Range: []int32{4, 1, 8}, Symbol: printlnSymbol},
+					{Range: []int32{6, 5, 8}, Symbol: barSymbol, SymbolRoles: int32(scip.SymbolRoleDefinition)},
+				},
+				Symbols: []*scip.SymbolInformation{
+					{Symbol: barSymbol, Relationships: []*scip.Relationship{{Symbol: fooSymbol, IsImplementation: true}}},
+				},
+			},
+			{
+				RelativePath: "root/foo.go",
+				Occurrences: []*scip.Occurrence{
+					{Range: []int32{0, 5, 8}, Symbol: fooSymbol},
+				},
+				Symbols: []*scip.SymbolInformation{
+					{Symbol: fooSymbol, Documentation: []string{"foo docs"}},
+				},
+			},
+		},
+		ExternalSymbols: []*scip.SymbolInformation{
+			{Symbol: printlnSymbol, Documentation: []string{"println docs"}},
+		},
+	}
+
+	groupedBundleData, err := CorrelateSCIP(context.Background(), bytes.NewReader(index.Marshal()), "root", nil)
+	if err != nil {
+		t.Fatalf("unexpected error correlating input: %s", err)
+	}
+	bundle := precise.GroupedBundleDataChansToMaps(groupedBundleData)
+
+	if len(bundle.Documents) != 2 {
+		t.Fatalf("unexpected number of documents. want=%d have=%d", 2, len(bundle.Documents))
+	}
+	main := bundle.Documents["main.go"]
+
+	rangesByLine := map[int]precise.RangeData{}
+	for _, r := range main.Ranges {
+		rangesByLine[r.StartLine] = r
+	}
+	if len(rangesByLine) != 5 {
+		t.Fatalf("unexpected number of ranges. want=%d have=%d", 5, len(rangesByLine))
+	}
+	foo := rangesByLine[1]
+	local := rangesByLine[3]
+	printlnRange := rangesByLine[4]
+
+	// Hover text is resolved from symbol information read after the document
+	if hover := main.HoverResults[foo.HoverResultID]; hover != "foo docs" {
+		t.Errorf("unexpected hover text. want=%q have=%q", "foo docs", hover)
+	}
+	if hover := main.HoverResults[printlnRange.HoverResultID]; hover != "println docs" {
+		t.Errorf("unexpected hover text. want=%q have=%q", "println docs", hover)
+	}
+
+	result := func(id precise.ID) []precise.DocumentPathRangeID {
+		resultChunk := bundle.ResultChunks[precise.HashKey(id, bundle.Meta.NumResultChunks)]
+
+		var locations []precise.DocumentPathRangeID
+		for _, documentIDRangeID := range resultChunk.DocumentIDRangeIDs[id] {
+			locations = append(locations, precise.DocumentPathRangeID{
+				Path:    resultChunk.DocumentPaths[documentIDRangeID.DocumentID],
+				RangeID: documentIDRangeID.RangeID,
+			})
+		}
+		return locations
+	}
+	rangeID := func(path string, line int) precise.ID {
+		for id, r := range bundle.Documents[path].Ranges {
+			if r.StartLine == line {
+				return id
+			}
+		}
+		t.Fatalf("no range at %s:%d", path, line)
+		return ""
+	}
+
+	expectedImplementations := []precise.DocumentPathRangeID{{Path: "main.go", RangeID: rangeID("main.go", 6)}}
+	if diff := cmp.Diff(expectedImplementations, result(foo.ImplementationResultID)); diff != "" {
+		t.Errorf("unexpected implementations (-want +got):\n%s", diff)
+	}
+	expectedReferences := []precise.DocumentPathRangeID{
+		{Path: "foo.go", RangeID: rangeID("foo.go", 0)},
+		{Path: "main.go", RangeID: rangeID("main.go", 1)},
+	}
+	if diff := cmp.Diff(expectedReferences, result(foo.ReferenceResultID)); diff != "" {
+		t.Errorf("unexpected references (-want +got):\n%s", diff)
+	}
+	if id := bundle.Documents["foo.go"].Ranges[rangeID("foo.go", 0)].DefinitionResultID; id != foo.DefinitionResultID {
+		t.Errorf("unexpected definition result. want=%q have=%q", foo.DefinitionResultID, id)
+	}
+
+	expectedLocalDefinitions := []precise.DocumentPathRangeID{{Path: "main.go", RangeID: rangeID("main.go", 2)}}
+	if diff := cmp.Diff(expectedLocalDefinitions, result(local.DefinitionResultID)); diff != "" {
+		t.Errorf("unexpected local definitions (-want +got):\n%s", diff)
+	}
+	expectedLocalReferences := []precise.DocumentPathRangeID{
+		{Path: "main.go", RangeID: rangeID("main.go", 2)},
+		{Path: "main.go", RangeID: rangeID("main.go", 3)},
+	}
+	if diff := cmp.Diff(expectedLocalReferences, result(local.ReferenceResultID)); diff != "" {
+		t.Errorf("unexpected local references (-want +got):\n%s", diff)
+	}
+	if printlnRange.DefinitionResultID != "" {
+		t.Errorf("unexpected definition result for external symbol")
+	}
+
+	if len(local.MonikerIDs) != 0 {
+		t.Errorf("unexpected monikers for local symbol")
+	}
+
+	var monikers []precise.MonikerData
+	for _, r := range []precise.RangeData{foo, printlnRange} {
+		for _, id := range r.MonikerIDs {
+			moniker := main.Monikers[id]
+			moniker.PackageInformationID = ""
+			monikers = append(monikers, moniker)
+		}
+	}
+	expectedMonikers := []precise.MonikerData{
+		{Kind: "export", Scheme: "scip-go", Identifier: "main/Foo#"},
+		{Kind: "import", Scheme: "scip-go", Identifier: "fmt/Println()."},
+	}
+	if diff := cmp.Diff(expectedMonikers, monikers); diff != "" {
+		t.Errorf("unexpected monikers (-want +got):\n%s", diff)
+	}
+
+	expectedDefinitions := []precise.LocationData{{URI: "main.go", StartLine: 1, StartCharacter: 5, EndLine: 1, EndCharacter: 8}}
+	if diff := cmp.Diff(expectedDefinitions, bundle.Definitions["scip-go"]["main/Foo#"]); diff != "" {
+		t.Errorf("unexpected moniker definitions (-want +got):\n%s", diff)
+	}
+
+	expectedPackages := []precise.Package{{Scheme: "scip-go", Name: "example", Version: "v1.0.0"}}
+	if diff := cmp.Diff(expectedPackages, bundle.Packages); diff != "" {
+		t.Errorf("unexpected packages (-want +got):\n%s", diff)
+	}
+	if len(bundle.PackageReferences) != 1 || bundle.PackageReferences[0].Name != "fmt" {
+		t.Errorf("unexpected package references: %v", bundle.PackageReferences)
+	}
+}
+
+func TestCorrelateSCIPPrune(t *testing.T) {
+	const fooSymbol = "scip-go gomod example v1.0.0 main/Foo#"
+
+	index := &scip.Index{
+		Metadata: scip.Metadata{ToolInfo: scip.ToolInfo{Name: "scip-go"}, ProjectRoot: "file:///test"},
+		Documents: []*scip.Document{
+			{
+				RelativePath: "root/main.go",
+				Occurrences:  []*scip.Occurrence{{Range: []int32{1, 5, 8}, Symbol: fooSymbol}},
+			},
+			{
+				RelativePath: "root/foo.generated.go",
+				Occurrences:  []*scip.Occurrence{{Range: []int32{0, 5, 8}, Symbol: fooSymbol, SymbolRoles: int32(scip.SymbolRoleDefinition)}},
+			},
+		},
+	}
+
+	getChildren := func(ctx context.Context, dirnames []string) (map[string][]string, error) {
+		return map[string][]string{"root": {"root/main.go"}}, nil
+	}
+
+	groupedBundleData, err := CorrelateSCIP(context.Background(), bytes.NewReader(index.Marshal()), "root", getChildren)
+	if err != nil {
+		t.Fatalf("unexpected error correlating input: %s", err)
+	}
+	bundle := precise.GroupedBundleDataChansToMaps(groupedBundleData)
+
+	if _, ok := bundle.Documents["foo.generated.go"]; ok || len(bundle.Documents) != 1 {
+		t.Fatalf("expected only main.go to be stored, have %d documents", len(bundle.Documents))
+	}
+	for _, r := range bundle.Documents["main.go"].Ranges {
+		if r.DefinitionResultID != "" {
+			t.Errorf("unexpected definition result in pruned document")
+		}
+	}
+	for _, resultChunk := range bundle.ResultChunks {
+		for _, path := range resultChunk.DocumentPaths {
+			if path != "main.go" {
+				t.Errorf("unexpected document %q in result chunk", path)
+			}
+		}
+	}
+	if definitions := bundle.Definitions["scip-go"]["main/Foo#"]; len(definitions) != 0 {
+		t.Errorf("unexpected moniker definitions in pruned document: %v", definitions)
+	}
+}
+
+func TestCorrelateSCIPMissingMetadata(t *testing.T) {
+	index := &scip.Index{Documents: []*scip.Document{{RelativePath: "main.go"}}}
+
+	// Drop the leading (empty) metadata message
+	encoded := index.Marshal()[2:]
+
+	if _, err := CorrelateSCIP(context.Background(), bytes.NewReader(encoded), "", nil); err == nil {
+		t.Fatalf("expected error correlating input without metadata")
+	}
+}
@@ -10,8 +10,8 @@ type MetaData struct {
 }
 
 // DocumentData represents a single document within an index. The data here can answer
-// definitions, references, and hover queries if the results are all contained in the
-// same document.
+// definitions, references, implementations, and hover queries if the results are all
+// contained in the same document.
 type DocumentData struct {
 	Ranges             map[ID]RangeData
 	HoverResults       map[ID]string // hover text normalized to markdown string
@@ -25,15 +25,17 @@ type DocumentData struct {
 // that was reachable via a result set has been collapsed into this object during
 // conversion.
 type RangeData struct {
-	StartLine             int  // 0-indexed, inclusive
-	StartCharacter        int  // 0-indexed, inclusive
-	EndLine               int  // 0-indexed, inclusive
-	EndCharacter          int  // 0-indexed, inclusive
-	DefinitionResultID    ID   // possibly empty
-	ReferenceResultID     ID   // possibly empty
-	HoverResultID         ID   // possibly empty
-	DocumentationResultID ID   // possibly empty
-	MonikerIDs            []ID // possibly empty
+	StartLine              int  // 0-indexed, inclusive
+	StartCharacter         int  // 0-indexed, inclusive
+	EndLine                int  // 0-indexed, inclusive
+	EndCharacter           int  // 0-indexed, inclusive
+	DefinitionResultID     ID   // possibly empty
+	ReferenceResultID      ID   // possibly empty
+	HoverResultID          ID   // possibly empty
+	DocumentationResultID  ID   // possibly empty
+	ImplementationResultID ID   // possibly empty
+	TypeDefinitionResultID ID   // possibly empty
+	MonikerIDs             []ID // possibly empty
 }
 
 // MonikerData represent a unique name (eventually) attached to a range.
@@ -73,7 +75,7 @@ type DiagnosticData struct {
 }
 
 // ResultChunkData represents a row of the resultChunk table. Each row is a subset
-// of definition and reference result data in the index. Results are inserted into
+// of definition, reference, and implementation result data in the index. Results are inserted into
 // chunks based on the hash of their identifier, thus every chunk has a roughly
 // proportional amount of data.
 type ResultChunkData struct {
@@ -210,6 +212,7 @@ type GroupedBundleDataChans struct {
 	ResultChunks          chan IndexedResultChunkData
 	Definitions           chan MonikerLocations
 	References            chan MonikerLocations
+	Implementations       chan MonikerLocations
 	Packages              []Package
 	PackageReferences     []PackageReference
 	DocumentationPages    chan *DocumentationPageData
@@ -223,6 +226,7 @@ type GroupedBundleDataMaps struct {
 	ResultChunks      map[int]ResultChunkData
 	Definitions       map[string]map[string][]LocationData
 	References        map[string]map[string][]LocationData
+	Implementations   map[string]map[string][]LocationData
 	Packages          []Package
 	PackageReferences []PackageReference
 }
@@ -0,0 +1,376 @@
+package scip
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+
+	"github.com/cockroachdb/errors"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// maxMessageSize is the maximum size of a single top-level message (metadata, document, or
+// external symbol) of an index. This guards against allocating unbounded memory when reading
+// a malformed or non-index payload.
+const maxMessageSize = 1 << 30
+
+// Visitor receives the top-level messages of an index in the order they are read. Nil fields
+// are skipped.
+type Visitor struct {
+	Metadata       func(metadata Metadata) error
+	Document       func(document *Document) error
+	ExternalSymbol func(symbol *SymbolInformation) error
+}
+
+// ReadIndex reads a serialized Index message from r and calls the visitor for each top-level
+// message. Only a single document is decoded and held in memory at a time.
+func ReadIndex(r io.Reader, visitor Visitor) error {
+	br := bufio.NewReader(r)
+
+	for {
+		tag, err := binary.ReadUvarint(br)
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return errors.Wrap(err, "reading field tag")
+		}
+
+		num, typ := protowire.DecodeTag(tag)
+		if typ != protowire.BytesType {
+			if err := skipStreamValue(br, typ); err != nil {
+				return err
+			}
+			continue
+		}
+
+		size, err := binary.ReadUvarint(br)
+		if err != nil {
+			return errors.Wrap(err, "reading field length")
+		}
+		if size > maxMessageSize {
+			return errors.Errorf("message of %d bytes exceeds the maximum size of %d bytes", size, maxMessageSize)
+		}
+		buf := make([]byte, size)
+		if _, err := io.ReadFull(br, buf); err != nil {
+			return errors.Wrap(err, "reading message")
+		}
+
+		switch num {
+		case 1:
+			metadata, err := unmarshalMetadata(buf)
+			if err != nil {
+				return errors.Wrap(err, "decoding metadata")
+			}
+			if visitor.Metadata != nil {
+				if err := visitor.Metadata(metadata); err != nil {
+					return err
+				}
+			}
+
+		case 2:
+			document, err := unmarshalDocument(buf)
+			if err != nil {
+				return errors.Wrap(err, "decoding document")
+			}
+			if visitor.Document != nil {
+				if err := visitor.Document(document); err != nil {
+					return err
+				}
+			}
+
+		case 3:
+			symbol, err := unmarshalSymbolInformation(buf)
+			if err != nil {
+				return errors.Wrap(err, "decoding external symbol")
+			}
+			if visitor.ExternalSymbol != nil {
+				if err := visitor.ExternalSymbol(symbol); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// Unmarshal decodes an entire serialized Index message.
+func Unmarshal(b []byte) (*Index, error) {
+	index := &Index{}
+	err := consumeFields(b, func(num protowire.Number, typ protowire.Type, v []byte, _ uint64) (err error) {
+		if typ != protowire.BytesType {
+			return nil
+		}
+
+		switch num {
+		case 1:
+			index.Metadata, err = unmarshalMetadata(v)
+		case 2:
+			var document *Document
+			if document, err = unmarshalDocument(v); err == nil {
+				index.Documents = append(index.Documents, document)
+			}
+		case 3:
+			var symbol *SymbolInformation
+			if symbol, err = unmarshalSymbolInformation(v); err == nil {
+				index.ExternalSymbols = append(index.ExternalSymbols, symbol)
+			}
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return index, nil
+}
+
+// IsIndex returns true if the given prefix of an (uncompressed) upload looks like the start of a
+// serialized Index rather than an LSIF JSON graph. The prefix should contain at least the encoded
+// metadata message, which is the first field written by indexers.
+func IsIndex(prefix []byte) bool {
+	if len(prefix) == 0 || prefix[0] == '{' {
+		return false
+	}
+
+	num, typ, n := protowire.ConsumeTag(prefix)
+	if n < 0 || typ != protowire.BytesType || num < 1 || num > 3 {
+		return false
+	}
+	if num != 1 {
+		// Documents or external symbols without a preceding metadata message
+		return true
+	}
+
+	// LSIF JSON may start with a newline (0x0a), which is also the tag of the metadata
+	// field. Make sure the payload actually decodes as a metadata message.
+	rest := prefix[n:]
+	metadata, m := protowire.ConsumeBytes(rest)
+	if m < 0 {
+		// The prefix ends before the metadata message does; check the fields we can see
+		if _, m = protowire.ConsumeVarint(rest); m < 0 {
+			return false
+		}
+		metadata = rest[m:]
+	}
+	if len(metadata) == 0 {
+		return true
+	}
+
+	for len(metadata) > 0 {
+		num, typ, n := protowire.ConsumeTag(metadata)
+		if n < 0 || num < 1 || num > 4 {
+			return false
+		}
+		if (num == 1 || num == 4) && typ != protowire.VarintType {
+			return false
+		}
+		if (num == 2 || num == 3) && typ != protowire.BytesType {
+			return false
+		}
+
+		m := protowire.ConsumeFieldValue(num, typ, metadata[n:])
+		if m < 0 {
+			// The field is cut off by the end of the prefix
+			return true
+		}
+		metadata = metadata[n+m:]
+	}
+
+	return true
+}
+
+func unmarshalMetadata(b []byte) (metadata Metadata, err error) {
+	err = consumeFields(b, func(num protowire.Number, typ protowire.Type, v []byte, x uint64) (err error) {
+		switch num {
+		case 1:
+			metadata.Version = int32(x)
+		case 2:
+			metadata.ToolInfo, err = unmarshalToolInfo(v)
+		case 3:
+			metadata.ProjectRoot = string(v)
+		case 4:
+			metadata.TextDocumentEncoding = int32(x)
+		}
+		return err
+	})
+	return metadata, err
+}
+
+func unmarshalToolInfo(b []byte) (toolInfo ToolInfo, err error) {
+	err = consumeFields(b, func(num protowire.Number, typ protowire.Type, v []byte, _ uint64) error {
+		switch num {
+		case 1:
+			toolInfo.Name = string(v)
+		case 2:
+			toolInfo.Version = string(v)
+		case 3:
+			toolInfo.Arguments = append(toolInfo.Arguments, string(v))
+		}
+		return nil
+	})
+	return toolInfo, err
+}
+
+func unmarshalDocument(b []byte) (*Document, error) {
+	document := &Document{}
+	err := consumeFields(b, func(num protowire.Number, typ protowire.Type, v []byte, _ uint64) (err error) {
+		switch num {
+		case 1:
+			document.RelativePath = string(v)
+		case 2:
+			var occurrence *Occurrence
+			if occurrence, err = unmarshalOccurrence(v); err == nil {
+				document.Occurrences = append(document.Occurrences, occurrence)
+			}
+		case 3:
+			var symbol *SymbolInformation
+			if symbol, err = unmarshalSymbolInformation(v); err == nil {
+				document.Symbols = append(document.Symbols, symbol)
+			}
+		case 4:
+			document.Language = string(v)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return document, nil
+}
+
+func unmarshalSymbolInformation(b []byte) (*SymbolInformation, error) {
+	symbol := &SymbolInformation{}
+	err := consumeFields(b, func(num protowire.Number, typ protowire.Type, v []byte, _ uint64) (err error) {
+		switch num {
+		case 1:
+			symbol.Symbol = string(v)
+		case 3:
+			symbol.Documentation = append(symbol.Documentation, string(v))
+		case 4:
+			var relationship *Relationship
+			if relationship, err = unmarshalRelationship(v); err == nil {
+				symbol.Relationships = append(symbol.Relationships, relationship)
+			}
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return symbol, nil
+}
+
+func unmarshalRelationship(b []byte) (*Relationship, error) {
+	relationship := &Relationship{}
+	err := consumeFields(b, func(num protowire.Number, typ protowire.Type, v []byte, x uint64) error {
+		switch num {
+		case 1:
+			relationship.Symbol = string(v)
+		case 2:
+			relationship.IsReference = x != 0
+		case 3:
+			relationship.IsImplementation = x != 0
+		case 4:
+			relationship.IsTypeDefinition = x != 0
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return relationship, nil
+}
+
+func unmarshalOccurrence(b []byte) (*Occurrence, error) {
+	occurrence := &Occurrence{}
+	err := consumeFields(b, func(num protowire.Number, typ protowire.Type, v []byte, x uint64) error {
+		switch num {
+		case 1:
+			if typ == protowire.VarintType {
+				occurrence.Range = append(occurrence.Range, int32(x))
+				return nil
+			}
+
+			// Packed encoding
+			for len(v) > 0 {
+				value, n := protowire.ConsumeVarint(v)
+				if n < 0 {
+					return protowire.ParseError(n)
+				}
+				occurrence.Range = append(occurrence.Range, int32(value))
+				v = v[n:]
+			}
+		case 2:
+			occurrence.Symbol = string(v)
+		case 3:
+			occurrence.SymbolRoles = int32(x)
+		case 4:
+			occurrence.OverrideDocumentation = append(occurrence.OverrideDocumentation, string(v))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return occurrence, nil
+}
+
+// consumeFields calls fn for each field of the given serialized message. Length-delimited field
+// values are passed as a byte slice and varint field values are passed as an integer. Fields of
+// other wire types are skipped.
+func consumeFields(b []byte, fn func(num protowire.Number, typ protowire.Type, v []byte, x uint64) error) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		var (
+			v []byte
+			x uint64
+		)
+		switch typ {
+		case protowire.VarintType:
+			x, n = protowire.ConsumeVarint(b)
+		case protowire.BytesType:
+			v, n = protowire.ConsumeBytes(b)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		if typ != protowire.VarintType && typ != protowire.BytesType {
+			continue
+		}
+		if err := fn(num, typ, v, x); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// skipStreamValue discards a non-length-delimited top-level field value from the given reader.
+func skipStreamValue(r *bufio.Reader, typ protowire.Type) error {
+	switch typ {
+	case protowire.VarintType:
+		_, err := binary.ReadUvarint(r)
+		return err
+	case protowire.Fixed32Type:
+		_, err := r.Discard(4)
+		return err
+	case protowire.Fixed64Type:
+		_, err := r.Discard(8)
+		return err
+	}
+
+	return errors.Errorf("unsupported wire type %d", typ)
+}
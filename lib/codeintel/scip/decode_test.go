@@ -0,0 +1,111 @@
+package scip
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestReadIndex(t *testing.T) {
+	index := testIndex()
+
+	var (
+		metadata  Metadata
+		documents []*Document
+		external  []*SymbolInformation
+	)
+	visitor := Visitor{
+		Metadata:       func(m Metadata) error { metadata = m; return nil },
+		Document:       func(d *Document) error { documents = append(documents, d); return nil },
+		ExternalSymbol: func(s *SymbolInformation) error { external = append(external, s); return nil },
+	}
+	if err := ReadIndex(bytes.NewReader(index.Marshal()), visitor); err != nil {
+		t.Fatalf("unexpected error reading index: %s", err)
+	}
+
+	if diff := cmp.Diff(index, &Index{Metadata: metadata, Documents: documents, ExternalSymbols: external}); diff != "" {
+		t.Errorf("unexpected index (-want +got):\n%s", diff)
+	}
+}
+
+func TestUnmarshal(t *testing.T) {
+	index := testIndex()
+
+	decoded, err := Unmarshal(index.Marshal())
+	if err != nil {
+		t.Fatalf("unexpected error unmarshalling index: %s", err)
+	}
+
+	if diff := cmp.Diff(index, decoded); diff != "" {
+		t.Errorf("unexpected index (-want +got):\n%s", diff)
+	}
+}
+
+func TestUnmarshalUnpackedRange(t *testing.T) {
+	// Occurrence{range: [1, 2, 3]} with an unpacked repeated field
+	occurrence := []byte{0x08, 0x01, 0x08, 0x02, 0x08, 0x03}
+
+	decoded, err := unmarshalOccurrence(occurrence)
+	if err != nil {
+		t.Fatalf("unexpected error unmarshalling occurrence: %s", err)
+	}
+
+	if diff := cmp.Diff([]int32{1, 2, 3}, decoded.Range); diff != "" {
+		t.Errorf("unexpected range (-want +got):\n%s", diff)
+	}
+}
+
+func TestIsIndex(t *testing.T) {
+	encoded := testIndex().Marshal()
+
+	testCases := []struct {
+		name     string
+		prefix   []byte
+		expected bool
+	}{
+		{"index", encoded, true},
+		{"truncated index", encoded[:12], true},
+		{"lsif", []byte(`{"id":1,"type":"vertex","label":"metaData"}`), false},
+		{"lsif with leading newline", []byte("\n{\"id\":1,\"type\":\"vertex\"}"), false},
+		{"empty", nil, false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if value := IsIndex(testCase.prefix); value != testCase.expected {
+				t.Errorf("unexpected result. want=%v have=%v", testCase.expected, value)
+			}
+		})
+	}
+}
+
+func testIndex() *Index {
+	return &Index{
+		Metadata: Metadata{
+			Version:     1,
+			ToolInfo:    ToolInfo{Name: "scip-go", Version: "0.1.0", Arguments: []string{"--verbose"}},
+			ProjectRoot: "file:///src",
+		},
+		Documents: []*Document{
+			{
+				RelativePath: "main.go",
+				Language:     "go",
+				Occurrences: []*Occurrence{
+					{Range: []int32{1, 5, 8}, Symbol: "scip-go gomod example v1.0.0 main/foo().", SymbolRoles: int32(SymbolRoleDefinition)},
+					{Range: []int32{3, 2, 4, 7}, Symbol: "local 0"},
+				},
+				Symbols: []*SymbolInformation{
+					{
+						Symbol:        "scip-go gomod example v1.0.0 main/foo().",
+						Documentation: []string{"```go\nfunc foo()\n```"},
+						Relationships: []*Relationship{{Symbol: "scip-go gomod example v1.0.0 main/Fooer#Foo().", IsImplementation: true}},
+					},
+				},
+			},
+		},
+		ExternalSymbols: []*SymbolInformation{
+			{Symbol: "scip-go gomod fmt . fmt/Println().", Documentation: []string{"Println formats..."}},
+		},
+	}
+}
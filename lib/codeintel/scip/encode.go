@@ -0,0 +1,114 @@
+package scip
+
+import "google.golang.org/protobuf/encoding/protowire"
+
+// Marshal serializes the index in the format read by ReadIndex and Unmarshal.
+func (x *Index) Marshal() []byte {
+	var b []byte
+	b = appendMessage(b, 1, appendMetadata(nil, x.Metadata))
+	for _, document := range x.Documents {
+		b = appendMessage(b, 2, appendDocument(nil, document))
+	}
+	for _, symbol := range x.ExternalSymbols {
+		b = appendMessage(b, 3, appendSymbolInformation(nil, symbol))
+	}
+
+	return b
+}
+
+func appendMetadata(b []byte, metadata Metadata) []byte {
+	b = appendVarint(b, 1, uint64(metadata.Version))
+	b = appendMessage(b, 2, appendToolInfo(nil, metadata.ToolInfo))
+	b = appendString(b, 3, metadata.ProjectRoot)
+	b = appendVarint(b, 4, uint64(metadata.TextDocumentEncoding))
+	return b
+}
+
+func appendToolInfo(b []byte, toolInfo ToolInfo) []byte {
+	b = appendString(b, 1, toolInfo.Name)
+	b = appendString(b, 2, toolInfo.Version)
+	for _, argument := range toolInfo.Arguments {
+		b = protowire.AppendTag(b, 3, protowire.BytesType)
+		b = protowire.AppendString(b, argument)
+	}
+	return b
+}
+
+func appendDocument(b []byte, document *Document) []byte {
+	b = appendString(b, 1, document.RelativePath)
+	for _, occurrence := range document.Occurrences {
+		b = appendMessage(b, 2, appendOccurrence(nil, occurrence))
+	}
+	for _, symbol := range document.Symbols {
+		b = appendMessage(b, 3, appendSymbolInformation(nil, symbol))
+	}
+	b = appendString(b, 4, document.Language)
+	return b
+}
+
+func appendSymbolInformation(b []byte, symbol *SymbolInformation) []byte {
+	b = appendString(b, 1, symbol.Symbol)
+	for _, documentation := range symbol.Documentation {
+		b = protowire.AppendTag(b, 3, protowire.BytesType)
+		b = protowire.AppendString(b, documentation)
+	}
+	for _, relationship := range symbol.Relationships {
+		b = appendMessage(b, 4, appendRelationship(nil, relationship))
+	}
+	return b
+}
+
+func appendRelationship(b []byte, relationship *Relationship) []byte {
+	b = appendString(b, 1, relationship.Symbol)
+	b = appendBool(b, 2, relationship.IsReference)
+	b = appendBool(b, 3, relationship.IsImplementation)
+	b = appendBool(b, 4, relationship.IsTypeDefinition)
+	return b
+}
+
+func appendOccurrence(b []byte, occurrence *Occurrence) []byte {
+	if len(occurrence.Range) > 0 {
+		var packed []byte
+		for _, value := range occurrence.Range {
+			packed = protowire.AppendVarint(packed, uint64(value))
+		}
+		b = appendMessage(b, 1, packed)
+	}
+	b = appendString(b, 2, occurrence.Symbol)
+	b = appendVarint(b, 3, uint64(occurrence.SymbolRoles))
+	for _, documentation := range occurrence.OverrideDocumentation {
+		b = protowire.AppendTag(b, 4, protowire.BytesType)
+		b = protowire.AppendString(b, documentation)
+	}
+	return b
+}
+
+// appendMessage appends a length-delimited field. Unlike the scalar helpers below, this
+// always writes the field so that empty messages are still present in the output.
+func appendMessage(b []byte, num protowire.Number, v []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, v)
+}
+
+func appendString(b []byte, num protowire.Number, v string) []byte {
+	if v == "" {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, v)
+}
+
+func appendVarint(b []byte, num protowire.Number, v uint64) []byte {
+	if v == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, v)
+}
+
+func appendBool(b []byte, num protowire.Number, v bool) []byte {
+	if !v {
+		return b
+	}
+	return appendVarint(b, num, 1)
+}
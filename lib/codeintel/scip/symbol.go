@@ -0,0 +1,82 @@
+package scip
+
+import (
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+// Symbol is a parsed symbol string. Global symbols have the form
+// `<scheme> <manager> <package-name> <version> <descriptors>`, where a double space within
+// the first four components encodes a literal space. Local symbols have the form `local <id>`
+// and are only meaningful within the document that contains them.
+type Symbol struct {
+	Scheme      string
+	Manager     string
+	Name        string
+	Version     string
+	Descriptors string
+}
+
+// IsLocalSymbol returns true if the given symbol string denotes a document-local symbol.
+func IsLocalSymbol(symbol string) bool {
+	return strings.HasPrefix(symbol, "local ")
+}
+
+// ParseSymbol parses the given global symbol string.
+func ParseSymbol(symbol string) (Symbol, error) {
+	if IsLocalSymbol(symbol) {
+		return Symbol{}, errors.Errorf("local symbol %q has no global components", symbol)
+	}
+
+	parts := make([]string, 0, 4)
+	rest := symbol
+	for len(parts) < 4 {
+		part, tail, ok := consumeSymbolPart(rest)
+		if !ok {
+			return Symbol{}, errors.Errorf("malformed symbol %q", symbol)
+		}
+		parts = append(parts, part)
+		rest = tail
+	}
+	if parts[0] == "" {
+		return Symbol{}, errors.Errorf("malformed symbol %q: empty scheme", symbol)
+	}
+
+	return Symbol{
+		Scheme:      parts[0],
+		Manager:     unplaceholder(parts[1]),
+		Name:        unplaceholder(parts[2]),
+		Version:     unplaceholder(parts[3]),
+		Descriptors: rest,
+	}, nil
+}
+
+// consumeSymbolPart reads a space-terminated component from the front of s, unescaping double
+// spaces. The second return value is the remainder of s after the terminating space.
+func consumeSymbolPart(s string) (string, string, bool) {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != ' ' {
+			sb.WriteByte(s[i])
+			continue
+		}
+		if i+1 < len(s) && s[i+1] == ' ' {
+			sb.WriteByte(' ')
+			i++
+			continue
+		}
+
+		return sb.String(), s[i+1:], true
+	}
+
+	return "", "", false
+}
+
+// unplaceholder converts the `.` placeholder used for an empty package component to the empty string.
+func unplaceholder(s string) string {
+	if s == "." {
+		return ""
+	}
+	return s
+}
@@ -0,0 +1,36 @@
+package scip
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseSymbol(t *testing.T) {
+	testCases := []struct {
+		symbol   string
+		expected Symbol
+	}{
+		{"scip-go gomod example v1.0.0 main/foo().", Symbol{"scip-go", "gomod", "example", "v1.0.0", "main/foo()."}},
+		{"scip-go gomod fmt . fmt/Println().", Symbol{"scip-go", "gomod", "fmt", "", "fmt/Println()."}},
+		{"scip-java maven my  pkg 1.0 com/Foo# bar().", Symbol{"scip-java", "maven", "my pkg", "1.0", "com/Foo# bar()."}},
+	}
+
+	for _, testCase := range testCases {
+		symbol, err := ParseSymbol(testCase.symbol)
+		if err != nil {
+			t.Fatalf("unexpected error parsing %q: %s", testCase.symbol, err)
+		}
+		if diff := cmp.Diff(testCase.expected, symbol); diff != "" {
+			t.Errorf("unexpected symbol for %q (-want +got):\n%s", testCase.symbol, diff)
+		}
+	}
+}
+
+func TestParseSymbolMalformed(t *testing.T) {
+	for _, symbol := range []string{"local 12", "scip-go gomod", ""} {
+		if _, err := ParseSymbol(symbol); err == nil {
+			t.Errorf("expected error parsing %q", symbol)
+		}
+	}
+}
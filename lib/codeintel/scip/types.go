@@ -0,0 +1,88 @@
+// Package scip implements reading and writing of the document-oriented Protobuf index format
+// described by scip.proto. Unlike LSIF, which encodes an index as a graph of vertices and edges
+// that must be correlated as a whole, this format stores one self-contained message per document.
+package scip
+
+// Index is a decoded index. Large indexes should be read with ReadIndex, which does not require
+// all documents to be held in memory at once.
+type Index struct {
+	Metadata        Metadata
+	Documents       []*Document
+	ExternalSymbols []*SymbolInformation
+}
+
+// Metadata describes the tool that produced an index and the project it indexes.
+type Metadata struct {
+	Version              int32
+	ToolInfo             ToolInfo
+	ProjectRoot          string
+	TextDocumentEncoding int32
+}
+
+// ToolInfo describes the tool that produced an index.
+type ToolInfo struct {
+	Name      string
+	Version   string
+	Arguments []string
+}
+
+// Document is the set of occurrences and symbols of a single source file.
+type Document struct {
+	RelativePath string
+	Language     string
+	Occurrences  []*Occurrence
+	Symbols      []*SymbolInformation
+}
+
+// SymbolInformation holds the hover documentation and relationships of a symbol.
+type SymbolInformation struct {
+	Symbol        string
+	Documentation []string
+	Relationships []*Relationship
+}
+
+// Relationship links a symbol to another symbol it references, implements, or has as its type.
+type Relationship struct {
+	Symbol           string
+	IsReference      bool
+	IsImplementation bool
+	IsTypeDefinition bool
+}
+
+// Occurrence is a single use of a symbol within a document.
+type Occurrence struct {
+	Range                 []int32
+	Symbol                string
+	SymbolRoles           int32
+	OverrideDocumentation []string
+}
+
+// SymbolRole is a bit in the SymbolRoles bitset of an occurrence.
+type SymbolRole int32
+
+const (
+	SymbolRoleDefinition  SymbolRole = 0x1
+	SymbolRoleImport      SymbolRole = 0x2
+	SymbolRoleWriteAccess SymbolRole = 0x4
+	SymbolRoleReadAccess  SymbolRole = 0x8
+	SymbolRoleGenerated   SymbolRole = 0x10
+	SymbolRoleTest        SymbolRole = 0x20
+)
+
+// HasRole returns true if the occurrence has the given role.
+func (o *Occurrence) HasRole(role SymbolRole) bool {
+	return o.SymbolRoles&int32(role) != 0
+}
+
+// Span returns the zero-based start and end positions of the occurrence. The second return
+// value is false if the range is malformed.
+func (o *Occurrence) Span() (startLine, startCharacter, endLine, endCharacter int, ok bool) {
+	switch len(o.Range) {
+	case 3:
+		return int(o.Range[0]), int(o.Range[1]), int(o.Range[0]), int(o.Range[2]), true
+	case 4:
+		return int(o.Range[0]), int(o.Range[1]), int(o.Range[2]), int(o.Range[3]), true
+	}
+
+	return 0, 0, 0, 0, false
+}
@@ -2,10 +2,13 @@ package upload
 
 import (
 	"bufio"
+	"bytes"
 	"encoding/json"
 	"io"
 
 	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/scip"
 )
 
 // MaxBufferSize is the maximum size of the metaData line in the dump. This should be large enough
@@ -32,10 +35,21 @@ type toolInfo struct {
 }
 
 // ReadIndexerName returns the name of the tool that generated the given index contents.
-// This function reads only the first line of the file, where the metadata vertex is
-// assumed to be in all valid dumps.
+// For LSIF indexes, this function reads only the first line of the file, where the metadata
+// vertex is assumed to be in all valid dumps. For SCIP indexes, this function reads only the
+// leading metadata message.
 func ReadIndexerName(r io.Reader) (string, error) {
-	line, isPrefix, err := bufio.NewReaderSize(r, MaxBufferSize).ReadLine()
+	reader := bufio.NewReaderSize(r, MaxBufferSize)
+
+	prefix, err := reader.Peek(MaxBufferSize)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return "", err
+	}
+	if scip.IsIndex(prefix) {
+		return readSCIPIndexerName(prefix)
+	}
+
+	line, isPrefix, err := reader.ReadLine()
 	if err != nil {
 		return "", err
 	}
@@ -54,3 +68,29 @@ func ReadIndexerName(r io.Reader) (string, error) {
 
 	return meta.ToolInfo.Name, nil
 }
+
+// errFoundMetadata is used to stop reading a SCIP index once its metadata has been decoded.
+var errFoundMetadata = errors.New("found metadata")
+
+// readSCIPIndexerName returns the tool name from the metadata message at the start of the given
+// SCIP index prefix.
+func readSCIPIndexerName(prefix []byte) (name string, err error) {
+	visitor := scip.Visitor{
+		Metadata: func(metadata scip.Metadata) error {
+			name = metadata.ToolInfo.Name
+			return errFoundMetadata
+		},
+	}
+
+	if err := scip.ReadIndex(bytes.NewReader(prefix), visitor); err != errFoundMetadata {
+		if err == nil {
+			return "", ErrInvalidMetaDataVertex
+		}
+		return "", ErrMetadataExceedsBuffer
+	}
+	if name == "" {
+		return "", ErrInvalidMetaDataVertex
+	}
+
+	return name, nil
+}
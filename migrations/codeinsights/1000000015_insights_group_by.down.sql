@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE series_points_snapshots DROP COLUMN IF EXISTS capture;
+ALTER TABLE series_points DROP COLUMN IF EXISTS capture;
+ALTER TABLE insight_series DROP COLUMN IF EXISTS group_by_select;
+
+COMMIT;
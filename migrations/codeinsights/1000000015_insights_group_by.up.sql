@@ -0,0 +1,20 @@
+BEGIN;
+
+-- Insert migration here. See README.md. Highlights:
+--  * Always use IF EXISTS. eg: DROP TABLE IF EXISTS global_dep_private;
+--  * All migrations must be backward-compatible. Old versions of Sourcegraph
+--    need to be able to read/write post migration.
+--  * Historically we advised against transactions since we thought the
+--    migrate library handled it. However, it does not! /facepalm
+
+ALTER TABLE insight_series ADD COLUMN IF NOT EXISTS group_by_select BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN insight_series.group_by_select IS 'Whether the results of the query are grouped by the value the query selects with select:, and recorded as one series per distinct value.';
+
+ALTER TABLE series_points ADD COLUMN IF NOT EXISTS capture TEXT;
+ALTER TABLE series_points_snapshots ADD COLUMN IF NOT EXISTS capture TEXT;
+
+COMMENT ON COLUMN series_points.capture IS 'The value the results of this data point were grouped by, if the series groups its results by the value its query selects with select:.';
+COMMENT ON COLUMN series_points_snapshots.capture IS 'The value the results of this data point were grouped by, if the series groups its results by the value its query selects with select:.';
+
+COMMIT;
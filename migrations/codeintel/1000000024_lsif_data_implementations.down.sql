@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP TRIGGER IF EXISTS lsif_data_implementations_schema_versions_insert ON lsif_data_implementations;
+DROP FUNCTION IF EXISTS update_lsif_data_implementations_schema_versions_insert;
+DROP TABLE IF EXISTS lsif_data_implementations_schema_versions;
+DROP TABLE IF EXISTS lsif_data_implementations;
+
+COMMIT;
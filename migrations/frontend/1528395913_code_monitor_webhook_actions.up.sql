@@ -0,0 +1,53 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS cm_webhooks (
+    id BIGSERIAL PRIMARY KEY,
+    monitor BIGINT NOT NULL REFERENCES cm_monitors(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL DEFAULT '',
+    enabled BOOLEAN NOT NULL,
+    created_by INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    changed_by INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS cm_webhooks_monitor ON cm_webhooks (monitor);
+
+COMMENT ON TABLE cm_webhooks IS 'Webhook actions configured on code monitors';
+COMMENT ON COLUMN cm_webhooks.url IS 'The URL the JSON payload is POSTed to';
+COMMENT ON COLUMN cm_webhooks.secret IS 'Optional shared secret used to sign the payload with HMAC-SHA256';
+
+CREATE TABLE IF NOT EXISTS cm_slack_webhooks (
+    id BIGSERIAL PRIMARY KEY,
+    monitor BIGINT NOT NULL REFERENCES cm_monitors(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    enabled BOOLEAN NOT NULL,
+    created_by INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    changed_by INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS cm_slack_webhooks_monitor ON cm_slack_webhooks (monitor);
+
+COMMENT ON TABLE cm_slack_webhooks IS 'Slack webhook actions configured on code monitors';
+COMMENT ON COLUMN cm_slack_webhooks.url IS 'The Slack incoming webhook URL messages are posted to';
+
+ALTER TABLE cm_action_jobs
+    ALTER COLUMN email DROP NOT NULL,
+    ADD COLUMN IF NOT EXISTS webhook BIGINT REFERENCES cm_webhooks(id) ON DELETE CASCADE,
+    ADD COLUMN IF NOT EXISTS slack_webhook BIGINT REFERENCES cm_slack_webhooks(id) ON DELETE CASCADE,
+    ADD CONSTRAINT cm_action_jobs_only_one_action_type CHECK (
+        (
+            CASE WHEN email IS NULL THEN 0 ELSE 1 END
+            + CASE WHEN webhook IS NULL THEN 0 ELSE 1 END
+            + CASE WHEN slack_webhook IS NULL THEN 0 ELSE 1 END
+        ) = 1
+    );
+
+COMMENT ON COLUMN cm_action_jobs.email IS 'The ID of the cm_emails action to execute if this is an email job. Mutually exclusive with webhook and slack_webhook';
+COMMENT ON COLUMN cm_action_jobs.webhook IS 'The ID of the cm_webhooks action to execute if this is a webhook job. Mutually exclusive with email and slack_webhook';
+COMMENT ON COLUMN cm_action_jobs.slack_webhook IS 'The ID of the cm_slack_webhook action to execute if this is a slack webhook job. Mutually exclusive with email and webhook';
+
+COMMIT;
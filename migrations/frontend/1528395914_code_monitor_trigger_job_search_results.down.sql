@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE cm_trigger_jobs
+    DROP COLUMN IF EXISTS search_results;
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE cm_trigger_jobs
+    ADD COLUMN IF NOT EXISTS search_results jsonb;
+
+COMMENT ON COLUMN cm_trigger_jobs.search_results IS 'A capped list of the commits and diffs matched by the query when the trigger job ran, included in notifications';
+
+COMMIT;
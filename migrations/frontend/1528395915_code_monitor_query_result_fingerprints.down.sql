@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE cm_queries
+    DROP COLUMN IF EXISTS result_fingerprints;
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE cm_queries
+    ADD COLUMN IF NOT EXISTS result_fingerprints text[];
+
+COMMENT ON COLUMN cm_queries.result_fingerprints IS 'Fingerprints of the results returned by the last run of a query which does not search commits or diffs. NULL if the query has not run since it was created or changed';
+
+COMMIT;
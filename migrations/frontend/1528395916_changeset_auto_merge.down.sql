@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE changesets
+    DROP COLUMN IF EXISTS auto_merge_job_id;
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE changesets
+    ADD COLUMN IF NOT EXISTS auto_merge_job_id bigint REFERENCES changeset_jobs(id) ON DELETE SET NULL DEFERRABLE;
+
+COMMENT ON COLUMN changesets.auto_merge_job_id IS 'The auto-merge changeset job that merges the changeset once it is approved and its checks have passed. The merge is pending as long as the job is queued.';
+
+COMMIT;
@@ -0,0 +1,16 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS batch_spec_execution_cache_entries (
+    id bigserial PRIMARY KEY,
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+    key text NOT NULL,
+    value text NOT NULL,
+    last_used_at timestamp with time zone,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS batch_spec_execution_cache_entries_user_id_key_unique ON batch_spec_execution_cache_entries(user_id, key);
+
+COMMENT ON TABLE batch_spec_execution_cache_entries IS 'Results of executing the steps of batch spec workspaces on executors, keyed by the inputs of the steps. Entries are scoped to the user that executed the batch spec.';
+
+COMMIT;
@@ -0,0 +1,18 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS batch_spec_workspace_step_artifacts (
+    id bigserial PRIMARY KEY,
+    batch_spec_workspace_id integer NOT NULL REFERENCES batch_spec_workspaces(id) ON DELETE CASCADE DEFERRABLE,
+    step integer NOT NULL,
+    name text NOT NULL,
+    path text NOT NULL,
+    content bytea NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS batch_spec_workspace_step_artifacts_unique ON batch_spec_workspace_step_artifacts(batch_spec_workspace_id, step, path);
+
+COMMENT ON TABLE batch_spec_workspace_step_artifacts IS 'Files produced by the steps of batch spec workspaces and uploaded by src-cli after the step finished.';
+COMMENT ON COLUMN batch_spec_workspace_step_artifacts.step IS 'The one-based number of the step in the batch spec workspace.';
+
+COMMIT;
@@ -154,6 +154,14 @@ type BackendInsightSeries struct {
 	// Stroke description: The color of the line for the series.
 	Stroke string `json:"stroke,omitempty"`
 }
+type BatchChangeAutoMergeWindow struct {
+	// Days description: Day(s) the window applies to. If omitted, this rule applies to all days of the week.
+	Days []string `json:"days,omitempty"`
+	// End description: Window end time. If omitted, no time window is applied to the day(s) that match this rule.
+	End string `json:"end,omitempty"`
+	// Start description: Window start time. If omitted, no time window is applied to the day(s) that match this rule.
+	Start string `json:"start,omitempty"`
+}
 type BatchChangeRolloutWindow struct {
 	// Days description: Day(s) the window applies to. If omitted, this rule applies to all days of the week.
 	Days []string `json:"days,omitempty"`
@@ -217,6 +225,8 @@ type BitbucketCloudConnection struct {
 	Url string `json:"url"`
 	// Username description: The username to use when authenticating to the Bitbucket Cloud. Also set the corresponding "appPassword" field.
 	Username string `json:"username"`
+	// Webhooks description: An array of webhook configurations. Bitbucket Cloud doesn't sign webhook payloads, so the secret must be passed in the "secret" query parameter of the webhook URL.
+	Webhooks []*BitbucketCloudWebhook `json:"webhooks,omitempty"`
 }
 
 // BitbucketCloudRateLimit description: Rate limit applied when making background API requests to Bitbucket Cloud.
@@ -227,6 +237,11 @@ type BitbucketCloudRateLimit struct {
 	RequestsPerHour float64 `json:"requestsPerHour"`
 }
 
+type BitbucketCloudWebhook struct {
+	// Secret description: The secret used to authenticate incoming webhook requests
+	Secret string `json:"secret"`
+}
+
 // BitbucketServerAuthorization description: If non-null, enforces Bitbucket Server repository permissions.
 type BitbucketServerAuthorization struct {
 	// IdentityProvider description: The source of identity to use when computing permissions. This defines how to compute the Bitbucket Server identity to use for a given Sourcegraph user. When 'username' is used, Sourcegraph assumes usernames are identical in Sourcegraph and Bitbucket Server accounts and `auth.enableUsernameChanges` must be set to false for security reasons.
@@ -379,14 +394,22 @@ type BuiltinAuthProvider struct {
 
 // ChangesetTemplate description: A template describing how to create (and update) changesets with the file changes produced by the command steps.
 type ChangesetTemplate struct {
+	// Assignees description: The usernames of the users to assign to published changesets. If not empty, the assignees on the code host are replaced by these users. Not supported on Bitbucket Server and Bitbucket Cloud.
+	Assignees interface{} `json:"assignees,omitempty"`
 	// Body description: The body (description) of the changeset.
 	Body string `json:"body,omitempty"`
 	// Branch description: The name of the Git branch to create or update on each repository with the changes.
 	Branch string `json:"branch"`
 	// Commit description: The Git commit to create with the changes.
 	Commit ExpandedGitCommitDescription `json:"commit"`
+	// Labels description: The labels to set on published changesets. If not empty, the labels on the code host are replaced by these labels. Not supported on Bitbucket Server and Bitbucket Cloud.
+	Labels interface{} `json:"labels,omitempty"`
 	// Published description: Whether to publish the changeset. An unpublished changeset can be previewed on Sourcegraph by any person who can view the batch change, but its commit, branch, and pull request aren't created on the code host. A published changeset results in a commit, branch, and pull request being created on the code host. If omitted, the publication state is controlled from the Batch Changes UI.
 	Published interface{} `json:"published,omitempty"`
+	// Rebase description: Whether to keep published changesets up to date with their base branch. If true, the diff of a changeset that the code host reports as behind its base branch or as having merge conflicts is re-applied on the latest commit of the base branch and force-pushed.
+	Rebase bool `json:"rebase,omitempty"`
+	// Reviewers description: The usernames of the users to request reviews from on published changesets. Reviews requested on the code host are never withdrawn. Not supported on Bitbucket Cloud.
+	Reviewers interface{} `json:"reviewers,omitempty"`
 	// Title description: The title of the changeset.
 	Title string `json:"title"`
 }
@@ -1472,6 +1495,8 @@ type SiteConfiguration struct {
 	AuthUserOrgMap map[string][]string `json:"auth.userOrgMap,omitempty"`
 	// AuthzEnforceForSiteAdmins description: When true, site admins will only be able to see private code they have access to via our authz system.
 	AuthzEnforceForSiteAdmins bool `json:"authz.enforceForSiteAdmins,omitempty"`
+	// BatchChangesAutoMergeWindows description: Specifies windows during which changesets marked for auto-merge may be merged. If omitted, changesets are merged as soon as they are approved and their checks pass. All days and times are handled in UTC.
+	BatchChangesAutoMergeWindows *[]*BatchChangeAutoMergeWindow `json:"batchChanges.autoMergeWindows,omitempty"`
 	// BatchChangesEnabled description: Enables/disables the Batch Changes feature.
 	BatchChangesEnabled *bool `json:"batchChanges.enabled,omitempty"`
 	// BatchChangesRestrictToAdmins description: When enabled, only site admins can create and apply batch changes.
@@ -1604,6 +1629,8 @@ type SiteConfiguration struct {
 
 // Step description: A command to run (as part of a sequence) in a repository branch to produce the required changes.
 type Step struct {
+	// Artifacts description: Files produced by this step that should be kept after the step ran, such as test reports or logs. The files are uploaded after the step finished and can be downloaded from the workspace of the step. Only supported when executing batch specs on Sourcegraph.
+	Artifacts []*StepArtifact `json:"artifacts,omitempty"`
 	// Container description: The Docker image used to launch the Docker container in which the shell command is run.
 	Container string `json:"container"`
 	// Env description: Environment variables to set in the step environment.
@@ -1617,6 +1644,12 @@ type Step struct {
 	// Run description: The shell command to run in the container. It can also be a multi-line shell script. The working directory is the root directory of the repository checkout.
 	Run string `json:"run"`
 }
+type StepArtifact struct {
+	// Name description: The name of the artifact. Defaults to the path of the file.
+	Name string `json:"name,omitempty"`
+	// Path description: The path of the file, relative to the root directory of the repository checkout.
+	Path string `json:"path"`
+}
 
 // TlsExternal description: Global TLS/SSL settings for Sourcegraph to use when communicating with code hosts.
 type TlsExternal struct {